@@ -65,6 +65,9 @@ type ConnectionArgs struct {
 	// Do not block for OIDC authentication
 	OIDCNonInteractive bool
 
+	// API token (used with the "token" authentication type)
+	APIToken string
+
 	// Skip the event listener endpoint
 	SkipGetEvents bool
 
@@ -398,10 +401,14 @@ func httpsIncus(ctx context.Context, requestURL string, args *ConnectionArgs) (I
 		tempPath:           args.TempPath,
 	}
 
-	if slices.Contains([]string{api.AuthenticationMethodOIDC}, args.AuthType) {
+	if slices.Contains([]string{api.AuthenticationMethodOIDC, api.AuthenticationMethodToken}, args.AuthType) {
 		server.RequireAuthenticated(true)
 	}
 
+	if args.AuthType == api.AuthenticationMethodToken {
+		server.apiToken = args.APIToken
+	}
+
 	// Setup the HTTP client
 	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.IdenticalCertificate, args.Proxy, args.TransportWrapper)
 	if err != nil {
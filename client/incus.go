@@ -54,6 +54,7 @@ type ProtocolIncus struct {
 	project       string
 
 	oidcClient *oidcClient
+	apiToken   string
 
 	tempPath string
 }
@@ -189,6 +190,7 @@ func (r *ProtocolIncus) DoWebsocket(dialer websocket.Dialer, uri string, req *ht
 // User-Agent (if r.httpUserAgent is set).
 // X-Incus-authenticated (if r.requireAuthenticated is set).
 // OIDC Authorization header (if r.oidcClient is set).
+// API token Authorization header (if r.apiToken is set).
 func (r *ProtocolIncus) addClientHeaders(req *http.Request) {
 	if r.httpUserAgent != "" {
 		req.Header.Set("User-Agent", r.httpUserAgent)
@@ -200,6 +202,8 @@ func (r *ProtocolIncus) addClientHeaders(req *http.Request) {
 
 	if r.oidcClient != nil {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.oidcClient.getAccessToken()))
+	} else if r.apiToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.apiToken))
 	}
 }
 
@@ -0,0 +1,97 @@
+package incus
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Approval handling functions
+
+// GetApprovals returns the privileged operations waiting to be approved in the current project.
+func (r *ProtocolIncus) GetApprovals() ([]api.Approval, error) {
+	err := r.CheckExtension("approvals")
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := []api.Approval{}
+
+	_, err = r.queryStruct("GET", "/approvals?recursion=1", nil, "", &approvals)
+	if err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+// GetApprovalsAllProjects returns the privileged operations waiting to be approved across all projects.
+func (r *ProtocolIncus) GetApprovalsAllProjects() ([]api.Approval, error) {
+	err := r.CheckExtension("approvals")
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := []api.Approval{}
+
+	v := url.Values{}
+	v.Set("recursion", "1")
+	v.Set("all-projects", "true")
+
+	_, err = r.queryStruct("GET", fmt.Sprintf("/approvals?%s", v.Encode()), nil, "", &approvals)
+	if err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+// GetApproval returns the privileged operation waiting to be approved with the given ID.
+func (r *ProtocolIncus) GetApproval(id string) (*api.Approval, string, error) {
+	err := r.CheckExtension("approvals")
+	if err != nil {
+		return nil, "", err
+	}
+
+	approval := api.Approval{}
+
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/approvals/%s", url.PathEscape(id)), nil, "", &approval)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &approval, etag, nil
+}
+
+// ApproveOperation approves the privileged operation with the given approval ID, starting it.
+func (r *ProtocolIncus) ApproveOperation(id string) error {
+	err := r.CheckExtension("approvals")
+	if err != nil {
+		return err
+	}
+
+	// Send the request
+	_, _, err = r.query("POST", fmt.Sprintf("/approvals/%s", url.PathEscape(id)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RejectOperation rejects the privileged operation with the given approval ID, cancelling it.
+func (r *ProtocolIncus) RejectOperation(id string) error {
+	err := r.CheckExtension("approvals")
+	if err != nil {
+		return err
+	}
+
+	// Send the request
+	_, _, err = r.query("DELETE", fmt.Sprintf("/approvals/%s", url.PathEscape(id)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
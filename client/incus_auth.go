@@ -0,0 +1,382 @@
+package incus
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// GetAuthGroupNames returns a list of authorization group names.
+func (r *ProtocolIncus) GetAuthGroupNames() ([]string, error) {
+	if !r.HasExtension("auth_rbac") {
+		return nil, errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := "/auth/groups"
+	_, err := r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetAuthGroups returns a list of authorization group structs.
+func (r *ProtocolIncus) GetAuthGroups() ([]api.AuthGroup, error) {
+	if !r.HasExtension("auth_rbac") {
+		return nil, errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	groups := []api.AuthGroup{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", "/auth/groups?recursion=1", nil, "", &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetAuthGroup returns an authorization group entry for the provided name.
+func (r *ProtocolIncus) GetAuthGroup(name string) (*api.AuthGroup, string, error) {
+	if !r.HasExtension("auth_rbac") {
+		return nil, "", errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	group := api.AuthGroup{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/auth/groups/%s", url.PathEscape(name)), nil, "", &group)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &group, etag, nil
+}
+
+// CreateAuthGroup defines a new authorization group using the provided struct.
+func (r *ProtocolIncus) CreateAuthGroup(group api.AuthGroupsPost) error {
+	if !r.HasExtension("auth_rbac") {
+		return errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", "/auth/groups", group, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateAuthGroup updates the authorization group to match the provided struct.
+func (r *ProtocolIncus) UpdateAuthGroup(name string, group api.AuthGroupPut, ETag string) error {
+	if !r.HasExtension("auth_rbac") {
+		return errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("PUT", fmt.Sprintf("/auth/groups/%s", url.PathEscape(name)), group, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenameAuthGroup renames an existing authorization group entry.
+func (r *ProtocolIncus) RenameAuthGroup(name string, group api.AuthGroupPost) error {
+	if !r.HasExtension("auth_rbac") {
+		return errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", fmt.Sprintf("/auth/groups/%s", url.PathEscape(name)), group, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAuthGroup deletes an existing authorization group.
+func (r *ProtocolIncus) DeleteAuthGroup(name string) error {
+	if !r.HasExtension("auth_rbac") {
+		return errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/auth/groups/%s", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAuthIdentities returns a list of identity structs.
+func (r *ProtocolIncus) GetAuthIdentities() ([]api.AuthIdentity, error) {
+	if !r.HasExtension("auth_rbac") {
+		return nil, errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	identities := []api.AuthIdentity{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", "/auth/identities?recursion=1", nil, "", &identities)
+	if err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+// GetAuthIdentity returns the identity entry for the provided authentication method and identifier.
+func (r *ProtocolIncus) GetAuthIdentity(authenticationMethod string, identifier string) (*api.AuthIdentity, string, error) {
+	if !r.HasExtension("auth_rbac") {
+		return nil, "", errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	identity := api.AuthIdentity{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/auth/identities/%s/%s", url.PathEscape(authenticationMethod), url.PathEscape(identifier)), nil, "", &identity)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &identity, etag, nil
+}
+
+// CreateAuthIdentity adds a new identity using the provided struct.
+func (r *ProtocolIncus) CreateAuthIdentity(identity api.AuthIdentitiesPost) error {
+	if !r.HasExtension("auth_rbac") {
+		return errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", "/auth/identities", identity, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateAuthIdentity updates the identity to match the provided struct.
+func (r *ProtocolIncus) UpdateAuthIdentity(authenticationMethod string, identifier string, identity api.AuthIdentityPut, ETag string) error {
+	if !r.HasExtension("auth_rbac") {
+		return errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("PUT", fmt.Sprintf("/auth/identities/%s/%s", url.PathEscape(authenticationMethod), url.PathEscape(identifier)), identity, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAuthIdentity removes an existing identity.
+func (r *ProtocolIncus) DeleteAuthIdentity(authenticationMethod string, identifier string) error {
+	if !r.HasExtension("auth_rbac") {
+		return errors.New(`The server is missing the required "auth_rbac" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/auth/identities/%s/%s", url.PathEscape(authenticationMethod), url.PathEscape(identifier)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAuthTokenNames returns a list of API token names.
+func (r *ProtocolIncus) GetAuthTokenNames() ([]string, error) {
+	if !r.HasExtension("auth_tokens") {
+		return nil, errors.New(`The server is missing the required "auth_tokens" API extension`)
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := "/auth/tokens"
+	_, err := r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetAuthTokens returns a list of API token structs.
+func (r *ProtocolIncus) GetAuthTokens() ([]api.AuthToken, error) {
+	if !r.HasExtension("auth_tokens") {
+		return nil, errors.New(`The server is missing the required "auth_tokens" API extension`)
+	}
+
+	tokens := []api.AuthToken{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", "/auth/tokens?recursion=1", nil, "", &tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// GetAuthToken returns an API token entry for the provided name.
+func (r *ProtocolIncus) GetAuthToken(name string) (*api.AuthToken, string, error) {
+	if !r.HasExtension("auth_tokens") {
+		return nil, "", errors.New(`The server is missing the required "auth_tokens" API extension`)
+	}
+
+	token := api.AuthToken{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/auth/tokens/%s", url.PathEscape(name)), nil, "", &token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &token, etag, nil
+}
+
+// CreateAuthToken defines a new API token using the provided struct and returns its secret.
+func (r *ProtocolIncus) CreateAuthToken(token api.AuthTokensPost) (*api.AuthTokenSecret, error) {
+	if !r.HasExtension("auth_tokens") {
+		return nil, errors.New(`The server is missing the required "auth_tokens" API extension`)
+	}
+
+	secret := api.AuthTokenSecret{}
+
+	// Send the request.
+	_, err := r.queryStruct("POST", "/auth/tokens", token, "", &secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+// UpdateAuthToken updates the API token to match the provided struct.
+func (r *ProtocolIncus) UpdateAuthToken(name string, token api.AuthTokenPut, ETag string) error {
+	if !r.HasExtension("auth_tokens") {
+		return errors.New(`The server is missing the required "auth_tokens" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("PUT", fmt.Sprintf("/auth/tokens/%s", url.PathEscape(name)), token, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAuthToken revokes an API token.
+func (r *ProtocolIncus) DeleteAuthToken(name string) error {
+	if !r.HasExtension("auth_tokens") {
+		return errors.New(`The server is missing the required "auth_tokens" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/auth/tokens/%s", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAuthCertificates returns a list of client certificates issued by the server.
+func (r *ProtocolIncus) GetAuthCertificates() ([]api.AuthCertificate, error) {
+	if !r.HasExtension("auth_certificates") {
+		return nil, errors.New(`The server is missing the required "auth_certificates" API extension`)
+	}
+
+	certificates := []api.AuthCertificate{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", "/auth/certificates?recursion=1", nil, "", &certificates)
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+// GetAuthCertificate returns the client certificate issued by the server with the provided serial number.
+func (r *ProtocolIncus) GetAuthCertificate(serial string) (*api.AuthCertificate, error) {
+	if !r.HasExtension("auth_certificates") {
+		return nil, errors.New(`The server is missing the required "auth_certificates" API extension`)
+	}
+
+	certificate := api.AuthCertificate{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", fmt.Sprintf("/auth/certificates/%s", url.PathEscape(serial)), nil, "", &certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificate, nil
+}
+
+// IssueAuthCertificate requests a short-lived client certificate for the provided certificate request.
+func (r *ProtocolIncus) IssueAuthCertificate(request api.AuthCertificatesPost) (*api.AuthCertificate, error) {
+	if !r.HasExtension("auth_certificates") {
+		return nil, errors.New(`The server is missing the required "auth_certificates" API extension`)
+	}
+
+	certificate := api.AuthCertificate{}
+
+	// Send the request.
+	_, err := r.queryStruct("POST", "/auth/certificates", request, "", &certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificate, nil
+}
+
+// RevokeAuthCertificate revokes a client certificate issued by the server.
+func (r *ProtocolIncus) RevokeAuthCertificate(serial string) error {
+	if !r.HasExtension("auth_certificates") {
+		return errors.New(`The server is missing the required "auth_certificates" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/auth/certificates/%s", url.PathEscape(serial)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAuthCertificateAuthority returns the authority issuing client certificates along with its revocation list.
+func (r *ProtocolIncus) GetAuthCertificateAuthority() (*api.AuthCertificateAuthority, error) {
+	if !r.HasExtension("auth_certificates") {
+		return nil, errors.New(`The server is missing the required "auth_certificates" API extension`)
+	}
+
+	authority := api.AuthCertificateAuthority{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", "/auth/certificate-authority", nil, "", &authority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authority, nil
+}
@@ -2292,6 +2292,13 @@ func (r *ProtocolIncus) UpdateInstanceState(name string, state api.InstanceState
 		return nil, err
 	}
 
+	if state.WithDependencies {
+		err := r.CheckExtension("instance_boot_dependencies")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("PUT", fmt.Sprintf("%s/%s/state", path, url.PathEscape(name)), state, ETag)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/lxc/incus/v7/shared/api"
 )
@@ -99,6 +100,38 @@ func (r *ProtocolIncus) GetProjectState(name string) (*api.ProjectState, error)
 	return &projectState, nil
 }
 
+// GetProjectUsage returns the resources consumed by the instances of a project between from and to.
+// Zero times select the server defaults.
+func (r *ProtocolIncus) GetProjectUsage(name string, from time.Time, to time.Time) (*api.ProjectUsage, error) {
+	if !r.HasExtension("project_usage_accounting") {
+		return nil, errors.New("The server is missing the required \"project_usage_accounting\" API extension")
+	}
+
+	values := url.Values{}
+	if !from.IsZero() {
+		values.Set("from", from.UTC().Format(time.RFC3339))
+	}
+
+	if !to.IsZero() {
+		values.Set("to", to.UTC().Format(time.RFC3339))
+	}
+
+	path := fmt.Sprintf("/projects/%s/usage", url.PathEscape(name))
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	projectUsage := api.ProjectUsage{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", path, nil, "", &projectUsage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &projectUsage, nil
+}
+
 // GetProjectAccess returns an Access entry for the specified project.
 func (r *ProtocolIncus) GetProjectAccess(name string) (api.Access, error) {
 	access := api.Access{}
@@ -122,6 +155,13 @@ func (r *ProtocolIncus) CreateProject(project api.ProjectsPost) error {
 		return errors.New("The server is missing the required \"projects\" API extension")
 	}
 
+	if project.Template != "" {
+		err := r.CheckExtension("project_templates")
+		if err != nil {
+			return err
+		}
+	}
+
 	// Send the request
 	_, _, err := r.query("POST", "/projects", project, "")
 	if err != nil {
@@ -379,6 +379,24 @@ func (r *ProtocolIncus) CreateStoragePoolBucketKey(poolName string, bucketName s
 	return &newKey, err
 }
 
+// CreateStoragePoolBucketPresignedURL generates a time-limited URL for an object of a storage bucket.
+func (r *ProtocolIncus) CreateStoragePoolBucketPresignedURL(poolName string, bucketName string, req api.StorageBucketPresignPost) (*api.StorageBucketPresign, error) {
+	err := r.CheckExtension("storage_bucket_policies")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request.
+	var presign api.StorageBucketPresign
+	u := api.NewURL().Path("storage-pools", poolName, "buckets", bucketName, "presign")
+	_, err = r.queryStruct("POST", u.String(), req, "", &presign)
+	if err != nil {
+		return nil, err
+	}
+
+	return &presign, nil
+}
+
 // UpdateStoragePoolBucketKey updates an existing storage bucket key.
 func (r *ProtocolIncus) UpdateStoragePoolBucketKey(poolName string, bucketName string, keyName string, key api.StorageBucketKeyPut, ETag string) error {
 	err := r.CheckExtension("storage_buckets")
@@ -143,3 +143,54 @@ func (r *ProtocolIncus) GetStoragePoolResources(name string) (*api.ResourcesStor
 
 	return &res, nil
 }
+
+// GetStoragePoolHealth gets the health of a given storage pool.
+func (r *ProtocolIncus) GetStoragePoolHealth(name string) (*api.StoragePoolHealth, error) {
+	err := r.CheckExtension("storage_pool_health")
+	if err != nil {
+		return nil, err
+	}
+
+	health := api.StoragePoolHealth{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct("GET", fmt.Sprintf("/storage-pools/%s/health", url.PathEscape(name)), nil, "", &health)
+	if err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
+
+// ScrubStoragePool starts a scrub of a given storage pool.
+func (r *ProtocolIncus) ScrubStoragePool(name string) (Operation, error) {
+	err := r.CheckExtension("storage_pool_health")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/scrub", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// ReclaimStoragePool releases the unused space of the volumes of a given storage pool.
+// The number of bytes reclaimed is returned in the operation metadata.
+func (r *ProtocolIncus) ReclaimStoragePool(name string) (Operation, error) {
+	err := r.CheckExtension("storage_pool_reclaim")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/reclaim", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/sftp"
@@ -96,6 +97,35 @@ type InstanceServer interface {
 	DeleteCertificate(fingerprint string) (err error)
 	CreateCertificateToken(certificate api.CertificatesPost) (op Operation, err error)
 
+	// Authorization functions ("auth_rbac" API extension)
+	GetAuthGroupNames() (names []string, err error)
+	GetAuthGroups() (groups []api.AuthGroup, err error)
+	GetAuthGroup(name string) (group *api.AuthGroup, ETag string, err error)
+	CreateAuthGroup(group api.AuthGroupsPost) (err error)
+	UpdateAuthGroup(name string, group api.AuthGroupPut, ETag string) (err error)
+	RenameAuthGroup(name string, group api.AuthGroupPost) (err error)
+	DeleteAuthGroup(name string) (err error)
+	GetAuthIdentities() (identities []api.AuthIdentity, err error)
+	GetAuthIdentity(authenticationMethod string, identifier string) (identity *api.AuthIdentity, ETag string, err error)
+	CreateAuthIdentity(identity api.AuthIdentitiesPost) (err error)
+	UpdateAuthIdentity(authenticationMethod string, identifier string, identity api.AuthIdentityPut, ETag string) (err error)
+	DeleteAuthIdentity(authenticationMethod string, identifier string) (err error)
+
+	// API token functions ("auth_tokens" API extension)
+	GetAuthTokenNames() (names []string, err error)
+	GetAuthTokens() (tokens []api.AuthToken, err error)
+	GetAuthToken(name string) (token *api.AuthToken, ETag string, err error)
+	CreateAuthToken(token api.AuthTokensPost) (secret *api.AuthTokenSecret, err error)
+	UpdateAuthToken(name string, token api.AuthTokenPut, ETag string) (err error)
+	DeleteAuthToken(name string) (err error)
+
+	// Issued client certificate functions ("auth_certificates" API extension)
+	GetAuthCertificates() (certificates []api.AuthCertificate, err error)
+	GetAuthCertificate(serial string) (certificate *api.AuthCertificate, err error)
+	IssueAuthCertificate(request api.AuthCertificatesPost) (certificate *api.AuthCertificate, err error)
+	RevokeAuthCertificate(serial string) (err error)
+	GetAuthCertificateAuthority() (authority *api.AuthCertificateAuthority, err error)
+
 	// Instance functions.
 	GetInstanceNames(instanceType api.InstanceType) (names []string, err error)
 	GetInstanceNamesAllProjects(instanceType api.InstanceType) (names map[string][]string, err error)
@@ -318,6 +348,7 @@ type InstanceServer interface {
 	GetProjectsWithFilter(filters []string) (projects []api.Project, err error)
 	GetProject(name string) (project *api.Project, ETag string, err error)
 	GetProjectState(name string) (project *api.ProjectState, err error)
+	GetProjectUsage(name string, from time.Time, to time.Time) (usage *api.ProjectUsage, err error)
 	GetProjectAccess(name string) (access api.Access, err error)
 	CreateProject(project api.ProjectsPost) (err error)
 	UpdateProject(name string, project api.ProjectPut, ETag string) (err error)
@@ -325,6 +356,15 @@ type InstanceServer interface {
 	DeleteProject(name string) (err error)
 	DeleteProjectForce(name string) (err error)
 
+	// Project template functions ("project_templates" API extension)
+	GetProjectTemplateNames() (names []string, err error)
+	GetProjectTemplates() (templates []api.ProjectTemplate, err error)
+	GetProjectTemplate(name string) (template *api.ProjectTemplate, ETag string, err error)
+	CreateProjectTemplate(template api.ProjectTemplatesPost) (err error)
+	UpdateProjectTemplate(name string, template api.ProjectTemplatePut, ETag string) (err error)
+	RenameProjectTemplate(name string, template api.ProjectTemplatePost) (err error)
+	DeleteProjectTemplate(name string) (err error)
+
 	// Storage pool functions ("storage" API extension)
 	GetStoragePoolNames() (names []string, err error)
 	GetStoragePools() (pools []api.StoragePool, err error)
@@ -335,6 +375,13 @@ type InstanceServer interface {
 	UpdateStoragePool(name string, pool api.StoragePoolPut, ETag string) (err error)
 	DeleteStoragePool(name string) (err error)
 
+	// Storage pool health functions ("storage_pool_health" API extension)
+	GetStoragePoolHealth(name string) (health *api.StoragePoolHealth, err error)
+	ScrubStoragePool(name string) (op Operation, err error)
+
+	// Storage pool reclaim functions ("storage_pool_reclaim" API extension)
+	ReclaimStoragePool(name string) (op Operation, err error)
+
 	// Storage bucket functions ("storage_buckets" API extension)
 	GetStoragePoolBucketNames(poolName string) ([]string, error)
 	GetStoragePoolBucketsAllProjects(poolName string) ([]api.StorageBucket, error)
@@ -356,6 +403,7 @@ type InstanceServer interface {
 	CreateStoragePoolBucketKey(poolName string, bucketName string, key api.StorageBucketKeysPost) (newKey *api.StorageBucketKey, err error)
 	UpdateStoragePoolBucketKey(poolName string, bucketName string, keyName string, key api.StorageBucketKeyPut, ETag string) (err error)
 	DeleteStoragePoolBucketKey(poolName string, bucketName string, keyName string) (err error)
+	CreateStoragePoolBucketPresignedURL(poolName string, bucketName string, req api.StorageBucketPresignPost) (presign *api.StorageBucketPresign, err error)
 
 	// Storage bucket backup functions ("storage_bucket_backup" API extension)
 	CreateStoragePoolBucketBackup(poolName string, bucketName string, backup api.StorageBucketBackupsPost) (op Operation, err error)
@@ -454,6 +502,13 @@ type InstanceServer interface {
 	UpdateClusterGroup(name string, group api.ClusterGroupPut, ETag string) error
 	GetClusterGroup(name string) (*api.ClusterGroup, string, error)
 
+	// Approval functions
+	GetApprovals() (approvals []api.Approval, err error)
+	GetApprovalsAllProjects() (approvals []api.Approval, err error)
+	GetApproval(id string) (approval *api.Approval, ETag string, err error)
+	ApproveOperation(id string) (err error)
+	RejectOperation(id string) (err error)
+
 	// Warning functions
 	GetWarningUUIDs() (uuids []string, err error)
 	GetWarnings() (warnings []api.Warning, err error)
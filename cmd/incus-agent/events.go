@@ -113,6 +113,23 @@ func eventsPost(d *Daemon, r *http.Request) response.Response {
 }
 
 func eventsProcess(d *Daemon, event api.Event) {
+	// Bring hotplugged CPUs online.
+	if event.Type == "cpu" {
+		type cpuEvent struct {
+			Count int `json:"count"`
+		}
+
+		e := cpuEvent{}
+		err := json.Unmarshal(event.Metadata, &e)
+		if err != nil {
+			return
+		}
+
+		osOnlineCPUs(e.Count)
+
+		return
+	}
+
 	// As we only handle mounts, skip if disabled.
 	if d.Features != nil && !d.Features["mounts"] {
 		return
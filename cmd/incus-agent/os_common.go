@@ -266,3 +266,7 @@ func osReconfigureNetworkInterfaces() {
 func osExecWrapper(ctx context.Context, pty io.ReadWriteCloser) io.ReadWriteCloser {
 	return pty
 }
+
+func osOnlineCPUs(count int) {
+	// Hotplugged CPUs are brought online by the guest itself.
+}
@@ -108,6 +108,38 @@ func osLoadModules() error {
 	return nil
 }
 
+// osOnlineCPUs brings any offline CPU online, waiting for up to count CPUs to show up.
+func osOnlineCPUs(count int) {
+	for range 20 {
+		paths, err := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*/online")
+		if err != nil {
+			return
+		}
+
+		online := len(paths)
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil || strings.TrimSpace(string(content)) == "1" {
+				continue
+			}
+
+			err = os.WriteFile(path, []byte("1"), 0o644)
+			if err != nil {
+				logger.Warn("Failed to online CPU", logger.Ctx{"path": path, "err": err})
+				online--
+			}
+		}
+
+		// The boot CPU usually can't be taken offline so has no online file.
+		if online+1 >= count {
+			return
+		}
+
+		// Wait for the guest kernel to register the new CPUs.
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
 func osMountShared(src string, dst string, fstype string, opts []string) error {
 	// Convert relative mounts to absolute from / otherwise dir creation fails or mount fails.
 	if !strings.HasPrefix(dst, "/") {
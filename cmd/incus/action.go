@@ -142,6 +142,7 @@ type cmdAction struct {
 	flagStateful  bool
 	flagStateless bool
 	flagTimeout   int
+	flagWithDeps  bool
 }
 
 func (c *cmdAction) command(action string) *cobra.Command {
@@ -155,6 +156,7 @@ func (c *cmdAction) command(action string) *cobra.Command {
 		cli.AddBoolFlag(cmd.Flags(), &c.flagStateful, "stateful", i18n.G("Store the instance state"))
 	case "start":
 		cli.AddBoolFlag(cmd.Flags(), &c.flagStateless, "stateless", i18n.G("Ignore the instance state"))
+		cli.AddBoolFlag(cmd.Flags(), &c.flagWithDeps, "with-deps", i18n.G("Start the instances it depends on first"))
 	}
 
 	if slices.Contains([]string{"start", "restart", "stop"}, action) {
@@ -264,10 +266,11 @@ func (c *cmdAction) doAction(action string, conf *config.Config, p *u.Parsed) er
 	}
 
 	req := api.InstanceStatePut{
-		Action:   action,
-		Timeout:  c.flagTimeout,
-		Force:    c.flagForce,
-		Stateful: state,
+		Action:           action,
+		Timeout:          c.flagTimeout,
+		Force:            c.flagForce,
+		Stateful:         state,
+		WithDependencies: action == "start" && c.flagWithDeps,
 	}
 
 	op, err := d.UpdateInstanceState(instanceName, req, "")
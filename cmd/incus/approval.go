@@ -0,0 +1,380 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/lxc/incus/v7/cmd/incus/color"
+	u "github.com/lxc/incus/v7/cmd/incus/usage"
+	"github.com/lxc/incus/v7/internal/i18n"
+	"github.com/lxc/incus/v7/shared/api"
+	cli "github.com/lxc/incus/v7/shared/cmd"
+)
+
+type cmdApproval struct {
+	global *cmdGlobal
+}
+
+type approvalColumn struct {
+	Name string
+	Data func(api.Approval) string
+}
+
+func (c *cmdApproval) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("approval")
+	cmd.Short = i18n.G("Manage privileged operations waiting for approval")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`Manage privileged operations waiting for approval`,
+	))
+
+	// Approve
+	approvalApproveCmd := cmdApprovalApprove{global: c.global, approval: c}
+	cmd.AddCommand(approvalApproveCmd.command())
+
+	// List
+	approvalListCmd := cmdApprovalList{global: c.global, approval: c}
+	cmd.AddCommand(approvalListCmd.command())
+
+	// Reject
+	approvalRejectCmd := cmdApprovalReject{global: c.global, approval: c}
+	cmd.AddCommand(approvalRejectCmd.command())
+
+	// Show
+	approvalShowCmd := cmdApprovalShow{global: c.global, approval: c}
+	cmd.AddCommand(approvalShowCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Approve.
+type cmdApprovalApprove struct {
+	global   *cmdGlobal
+	approval *cmdApproval
+}
+
+var cmdApprovalApproveUsage = u.Usage{u.Approval.Remote().List(1)}
+
+func (c *cmdApprovalApprove) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("approve", cmdApprovalApproveUsage...)
+	cmd.Short = i18n.G("Approve privileged operations")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`Approve privileged operations
+
+The operations are started once approved.
+An operation can't be approved by the user who requested it.`,
+	))
+
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdApprovalApprove) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdApprovalApproveUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, p := range parsed[0].List {
+		d := p.RemoteServer
+		approvalID := p.RemoteObject.String
+
+		err = d.ApproveOperation(approvalID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Operation %s approved")+"\n", formatRemote(c.global.conf, p))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// List.
+type cmdApprovalList struct {
+	global   *cmdGlobal
+	approval *cmdApproval
+
+	flagFormat      string
+	flagColumns     string
+	flagAllProjects bool
+}
+
+var cmdApprovalListUsage = u.Usage{u.RemoteColonOpt}
+
+func (c *cmdApprovalList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("list", cmdApprovalListUsage...)
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List privileged operations waiting for approval")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`List privileged operations waiting for approval
+
+Default column layout: itdrC
+
+== Columns ==
+The -c option takes a comma separated list of arguments that control
+which attributes of the approvals to output when displaying in table
+or csv format.
+
+Commas between consecutive shorthand chars are optional.
+
+Pre-defined column shorthand chars:
+  i - ID
+  t - Type
+  d - Description
+  p - Project
+  r - Requestor
+  C - Created
+  L - Location of the operation (e.g. its cluster member)`,
+	))
+	cli.AddStringFlag(cmd.Flags(), &c.flagFormat, "format|f", c.global.defaultListFormat(), "", i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagAllProjects, "all-projects", i18n.G("List approvals from all projects"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagColumns, "columns|c", defaultApprovalColumns, "", i18n.G("Columns"))
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+const defaultApprovalColumns = "itdrC"
+
+func (c *cmdApprovalList) parseColumns(clustered bool) ([]approvalColumn, error) {
+	columnsShorthandMap := map[rune]approvalColumn{
+		'i': {i18n.G("ID"), c.idColumnData},
+		't': {i18n.G("TYPE"), c.typeColumnData},
+		'd': {i18n.G("DESCRIPTION"), c.descriptionColumnData},
+		'p': {i18n.G("PROJECT"), c.projectColumnData},
+		'r': {i18n.G("REQUESTOR"), c.requestorColumnData},
+		'C': {i18n.G("CREATED"), c.createdColumnData},
+		'L': {i18n.G("LOCATION"), c.locationColumnData},
+	}
+
+	columnList := strings.Split(c.flagColumns, ",")
+	columns := []approvalColumn{}
+	if c.flagColumns == defaultApprovalColumns {
+		if c.flagAllProjects {
+			columnList = append(columnList, "p")
+		}
+
+		if clustered {
+			columnList = append(columnList, "L")
+		}
+	}
+
+	for _, columnEntry := range columnList {
+		if columnEntry == "" {
+			return nil, fmt.Errorf(i18n.G("Empty column entry (redundant, leading or trailing command) in '%s'"), c.flagColumns)
+		}
+
+		for _, columnRune := range columnEntry {
+			column, ok := columnsShorthandMap[columnRune]
+			if !ok {
+				return nil, fmt.Errorf(i18n.G("Unknown column shorthand char '%c' in '%s'"), columnRune, columnEntry)
+			}
+
+			columns = append(columns, column)
+		}
+	}
+
+	return columns, nil
+}
+
+func (c *cmdApprovalList) idColumnData(approval api.Approval) string {
+	return approval.ID
+}
+
+func (c *cmdApprovalList) typeColumnData(approval api.Approval) string {
+	return approval.Type
+}
+
+func (c *cmdApprovalList) descriptionColumnData(approval api.Approval) string {
+	return approval.Description
+}
+
+func (c *cmdApprovalList) projectColumnData(approval api.Approval) string {
+	return approval.Project
+}
+
+func (c *cmdApprovalList) requestorColumnData(approval api.Approval) string {
+	return approval.Requestor.Username
+}
+
+func (c *cmdApprovalList) createdColumnData(approval api.Approval) string {
+	return approval.CreatedAt.Local().Format(dateLayout)
+}
+
+func (c *cmdApprovalList) locationColumnData(approval api.Approval) string {
+	return approval.Location
+}
+
+func (c *cmdApprovalList) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdApprovalListUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	// Get the approvals
+	var approvals []api.Approval
+	if c.flagAllProjects {
+		approvals, err = d.GetApprovalsAllProjects()
+	} else {
+		approvals, err = d.GetApprovals()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Parse column flags.
+	columns, err := c.parseColumns(d.IsClustered())
+	if err != nil {
+		return err
+	}
+
+	// Render the table
+	data := [][]string{}
+	for _, approval := range approvals {
+		line := []string{}
+		for _, column := range columns {
+			line = append(line, column.Data(approval))
+		}
+
+		data = append(data, line)
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{}
+	for _, column := range columns {
+		header = append(header, column.Name)
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, approvals)
+}
+
+// Reject.
+type cmdApprovalReject struct {
+	global   *cmdGlobal
+	approval *cmdApproval
+}
+
+var cmdApprovalRejectUsage = u.Usage{u.Approval.Remote().List(1)}
+
+func (c *cmdApprovalReject) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("reject", cmdApprovalRejectUsage...)
+	cmd.Short = i18n.G("Reject privileged operations")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`Reject privileged operations
+
+The operations are cancelled once rejected.
+The user who requested an operation can also reject it to withdraw it.`,
+	))
+
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdApprovalReject) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdApprovalRejectUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, p := range parsed[0].List {
+		d := p.RemoteServer
+		approvalID := p.RemoteObject.String
+
+		err = d.RejectOperation(approvalID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Operation %s rejected")+"\n", formatRemote(c.global.conf, p))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// Show.
+type cmdApprovalShow struct {
+	global   *cmdGlobal
+	approval *cmdApproval
+}
+
+var cmdApprovalShowUsage = u.Usage{u.Approval.Remote()}
+
+func (c *cmdApprovalShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("show", cmdApprovalShowUsage...)
+	cmd.Short = i18n.G("Show details on a privileged operation waiting for approval")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`Show details on a privileged operation waiting for approval`,
+	))
+
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdApprovalShow) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdApprovalShowUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	approvalID := parsed[0].RemoteObject.String
+
+	approval, _, err := d.GetApproval(approvalID)
+	if err != nil {
+		return err
+	}
+
+	// Render as YAML
+	data, err := yaml.Dump(&approval, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
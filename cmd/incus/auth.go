@@ -0,0 +1,1848 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	yaml "go.yaml.in/yaml/v4"
+
+	"github.com/lxc/incus/v7/cmd/incus/color"
+	u "github.com/lxc/incus/v7/cmd/incus/usage"
+	"github.com/lxc/incus/v7/internal/i18n"
+	"github.com/lxc/incus/v7/internal/instance"
+	"github.com/lxc/incus/v7/shared/api"
+	cli "github.com/lxc/incus/v7/shared/cmd"
+	"github.com/lxc/incus/v7/shared/termios"
+)
+
+type cmdAuth struct {
+	global *cmdGlobal
+}
+
+func (c *cmdAuth) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("auth")
+	cmd.Short = i18n.G("Manage authorization groups, identities and API tokens")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage authorization groups, identities and API tokens
+
+The group and identity commands manage the built-in role-based access control (authorization.rbac).
+The token commands manage scoped API tokens.
+The certificate commands manage short-lived client certificates issued by the server.`))
+
+	// Group
+	authGroupCmd := cmdAuthGroup{global: c.global, auth: c}
+	cmd.AddCommand(authGroupCmd.command())
+
+	// Identity
+	authIdentityCmd := cmdAuthIdentity{global: c.global, auth: c}
+	cmd.AddCommand(authIdentityCmd.command())
+
+	// Token
+	authTokenCmd := cmdAuthToken{global: c.global, auth: c}
+	cmd.AddCommand(authTokenCmd.command())
+
+	// Certificate
+	authCertificateCmd := cmdAuthCertificate{global: c.global, auth: c}
+	cmd.AddCommand(authCertificateCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// parseAuthIdentity splits an identity in the `<authentication method>/<identifier>` form.
+func parseAuthIdentity(identity string) (string, string, error) {
+	authenticationMethod, identifier, ok := strings.Cut(identity, "/")
+	if !ok || authenticationMethod == "" || identifier == "" {
+		return "", "", fmt.Errorf(i18n.G("Invalid identity %q, must be <authentication method>/<identifier>"), identity)
+	}
+
+	return authenticationMethod, identifier, nil
+}
+
+// Group.
+type cmdAuthGroup struct {
+	global *cmdGlobal
+	auth   *cmdAuth
+}
+
+func (c *cmdAuthGroup) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("group")
+	cmd.Short = i18n.G("Manage authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage authorization groups`))
+
+	// Create
+	authGroupCreateCmd := cmdAuthGroupCreate{global: c.global, authGroup: c}
+	cmd.AddCommand(authGroupCreateCmd.command())
+
+	// Delete
+	authGroupDeleteCmd := cmdAuthGroupDelete{global: c.global, authGroup: c}
+	cmd.AddCommand(authGroupDeleteCmd.command())
+
+	// Edit
+	authGroupEditCmd := cmdAuthGroupEdit{global: c.global, authGroup: c}
+	cmd.AddCommand(authGroupEditCmd.command())
+
+	// List
+	authGroupListCmd := cmdAuthGroupList{global: c.global, authGroup: c}
+	cmd.AddCommand(authGroupListCmd.command())
+
+	// Permission
+	authGroupPermissionCmd := cmdAuthGroupPermission{global: c.global, authGroup: c}
+	cmd.AddCommand(authGroupPermissionCmd.command())
+
+	// Rename
+	authGroupRenameCmd := cmdAuthGroupRename{global: c.global, authGroup: c}
+	cmd.AddCommand(authGroupRenameCmd.command())
+
+	// Show
+	authGroupShowCmd := cmdAuthGroupShow{global: c.global, authGroup: c}
+	cmd.AddCommand(authGroupShowCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Create.
+type cmdAuthGroupCreate struct {
+	global    *cmdGlobal
+	authGroup *cmdAuthGroup
+
+	flagDescription string
+}
+
+var cmdAuthGroupCreateUsage = u.Usage{u.NewName(u.Group).Remote()}
+
+func (c *cmdAuthGroupCreate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("create", cmdAuthGroupCreateUsage...)
+	cmd.Short = i18n.G("Create authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Create authorization groups`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus auth group create operators
+    Create an authorization group called operators
+
+incus auth group create operators < group.yaml
+    Create an authorization group with the description and permissions from group.yaml`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Authorization group description"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupCreate) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupCreateUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[0].RemoteObject.String
+
+	var groupPut api.AuthGroupPut
+	if !termios.IsTerminal(getStdinFd()) {
+		loader, err := yaml.NewLoader(os.Stdin, yaml.WithKnownFields())
+		if err != nil {
+			return err
+		}
+
+		err = loader.Load(&groupPut)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+
+	group := api.AuthGroupsPost{
+		AuthGroupPost: api.AuthGroupPost{
+			Name: groupName,
+		},
+		AuthGroupPut: groupPut,
+	}
+
+	if c.flagDescription != "" {
+		group.Description = c.flagDescription
+	}
+
+	err = d.CreateAuthGroup(group)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Authorization group %s created")+"\n", formatRemote(c.global.conf, parsed[0]))
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdAuthGroupDelete struct {
+	global    *cmdGlobal
+	authGroup *cmdAuthGroup
+}
+
+var cmdAuthGroupDeleteUsage = u.Usage{u.Group.Remote().List(1)}
+
+func (c *cmdAuthGroupDelete) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("delete", cmdAuthGroupDeleteUsage...)
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = i18n.G("Delete authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Delete authorization groups`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.global.cmpAuthGroups(toComplete)
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupDelete) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupDeleteUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range parsed[0].List {
+		d := p.RemoteServer
+		groupName := p.RemoteObject.String
+
+		err = d.DeleteAuthGroup(groupName)
+		if err != nil {
+			return err
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Authorization group %s deleted")+"\n", formatRemote(c.global.conf, p))
+		}
+	}
+
+	return nil
+}
+
+// Edit.
+type cmdAuthGroupEdit struct {
+	global    *cmdGlobal
+	authGroup *cmdAuthGroup
+}
+
+var cmdAuthGroupEditUsage = u.Usage{u.Group.Remote()}
+
+func (c *cmdAuthGroupEdit) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("edit", cmdAuthGroupEditUsage...)
+	cmd.Short = i18n.G("Edit authorization groups as YAML")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Edit authorization groups as YAML`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the authorization group.
+### Any line starting with a '#' will be ignored.
+###
+### A sample authorization group looks like:
+### name: operators
+### description: Project operators
+### permissions:
+### - entitlement: operator
+###   object: project:default
+### - entitlement: can_view
+###   object: storage_pool:default
+###
+### Note that the name and identities are shown but cannot be changed here.`)
+}
+
+func (c *cmdAuthGroupEdit) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupEditUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[0].RemoteObject.String
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		loader, err := yaml.NewLoader(os.Stdin, yaml.WithKnownFields())
+		if err != nil {
+			return err
+		}
+
+		newdata := api.AuthGroupPut{}
+		err = loader.Load(&newdata)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		return d.UpdateAuthGroup(groupName, newdata, "")
+	}
+
+	// Extract the current value
+	group, etag, err := d.GetAuthGroup(groupName)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&group, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor
+	content, err := cli.TextEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor
+		newdata := api.AuthGroup{}
+		err = yaml.Load(content, &newdata, yaml.WithKnownFields())
+		if err == nil {
+			err = d.UpdateAuthGroup(groupName, newdata.Writable(), etag)
+		}
+
+		// Respawn the editor
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = cli.TextEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// List.
+type cmdAuthGroupList struct {
+	global    *cmdGlobal
+	authGroup *cmdAuthGroup
+
+	flagFormat string
+}
+
+var cmdAuthGroupListUsage = u.Usage{u.RemoteColonOpt}
+
+func (c *cmdAuthGroupList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("list", cmdAuthGroupListUsage...)
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`List authorization groups`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagFormat, "format|f", c.global.defaultListFormat(), "", i18n.G("Format (csv|json|table|yaml|compact|markdown)"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupList) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupListUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	groups, err := d.GetAuthGroups()
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, group := range groups {
+		data = append(data, []string{
+			group.Name,
+			group.Description,
+			fmt.Sprintf("%d", len(group.Permissions)),
+			fmt.Sprintf("%d", len(group.Identities)),
+		})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("DESCRIPTION"),
+		i18n.G("PERMISSIONS"),
+		i18n.G("IDENTITIES"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, groups)
+}
+
+// Permission.
+type cmdAuthGroupPermission struct {
+	global    *cmdGlobal
+	authGroup *cmdAuthGroup
+}
+
+func (c *cmdAuthGroupPermission) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("permission")
+	cmd.Short = i18n.G("Manage authorization group permissions")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage authorization group permissions
+
+Permissions grant an entitlement on an object, for example:
+  operator project:default
+  can_exec instance:default/c1
+  admin server:incus`))
+
+	// Add
+	authGroupPermissionAddCmd := cmdAuthGroupPermissionAdd{global: c.global, authGroupPermission: c}
+	cmd.AddCommand(authGroupPermissionAddCmd.command())
+
+	// Remove
+	authGroupPermissionRemoveCmd := cmdAuthGroupPermissionRemove{global: c.global, authGroupPermission: c}
+	cmd.AddCommand(authGroupPermissionRemoveCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Add.
+type cmdAuthGroupPermissionAdd struct {
+	global              *cmdGlobal
+	authGroupPermission *cmdAuthGroupPermission
+}
+
+var cmdAuthGroupPermissionAddUsage = u.Usage{u.Group.Remote(), u.Entitlement, u.Object}
+
+func (c *cmdAuthGroupPermissionAdd) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("add", cmdAuthGroupPermissionAddUsage...)
+	cmd.Short = i18n.G("Add permissions to authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Add permissions to authorization groups`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus auth group permission add operators operator project:default
+    Make members of the operators group operators of the default project`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupPermissionAdd) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupPermissionAddUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[0].RemoteObject.String
+	permission := api.AuthPermission{Entitlement: parsed[1].String, Object: parsed[2].String}
+
+	group, etag, err := d.GetAuthGroup(groupName)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(group.Permissions, permission) {
+		return fmt.Errorf(i18n.G("Authorization group %s already has permission %s on %s"), groupName, permission.Entitlement, permission.Object)
+	}
+
+	group.Permissions = append(group.Permissions, permission)
+
+	return d.UpdateAuthGroup(groupName, group.Writable(), etag)
+}
+
+// Remove.
+type cmdAuthGroupPermissionRemove struct {
+	global              *cmdGlobal
+	authGroupPermission *cmdAuthGroupPermission
+}
+
+var cmdAuthGroupPermissionRemoveUsage = u.Usage{u.Group.Remote(), u.Entitlement, u.Object}
+
+func (c *cmdAuthGroupPermissionRemove) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("remove", cmdAuthGroupPermissionRemoveUsage...)
+	cmd.Short = i18n.G("Remove permissions from authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Remove permissions from authorization groups`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupPermissionRemove) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupPermissionRemoveUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[0].RemoteObject.String
+	permission := api.AuthPermission{Entitlement: parsed[1].String, Object: parsed[2].String}
+
+	group, etag, err := d.GetAuthGroup(groupName)
+	if err != nil {
+		return err
+	}
+
+	index := slices.Index(group.Permissions, permission)
+	if index < 0 {
+		return fmt.Errorf(i18n.G("Authorization group %s doesn't have permission %s on %s"), groupName, permission.Entitlement, permission.Object)
+	}
+
+	group.Permissions = slices.Delete(group.Permissions, index, index+1)
+
+	return d.UpdateAuthGroup(groupName, group.Writable(), etag)
+}
+
+// Rename.
+type cmdAuthGroupRename struct {
+	global    *cmdGlobal
+	authGroup *cmdAuthGroup
+}
+
+var cmdAuthGroupRenameUsage = u.Usage{u.Group.Remote(), u.NewName(u.Group)}
+
+func (c *cmdAuthGroupRename) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("rename", cmdAuthGroupRenameUsage...)
+	cmd.Aliases = []string{"mv"}
+	cmd.Short = i18n.G("Rename authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Rename authorization groups`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupRename) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupRenameUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[0].RemoteObject.String
+	newGroupName := parsed[1].String
+
+	err = d.RenameAuthGroup(groupName, api.AuthGroupPost{Name: newGroupName})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Authorization group %s renamed to %s")+"\n", formatRemote(c.global.conf, parsed[0]), newGroupName)
+	}
+
+	return nil
+}
+
+// Show.
+type cmdAuthGroupShow struct {
+	global    *cmdGlobal
+	authGroup *cmdAuthGroup
+}
+
+var cmdAuthGroupShowUsage = u.Usage{u.Group.Remote()}
+
+func (c *cmdAuthGroupShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("show", cmdAuthGroupShowUsage...)
+	cmd.Short = i18n.G("Show authorization group configurations")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Show authorization group configurations`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthGroupShow) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthGroupShowUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[0].RemoteObject.String
+
+	group, _, err := d.GetAuthGroup(groupName)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&group, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
+// Identity.
+type cmdAuthIdentity struct {
+	global *cmdGlobal
+	auth   *cmdAuth
+}
+
+func (c *cmdAuthIdentity) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("identity")
+	cmd.Short = i18n.G("Manage identities")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage identities
+
+Identities are referred to as <authentication method>/<identifier>, for example:
+  oidc/jane@example.com
+  tls/<certificate fingerprint>`))
+
+	// Create
+	authIdentityCreateCmd := cmdAuthIdentityCreate{global: c.global, authIdentity: c}
+	cmd.AddCommand(authIdentityCreateCmd.command())
+
+	// Delete
+	authIdentityDeleteCmd := cmdAuthIdentityDelete{global: c.global, authIdentity: c}
+	cmd.AddCommand(authIdentityDeleteCmd.command())
+
+	// Edit
+	authIdentityEditCmd := cmdAuthIdentityEdit{global: c.global, authIdentity: c}
+	cmd.AddCommand(authIdentityEditCmd.command())
+
+	// Group
+	authIdentityGroupCmd := cmdAuthIdentityGroup{global: c.global, authIdentity: c}
+	cmd.AddCommand(authIdentityGroupCmd.command())
+
+	// List
+	authIdentityListCmd := cmdAuthIdentityList{global: c.global, authIdentity: c}
+	cmd.AddCommand(authIdentityListCmd.command())
+
+	// Show
+	authIdentityShowCmd := cmdAuthIdentityShow{global: c.global, authIdentity: c}
+	cmd.AddCommand(authIdentityShowCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Create.
+type cmdAuthIdentityCreate struct {
+	global       *cmdGlobal
+	authIdentity *cmdAuthIdentity
+
+	flagDescription string
+	flagGroups      []string
+}
+
+var cmdAuthIdentityCreateUsage = u.Usage{u.Identity.Remote()}
+
+func (c *cmdAuthIdentityCreate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("create", cmdAuthIdentityCreateUsage...)
+	cmd.Short = i18n.G("Create identities")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Create identities`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus auth identity create oidc/jane@example.com --group operators
+    Add the OIDC user jane@example.com to the operators authorization group`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Identity description"))
+	cli.AddStringArrayFlag(cmd.Flags(), &c.flagGroups, "group|g", i18n.G("Authorization group to add the identity to"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthIdentityCreate) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthIdentityCreateUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	authenticationMethod, identifier, err := parseAuthIdentity(parsed[0].RemoteObject.String)
+	if err != nil {
+		return err
+	}
+
+	var identityPut api.AuthIdentityPut
+	if !termios.IsTerminal(getStdinFd()) {
+		loader, err := yaml.NewLoader(os.Stdin, yaml.WithKnownFields())
+		if err != nil {
+			return err
+		}
+
+		err = loader.Load(&identityPut)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+
+	identity := api.AuthIdentitiesPost{
+		AuthIdentityPut:      identityPut,
+		AuthenticationMethod: authenticationMethod,
+		Identifier:           identifier,
+	}
+
+	if c.flagDescription != "" {
+		identity.Description = c.flagDescription
+	}
+
+	identity.Groups = append(identity.Groups, c.flagGroups...)
+
+	err = d.CreateAuthIdentity(identity)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Identity %s created")+"\n", formatRemote(c.global.conf, parsed[0]))
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdAuthIdentityDelete struct {
+	global       *cmdGlobal
+	authIdentity *cmdAuthIdentity
+}
+
+var cmdAuthIdentityDeleteUsage = u.Usage{u.Identity.Remote().List(1)}
+
+func (c *cmdAuthIdentityDelete) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("delete", cmdAuthIdentityDeleteUsage...)
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = i18n.G("Delete identities")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Delete identities`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.global.cmpAuthIdentities(toComplete)
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthIdentityDelete) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthIdentityDeleteUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range parsed[0].List {
+		d := p.RemoteServer
+
+		authenticationMethod, identifier, err := parseAuthIdentity(p.RemoteObject.String)
+		if err != nil {
+			return err
+		}
+
+		err = d.DeleteAuthIdentity(authenticationMethod, identifier)
+		if err != nil {
+			return err
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Identity %s deleted")+"\n", formatRemote(c.global.conf, p))
+		}
+	}
+
+	return nil
+}
+
+// Edit.
+type cmdAuthIdentityEdit struct {
+	global       *cmdGlobal
+	authIdentity *cmdAuthIdentity
+}
+
+var cmdAuthIdentityEditUsage = u.Usage{u.Identity.Remote()}
+
+func (c *cmdAuthIdentityEdit) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("edit", cmdAuthIdentityEditUsage...)
+	cmd.Short = i18n.G("Edit identities as YAML")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Edit identities as YAML`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthIdentities(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthIdentityEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the identity.
+### Any line starting with a '#' will be ignored.
+###
+### A sample identity looks like:
+### authentication_method: oidc
+### identifier: jane@example.com
+### description: Jane Doe
+### groups:
+### - operators
+###
+### Note that the authentication method and identifier cannot be changed.`)
+}
+
+func (c *cmdAuthIdentityEdit) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthIdentityEditUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	authenticationMethod, identifier, err := parseAuthIdentity(parsed[0].RemoteObject.String)
+	if err != nil {
+		return err
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		loader, err := yaml.NewLoader(os.Stdin, yaml.WithKnownFields())
+		if err != nil {
+			return err
+		}
+
+		newdata := api.AuthIdentityPut{}
+		err = loader.Load(&newdata)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		return d.UpdateAuthIdentity(authenticationMethod, identifier, newdata, "")
+	}
+
+	// Extract the current value
+	identity, etag, err := d.GetAuthIdentity(authenticationMethod, identifier)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&identity, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor
+	content, err := cli.TextEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor
+		newdata := api.AuthIdentity{}
+		err = yaml.Load(content, &newdata, yaml.WithKnownFields())
+		if err == nil {
+			err = d.UpdateAuthIdentity(authenticationMethod, identifier, newdata.Writable(), etag)
+		}
+
+		// Respawn the editor
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = cli.TextEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// Group.
+type cmdAuthIdentityGroup struct {
+	global       *cmdGlobal
+	authIdentity *cmdAuthIdentity
+}
+
+func (c *cmdAuthIdentityGroup) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("group")
+	cmd.Short = i18n.G("Manage the authorization groups of identities")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage the authorization groups of identities`))
+
+	// Add
+	authIdentityGroupAddCmd := cmdAuthIdentityGroupAdd{global: c.global, authIdentityGroup: c}
+	cmd.AddCommand(authIdentityGroupAddCmd.command())
+
+	// Remove
+	authIdentityGroupRemoveCmd := cmdAuthIdentityGroupRemove{global: c.global, authIdentityGroup: c}
+	cmd.AddCommand(authIdentityGroupRemoveCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Add.
+type cmdAuthIdentityGroupAdd struct {
+	global            *cmdGlobal
+	authIdentityGroup *cmdAuthIdentityGroup
+}
+
+var cmdAuthIdentityGroupAddUsage = u.Usage{u.Identity.Remote(), u.Group}
+
+func (c *cmdAuthIdentityGroupAdd) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("add", cmdAuthIdentityGroupAddUsage...)
+	cmd.Short = i18n.G("Add identities to authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Add identities to authorization groups`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthIdentities(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthIdentityGroupAdd) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthIdentityGroupAddUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[1].String
+
+	authenticationMethod, identifier, err := parseAuthIdentity(parsed[0].RemoteObject.String)
+	if err != nil {
+		return err
+	}
+
+	identity, etag, err := d.GetAuthIdentity(authenticationMethod, identifier)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(identity.Groups, groupName) {
+		return fmt.Errorf(i18n.G("Identity %s is already a member of authorization group %s"), parsed[0].RemoteObject.String, groupName)
+	}
+
+	identity.Groups = append(identity.Groups, groupName)
+
+	err = d.UpdateAuthIdentity(authenticationMethod, identifier, identity.Writable(), etag)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Identity %s added to authorization group %s")+"\n", formatRemote(c.global.conf, parsed[0]), groupName)
+	}
+
+	return nil
+}
+
+// Remove.
+type cmdAuthIdentityGroupRemove struct {
+	global            *cmdGlobal
+	authIdentityGroup *cmdAuthIdentityGroup
+}
+
+var cmdAuthIdentityGroupRemoveUsage = u.Usage{u.Identity.Remote(), u.Group}
+
+func (c *cmdAuthIdentityGroupRemove) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("remove", cmdAuthIdentityGroupRemoveUsage...)
+	cmd.Short = i18n.G("Remove identities from authorization groups")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Remove identities from authorization groups`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthIdentities(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthIdentityGroupRemove) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthIdentityGroupRemoveUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	groupName := parsed[1].String
+
+	authenticationMethod, identifier, err := parseAuthIdentity(parsed[0].RemoteObject.String)
+	if err != nil {
+		return err
+	}
+
+	identity, etag, err := d.GetAuthIdentity(authenticationMethod, identifier)
+	if err != nil {
+		return err
+	}
+
+	index := slices.Index(identity.Groups, groupName)
+	if index < 0 {
+		return fmt.Errorf(i18n.G("Identity %s isn't a member of authorization group %s"), parsed[0].RemoteObject.String, groupName)
+	}
+
+	identity.Groups = slices.Delete(identity.Groups, index, index+1)
+
+	err = d.UpdateAuthIdentity(authenticationMethod, identifier, identity.Writable(), etag)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Identity %s removed from authorization group %s")+"\n", formatRemote(c.global.conf, parsed[0]), groupName)
+	}
+
+	return nil
+}
+
+// List.
+type cmdAuthIdentityList struct {
+	global       *cmdGlobal
+	authIdentity *cmdAuthIdentity
+
+	flagFormat string
+}
+
+var cmdAuthIdentityListUsage = u.Usage{u.RemoteColonOpt}
+
+func (c *cmdAuthIdentityList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("list", cmdAuthIdentityListUsage...)
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List identities")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`List identities`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagFormat, "format|f", c.global.defaultListFormat(), "", i18n.G("Format (csv|json|table|yaml|compact|markdown)"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthIdentityList) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthIdentityListUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	identities, err := d.GetAuthIdentities()
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, identity := range identities {
+		data = append(data, []string{
+			identity.AuthenticationMethod,
+			identity.Identifier,
+			identity.Description,
+			strings.Join(identity.Groups, "\n"),
+		})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("AUTHENTICATION METHOD"),
+		i18n.G("IDENTIFIER"),
+		i18n.G("DESCRIPTION"),
+		i18n.G("GROUPS"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, identities)
+}
+
+// Show.
+type cmdAuthIdentityShow struct {
+	global       *cmdGlobal
+	authIdentity *cmdAuthIdentity
+}
+
+var cmdAuthIdentityShowUsage = u.Usage{u.Identity.Remote()}
+
+func (c *cmdAuthIdentityShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("show", cmdAuthIdentityShowUsage...)
+	cmd.Short = i18n.G("Show identities")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Show identities`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthIdentities(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthIdentityShow) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthIdentityShowUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	authenticationMethod, identifier, err := parseAuthIdentity(parsed[0].RemoteObject.String)
+	if err != nil {
+		return err
+	}
+
+	identity, _, err := d.GetAuthIdentity(authenticationMethod, identifier)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&identity, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
+// Token.
+type cmdAuthToken struct {
+	global *cmdGlobal
+	auth   *cmdAuth
+}
+
+func (c *cmdAuthToken) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("token")
+	cmd.Short = i18n.G("Manage API tokens")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage API tokens
+
+API tokens are bearer tokens restricted to a set of projects and entitlements.
+They can be used as an alternative to client certificates by non-interactive clients.`))
+
+	// Create
+	authTokenCreateCmd := cmdAuthTokenCreate{global: c.global, authToken: c}
+	cmd.AddCommand(authTokenCreateCmd.command())
+
+	// Delete
+	authTokenDeleteCmd := cmdAuthTokenDelete{global: c.global, authToken: c}
+	cmd.AddCommand(authTokenDeleteCmd.command())
+
+	// List
+	authTokenListCmd := cmdAuthTokenList{global: c.global, authToken: c}
+	cmd.AddCommand(authTokenListCmd.command())
+
+	// Show
+	authTokenShowCmd := cmdAuthTokenShow{global: c.global, authToken: c}
+	cmd.AddCommand(authTokenShowCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Create.
+type cmdAuthTokenCreate struct {
+	global    *cmdGlobal
+	authToken *cmdAuthToken
+
+	flagDescription  string
+	flagProjects     []string
+	flagEntitlements []string
+	flagExpiry       string
+}
+
+var cmdAuthTokenCreateUsage = u.Usage{u.NewName(u.Token).Remote()}
+
+func (c *cmdAuthTokenCreate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("create", cmdAuthTokenCreateUsage...)
+	cmd.Short = i18n.G("Create API tokens")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Create API tokens
+
+The secret of the token is only shown once, on creation.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus auth token create webapp-ci --project webapp --entitlement can_view --entitlement can_edit --entitlement can_create_instances --expiry 30d
+    Create an API token for the webapp project, expiring in 30 days`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("API token description"))
+	cli.AddStringArrayFlag(cmd.Flags(), &c.flagProjects, "project", i18n.G("Project the API token is restricted to (can be repeated, defaults to all projects)"))
+	cli.AddStringArrayFlag(cmd.Flags(), &c.flagEntitlements, "entitlement", i18n.G("Entitlement granted to the API token (can be repeated)"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagExpiry, "expiry", "", "", i18n.G("Expiry of the API token (either a time span like `1d 3H` or a date in `2006/01/02 15:04 MST` format)"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthTokenCreate) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthTokenCreateUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	tokenName := parsed[0].RemoteObject.String
+
+	token := api.AuthTokensPost{
+		AuthTokenPut: api.AuthTokenPut{
+			Description: c.flagDescription,
+		},
+		Name:         tokenName,
+		Projects:     c.flagProjects,
+		Entitlements: c.flagEntitlements,
+	}
+
+	if c.flagExpiry != "" {
+		// Try to parse as a duration.
+		expiry, err := instance.GetExpiry(time.Now(), c.flagExpiry)
+		if err != nil {
+			if !errors.Is(err, instance.ErrInvalidExpiry) {
+				return err
+			}
+
+			// Fallback to date parsing.
+			expiry, err = time.Parse(dateLayout, c.flagExpiry)
+			if err != nil {
+				return err
+			}
+		}
+
+		token.ExpiresAt = expiry
+	}
+
+	secret, err := d.CreateAuthToken(token)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("API token %s created")+"\n", formatRemote(c.global.conf, parsed[0]))
+		fmt.Printf(i18n.G("Secret (only shown once): %s")+"\n", secret.Token)
+	} else {
+		fmt.Println(secret.Token)
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdAuthTokenDelete struct {
+	global    *cmdGlobal
+	authToken *cmdAuthToken
+}
+
+var cmdAuthTokenDeleteUsage = u.Usage{u.Token.Remote().List(1)}
+
+func (c *cmdAuthTokenDelete) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("delete", cmdAuthTokenDeleteUsage...)
+	cmd.Aliases = []string{"rm", "revoke"}
+	cmd.Short = i18n.G("Revoke API tokens")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Revoke API tokens`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.global.cmpAuthTokens(toComplete)
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthTokenDelete) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthTokenDeleteUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range parsed[0].List {
+		d := p.RemoteServer
+		tokenName := p.RemoteObject.String
+
+		err = d.DeleteAuthToken(tokenName)
+		if err != nil {
+			return err
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("API token %s revoked")+"\n", formatRemote(c.global.conf, p))
+		}
+	}
+
+	return nil
+}
+
+// List.
+type cmdAuthTokenList struct {
+	global    *cmdGlobal
+	authToken *cmdAuthToken
+
+	flagFormat string
+}
+
+var cmdAuthTokenListUsage = u.Usage{u.RemoteColonOpt}
+
+func (c *cmdAuthTokenList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("list", cmdAuthTokenListUsage...)
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List API tokens")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`List API tokens`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagFormat, "format|f", c.global.defaultListFormat(), "", i18n.G("Format (csv|json|table|yaml|compact|markdown)"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthTokenList) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthTokenListUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	tokens, err := d.GetAuthTokens()
+	if err != nil {
+		return err
+	}
+
+	formatDate := func(date time.Time) string {
+		if date.IsZero() {
+			return ""
+		}
+
+		return date.Local().Format(dateLayout)
+	}
+
+	data := [][]string{}
+	for _, token := range tokens {
+		projects := strings.Join(token.Projects, ", ")
+		if projects == "" {
+			projects = i18n.G("all")
+		}
+
+		data = append(data, []string{
+			token.Name,
+			token.Description,
+			projects,
+			strings.Join(token.Entitlements, ", "),
+			formatDate(token.ExpiresAt),
+			formatDate(token.LastUsedAt),
+		})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("DESCRIPTION"),
+		i18n.G("PROJECTS"),
+		i18n.G("ENTITLEMENTS"),
+		i18n.G("EXPIRES AT"),
+		i18n.G("LAST USED AT"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, tokens)
+}
+
+// Show.
+type cmdAuthTokenShow struct {
+	global    *cmdGlobal
+	authToken *cmdAuthToken
+}
+
+var cmdAuthTokenShowUsage = u.Usage{u.Token.Remote()}
+
+func (c *cmdAuthTokenShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("show", cmdAuthTokenShowUsage...)
+	cmd.Short = i18n.G("Show API token details")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Show API token details`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpAuthTokens(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthTokenShow) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthTokenShowUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	tokenName := parsed[0].RemoteObject.String
+
+	token, _, err := d.GetAuthToken(tokenName)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&token, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
+// Certificate.
+type cmdAuthCertificate struct {
+	global *cmdGlobal
+	auth   *cmdAuth
+}
+
+func (c *cmdAuthCertificate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("certificate")
+	cmd.Short = i18n.G("Manage issued client certificates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage issued client certificates
+
+OIDC users and API tokens can request short-lived client certificates from the server.
+Requests made with those certificates get the identity and permissions of the original requestor.`))
+
+	// Issue
+	authCertificateIssueCmd := cmdAuthCertificateIssue{global: c.global, authCertificate: c}
+	cmd.AddCommand(authCertificateIssueCmd.command())
+
+	// List
+	authCertificateListCmd := cmdAuthCertificateList{global: c.global, authCertificate: c}
+	cmd.AddCommand(authCertificateListCmd.command())
+
+	// Revoke
+	authCertificateRevokeCmd := cmdAuthCertificateRevoke{global: c.global, authCertificate: c}
+	cmd.AddCommand(authCertificateRevokeCmd.command())
+
+	// Show
+	authCertificateShowCmd := cmdAuthCertificateShow{global: c.global, authCertificate: c}
+	cmd.AddCommand(authCertificateShowCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Issue.
+type cmdAuthCertificateIssue struct {
+	global          *cmdGlobal
+	authCertificate *cmdAuthCertificate
+
+	flagCertFile string
+	flagKeyFile  string
+	flagExpiry   string
+}
+
+var cmdAuthCertificateIssueUsage = u.Usage{u.RemoteColonOpt}
+
+func (c *cmdAuthCertificateIssue) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("issue", cmdAuthCertificateIssueUsage...)
+	cmd.Short = i18n.G("Issue client certificates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Issue client certificates
+
+A new private key is generated locally and the server signs a certificate for it.
+This requires being authenticated through OIDC or with an API token.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus auth certificate issue my-server: --cert-file client.crt --key-file client.key --expiry 8H
+    Issue a client certificate valid for 8 hours`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagCertFile, "cert-file", "", "", i18n.G("File to write the issued certificate to"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagKeyFile, "key-file", "", "", i18n.G("File to write the private key to"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagExpiry, "expiry", "", "", i18n.G("Expiry of the certificate (either a time span like `1d 3H` or a date in `2006/01/02 15:04 MST` format)"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthCertificateIssue) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthCertificateIssueUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	if c.flagCertFile == "" || c.flagKeyFile == "" {
+		return errors.New(i18n.G("Both --cert-file and --key-file must be provided"))
+	}
+
+	d := parsed[0].RemoteServer
+
+	req := api.AuthCertificatesPost{}
+
+	if c.flagExpiry != "" {
+		// Try to parse as a duration.
+		expiry, err := instance.GetExpiry(time.Now(), c.flagExpiry)
+		if err != nil {
+			if !errors.Is(err, instance.ErrInvalidExpiry) {
+				return err
+			}
+
+			// Fallback to date parsing.
+			expiry, err = time.Parse(dateLayout, c.flagExpiry)
+			if err != nil {
+				return err
+			}
+		}
+
+		req.ExpiresAt = expiry
+	}
+
+	// Generate the key and the certificate request.
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to generate key: %w"), err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to create certificate request: %w"), err)
+	}
+
+	req.CSR = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}))
+
+	certificate, err := d.IssueAuthCertificate(req)
+	if err != nil {
+		return err
+	}
+
+	keyData, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(c.flagKeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyData}), 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(c.flagCertFile, []byte(certificate.Certificate), 0o644)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Certificate %s issued, expires at %s")+"\n", certificate.Serial, certificate.ExpiresAt.Local().Format(dateLayout))
+	}
+
+	return nil
+}
+
+// List.
+type cmdAuthCertificateList struct {
+	global          *cmdGlobal
+	authCertificate *cmdAuthCertificate
+
+	flagFormat string
+}
+
+var cmdAuthCertificateListUsage = u.Usage{u.RemoteColonOpt}
+
+func (c *cmdAuthCertificateList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("list", cmdAuthCertificateListUsage...)
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List issued client certificates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`List issued client certificates`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagFormat, "format|f", c.global.defaultListFormat(), "", i18n.G("Format (csv|json|table|yaml|compact|markdown)"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdAuthCertificateList) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthCertificateListUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	certificates, err := d.GetAuthCertificates()
+	if err != nil {
+		return err
+	}
+
+	formatDate := func(date time.Time) string {
+		if date.IsZero() {
+			return ""
+		}
+
+		return date.Local().Format(dateLayout)
+	}
+
+	data := [][]string{}
+	for _, certificate := range certificates {
+		data = append(data, []string{
+			certificate.Serial,
+			certificate.AuthenticationMethod + "/" + certificate.Identifier,
+			formatDate(certificate.CreatedAt),
+			formatDate(certificate.ExpiresAt),
+			formatDate(certificate.RevokedAt),
+		})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("SERIAL"),
+		i18n.G("IDENTITY"),
+		i18n.G("CREATED AT"),
+		i18n.G("EXPIRES AT"),
+		i18n.G("REVOKED AT"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, certificates)
+}
+
+// Revoke.
+type cmdAuthCertificateRevoke struct {
+	global          *cmdGlobal
+	authCertificate *cmdAuthCertificate
+}
+
+var cmdAuthCertificateRevokeUsage = u.Usage{u.Serial.Remote().List(1)}
+
+func (c *cmdAuthCertificateRevoke) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("revoke", cmdAuthCertificateRevokeUsage...)
+	cmd.Short = i18n.G("Revoke issued client certificates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Revoke issued client certificates`))
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdAuthCertificateRevoke) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthCertificateRevokeUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range parsed[0].List {
+		d := p.RemoteServer
+		serial := p.RemoteObject.String
+
+		err = d.RevokeAuthCertificate(serial)
+		if err != nil {
+			return err
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Certificate %s revoked")+"\n", formatRemote(c.global.conf, p))
+		}
+	}
+
+	return nil
+}
+
+// Show.
+type cmdAuthCertificateShow struct {
+	global          *cmdGlobal
+	authCertificate *cmdAuthCertificate
+}
+
+var cmdAuthCertificateShowUsage = u.Usage{u.Serial.Remote()}
+
+func (c *cmdAuthCertificateShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("show", cmdAuthCertificateShowUsage...)
+	cmd.Short = i18n.G("Show issued client certificate details")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Show issued client certificate details`))
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdAuthCertificateShow) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdAuthCertificateShowUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	serial := parsed[0].RemoteObject.String
+
+	certificate, err := d.GetAuthCertificate(serial)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&certificate, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
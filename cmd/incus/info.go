@@ -693,6 +693,21 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, name string, showLog stri
 			fmt.Printf(i18n.G("Started: %s")+"\n", inst.State.StartedAt.Local().Format(dateLayout))
 		}
 
+		// Health check state
+		if inst.State.Health != nil {
+			fmt.Println("\n" + i18n.G("Health:"))
+			fmt.Printf("  "+i18n.G("Status: %s")+"\n", inst.State.Health.Status)
+			fmt.Printf("  "+i18n.G("Failing streak: %d")+"\n", inst.State.Health.FailingStreak)
+
+			if !inst.State.Health.LastCheck.IsZero() {
+				fmt.Printf("  "+i18n.G("Last check: %s")+"\n", inst.State.Health.LastCheck.Local().Format(dateLayout))
+			}
+
+			if inst.State.Health.LastOutput != "" {
+				fmt.Printf("  "+i18n.G("Last output: %s")+"\n", inst.State.Health.LastOutput)
+			}
+		}
+
 		// Operating System info
 		if inst.State.OSInfo != nil {
 			fmt.Println("\n" + i18n.G("Operating System:"))
@@ -10,7 +10,9 @@ import (
 	"reflect"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.yaml.in/yaml/v4"
@@ -80,6 +82,14 @@ func (c *cmdProject) command() *cobra.Command {
 	projectGetInfo := cmdProjectInfo{global: c.global, project: c}
 	cmd.AddCommand(projectGetInfo.command())
 
+	// Usage
+	projectUsageCmd := cmdProjectUsage{global: c.global, project: c}
+	cmd.AddCommand(projectUsageCmd.command())
+
+	// Template
+	projectTemplateCmd := cmdProjectTemplate{global: c.global}
+	cmd.AddCommand(projectTemplateCmd.command())
+
 	// Set default
 	projectSwitchCmd := cmdProjectSwitch{global: c.global, project: c}
 	cmd.AddCommand(projectSwitchCmd.command())
@@ -100,6 +110,7 @@ type cmdProjectCreate struct {
 	project         *cmdProject
 	flagConfig      []string
 	flagDescription string
+	flagTemplate    string
 }
 
 var cmdProjectCreateUsage = u.Usage{u.NewName(u.Project).Remote()}
@@ -114,10 +125,14 @@ func (c *cmdProjectCreate) command() *cobra.Command {
     Create a project named p1
 
 incus project create p1 < config.yaml
-    Create a project named p1 with configuration from config.yaml`))
+    Create a project named p1 with configuration from config.yaml
+
+incus project create p1 --template tenant
+    Create a project named p1 from the project template tenant`))
 
 	cli.AddStringArrayFlag(cmd.Flags(), &c.flagConfig, "config|c", i18n.G("Config key/value to apply to the new project"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Project description"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagTemplate, "template", "", "", i18n.G("Project template to create the project from"))
 
 	cmd.RunE = c.run
 
@@ -176,6 +191,8 @@ func (c *cmdProjectCreate) run(cmd *cobra.Command, args []string) error {
 		project.Description = c.flagDescription
 	}
 
+	project.Template = c.flagTemplate
+
 	err = d.CreateProject(project)
 	if err != nil {
 		return err
@@ -1048,6 +1065,7 @@ func (c *cmdProjectInfo) run(cmd *cobra.Command, args []string) error {
 
 	// Render the output
 	byteLimits := []string{"disk", "memory"}
+	bitLimits := []string{"network.egress", "network.ingress"}
 	data := [][]string{}
 	for k, v := range projectState.Resources {
 		shortKey, _, _ := strings.Cut(k, ".")
@@ -1056,6 +1074,8 @@ func (c *cmdProjectInfo) run(cmd *cobra.Command, args []string) error {
 		if v.Limit >= 0 {
 			if slices.Contains(byteLimits, shortKey) {
 				limit = units.GetByteSizeStringIEC(v.Limit, 2)
+			} else if slices.Contains(bitLimits, k) {
+				limit = units.GetBitSizeString(v.Limit, 2)
 			} else {
 				limit = fmt.Sprintf("%d", v.Limit)
 			}
@@ -1064,6 +1084,8 @@ func (c *cmdProjectInfo) run(cmd *cobra.Command, args []string) error {
 		usage := ""
 		if slices.Contains(byteLimits, shortKey) {
 			usage = units.GetByteSizeStringIEC(v.Usage, 2)
+		} else if slices.Contains(bitLimits, k) {
+			usage = units.GetBitSizeString(v.Usage, 2)
 		} else {
 			usage = fmt.Sprintf("%d", v.Usage)
 		}
@@ -1158,3 +1180,117 @@ func prepareProjectServerFilters(filters []string, i any) []string {
 
 	return formattedFilters
 }
+
+// Usage.
+type cmdProjectUsage struct {
+	global  *cmdGlobal
+	project *cmdProject
+
+	flagFrom   string
+	flagTo     string
+	flagFormat string
+}
+
+var cmdProjectUsageUsage = u.Usage{u.Project.Remote()}
+
+func (c *cmdProjectUsage) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("usage", cmdProjectUsageUsage...)
+	cmd.Short = i18n.G("Show the resources consumed by the instances of a project")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Show the resources consumed by the instances of a project
+
+The resource usage is recorded hourly. By default, the last 30 days are covered.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus project usage webapp --from "2024/03/01 00:00 UTC" --to "2024/04/01 00:00 UTC" --format csv
+    Export the resources consumed by the instances of the webapp project in March 2024`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagFrom, "from", "", "", i18n.G("Start of the period (in `2006/01/02 15:04 MST` format)"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagTo, "to", "", "", i18n.G("End of the period (in `2006/01/02 15:04 MST` format)"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagFormat, "format|f", c.global.defaultListFormat(), "", i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`))
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjects(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdProjectUsage) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdProjectUsageUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	projectName := parsed[0].RemoteObject.String
+
+	var from time.Time
+	if c.flagFrom != "" {
+		from, err = time.Parse(dateLayout, c.flagFrom)
+		if err != nil {
+			return err
+		}
+	}
+
+	var to time.Time
+	if c.flagTo != "" {
+		to, err = time.Parse(dateLayout, c.flagTo)
+		if err != nil {
+			return err
+		}
+	}
+
+	projectUsage, err := d.GetProjectUsage(projectName, from, to)
+	if err != nil {
+		return err
+	}
+
+	// Render the output, keeping exact values for exports.
+	formatBytes := func(value int64) string {
+		if strings.HasPrefix(c.flagFormat, cli.TableFormatCSV) {
+			return strconv.FormatInt(value, 10)
+		}
+
+		return units.GetByteSizeStringIEC(value, 2)
+	}
+
+	row := func(name string, resources api.ProjectUsageResources) []string {
+		return []string{
+			name,
+			fmt.Sprintf("%.0f", resources.CPUSeconds),
+			fmt.Sprintf("%.2f", resources.MemoryGiBHours),
+			fmt.Sprintf("%.2f", resources.DiskGiBHours),
+			formatBytes(resources.NetworkReceivedBytes),
+			formatBytes(resources.NetworkSentBytes),
+		}
+	}
+
+	data := [][]string{}
+	for name, resources := range projectUsage.Instances {
+		data = append(data, row(name, resources))
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	data = append(data, row(i18n.G("TOTAL"), projectUsage.Total))
+
+	header := []string{
+		i18n.G("INSTANCE"),
+		i18n.G("CPU (SECONDS)"),
+		i18n.G("MEMORY (GIB-HOURS)"),
+		i18n.G("DISK (GIB-HOURS)"),
+		i18n.G("RECEIVED"),
+		i18n.G("SENT"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, projectUsage)
+}
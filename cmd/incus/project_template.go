@@ -0,0 +1,532 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/lxc/incus/v7/cmd/incus/color"
+	u "github.com/lxc/incus/v7/cmd/incus/usage"
+	"github.com/lxc/incus/v7/internal/i18n"
+	"github.com/lxc/incus/v7/shared/api"
+	cli "github.com/lxc/incus/v7/shared/cmd"
+	"github.com/lxc/incus/v7/shared/termios"
+)
+
+type cmdProjectTemplate struct {
+	global *cmdGlobal
+}
+
+func (c *cmdProjectTemplate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("template")
+	cmd.Short = i18n.G("Manage project templates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Manage project templates
+
+Project templates define the configuration, profiles, networks and network ACLs
+of the projects created from them with "incus project create --template".`))
+
+	// Create.
+	projectTemplateCreateCmd := cmdProjectTemplateCreate{global: c.global, projectTemplate: c}
+	cmd.AddCommand(projectTemplateCreateCmd.command())
+
+	// Delete.
+	projectTemplateDeleteCmd := cmdProjectTemplateDelete{global: c.global, projectTemplate: c}
+	cmd.AddCommand(projectTemplateDeleteCmd.command())
+
+	// Edit.
+	projectTemplateEditCmd := cmdProjectTemplateEdit{global: c.global, projectTemplate: c}
+	cmd.AddCommand(projectTemplateEditCmd.command())
+
+	// List.
+	projectTemplateListCmd := cmdProjectTemplateList{global: c.global, projectTemplate: c}
+	cmd.AddCommand(projectTemplateListCmd.command())
+
+	// Rename.
+	projectTemplateRenameCmd := cmdProjectTemplateRename{global: c.global, projectTemplate: c}
+	cmd.AddCommand(projectTemplateRenameCmd.command())
+
+	// Show.
+	projectTemplateShowCmd := cmdProjectTemplateShow{global: c.global, projectTemplate: c}
+	cmd.AddCommand(projectTemplateShowCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Create.
+type cmdProjectTemplateCreate struct {
+	global          *cmdGlobal
+	projectTemplate *cmdProjectTemplate
+
+	flagDescription string
+	flagSelfService bool
+}
+
+var cmdProjectTemplateCreateUsage = u.Usage{u.NewName(u.Template).Remote()}
+
+func (c *cmdProjectTemplateCreate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("create", cmdProjectTemplateCreateUsage...)
+	cmd.Aliases = []string{"add"}
+	cmd.Short = i18n.G("Create project templates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G("Create project templates"))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus project template create tenant < tenant.yaml
+    Create a project template named tenant with the content of tenant.yaml
+
+incus project template create tenant --self-service < tenant.yaml
+    Create a project template which users not allowed to create projects may use`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Project template description"))
+	cli.AddBoolFlag(cmd.Flags(), &c.flagSelfService, "self-service", i18n.G("Allow users not allowed to create projects to use the template"))
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdProjectTemplateCreate) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdProjectTemplateCreateUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	templateName := parsed[0].RemoteObject.String
+
+	// If stdin isn't a terminal, read yaml from it.
+	var templatePut api.ProjectTemplatePut
+	if !termios.IsTerminal(getStdinFd()) {
+		loader, err := yaml.NewLoader(os.Stdin, yaml.WithKnownFields())
+		if err != nil {
+			return err
+		}
+
+		err = loader.Load(&templatePut)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+
+	// Create the project template.
+	template := api.ProjectTemplatesPost{
+		Name:               templateName,
+		ProjectTemplatePut: templatePut,
+	}
+
+	if c.flagDescription != "" {
+		template.Description = c.flagDescription
+	}
+
+	if c.flagSelfService {
+		template.SelfService = true
+	}
+
+	if template.Config == nil {
+		template.Config = map[string]string{}
+	}
+
+	err = d.CreateProjectTemplate(template)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Project template %s created")+"\n", formatRemote(c.global.conf, parsed[0]))
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdProjectTemplateDelete struct {
+	global          *cmdGlobal
+	projectTemplate *cmdProjectTemplate
+}
+
+var cmdProjectTemplateDeleteUsage = u.Usage{u.Template.Remote().List(1)}
+
+func (c *cmdProjectTemplateDelete) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("delete", cmdProjectTemplateDeleteUsage...)
+	cmd.Aliases = []string{"rm", "remove"}
+	cmd.Short = i18n.G("Delete project templates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Delete project templates
+
+The projects created from the templates are not affected.`))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.global.cmpProjectTemplates(toComplete)
+	}
+
+	return cmd
+}
+
+func (c *cmdProjectTemplateDelete) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdProjectTemplateDeleteUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, p := range parsed[0].List {
+		d := p.RemoteServer
+		templateName := p.RemoteObject.String
+
+		// Delete the project template.
+		err = d.DeleteProjectTemplate(templateName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Project template %s deleted")+"\n", formatRemote(c.global.conf, p))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// Edit.
+type cmdProjectTemplateEdit struct {
+	global          *cmdGlobal
+	projectTemplate *cmdProjectTemplate
+}
+
+var cmdProjectTemplateEditUsage = u.Usage{u.Template.Remote()}
+
+func (c *cmdProjectTemplateEdit) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("edit", cmdProjectTemplateEditUsage...)
+	cmd.Short = i18n.G("Edit project templates as YAML")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Edit project templates as YAML
+
+The projects already created from the template are not affected.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus project template edit <template> < template.yaml
+    Update a project template using the content of template.yaml`))
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjectTemplates(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdProjectTemplateEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the project template.
+### Any line starting with a '# will be ignored.
+###
+### A project template consists of the configuration of the projects
+### created from it along with the profiles, networks and network ACLs
+### to create in them. The default profile is updated instead of created.
+###
+### An example would look like:
+### name: tenant
+### description: Restricted tenant project
+### config:
+###   features.networks: "true"
+###   restricted: "true"
+###   limits.instances: "10"
+### profiles:
+### - name: default
+###   devices:
+###     eth0:
+###       type: nic
+###       network: internal
+### networks:
+### - name: internal
+###   type: ovn
+###   config:
+###     network: UPLINK
+### network_acls: []
+### self_service: true
+###
+### Note that the name is shown but cannot be changed`,
+	)
+}
+
+func (c *cmdProjectTemplateEdit) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdProjectTemplateEditUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	templateName := parsed[0].RemoteObject.String
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		loader, err := yaml.NewLoader(os.Stdin, yaml.WithKnownFields())
+		if err != nil {
+			return err
+		}
+
+		// Allow output of `incus project template show` command to be passed in here, but only take the
+		// contents of the ProjectTemplatePut fields when updating. The other fields are silently discarded.
+		newdata := api.ProjectTemplate{}
+		err = loader.Load(&newdata)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		return d.UpdateProjectTemplate(templateName, newdata.Writable(), "")
+	}
+
+	// Get the current config.
+	template, etag, err := d.GetProjectTemplate(templateName)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&template, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor.
+	content, err := cli.TextEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor.
+		newdata := api.ProjectTemplate{}
+		err = yaml.Load(content, &newdata, yaml.WithKnownFields())
+		if err == nil {
+			err = d.UpdateProjectTemplate(templateName, newdata.Writable(), etag)
+		}
+
+		// Respawn the editor.
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = cli.TextEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// List.
+type cmdProjectTemplateList struct {
+	global          *cmdGlobal
+	projectTemplate *cmdProjectTemplate
+
+	flagFormat string
+}
+
+var cmdProjectTemplateListUsage = u.Usage{u.RemoteColonOpt}
+
+func (c *cmdProjectTemplateList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("list", cmdProjectTemplateListUsage...)
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List project templates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`List project templates
+
+Users not allowed to create projects only get the self-service templates.`))
+
+	cmd.RunE = c.run
+	cli.AddStringFlag(cmd.Flags(), &c.flagFormat, "format|f", c.global.defaultListFormat(), "", i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`))
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdProjectTemplateList) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdProjectTemplateListUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+
+	templates, err := d.GetProjectTemplates()
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, template := range templates {
+		selfService := i18n.G("NO")
+		if template.SelfService {
+			selfService = i18n.G("YES")
+		}
+
+		data = append(data, []string{
+			template.Name,
+			template.Description,
+			fmt.Sprintf("%d", len(template.Profiles)),
+			fmt.Sprintf("%d", len(template.Networks)),
+			fmt.Sprintf("%d", len(template.NetworkACLs)),
+			selfService,
+		})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("DESCRIPTION"),
+		i18n.G("PROFILES"),
+		i18n.G("NETWORKS"),
+		i18n.G("NETWORK ACLS"),
+		i18n.G("SELF-SERVICE"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, templates)
+}
+
+// Rename.
+type cmdProjectTemplateRename struct {
+	global          *cmdGlobal
+	projectTemplate *cmdProjectTemplate
+}
+
+var cmdProjectTemplateRenameUsage = u.Usage{u.Template.Remote(), u.NewName(u.Template)}
+
+func (c *cmdProjectTemplateRename) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("rename", cmdProjectTemplateRenameUsage...)
+	cmd.Aliases = []string{"mv"}
+	cmd.Short = i18n.G("Rename project templates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G("Rename project templates"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjectTemplates(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdProjectTemplateRename) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdProjectTemplateRenameUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	templateName := parsed[0].RemoteObject.String
+	newTemplateName := parsed[1].String
+
+	// Rename the project template.
+	err = d.RenameProjectTemplate(templateName, api.ProjectTemplatePost{Name: newTemplateName})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Project template %s renamed to %s")+"\n", formatRemote(c.global.conf, parsed[0]), newTemplateName)
+	}
+
+	return nil
+}
+
+// Show.
+type cmdProjectTemplateShow struct {
+	global          *cmdGlobal
+	projectTemplate *cmdProjectTemplate
+}
+
+var cmdProjectTemplateShowUsage = u.Usage{u.Template.Remote()}
+
+func (c *cmdProjectTemplateShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("show", cmdProjectTemplateShowUsage...)
+	cmd.Short = i18n.G("Show project templates")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G("Show project templates"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjectTemplates(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdProjectTemplateShow) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdProjectTemplateShowUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	templateName := parsed[0].RemoteObject.String
+
+	template, _, err := d.GetProjectTemplate(templateName)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Dump(&template, yaml.WithV2Defaults())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
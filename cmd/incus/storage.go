@@ -65,6 +65,14 @@ func (c *cmdStorage) command() *cobra.Command {
 	storageListCmd := cmdStorageList{global: c.global, storage: c}
 	cmd.AddCommand(storageListCmd.command())
 
+	// Reclaim
+	storageReclaimCmd := cmdStorageReclaim{global: c.global, storage: c}
+	cmd.AddCommand(storageReclaimCmd.command())
+
+	// Scrub
+	storageScrubCmd := cmdStorageScrub{global: c.global, storage: c}
+	cmd.AddCommand(storageScrubCmd.command())
+
 	// Set
 	storageSetCmd := cmdStorageSet{global: c.global, storage: c}
 	cmd.AddCommand(storageSetCmd.command())
@@ -514,6 +522,12 @@ func (c *cmdStorageInfo) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Get the pool health (if supported by the server and driver)
+	var health *api.StoragePoolHealth
+	if d.HasExtension("storage_pool_health") {
+		health, _ = d.GetStoragePoolHealth(poolName)
+	}
+
 	// Declare the poolinfo map of maps in order to build up the yaml
 	poolinfo := make(map[string]map[string]string)
 	poolusedby := make(map[string]map[string][]string)
@@ -526,6 +540,8 @@ func (c *cmdStorageInfo) run(cmd *cobra.Command, args []string) error {
 	descriptionstring := i18n.G("description")
 	totalspacestring := i18n.G("total space")
 	spaceusedstring := i18n.G("space used")
+	healthstring := i18n.G("health")
+	scrubstring := i18n.G("last scrub")
 
 	// Initialize the usedby map
 	poolusedby[usedbystring] = make(map[string][]string)
@@ -608,6 +624,21 @@ func (c *cmdStorageInfo) run(cmd *cobra.Command, args []string) error {
 		poolinfo[infostring][spaceusedstring] = units.GetByteSizeStringIEC(int64(res.Space.Used), 2)
 	}
 
+	if health != nil {
+		poolinfo[infostring][healthstring] = health.Status
+
+		if health.Scrub != nil {
+			switch health.Scrub.Status {
+			case api.StoragePoolScrubStatusRunning:
+				poolinfo[infostring][scrubstring] = fmt.Sprintf(i18n.G("running (%.2f%%)"), health.Scrub.Progress)
+			case api.StoragePoolScrubStatusNone:
+				poolinfo[infostring][scrubstring] = i18n.G("never")
+			default:
+				poolinfo[infostring][scrubstring] = fmt.Sprintf(i18n.G("%s with %d errors"), health.Scrub.Status, health.Scrub.Errors)
+			}
+		}
+	}
+
 	poolinfodata, err := yaml.Dump(poolinfo, yaml.WithV2Defaults())
 	if err != nil {
 		return err
@@ -782,6 +813,141 @@ func (c *cmdStorageList) run(cmd *cobra.Command, args []string) error {
 	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, pools)
 }
 
+// Reclaim.
+type cmdStorageReclaim struct {
+	global  *cmdGlobal
+	storage *cmdStorage
+}
+
+var cmdStorageReclaimUsage = u.Usage{u.Pool.Remote()}
+
+func (c *cmdStorageReclaim) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("reclaim", cmdStorageReclaimUsage...)
+	cmd.Short = i18n.G("Reclaim unused space in storage pools")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`Reclaim unused space in storage pools
+
+Releases the space that is no longer used by the storage volumes back to the pool.
+Block volumes used by running instances are skipped.`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdStorageReclaim) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdStorageReclaimUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	poolName := parsed[0].RemoteObject.String
+
+	// Targeting
+	if c.storage.flagTarget != "" {
+		if !d.IsClustered() {
+			return errors.New(i18n.G("To use --target, the destination remote must be a cluster"))
+		}
+
+		d = d.UseTarget(c.storage.flagTarget)
+	}
+
+	op, err := d.ReclaimStoragePool(poolName)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		reclaimed, _ := op.Get().Metadata["reclaimed_bytes"].(float64)
+		fmt.Printf(i18n.G("Reclaimed %s in storage pool %s")+"\n", units.GetByteSizeStringIEC(int64(reclaimed), 2), formatRemote(c.global.conf, parsed[0]))
+	}
+
+	return nil
+}
+
+// Scrub.
+type cmdStorageScrub struct {
+	global  *cmdGlobal
+	storage *cmdStorage
+}
+
+var cmdStorageScrubUsage = u.Usage{u.Pool.Remote()}
+
+func (c *cmdStorageScrub) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("scrub", cmdStorageScrubUsage...)
+	cmd.Short = i18n.G("Scrub storage pools")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(
+		`Scrub storage pools
+
+Starts a data integrity check of the storage pool.
+Progress and results can be seen with "incus storage info".`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.storage.flagTarget, "target", "", "", i18n.G("Cluster member name"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdStorageScrub) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdStorageScrubUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	poolName := parsed[0].RemoteObject.String
+
+	// Targeting
+	if c.storage.flagTarget != "" {
+		if !d.IsClustered() {
+			return errors.New(i18n.G("To use --target, the destination remote must be a cluster"))
+		}
+
+		d = d.UseTarget(c.storage.flagTarget)
+	}
+
+	op, err := d.ScrubStoragePool(poolName)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Scrub of storage pool %s started")+"\n", formatRemote(c.global.conf, parsed[0]))
+	}
+
+	return nil
+}
+
 // Set.
 type cmdStorageSet struct {
 	global  *cmdGlobal
@@ -60,6 +60,10 @@ func (c *cmdStorageBucket) command() *cobra.Command {
 	storageBucketListCmd := cmdStorageBucketList{global: c.global, storageBucket: c}
 	cmd.AddCommand(storageBucketListCmd.command())
 
+	// Presign.
+	storageBucketPresignCmd := cmdStorageBucketPresign{global: c.global, storageBucket: c}
+	cmd.AddCommand(storageBucketPresignCmd.command())
+
 	// Set.
 	storageBucketSetCmd := cmdStorageBucketSet{global: c.global, storageBucket: c}
 	cmd.AddCommand(storageBucketSetCmd.command())
@@ -743,6 +747,81 @@ func (c *cmdStorageBucketShow) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Presign.
+type cmdStorageBucketPresign struct {
+	global        *cmdGlobal
+	storageBucket *cmdStorageBucket
+
+	flagMethod string
+	flagExpiry string
+	flagKey    string
+}
+
+var cmdStorageBucketPresignUsage = u.Usage{u.Pool.Remote(), u.Bucket, u.Placeholder(i18n.G("object"))}
+
+func (c *cmdStorageBucketPresign) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.U("presign", cmdStorageBucketPresignUsage...)
+	cmd.Short = i18n.G("Generate presigned URLs for storage bucket objects")
+	cmd.Long = cli.FormatSection(color.DescriptionPrefix, i18n.G(`Generate presigned URLs for storage bucket objects
+
+The URL allows downloading (GET) or uploading (PUT) a single object without
+any credentials until it expires.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus storage bucket presign default data artifacts/build.tar.gz --expiry 2h
+    Generate a URL to download "artifacts/build.tar.gz" from the "data" bucket, valid for two hours.
+
+incus storage bucket presign default data uploads/log.txt --method PUT --key ci
+    Generate a URL to upload "uploads/log.txt" to the "data" bucket, signed with the "ci" key.`))
+
+	cli.AddStringFlag(cmd.Flags(), &c.storageBucket.flagTarget, "target", "", "", i18n.G("Cluster member name"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagMethod, "method", "GET", "", i18n.G("HTTP method allowed by the URL (GET or PUT)"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagExpiry, "expiry", "1h", "", i18n.G("Validity of the URL (e.g. 30m, 12h)"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagKey, "key", "", "", i18n.G("Bucket key to sign the URL with"))
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdStorageBucketPresign) run(cmd *cobra.Command, args []string) error {
+	parsed, err := c.global.Parse(cmdStorageBucketPresignUsage, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	d := parsed[0].RemoteServer
+	poolName := parsed[0].RemoteObject.String
+	bucketName := parsed[1].String
+	objectName := parsed[2].String
+
+	expiry, err := time.ParseDuration(c.flagExpiry)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Invalid expiry %q: %w"), c.flagExpiry, err)
+	}
+
+	// If a target member was specified, use the bucket with the matching name on that member.
+	if c.storageBucket.flagTarget != "" {
+		if !d.IsClustered() {
+			return errors.New(i18n.G("To use --target, the destination remote must be a cluster"))
+		}
+
+		d = d.UseTarget(c.storageBucket.flagTarget)
+	}
+
+	presign, err := d.CreateStoragePoolBucketPresignedURL(poolName, bucketName, api.StorageBucketPresignPost{
+		Object: objectName,
+		Method: c.flagMethod,
+		Expiry: int64(expiry / time.Second),
+		Key:    c.flagKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(presign.URL)
+
+	return nil
+}
+
 // Unset.
 type cmdStorageBucketUnset struct {
 	global           *cmdGlobal
@@ -967,6 +1046,7 @@ type cmdStorageBucketKeyCreate struct {
 	flagAccessKey    string
 	flagSecretKey    string
 	flagDescription  string
+	flagPrefix       string
 }
 
 var cmdStorageBucketKeyCreateUsage = u.Usage{u.Pool.Remote(), u.Bucket, u.NewName(u.Key)}
@@ -990,6 +1070,7 @@ incus storage bucket key create p1 b01 k1 < config.yaml
 	cli.AddStringFlag(cmd.Flags(), &c.flagAccessKey, "access-key", "", "", i18n.G("Access key (auto-generated if empty)"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagSecretKey, "secret-key", "", "", i18n.G("Secret key (auto-generated if empty)"))
 	cli.AddStringFlag(cmd.Flags(), &c.flagDescription, "description", "", "", i18n.G("Key description"))
+	cli.AddStringFlag(cmd.Flags(), &c.flagPrefix, "prefix", "", "", i18n.G("Restrict the key to objects under this prefix"))
 
 	return cmd
 }
@@ -1045,6 +1126,10 @@ func (c *cmdStorageBucketKeyCreate) runAdd(cmd *cobra.Command, args []string) er
 		req.Description = c.flagDescription
 	}
 
+	if c.flagPrefix != "" {
+		req.Prefix = c.flagPrefix
+	}
+
 	key, err := d.CreateStoragePoolBucketKey(poolName, bucketName, req)
 	if err != nil {
 		return err
@@ -814,6 +814,7 @@ var (
 	Address            = placeholder{i18n.G("address")}
 	AddressSet         = placeholder{i18n.G("address set")}
 	Alias              = placeholder{i18n.G("alias")}
+	Approval           = placeholder{i18n.G("approval")}
 	Backend            = placeholder{i18n.G("backend")}
 	BackupFile         = placeholder{i18n.G("backup file")}
 	Bucket             = placeholder{i18n.G("bucket")}
@@ -825,11 +826,13 @@ var (
 	Directory          = placeholder{i18n.G("directory")}
 	Driver             = placeholder{i18n.G("driver")}
 	EndOfFlags         = hide{optional{verbatim{"--"}}, verbatim{"[flags] [--]"}}
+	Entitlement        = placeholder{i18n.G("entitlement")}
 	Expiry             = placeholder{i18n.G("expiry")}
 	File               = placeholder{i18n.G("file")}
 	Filter             = placeholder{i18n.G("filter")}
 	Fingerprint        = placeholder{i18n.G("fingerprint")}
 	Group              = placeholder{i18n.G("group")}
+	Identity           = placeholder{i18n.G("identity")}
 	Image              = placeholder{i18n.G("image")}
 	Instance           = placeholder{i18n.G("instance")}
 	Interface          = placeholder{i18n.G("interface")}
@@ -841,6 +844,7 @@ var (
 	Member             = placeholder{i18n.G("member")}
 	Network            = placeholder{i18n.G("network")}
 	NetworkIntegration = placeholder{i18n.G("network integration")}
+	Object             = placeholder{i18n.G("object")}
 	Operation          = placeholder{i18n.G("operation")}
 	Path               = placeholder{i18n.G("path")}
 	Peer               = placeholder{i18n.G("peer")}
@@ -856,6 +860,7 @@ var (
 	RemoteColonOpt     = remote{Remote, nil, true}
 	RemoteImage        = compound{":", []Atom{optional{Remote}, Image}}
 	Role               = placeholder{i18n.G("role")}
+	Serial             = placeholder{i18n.G("serial")}
 	Snapshot           = placeholder{i18n.G("snapshot")}
 	StorageVolumeType  = hide{alternative{[]Atom{verbatim{"custom"}, verbatim{"image"}, verbatim{"container"}, verbatim{"virtual-machine"}}}, placeholder{i18n.G("type")}}
 	SymlinkTargetPath  = placeholder{i18n.G("symlink target path")}
@@ -367,6 +367,7 @@ func serveLocalBucket(d *Daemon, w http.ResponseWriter, r *http.Request, bucket
 			AccessKey: k.AccessKey,
 			SecretKey: k.SecretKey,
 			Role:      local.Role(k.Role),
+			Prefix:    k.Prefix,
 		})
 	}
 
@@ -384,6 +385,9 @@ func serveLocalBucket(d *Daemon, w http.ResponseWriter, r *http.Request, bucket
 	}()
 
 	srv := local.NewServer(bucketDir, creds)
+	srv.Versioning = util.IsTrue(bucket.Config["versioning"])
+	srv.Lifecycle = localBucketLifecycle(bucket.Config)
+	srv.PublicRead = util.IsTrue(bucket.Config["security.public_read"])
 
 	// Migrate any data left over from the legacy minio layout, but only
 	// once the request has cleared authentication. This is a no-op once
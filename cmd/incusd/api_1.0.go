@@ -43,6 +43,17 @@ var api10Cmd = APIEndpoint{
 var api10 = []APIEndpoint{
 	api10Cmd,
 	api10ResourcesCmd,
+	approvalCmd,
+	approvalsCmd,
+	authCertificateAuthorityCmd,
+	authCertificateCmd,
+	authCertificatesCmd,
+	authGroupCmd,
+	authGroupsCmd,
+	authIdentitiesCmd,
+	authIdentityCmd,
+	authTokenCmd,
+	authTokensCmd,
 	certificateCmd,
 	certificatesCmd,
 	clusterCmd,
@@ -115,14 +126,21 @@ var api10 = []APIEndpoint{
 	projectCmd,
 	projectsCmd,
 	projectStateCmd,
+	projectUsageCmd,
 	projectAccessCmd,
+	projectTemplateCmd,
+	projectTemplatesCmd,
 	storagePoolCmd,
+	storagePoolHealthCmd,
 	storagePoolResourcesCmd,
+	storagePoolScrubCmd,
+	storagePoolReclaimCmd,
 	storagePoolsCmd,
 	storagePoolBucketsCmd,
 	storagePoolBucketCmd,
 	storagePoolBucketKeysCmd,
 	storagePoolBucketKeyCmd,
+	storagePoolBucketPresignCmd,
 	storagePoolBucketBackupsCmd,
 	storagePoolBucketBackupCmd,
 	storagePoolBucketBackupsExportCmd,
@@ -810,6 +828,7 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	s := d.State()
 
 	acmeChanged := false
+	auditChanged := false
 	bgpChanged := false
 	dnsChanged := false
 	oidcChanged := false
@@ -825,6 +844,9 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		case "acme.agree_tos", "acme.ca_url", "acme.challenge", "acme.domain", "acme.email", "acme.provider", "acme.provider.environment", "acme.provider.resolvers", "acme.http.port":
 			acmeChanged = true
 
+		case "audit.enabled", "audit.max_files", "audit.max_size":
+			auditChanged = true
+
 		case "cluster.images_minimal_replica":
 			err := autoSyncImages(s.ShutdownCtx, s)
 			if err != nil {
@@ -859,7 +881,7 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		case "network.ovn.northbound_connection", "network.ovn.ca_cert", "network.ovn.client_cert", "network.ovn.client_key":
 			ovnChanged = true
 
-		case "oidc.issuer", "oidc.client.id", "oidc.audience", "oidc.claim", "oidc.scopes":
+		case "oidc.issuer", "oidc.client.id", "oidc.audience", "oidc.claim", "oidc.scopes", "oidc.groups.claim", "oidc.groups.mapping":
 			oidcChanged = true
 
 		case "authorization.openfga.api.url", "authorization.openfga.api.token", "authorization.openfga.store.id":
@@ -1005,16 +1027,19 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	}
 	if oidcChanged {
 		oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim := clusterConf.OIDCServer()
+		oidcGroupsClaim, oidcGroupsMapping := clusterConf.OIDCGroups()
 
 		if oidcIssuer == "" || oidcClientID == "" {
 			d.oidcVerifier = nil
 		} else {
 			var err error
-			d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim)
+			d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim, oidcGroupsClaim)
 			if err != nil {
 				return fmt.Errorf("Failed creating verifier: %w", err)
 			}
 		}
+
+		d.oidcGroups = oidcGroupsMapping
 	}
 
 	if openFGAChanged {
@@ -1039,6 +1064,14 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if auditChanged {
+		auditEnabled, auditMaxSize, auditMaxFiles := clusterConf.Audit()
+		err := d.auditLogger.Configure(auditEnabled, auditMaxSize, auditMaxFiles)
+		if err != nil {
+			return err
+		}
+	}
+
 	if syslogChanged {
 		err := d.setupSyslogSocket(nodeConfig.SyslogSocket())
 		if err != nil {
@@ -1071,5 +1104,14 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	// Setup the built-in role-based access control.
+	value, ok = clusterChanged["authorization.rbac"]
+	if ok {
+		err := d.setupAuthorizationRBAC(util.IsTrue(value))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,700 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	projecthelpers "github.com/lxc/incus/v7/internal/server/project"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	"github.com/lxc/incus/v7/internal/server/state"
+	localUtil "github.com/lxc/incus/v7/internal/server/util"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+var approvalsCmd = APIEndpoint{
+	Path: "approvals",
+
+	Get: APIEndpointAction{Handler: approvalsGet, AccessHandler: allowAuthenticated},
+}
+
+var approvalCmd = APIEndpoint{
+	Path: "approvals/{id}",
+
+	Delete: APIEndpointAction{Handler: approvalDelete, AccessHandler: allowAuthenticated},
+	Get:    APIEndpointAction{Handler: approvalGet, AccessHandler: allowAuthenticated},
+	Post:   APIEndpointAction{Handler: approvalPost, AccessHandler: allowAuthenticated},
+}
+
+// swagger:operation GET /1.0/approvals approvals approvals_get
+//
+//  Get the approvals
+//
+//  Returns a list of privileged operations waiting to be approved (URLs).
+//
+//  ---
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: project
+//      description: Project name
+//      type: string
+//      example: default
+//    - in: query
+//      name: all-projects
+//      description: Retrieve approvals from all projects
+//      type: boolean
+//  responses:
+//    "200":
+//      description: API endpoints
+//      schema:
+//        type: object
+//        description: Sync response
+//        properties:
+//          type:
+//            type: string
+//            description: Response type
+//            example: sync
+//          status:
+//            type: string
+//            description: Status description
+//            example: Success
+//          status_code:
+//            type: integer
+//            description: Status code
+//            example: 200
+//          metadata:
+//            type: array
+//            description: List of endpoints
+//            items:
+//              type: string
+//            example: |-
+//              [
+//                "/1.0/approvals/6916c8a6-9b7d-4abd-90b3-aedfec7ec7da?project=default"
+//              ]
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/approvals?recursion=1 approvals approvals_get_recursion1
+//
+//	Get the approvals
+//
+//	Returns a list of privileged operations waiting to be approved (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: all-projects
+//	    description: Retrieve approvals from all projects
+//	    type: boolean
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of approvals
+//	          items:
+//	            $ref: "#/definitions/Approval"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func approvalsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.QueryParam(r, "project")
+	allProjects := util.IsTrue(request.QueryParam(r, "all-projects"))
+	recursion := localUtil.IsRecursionRequest(r)
+
+	if allProjects && projectName != "" {
+		return response.SmartError(api.StatusErrorf(http.StatusBadRequest, "Cannot specify a project when requesting all projects"))
+	} else if !allProjects && projectName == "" {
+		projectName = api.ProjectDefaultName
+	}
+
+	userHasPermission, err := s.Authorizer.GetPermissionChecker(r.Context(), r, auth.EntitlementCanView, auth.ObjectTypeProject)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to get approval permission checker: %w", err))
+	}
+
+	// Don't list the approvals whose operation was lost, like with a restart of this member.
+	err = approvalsPruneOrphaned(r.Context(), s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var dbApprovals []dbCluster.Approval
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		if allProjects {
+			projectName = ""
+		}
+
+		dbApprovals, err = dbCluster.GetApprovals(ctx, tx.Tx(), projectName)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	approvals := []api.Approval{}
+	urls := []string{}
+	for _, dbApproval := range dbApprovals {
+		if !userHasPermission(auth.ObjectProject(dbApproval.Project)) {
+			continue
+		}
+
+		approvals = append(approvals, dbApproval.ToAPI())
+		urls = append(urls, api.NewURL().Path(version.APIVersion, "approvals", dbApproval.OperationUUID).Project(dbApproval.Project).String())
+	}
+
+	if !recursion {
+		return response.SyncResponse(true, urls)
+	}
+
+	return response.SyncResponse(true, approvals)
+}
+
+// swagger:operation GET /1.0/approvals/{id} approvals approval_get
+//
+//	Get the approval
+//
+//	Gets a specific privileged operation waiting to be approved.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: id
+//	    description: Approval ID
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: Approval
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Approval"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func approvalGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	approval, err := approvalLoad(s, r, auth.EntitlementCanView)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, approval.ToAPI())
+}
+
+// swagger:operation POST /1.0/approvals/{id} approvals approval_post
+//
+//	Approve the operation
+//
+//	Approves a privileged operation, starting it.
+//	The operation can't be approved by the identity which requested it.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: id
+//	    description: Approval ID
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func approvalPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	approval, err := approvalLoad(s, r, auth.EntitlementCanEdit)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The operation is held on the member where it was requested.
+	if s.ServerClustered {
+		resp := forwardedResponseToNode(s, r, approval.Location)
+		if resp != nil {
+			return resp
+		}
+	}
+
+	requestor := request.CreateRequestor(r)
+	if requestor.Protocol == approval.RequestorProtocol && requestor.Username == approval.RequestorUsername {
+		return response.Forbidden(errors.New("Operations can't be approved by the identity which requested them"))
+	}
+
+	op, err := operations.OperationGetInternal(approval.OperationUUID)
+	if err != nil {
+		err = approvalsPruneOrphaned(r.Context(), s)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.NotFound(errors.New("The operation of the approval no longer exists"))
+	}
+
+	// Remove the approval first so that the operation can't be started twice.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteApproval(ctx, tx.Tx(), approval.OperationUUID)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = op.Start()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(approval.Project, lifecycle.ApprovalApproved.Event(approval.OperationUUID, approval.Project, requestor, approvalEventContext(approval)))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/approvals/{id} approvals approval_delete
+//
+//	Reject the operation
+//
+//	Rejects a privileged operation, cancelling it.
+//	The identity which requested the operation can also withdraw it.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: id
+//	    description: Approval ID
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func approvalDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	approval, err := approvalLoad(s, r, auth.EntitlementCanView)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The operation is held on the member where it was requested.
+	if s.ServerClustered {
+		resp := forwardedResponseToNode(s, r, approval.Location)
+		if resp != nil {
+			return resp
+		}
+	}
+
+	// Only the requestor itself or identities allowed to edit the project may reject the operation.
+	requestor := request.CreateRequestor(r)
+	if requestor.Protocol != approval.RequestorProtocol || requestor.Username != approval.RequestorUsername {
+		err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(approval.Project), auth.EntitlementCanEdit)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	op, err := operations.OperationGetInternal(approval.OperationUUID)
+	if err != nil {
+		err = approvalsPruneOrphaned(r.Context(), s)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.NotFound(errors.New("The operation of the approval no longer exists"))
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteApproval(ctx, tx.Tx(), approval.OperationUUID)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = op.Discard(errors.New("Operation rejected"))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(approval.Project, lifecycle.ApprovalRejected.Event(approval.OperationUUID, approval.Project, requestor, approvalEventContext(approval)))
+
+	return response.EmptySyncResponse
+}
+
+// approvalLoad loads the approval requested and checks that the requestor has the given entitlement on its project.
+func approvalLoad(s *state.State, r *http.Request, entitlement auth.Entitlement) (*dbCluster.Approval, error) {
+	id, err := pathVar(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var approval *dbCluster.Approval
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		approval, err = dbCluster.GetApproval(ctx, tx.Tx(), id)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(approval.Project), entitlement)
+	if err != nil {
+		// Don't reveal the existence of approvals in inaccessible projects.
+		if entitlement == auth.EntitlementCanView && api.StatusErrorCheck(err, http.StatusForbidden) {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Approval not found")
+		}
+
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// approvalsPruneOrphaned rejects the approvals held on this member whose operation no longer exists.
+// Operations are only kept in memory, so their approvals are left behind when the daemon restarts.
+func approvalsPruneOrphaned(ctx context.Context, s *state.State) error {
+	var orphans []dbCluster.Approval
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		approvals, err := dbCluster.GetApprovals(ctx, tx.Tx(), "")
+		if err != nil {
+			return err
+		}
+
+		for _, approval := range approvals {
+			if approval.Location != s.ServerName {
+				continue
+			}
+
+			_, err = operations.OperationGetInternal(approval.OperationUUID)
+			if err == nil {
+				continue
+			}
+
+			err = dbCluster.DeleteApproval(ctx, tx.Tx(), approval.OperationUUID)
+			if err != nil {
+				return err
+			}
+
+			err = dbCluster.DeleteOperation(ctx, tx.Tx(), approval.OperationUUID)
+			if err != nil && !response.IsNotFoundError(err) {
+				return err
+			}
+
+			orphans = append(orphans, approval)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed pruning orphaned approvals: %w", err)
+	}
+
+	for _, approval := range orphans {
+		s.Events.SendLifecycle(approval.Project, lifecycle.ApprovalRejected.Event(approval.OperationUUID, approval.Project, nil, approvalEventContext(&approval)))
+	}
+
+	return nil
+}
+
+// approvalEventContext returns the context of the lifecycle events of an approval.
+func approvalEventContext(approval *dbCluster.Approval) map[string]any {
+	return map[string]any{
+		"type":        approval.Type,
+		"description": approval.Description,
+		"operation":   api.NewURL().Path(version.APIVersion, "operations", approval.OperationUUID).String(),
+		"requestor": api.EventLifecycleRequestor{
+			Protocol: approval.RequestorProtocol,
+			Username: approval.RequestorUsername,
+		},
+	}
+}
+
+// approvalRequired returns whether the given type of privileged operation must be approved in a project.
+func approvalRequired(ctx context.Context, s *state.State, projectName string, approvalType string) (bool, error) {
+	var config map[string]string
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		projectID, err := dbCluster.GetProjectID(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		config, err = dbCluster.GetProjectConfig(ctx, tx.Tx(), int(projectID))
+
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed loading project %q: %w", projectName, err)
+	}
+
+	return projecthelpers.ApprovalRequired(config, approvalType), nil
+}
+
+// approvalOperationResponse holds an operation in a pending state until it gets approved.
+func approvalOperationResponse(s *state.State, r *http.Request, op *operations.Operation, approvalType string, description string) response.Response {
+	requestor := request.CreateRequestor(r)
+
+	approval := dbCluster.Approval{
+		OperationUUID:     op.ID(),
+		Project:           op.Project(),
+		Type:              approvalType,
+		Description:       description,
+		RequestorProtocol: requestor.Protocol,
+		RequestorUsername: requestor.Username,
+		CreatedAt:         time.Now().UTC(),
+	}
+
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.CreateApproval(ctx, tx.Tx(), approval)
+	})
+	if err != nil {
+		_ = op.Discard(err)
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(approval.Project, lifecycle.ApprovalCreated.Event(approval.OperationUUID, approval.Project, requestor, approvalEventContext(&approval)))
+
+	return operations.PendingOperationResponse(op)
+}
+
+// instancePrivilegedApprovalRequired returns whether creating or updating an instance must be approved because it
+// makes the instance privileged. The current config is the expanded config of the instance being updated, if any.
+func instancePrivilegedApprovalRequired(ctx context.Context, s *state.State, projectName string, currentConfig map[string]string, config map[string]string, profiles []api.Profile) (bool, error) {
+	if !util.IsTrue(db.ExpandInstanceConfig(config, profiles)["security.privileged"]) || util.IsTrue(currentConfig["security.privileged"]) {
+		return false, nil
+	}
+
+	return approvalRequired(ctx, s, projectName, projecthelpers.ApprovalInstancesPrivileged)
+}
+
+// instanceUpdateApprovalResponse returns an operation updating the instance once the change got approved.
+// The instance lock must not be held by the caller as it is only taken when the operation runs.
+// The update is refused if the instance changed since the given ETag was computed.
+func instanceUpdateApprovalResponse(s *state.State, r *http.Request, projectName string, name string, etag any, args db.InstanceArgs) response.Response {
+	hash, err := localUtil.EtagHash(etag)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	run := func(op *operations.Operation) error {
+		unlock, err := instanceOperationLock(s.ShutdownCtx, projectName, name)
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		inst, err := instance.LoadByProjectAndName(s, projectName, name)
+		if err != nil {
+			return err
+		}
+
+		currentHash, err := localUtil.EtagHash(inst.ETag())
+		if err != nil {
+			return err
+		}
+
+		if currentHash != hash {
+			return fmt.Errorf("Instance %q was changed since the update was requested", name)
+		}
+
+		inst.SetOperation(op)
+
+		return inst.Update(args, true)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceUpdate, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return approvalOperationResponse(s, r, op, projecthelpers.ApprovalInstancesPrivileged, fmt.Sprintf("Making instance %q privileged", name))
+}
+
+// instanceRestoreApprovalResponse returns an operation restoring the instance from a snapshot once approved.
+// The instance lock must not be held by the caller as it is only taken when the operation runs.
+func instanceRestoreApprovalResponse(s *state.State, r *http.Request, projectName string, name string, req api.InstancePut) response.Response {
+	run := func(op *operations.Operation) error {
+		unlock, err := instanceOperationLock(s.ShutdownCtx, projectName, name)
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		return instanceSnapRestore(s, projectName, name, req.Restore, req.Stateful, req.DiskOnly, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.SnapshotRestore, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return approvalOperationResponse(s, r, op, projecthelpers.ApprovalInstancesPrivileged, fmt.Sprintf("Restoring instance %q from privileged snapshot %q", name, req.Restore))
+}
+
+// profilePrivilegedApprovalRequired returns whether updating a profile must be approved because it makes any of
+// the instances using it privileged. The approval requirement is taken from the project of each instance.
+func profilePrivilegedApprovalRequired(ctx context.Context, s *state.State, projectName string, profileName string, config map[string]string) (bool, error) {
+	if !util.IsTrue(config["security.privileged"]) {
+		return false, nil
+	}
+
+	insts, _, err := getProfileInstancesInfo(ctx, s.DB.Cluster, projectName, profileName)
+	if err != nil {
+		return false, err
+	}
+
+	for _, inst := range insts {
+		profiles := slices.Clone(inst.Profiles)
+		for i := range profiles {
+			if profiles[i].Name == profileName {
+				profiles[i].Config = config
+			}
+		}
+
+		approval, err := instancePrivilegedApprovalRequired(ctx, s, inst.Project, db.ExpandInstanceConfig(inst.Config, inst.Profiles), inst.Config, profiles)
+		if err != nil || approval {
+			return approval, err
+		}
+	}
+
+	return false, nil
+}
+
+// profileUpdateApprovalResponse returns an operation updating the profile once the change got approved.
+// The update is refused if the profile changed since the given ETag was computed.
+func profileUpdateApprovalResponse(s *state.State, r *http.Request, p api.Project, name string, etag any, req api.ProfilePut) response.Response {
+	hash, err := localUtil.EtagHash(etag)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	run := func(op *operations.Operation) error {
+		var profile *api.Profile
+		err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			current, err := dbCluster.GetProfile(ctx, tx.Tx(), p.Name, name)
+			if err != nil {
+				return err
+			}
+
+			profile, err = current.ToAPI(ctx, tx.Tx(), nil, nil)
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		currentHash, err := localUtil.EtagHash([]any{profile.Config, profile.Description, profile.Devices})
+		if err != nil {
+			return err
+		}
+
+		if currentHash != hash {
+			return fmt.Errorf("Profile %q was changed since the update was requested", name)
+		}
+
+		err = profileUpdate(context.TODO(), s, p, name, profile, req)
+		if err != nil {
+			return err
+		}
+
+		s.Events.SendLifecycle(p.Name, lifecycle.ProfileUpdated.Event(name, p.Name, op.Requestor(), nil))
+
+		return nil
+	}
+
+	resources := map[string][]api.URL{}
+	resources["profiles"] = []api.URL{*api.NewURL().Path(version.APIVersion, "profiles", name).Project(p.Name)}
+
+	op, err := operations.OperationCreate(s, p.Name, operations.OperationClassTask, operationtype.ProfileUpdate, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return approvalOperationResponse(s, r, op, projecthelpers.ApprovalInstancesPrivileged, fmt.Sprintf("Making instances using profile %q privileged", name))
+}
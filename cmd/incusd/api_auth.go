@@ -0,0 +1,1086 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	localUtil "github.com/lxc/incus/v7/internal/server/util"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/validate"
+)
+
+var authGroupsCmd = APIEndpoint{
+	Path: "auth/groups",
+
+	Get:  APIEndpointAction{Handler: authGroupsGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewSensitive)},
+	Post: APIEndpointAction{Handler: authGroupsPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var authGroupCmd = APIEndpoint{
+	Path: "auth/groups/{name}",
+
+	Get:    APIEndpointAction{Handler: authGroupGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewSensitive)},
+	Post:   APIEndpointAction{Handler: authGroupPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Put:    APIEndpointAction{Handler: authGroupPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Patch:  APIEndpointAction{Handler: authGroupPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Delete: APIEndpointAction{Handler: authGroupDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var authIdentitiesCmd = APIEndpoint{
+	Path: "auth/identities",
+
+	Get:  APIEndpointAction{Handler: authIdentitiesGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewSensitive)},
+	Post: APIEndpointAction{Handler: authIdentitiesPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var authIdentityCmd = APIEndpoint{
+	Path: "auth/identities/{authenticationMethod}/{identifier}",
+
+	Get:    APIEndpointAction{Handler: authIdentityGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewSensitive)},
+	Put:    APIEndpointAction{Handler: authIdentityPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Patch:  APIEndpointAction{Handler: authIdentityPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Delete: APIEndpointAction{Handler: authIdentityDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// authGroupValidate validates the permissions of an authorization group.
+func authGroupValidate(req api.AuthGroupPut) ([]dbCluster.AuthGroupPermission, error) {
+	permissions := make([]dbCluster.AuthGroupPermission, 0, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		err := auth.ValidatePermission(auth.Entitlement(permission.Entitlement), auth.Object(permission.Object))
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, dbCluster.AuthGroupPermission{
+			Entitlement: permission.Entitlement,
+			Object:      permission.Object,
+		})
+	}
+
+	return permissions, nil
+}
+
+// swagger:operation GET /1.0/auth/groups auth auth_groups_get
+//
+//	Get the authorization groups
+//
+//	Returns a list of authorization groups (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/auth/groups/developers",
+//	              "/1.0/auth/groups/operators"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/groups?recursion=1 auth auth_groups_get_recursion1
+//
+//	Get the authorization groups
+//
+//	Returns a list of authorization groups (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of authorization groups
+//	          items:
+//	            $ref: "#/definitions/AuthGroup"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authGroupsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	recursion := localUtil.IsRecursionRequest(r)
+
+	var groups []dbCluster.AuthGroup
+	var apiGroups []*api.AuthGroup
+
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		groups, err = dbCluster.GetAuthGroups(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		if !recursion {
+			return nil
+		}
+
+		apiGroups = make([]*api.AuthGroup, 0, len(groups))
+		for _, group := range groups {
+			apiGroup, err := group.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			apiGroups = append(apiGroups, apiGroup)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if recursion {
+		return response.SyncResponse(true, apiGroups)
+	}
+
+	urls := make([]string, 0, len(groups))
+	for _, group := range groups {
+		urls = append(urls, api.NewURL().Path(version.APIVersion, "auth", "groups", group.Name).String())
+	}
+
+	return response.SyncResponse(true, urls)
+}
+
+// swagger:operation POST /1.0/auth/groups auth auth_groups_post
+//
+//	Add an authorization group
+//
+//	Creates a new authorization group.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: group
+//	    description: Authorization group to create
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthGroupsPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authGroupsPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.AuthGroupsPost{}
+
+	// Parse the request.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Quick checks.
+	err = validate.IsAPIName(req.Name, false)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid authorization group name: %w", err))
+	}
+
+	permissions, err := authGroupValidate(req.AuthGroupPut)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		exists, err := dbCluster.AuthGroupExists(ctx, tx.Tx(), req.Name)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return api.StatusErrorf(http.StatusConflict, "Authorization group %q already exists", req.Name)
+		}
+
+		groupID, err := dbCluster.CreateAuthGroup(ctx, tx.Tx(), dbCluster.AuthGroup{Name: req.Name, Description: req.Description})
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.UpdateAuthGroupPermissions(ctx, tx.Tx(), groupID, permissions)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.AuthGroupCreated.Event(req.Name, request.CreateRequestor(r), nil)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation GET /1.0/auth/groups/{name} auth auth_group_get
+//
+//	Get the authorization group
+//
+//	Gets a specific authorization group.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Authorization group name
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: Authorization group
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthGroup"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authGroupGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var apiGroup *api.AuthGroup
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		group, err := dbCluster.GetAuthGroup(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		apiGroup, err = group.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, apiGroup, apiGroup.Writable())
+}
+
+// swagger:operation POST /1.0/auth/groups/{name} auth auth_group_post
+//
+//	Rename the authorization group
+//
+//	Renames an existing authorization group.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Authorization group name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: group
+//	    description: Authorization group rename request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthGroupPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authGroupPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.AuthGroupPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Quick checks.
+	err = validate.IsAPIName(req.Name, false)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid authorization group name: %w", err))
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check that the name isn't already in use.
+		exists, err := dbCluster.AuthGroupExists(ctx, tx.Tx(), req.Name)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return api.StatusErrorf(http.StatusConflict, "Name %q already in use", req.Name)
+		}
+
+		return dbCluster.RenameAuthGroup(ctx, tx.Tx(), name, req.Name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.AuthGroupRenamed.Event(req.Name, request.CreateRequestor(r), logger.Ctx{"old_name": name})
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation PUT /1.0/auth/groups/{name} auth auth_group_put
+//
+//	Update the authorization group
+//
+//	Updates the entire authorization group.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Authorization group name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: group
+//	    description: Authorization group
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthGroupPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation PATCH /1.0/auth/groups/{name} auth auth_group_patch
+//
+//	Partially update the authorization group
+//
+//	Updates a subset of the authorization group fields.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Authorization group name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: group
+//	    description: Authorization group
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthGroupPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authGroupPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get the current state.
+	var group *dbCluster.AuthGroup
+	var apiGroup *api.AuthGroup
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		group, err = dbCluster.GetAuthGroup(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		apiGroup, err = group.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate ETag.
+	err = localUtil.EtagCheck(r, apiGroup.Writable())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Fields missing from a PATCH request keep their current value.
+	req := api.AuthGroupPut{}
+	if r.Method == http.MethodPatch {
+		req = apiGroup.Writable()
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	permissions, err := authGroupValidate(req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		err := dbCluster.UpdateAuthGroup(ctx, tx.Tx(), name, dbCluster.AuthGroup{Name: name, Description: req.Description})
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.UpdateAuthGroupPermissions(ctx, tx.Tx(), int64(group.ID), permissions)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.AuthGroupUpdated.Event(name, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/auth/groups/{name} auth auth_group_delete
+//
+//	Delete the authorization group
+//
+//	Removes the authorization group. Its members lose the permissions granted through it.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Authorization group name
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authGroupDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteAuthGroup(ctx, tx.Tx(), name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.AuthGroupDeleted.Event(name, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// authIdentityValidate validates the authentication method and identifier of an identity.
+func authIdentityValidate(authenticationMethod string, identifier string) error {
+	if !slices.Contains([]string{api.AuthenticationMethodTLS, api.AuthenticationMethodOIDC}, authenticationMethod) {
+		return fmt.Errorf("Invalid authentication method %q", authenticationMethod)
+	}
+
+	if identifier == "" {
+		return errors.New("The identifier must be set")
+	}
+
+	return nil
+}
+
+// swagger:operation GET /1.0/auth/identities auth auth_identities_get
+//
+//	Get the identities
+//
+//	Returns a list of identities (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/auth/identities/oidc/jane@example.com",
+//	              "/1.0/auth/identities/tls/b4b1b4a7e5d9f4f0d3d5a1b2c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/identities?recursion=1 auth auth_identities_get_recursion1
+//
+//	Get the identities
+//
+//	Returns a list of identities (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of identities
+//	          items:
+//	            $ref: "#/definitions/AuthIdentity"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authIdentitiesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	recursion := localUtil.IsRecursionRequest(r)
+
+	var identities []dbCluster.Identity
+	var apiIdentities []*api.AuthIdentity
+
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		identities, err = dbCluster.GetIdentities(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		if !recursion {
+			return nil
+		}
+
+		apiIdentities = make([]*api.AuthIdentity, 0, len(identities))
+		for _, identity := range identities {
+			apiIdentity, err := identity.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			apiIdentities = append(apiIdentities, apiIdentity)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if recursion {
+		return response.SyncResponse(true, apiIdentities)
+	}
+
+	urls := make([]string, 0, len(identities))
+	for _, identity := range identities {
+		urls = append(urls, api.NewURL().Path(version.APIVersion, "auth", "identities", identity.AuthMethod, identity.Identifier).String())
+	}
+
+	return response.SyncResponse(true, urls)
+}
+
+// swagger:operation POST /1.0/auth/identities auth auth_identities_post
+//
+//	Add an identity
+//
+//	Adds a new identity and its authorization group memberships.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: identity
+//	    description: Identity to add
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthIdentitiesPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authIdentitiesPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.AuthIdentitiesPost{}
+
+	// Parse the request.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Quick checks.
+	err = authIdentityValidate(req.AuthenticationMethod, req.Identifier)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		exists, err := dbCluster.IdentityExists(ctx, tx.Tx(), req.AuthenticationMethod, req.Identifier)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return api.StatusErrorf(http.StatusConflict, "Identity %q already exists", req.AuthenticationMethod+"/"+req.Identifier)
+		}
+
+		identityID, err := dbCluster.CreateIdentity(ctx, tx.Tx(), dbCluster.Identity{
+			AuthMethod:  req.AuthenticationMethod,
+			Identifier:  req.Identifier,
+			Description: req.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.UpdateIdentityAuthGroups(ctx, tx.Tx(), identityID, req.Groups)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.IdentityCreated.Event(req.AuthenticationMethod, req.Identifier, request.CreateRequestor(r), nil)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation GET /1.0/auth/identities/{authenticationMethod}/{identifier} auth auth_identity_get
+//
+//	Get the identity
+//
+//	Gets a specific identity.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: authenticationMethod
+//	    description: Authentication method
+//	    type: string
+//	    required: true
+//	  - in: path
+//	    name: identifier
+//	    description: Identifier
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: Identity
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthIdentity"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authIdentityGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	authenticationMethod, err := pathVar(r, "authenticationMethod")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	identifier, err := pathVar(r, "identifier")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var apiIdentity *api.AuthIdentity
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		identity, err := dbCluster.GetIdentity(ctx, tx.Tx(), authenticationMethod, identifier)
+		if err != nil {
+			return err
+		}
+
+		apiIdentity, err = identity.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, apiIdentity, apiIdentity.Writable())
+}
+
+// swagger:operation PUT /1.0/auth/identities/{authenticationMethod}/{identifier} auth auth_identity_put
+//
+//	Update the identity
+//
+//	Updates the description and authorization groups of the identity.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: authenticationMethod
+//	    description: Authentication method
+//	    type: string
+//	    required: true
+//	  - in: path
+//	    name: identifier
+//	    description: Identifier
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: identity
+//	    description: Identity
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthIdentityPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation PATCH /1.0/auth/identities/{authenticationMethod}/{identifier} auth auth_identity_patch
+//
+//	Partially update the identity
+//
+//	Updates a subset of the identity fields.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: authenticationMethod
+//	    description: Authentication method
+//	    type: string
+//	    required: true
+//	  - in: path
+//	    name: identifier
+//	    description: Identifier
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: identity
+//	    description: Identity
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthIdentityPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authIdentityPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	authenticationMethod, err := pathVar(r, "authenticationMethod")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	identifier, err := pathVar(r, "identifier")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get the current state.
+	var identity *dbCluster.Identity
+	var apiIdentity *api.AuthIdentity
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		identity, err = dbCluster.GetIdentity(ctx, tx.Tx(), authenticationMethod, identifier)
+		if err != nil {
+			return err
+		}
+
+		apiIdentity, err = identity.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate ETag.
+	err = localUtil.EtagCheck(r, apiIdentity.Writable())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Fields missing from a PATCH request keep their current value.
+	req := api.AuthIdentityPut{}
+	if r.Method == http.MethodPatch {
+		req = apiIdentity.Writable()
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		identity.Description = req.Description
+
+		err := dbCluster.UpdateIdentity(ctx, tx.Tx(), authenticationMethod, identifier, *identity)
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.UpdateIdentityAuthGroups(ctx, tx.Tx(), int64(identity.ID), req.Groups)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.IdentityUpdated.Event(authenticationMethod, identifier, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/auth/identities/{authenticationMethod}/{identifier} auth auth_identity_delete
+//
+//	Delete the identity
+//
+//	Removes the identity and its authorization group memberships.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: authenticationMethod
+//	    description: Authentication method
+//	    type: string
+//	    required: true
+//	  - in: path
+//	    name: identifier
+//	    description: Identifier
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authIdentityDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	authenticationMethod, err := pathVar(r, "authenticationMethod")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	identifier, err := pathVar(r, "identifier")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteIdentity(ctx, tx.Tx(), authenticationMethod, identifier)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.IdentityDeleted.Event(authenticationMethod, identifier, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
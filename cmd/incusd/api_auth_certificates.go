@@ -0,0 +1,729 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v7/internal/instance"
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/certificate"
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	"github.com/lxc/incus/v7/internal/server/task"
+	localUtil "github.com/lxc/incus/v7/internal/server/util"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+var authCertificatesCmd = APIEndpoint{
+	Path: "auth/certificates",
+
+	Get:  APIEndpointAction{Handler: authCertificatesGet, AccessHandler: allowAuthenticated},
+	Post: APIEndpointAction{Handler: authCertificatesPost, AccessHandler: allowAuthenticated},
+}
+
+var authCertificateCmd = APIEndpoint{
+	Path: "auth/certificates/{serial}",
+
+	Get:    APIEndpointAction{Handler: authCertificateGet, AccessHandler: allowAuthenticated},
+	Delete: APIEndpointAction{Handler: authCertificateDelete, AccessHandler: allowAuthenticated},
+}
+
+var authCertificateAuthorityCmd = APIEndpoint{
+	Path: "auth/certificate-authority",
+
+	Get: APIEndpointAction{Handler: authCertificateAuthorityGet, AllowUntrusted: true},
+}
+
+// issuedCertificatesAuthorityRefresh is how long the absence of a certificate authority is cached for.
+const issuedCertificatesAuthorityRefresh = time.Minute
+
+// getIssuedCertificatesAuthority returns the authority issuing short-lived client certificates.
+// If create is false and no certificate was ever issued, nil is returned.
+func (d *Daemon) getIssuedCertificatesAuthority(ctx context.Context, create bool) (*certificate.Authority, error) {
+	d.issuedCertificatesAuthorityMu.Lock()
+	defer d.issuedCertificatesAuthorityMu.Unlock()
+
+	if d.issuedCertificatesAuthority != nil {
+		return d.issuedCertificatesAuthority, nil
+	}
+
+	// Avoid querying the database on every TLS handshake until a certificate gets issued.
+	if !create && time.Since(d.issuedCertificatesAuthorityChecked) < issuedCertificatesAuthorityRefresh {
+		return nil, nil
+	}
+
+	var certPEM string
+	var keyPEM string
+
+	err := d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		certPEM, keyPEM, err = dbCluster.GetIssuedCertificatesAuthority(ctx, tx.Tx())
+		if err == nil || !create || !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return err
+		}
+
+		cert, key, err := certificate.GenerateAuthority()
+		if err != nil {
+			return err
+		}
+
+		certPEM = string(cert)
+		keyPEM = string(key)
+
+		return dbCluster.CreateIssuedCertificatesAuthority(ctx, tx.Tx(), certPEM, keyPEM)
+	})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			d.issuedCertificatesAuthorityChecked = time.Now()
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	authority, err := certificate.LoadAuthority([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, err
+	}
+
+	d.issuedCertificatesAuthority = authority
+
+	return authority, nil
+}
+
+// getIssuedCertificate returns the record of a valid client certificate issued by the server.
+// It returns nil if the certificate wasn't issued by the server and an error if it was revoked.
+func (d *Daemon) getIssuedCertificate(ctx context.Context, cert *x509.Certificate) (*dbCluster.IssuedCertificate, error) {
+	authority, err := d.getIssuedCertificatesAuthority(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if authority == nil || !authority.Issued(cert) {
+		return nil, nil
+	}
+
+	serial := certificate.SerialString(cert.SerialNumber)
+
+	var issued *dbCluster.IssuedCertificate
+	err = d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		issued, err = dbCluster.GetIssuedCertificate(ctx, tx.Tx(), serial)
+		return err
+	})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil, fmt.Errorf("Unknown issued certificate %q", serial)
+		}
+
+		return nil, err
+	}
+
+	if issued.RevokedAt.Valid {
+		return nil, fmt.Errorf("Certificate %q has been revoked", serial)
+	}
+
+	return issued, nil
+}
+
+// issuedCertificatesRevocationRefresh is how often the list of revoked issued client certificates is refreshed.
+const issuedCertificatesRevocationRefresh = time.Minute
+
+// issuedCertificatesRevocation holds the state needed to reject revoked client certificates without querying the database.
+type issuedCertificatesRevocation struct {
+	authority *certificate.Authority
+	revoked   map[string]bool
+}
+
+// refreshIssuedCertificatesRevocation reloads the list of revoked issued client certificates from the database.
+func (d *Daemon) refreshIssuedCertificatesRevocation(ctx context.Context) error {
+	authority, err := d.getIssuedCertificatesAuthority(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	if authority == nil {
+		return nil
+	}
+
+	revoked := map[string]bool{}
+	err = d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		issued, err := dbCluster.GetIssuedCertificates(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, cert := range issued {
+			if cert.RevokedAt.Valid {
+				revoked[cert.Serial] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.issuedCertificatesRevocationMu.Lock()
+	defer d.issuedCertificatesRevocationMu.Unlock()
+
+	d.issuedCertificatesRevocation = &issuedCertificatesRevocation{authority: authority, revoked: revoked}
+
+	return nil
+}
+
+// checkCertificateRevocation rejects revoked client certificates during the TLS handshake.
+// It only relies on the in-memory list of revoked certificates, leaving any other check to request authentication.
+func (d *Daemon) checkCertificateRevocation(cert *x509.Certificate) error {
+	d.issuedCertificatesRevocationMu.RLock()
+	defer d.issuedCertificatesRevocationMu.RUnlock()
+
+	revocation := d.issuedCertificatesRevocation
+	if revocation == nil || !revocation.authority.Issued(cert) {
+		return nil
+	}
+
+	serial := certificate.SerialString(cert.SerialNumber)
+	if revocation.revoked[serial] {
+		return fmt.Errorf("Certificate %q has been revoked", serial)
+	}
+
+	return nil
+}
+
+func issuedCertificatesRevocationTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := d.refreshIssuedCertificatesRevocation(ctx)
+		if err != nil {
+			logger.Warn("Failed refreshing revoked client certificates", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(issuedCertificatesRevocationRefresh)
+}
+
+// issuedCertificateOwner returns whether the requestor is the identity the certificate was issued to.
+func issuedCertificateOwner(r *http.Request, issued *dbCluster.IssuedCertificate) bool {
+	requestor := request.CreateRequestor(r)
+
+	return requestor.Protocol == issued.AuthMethod && requestor.Username == issued.Identifier
+}
+
+// swagger:operation GET /1.0/auth/certificates auth auth_certificates_get
+//
+//	Get the issued client certificates
+//
+//	Returns a list of client certificates issued by the server (URLs).
+//	Users without the `can_view_sensitive` entitlement on the server only see their own certificates.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/auth/certificates/3f2a9c1e0b7d4e6f8a1b2c3d4e5f6a7b",
+//	              "/1.0/auth/certificates/5c9d2e7f1a3b4c6d8e0f1a2b3c4d5e6f"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/certificates?recursion=1 auth auth_certificates_get_recursion1
+//
+//	Get the issued client certificates
+//
+//	Returns a list of client certificates issued by the server (structs).
+//	Users without the `can_view_sensitive` entitlement on the server only see their own certificates.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of issued client certificates
+//	          items:
+//	            $ref: "#/definitions/AuthCertificate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authCertificatesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	recursion := localUtil.IsRecursionRequest(r)
+
+	// Restrict the list to the certificates of the requestor unless allowed to see all of them.
+	var filters []dbCluster.IssuedCertificateFilter
+	err := s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectServer(), auth.EntitlementCanViewSensitive)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusForbidden) {
+			return response.SmartError(err)
+		}
+
+		requestor := request.CreateRequestor(r)
+		filters = append(filters, dbCluster.IssuedCertificateFilter{AuthMethod: &requestor.Protocol, Identifier: &requestor.Username})
+	}
+
+	var issued []dbCluster.IssuedCertificate
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		issued, err = dbCluster.GetIssuedCertificates(ctx, tx.Tx(), filters...)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if recursion {
+		apiCertificates := make([]*api.AuthCertificate, 0, len(issued))
+		for _, cert := range issued {
+			apiCertificates = append(apiCertificates, cert.ToAPI())
+		}
+
+		return response.SyncResponse(true, apiCertificates)
+	}
+
+	urls := make([]string, 0, len(issued))
+	for _, cert := range issued {
+		urls = append(urls, api.NewURL().Path(version.APIVersion, "auth", "certificates", cert.Serial).String())
+	}
+
+	return response.SyncResponse(true, urls)
+}
+
+// swagger:operation POST /1.0/auth/certificates auth auth_certificates_post
+//
+//	Issue a client certificate
+//
+//	Signs a short-lived client certificate for the OpenID Connect user or API token making the request.
+//	Requests authenticated with the certificate get the same identity and permissions as the original requestor.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: certificate
+//	    description: Certificate request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthCertificatesPost"
+//	responses:
+//	  "200":
+//	    description: Issued client certificate
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthCertificate"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authCertificatesPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	requestor := request.CreateRequestor(r)
+	if !slices.Contains([]string{api.AuthenticationMethodOIDC, api.AuthenticationMethodToken}, requestor.Protocol) {
+		return response.Forbidden(errors.New("Client certificates can only be issued to OpenID Connect users and API tokens"))
+	}
+
+	// Issued certificates can't be used to issue new ones, as that would allow extending their lifetime.
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		issued, err := d.getIssuedCertificate(r.Context(), r.TLS.PeerCertificates[0])
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if issued != nil {
+			return response.Forbidden(errors.New("Issued client certificates can't be used to issue client certificates"))
+		}
+	}
+
+	req := api.AuthCertificatesPost{}
+
+	// Parse the request.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	now := time.Now().UTC()
+	maxExpiresAt, err := internalInstance.GetExpiry(now, s.GlobalConfig.IssuedCertificatesExpiry())
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	expiresAt := req.ExpiresAt.UTC()
+	if req.ExpiresAt.IsZero() {
+		expiresAt = maxExpiresAt
+	} else if expiresAt.Before(now) {
+		return response.BadRequest(errors.New("The expiry date of the certificate is in the past"))
+	} else if expiresAt.After(maxExpiresAt) {
+		return response.BadRequest(fmt.Errorf("The certificate can't be valid for longer than %q", s.GlobalConfig.IssuedCertificatesExpiry()))
+	}
+
+	// Certificates issued to API tokens don't outlive the token.
+	if requestor.Protocol == api.AuthenticationMethodToken {
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			token, err := dbCluster.GetAuthToken(ctx, tx.Tx(), requestor.Username)
+			if err != nil {
+				return err
+			}
+
+			if token.ExpiresAt.Valid && token.ExpiresAt.Time.Before(expiresAt) {
+				expiresAt = token.ExpiresAt.Time.UTC()
+			}
+
+			return nil
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	authority, err := d.getIssuedCertificatesAuthority(r.Context(), true)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	certPEM, serial, err := authority.Issue([]byte(req.CSR), requestor.Username, expiresAt)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	identityProviderGroups, _ := r.Context().Value(request.CtxIdentityProviderGroups).([]string)
+
+	issued := dbCluster.IssuedCertificate{
+		Serial:                 serial,
+		AuthMethod:             requestor.Protocol,
+		Identifier:             requestor.Username,
+		IdentityProviderGroups: strings.Join(identityProviderGroups, ","),
+		Certificate:            string(certPEM),
+		CreatedAt:              now,
+		ExpiresAt:              expiresAt,
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, err := dbCluster.CreateIssuedCertificate(ctx, tx.Tx(), issued)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.AuthCertificateIssued.Event(serial, requestor, logger.Ctx{"identifier": requestor.Username, "expires_at": expiresAt})
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, issued.ToAPI(), lc.Source)
+}
+
+// swagger:operation GET /1.0/auth/certificates/{serial} auth auth_certificate_get
+//
+//	Get the issued client certificate
+//
+//	Gets a specific client certificate issued by the server.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: serial
+//	    description: Certificate serial number
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: Issued client certificate
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthCertificate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authCertificateGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	serial, err := pathVar(r, "serial")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var issued *dbCluster.IssuedCertificate
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		issued, err = dbCluster.GetIssuedCertificate(ctx, tx.Tx(), serial)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Hide the certificates of other identities unless allowed to see them.
+	if !issuedCertificateOwner(r, issued) {
+		err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectServer(), auth.EntitlementCanViewSensitive)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusForbidden) {
+				return response.NotFound(nil)
+			}
+
+			return response.SmartError(err)
+		}
+	}
+
+	return response.SyncResponse(true, issued.ToAPI())
+}
+
+// swagger:operation DELETE /1.0/auth/certificates/{serial} auth auth_certificate_delete
+//
+//	Revoke the issued client certificate
+//
+//	Revokes a client certificate issued by the server. Any further connection made with it is rejected.
+//	Users without the `can_edit` entitlement on the server can only revoke their own certificates.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: serial
+//	    description: Certificate serial number
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authCertificateDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	serial, err := pathVar(r, "serial")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		issued, err := dbCluster.GetIssuedCertificate(ctx, tx.Tx(), serial)
+		if err != nil {
+			return err
+		}
+
+		if !issuedCertificateOwner(r, issued) {
+			err = s.Authorizer.CheckPermission(ctx, r, auth.ObjectServer(), auth.EntitlementCanEdit)
+			if err != nil {
+				return err
+			}
+		}
+
+		if issued.RevokedAt.Valid {
+			return api.StatusErrorf(http.StatusBadRequest, "Certificate %q has already been revoked", serial)
+		}
+
+		issued.RevokedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+		return dbCluster.UpdateIssuedCertificate(ctx, tx.Tx(), serial, *issued)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Reject the certificate during the TLS handshake without waiting for the next refresh.
+	err = d.refreshIssuedCertificatesRevocation(r.Context())
+	if err != nil {
+		logger.Warn("Failed refreshing revoked client certificates", logger.Ctx{"err": err})
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.AuthCertificateRevoked.Event(serial, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/auth/certificate-authority auth auth_certificate_authority_get
+//
+//	Get the client certificate authority
+//
+//	Gets the certificate of the authority issuing short-lived client certificates along with its
+//	certificate revocation list.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Client certificate authority
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthCertificateAuthority"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authCertificateAuthorityGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	authority, err := d.getIssuedCertificatesAuthority(r.Context(), false)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if authority == nil {
+		return response.NotFound(errors.New("No client certificate has been issued yet"))
+	}
+
+	var revoked []certificate.RevokedCertificate
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		issued, err := dbCluster.GetIssuedCertificates(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, cert := range issued {
+			if cert.RevokedAt.Valid {
+				revoked = append(revoked, certificate.RevokedCertificate{Serial: cert.Serial, RevokedAt: cert.RevokedAt.Time})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The revocation list is generated on request, so its number only needs to increase over time.
+	crl, err := authority.CRL(revoked, time.Now().Unix())
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, api.AuthCertificateAuthority{
+		Certificate: string(authority.CertificatePEM()),
+		CRL:         string(crl),
+	})
+}
+
+// pruneExpiredIssuedCertificates removes the records of issued client certificates which have expired.
+func pruneExpiredIssuedCertificates(ctx context.Context, s *db.Cluster) error {
+	return s.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteExpiredIssuedCertificates(ctx, tx.Tx(), time.Now().UTC())
+	})
+}
@@ -0,0 +1,603 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	localUtil "github.com/lxc/incus/v7/internal/server/util"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/validate"
+)
+
+var authTokensCmd = APIEndpoint{
+	Path: "auth/tokens",
+
+	Get:  APIEndpointAction{Handler: authTokensGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewSensitive)},
+	Post: APIEndpointAction{Handler: authTokensPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var authTokenCmd = APIEndpoint{
+	Path: "auth/tokens/{name}",
+
+	Get:    APIEndpointAction{Handler: authTokenGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewSensitive)},
+	Put:    APIEndpointAction{Handler: authTokenPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Patch:  APIEndpointAction{Handler: authTokenPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Delete: APIEndpointAction{Handler: authTokenDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// authTokenLastUsedInterval is how often the last use of an API token is recorded.
+const authTokenLastUsedInterval = time.Minute
+
+// authenticateToken validates an API token secret, returning the name of the token.
+func (d *Daemon) authenticateToken(ctx context.Context, secret string) (string, error) {
+	var token *dbCluster.AuthToken
+
+	secretHash := auth.TokenHash(secret)
+	err := d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		tokens, err := dbCluster.GetAuthTokens(ctx, tx.Tx(), dbCluster.AuthTokenFilter{SecretHash: &secretHash})
+		if err != nil {
+			return err
+		}
+
+		if len(tokens) != 1 {
+			return errors.New("Unknown API token")
+		}
+
+		token = &tokens[0]
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if token.IsExpired() {
+		return "", fmt.Errorf("API token %q has expired", token.Name)
+	}
+
+	// Record the use of the token without writing to the database on every request.
+	now := time.Now().UTC()
+	if !token.LastUsedAt.Valid || now.Sub(token.LastUsedAt.Time) > authTokenLastUsedInterval {
+		err = d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return dbCluster.UpdateAuthTokenLastUsed(ctx, tx.Tx(), token.ID, now)
+		})
+		if err != nil {
+			logger.Warn("Failed recording API token use", logger.Ctx{"token": token.Name, "err": err})
+		}
+	}
+
+	return token.Name, nil
+}
+
+// getAuthToken returns the scope of an API token for the authorizer.
+func (d *Daemon) getAuthToken(ctx context.Context, name string) (*auth.Token, error) {
+	var apiToken *api.AuthToken
+
+	err := d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		token, err := dbCluster.GetAuthToken(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		if token.IsExpired() {
+			return fmt.Errorf("API token %q has expired", name)
+		}
+
+		apiToken, err = token.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token := &auth.Token{
+		Projects:     apiToken.Projects,
+		Entitlements: make([]auth.Entitlement, 0, len(apiToken.Entitlements)),
+	}
+
+	for _, entitlement := range apiToken.Entitlements {
+		token.Entitlements = append(token.Entitlements, auth.Entitlement(entitlement))
+	}
+
+	return token, nil
+}
+
+// swagger:operation GET /1.0/auth/tokens auth auth_tokens_get
+//
+//	Get the API tokens
+//
+//	Returns a list of API tokens (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/auth/tokens/webapp-ci",
+//	              "/1.0/auth/tokens/backups"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/auth/tokens?recursion=1 auth auth_tokens_get_recursion1
+//
+//	Get the API tokens
+//
+//	Returns a list of API tokens (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of API tokens
+//	          items:
+//	            $ref: "#/definitions/AuthToken"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authTokensGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	recursion := localUtil.IsRecursionRequest(r)
+
+	var tokens []dbCluster.AuthToken
+	var apiTokens []*api.AuthToken
+
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		tokens, err = dbCluster.GetAuthTokens(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		if !recursion {
+			return nil
+		}
+
+		apiTokens = make([]*api.AuthToken, 0, len(tokens))
+		for _, token := range tokens {
+			apiToken, err := token.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			apiTokens = append(apiTokens, apiToken)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if recursion {
+		return response.SyncResponse(true, apiTokens)
+	}
+
+	urls := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		urls = append(urls, api.NewURL().Path(version.APIVersion, "auth", "tokens", token.Name).String())
+	}
+
+	return response.SyncResponse(true, urls)
+}
+
+// swagger:operation POST /1.0/auth/tokens auth auth_tokens_post
+//
+//	Add an API token
+//
+//	Creates a new API token and returns its secret.
+//	The secret is only returned once and can't be retrieved afterwards.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: token
+//	    description: API token to create
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthTokensPost"
+//	responses:
+//	  "200":
+//	    description: API token secret
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthTokenSecret"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authTokensPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// API tokens can't be used to issue new tokens, as that would allow extending their lifetime.
+	requestor := request.CreateRequestor(r)
+	if requestor.Protocol == api.AuthenticationMethodToken {
+		return response.Forbidden(errors.New("API tokens can't be used to create API tokens"))
+	}
+
+	req := api.AuthTokensPost{}
+
+	// Parse the request.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Quick checks.
+	err = validate.IsAPIName(req.Name, false)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid API token name: %w", err))
+	}
+
+	if len(req.Entitlements) == 0 {
+		return response.BadRequest(errors.New("At least one entitlement must be granted to the API token"))
+	}
+
+	for _, entitlement := range req.Entitlements {
+		err = auth.ValidateTokenEntitlement(auth.Entitlement(entitlement))
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
+	now := time.Now().UTC()
+	if !req.ExpiresAt.IsZero() && req.ExpiresAt.Before(now) {
+		return response.BadRequest(errors.New("The expiry date of the API token is in the past"))
+	}
+
+	secret, err := auth.NewTokenSecret()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	token := dbCluster.AuthToken{
+		Name:        req.Name,
+		Description: req.Description,
+		SecretHash:  auth.TokenHash(secret),
+		CreatedAt:   now,
+		ExpiresAt:   sql.NullTime{Time: req.ExpiresAt.UTC(), Valid: !req.ExpiresAt.IsZero()},
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		exists, err := dbCluster.AuthTokenExists(ctx, tx.Tx(), req.Name)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return api.StatusErrorf(http.StatusConflict, "API token %q already exists", req.Name)
+		}
+
+		tokenID, err := dbCluster.CreateAuthToken(ctx, tx.Tx(), token)
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.UpdateAuthTokenScope(ctx, tx.Tx(), tokenID, req.Projects, req.Entitlements)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.AuthTokenCreated.Event(req.Name, requestor, logger.Ctx{"projects": req.Projects, "entitlements": req.Entitlements})
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, api.AuthTokenSecret{Name: req.Name, Token: secret}, lc.Source)
+}
+
+// swagger:operation GET /1.0/auth/tokens/{name} auth auth_token_get
+//
+//	Get the API token
+//
+//	Gets a specific API token.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: API token name
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: API token
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/AuthToken"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authTokenGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var apiToken *api.AuthToken
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		token, err := dbCluster.GetAuthToken(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		apiToken, err = token.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, apiToken, apiToken.Writable())
+}
+
+// swagger:operation PUT /1.0/auth/tokens/{name} auth auth_token_put
+//
+//	Update the API token
+//
+//	Updates the entire API token.
+//	The scope and expiry of a token can't be changed.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: API token name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: token
+//	    description: API token
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthTokenPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation PATCH /1.0/auth/tokens/{name} auth auth_token_patch
+//
+//	Partially update the API token
+//
+//	Updates a subset of the API token fields.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: API token name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: token
+//	    description: API token
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/AuthTokenPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authTokenPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get the current state.
+	var token *dbCluster.AuthToken
+	var apiToken *api.AuthToken
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		token, err = dbCluster.GetAuthToken(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		apiToken, err = token.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate ETag.
+	err = localUtil.EtagCheck(r, apiToken.Writable())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Fields missing from a PATCH request keep their current value.
+	req := api.AuthTokenPut{}
+	if r.Method == http.MethodPatch {
+		req = apiToken.Writable()
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	token.Description = req.Description
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.UpdateAuthToken(ctx, tx.Tx(), name, *token)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.AuthTokenUpdated.Event(name, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/auth/tokens/{name} auth auth_token_delete
+//
+//	Revoke the API token
+//
+//	Removes the API token. Any further request made with it is rejected.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: API token name
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func authTokenDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteAuthToken(ctx, tx.Tx(), name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.AuthTokenDeleted.Event(name, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
@@ -474,50 +474,74 @@ func restoreClusterMember(d *Daemon, r *http.Request, skipInstances bool) respon
 			return err
 		}
 
-		// Restart the local instances.
+		// Restore the instances in dependency order, starting the local ones and
+		// migrating back the remote ones of each level before moving to the next.
+		levels, err := instancesDependencyLevels(append(append([]instance.Instance{}, localInstances...), instances...))
+		if err != nil {
+			logger.Warn("Failed resolving instance dependencies", logger.Ctx{"err": err})
+		}
+
+		isLocal := make(map[instance.Instance]bool, len(localInstances))
 		for _, inst := range localInstances {
-			// Don't start instances which were stopped by the user.
-			if inst.LocalConfig()["volatile.last_state.power"] != instance.PowerStateRunning {
-				continue
-			}
+			isLocal[inst] = true
+		}
 
-			// Don't attempt to start instances which are already running.
-			if inst.IsRunning() {
-				continue
-			}
+		// Limit the number of concurrent migrations to run at the same time
+		numParallelMigrations := max(runtime.NumCPU()/16, 1)
 
-			// Start the instance.
-			_ = op.ExtendMetadata(map[string]any{"evacuation_progress": fmt.Sprintf("Starting %q in project %q", inst.Name(), inst.Project().Name)})
+		for _, level := range levels {
+			// Restart the local instances.
+			for _, inst := range level {
+				if !isLocal[inst] {
+					continue
+				}
 
-			// If configured for stateful stop, try restoring its state.
-			action := inst.CanMigrate()
-			if action == "stateful-stop" {
-				err = inst.Start(true)
-			} else {
-				err = inst.Start(false)
-			}
+				// Don't start instances which were stopped by the user.
+				if inst.LocalConfig()["volatile.last_state.power"] != instance.PowerStateRunning {
+					continue
+				}
 
-			if err != nil {
-				return fmt.Errorf("Failed to start instance %q: %w", inst.Name(), err)
+				// Don't attempt to start instances which are already running.
+				if inst.IsRunning() {
+					continue
+				}
+
+				restoreClusterMemberWaitDependencies(s, inst, op)
+
+				// Start the instance.
+				_ = op.ExtendMetadata(map[string]any{"evacuation_progress": fmt.Sprintf("Starting %q in project %q", inst.Name(), inst.Project().Name)})
+
+				// If configured for stateful stop, try restoring its state.
+				action := inst.CanMigrate()
+				if action == "stateful-stop" {
+					err = inst.Start(true)
+				} else {
+					err = inst.Start(false)
+				}
+
+				if err != nil {
+					return fmt.Errorf("Failed to start instance %q: %w", inst.Name(), err)
+				}
 			}
-		}
 
-		// Limit the number of concurrent migrations to run at the same time
-		numParallelMigrations := max(runtime.NumCPU()/16, 1)
+			group := &errgroup.Group{}
+			group.SetLimit(numParallelMigrations)
 
-		group := &errgroup.Group{}
-		group.SetLimit(numParallelMigrations)
+			// Migrate back the remote instances.
+			for _, inst := range level {
+				if isLocal[inst] {
+					continue
+				}
 
-		// Migrate back the remote instances.
-		for _, inst := range instances {
-			group.Go(func() error {
-				return restoreClusterMemberFunc(inst, op, originName, r, s)
-			})
-		}
+				group.Go(func() error {
+					return restoreClusterMemberFunc(inst, op, originName, r, s)
+				})
+			}
 
-		err = group.Wait()
-		if err != nil {
-			return fmt.Errorf("Failed to restore instances: %w", err)
+			err = group.Wait()
+			if err != nil {
+				return fmt.Errorf("Failed to restore instances: %w", err)
+			}
 		}
 
 		// Set node status to CREATED.
@@ -670,6 +694,8 @@ func restoreClusterMemberFunc(inst instance.Instance, op *operations.Operation,
 		return nil
 	}
 
+	restoreClusterMemberWaitDependencies(s, inst, op)
+
 	_ = op.ExtendMetadata(map[string]any{"evacuation_progress": fmt.Sprintf("Starting %q in project %q", inst.Name(), inst.Project().Name)})
 
 	err = inst.Start(false)
@@ -680,6 +706,21 @@ func restoreClusterMemberFunc(inst instance.Instance, op *operations.Operation,
 	return nil
 }
 
+// restoreClusterMemberWaitDependencies waits for the dependencies of an instance being restored.
+// The instance is started anyway if they don't become ready in time.
+func restoreClusterMemberWaitDependencies(s *state.State, inst instance.Instance, op *operations.Operation) {
+	if len(instanceDependencies(inst)) == 0 {
+		return
+	}
+
+	_ = op.ExtendMetadata(map[string]any{"evacuation_progress": fmt.Sprintf("Waiting for dependencies of %q in project %q", inst.Name(), inst.Project().Name)})
+
+	err := instanceWaitDependencies(s.ShutdownCtx, s, inst, time.Now().Add(instanceDependsOnTimeout(inst)))
+	if err != nil {
+		logger.Warn("Starting instance without its dependencies being ready", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+	}
+}
+
 func evacuateClusterSelectTarget(ctx context.Context, s *state.State, inst instance.Instance) (*db.NodeInfo, *db.NodeInfo, error) {
 	var sourceMemberInfo *db.NodeInfo
 	var targetMemberInfo *db.NodeInfo
@@ -700,11 +741,19 @@ func evacuateClusterSelectTarget(ctx context.Context, s *state.State, inst insta
 			return fmt.Errorf("Failed getting cluster members: %w", err)
 		}
 
+		// Never move the instance back to its current location.
+		otherMembers := make([]db.NodeInfo, 0, len(allMembers))
+		for _, member := range allMembers {
+			if member.Name != srcMember.Name {
+				otherMembers = append(otherMembers, member)
+			}
+		}
+
 		// Filter candidates by the instance's cluster group and offline servers.
 		group := inst.LocalConfig()["volatile.cluster.group"]
 		instProject := inst.Project()
 		clusterGroupsAllowed := project.GetRestrictedClusterGroups(&instProject)
-		candidateMembers, err = tx.GetCandidateMembers(ctx, allMembers, []int{inst.Architecture()}, group, clusterGroupsAllowed, s.GlobalConfig.OfflineThreshold())
+		candidateMembers, err = tx.GetCandidateMembers(ctx, otherMembers, []int{inst.Architecture()}, group, clusterGroupsAllowed, s.GlobalConfig.OfflineThreshold())
 		if err != nil {
 			return err
 		}
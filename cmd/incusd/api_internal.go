@@ -61,6 +61,7 @@ var apiInternal = []APIEndpoint{
 	internalContainerOnStopCmd,
 	internalContainerOnStopNSCmd,
 	internalVirtualMachineOnResizeCmd,
+	internalInstanceOnReloadCmd,
 	internalGarbageCollectorCmd,
 	internalImageOptimizeCmd,
 	internalImageRefreshCmd,
@@ -169,6 +170,13 @@ var internalVirtualMachineOnResizeCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: internalVirtualMachineOnResize, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+// Instance hooks.
+var internalInstanceOnReloadCmd = APIEndpoint{
+	Path: "instances/{instanceRef}/onreload",
+
+	Get: APIEndpointAction{Handler: internalInstanceOnReload, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 // Debugging.
 var internalBGPStateCmd = APIEndpoint{
 	Path: "debug/bgp",
@@ -572,6 +580,46 @@ func internalVirtualMachineOnResize(d *Daemon, r *http.Request) response.Respons
 	return response.EmptySyncResponse
 }
 
+func internalInstanceOnReload(d *Daemon, r *http.Request) response.Response {
+	// Wait until daemon is fully started.
+	<-d.waitReady.Done()
+
+	s := d.State()
+
+	// Get the instance ID.
+	instanceID, err := strconv.Atoi(r.PathValue("instanceRef"))
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Get the devices list.
+	devices := request.QueryParam(r, "devices")
+	if devices == "" {
+		return response.BadRequest(errors.New("Reload hook requires a list of devices"))
+	}
+
+	// Load by ID.
+	inst, err := instance.LoadByID(s, instanceID)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Nothing to do if the instance isn't running.
+	if !inst.IsRunning() {
+		return response.EmptySyncResponse
+	}
+
+	// Reload the devices.
+	for _, devName := range strings.Split(devices, ",") {
+		err = inst.ReloadDevice(devName)
+		if err != nil {
+			return response.InternalError(err)
+		}
+	}
+
+	return response.EmptySyncResponse
+}
+
 // Perform a database dump.
 func internalSQLGet(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
@@ -7,14 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/http"
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
 	incus "github.com/lxc/incus/v7/client"
 	"github.com/lxc/incus/v7/internal/filter"
+	internalInstance "github.com/lxc/incus/v7/internal/instance"
 	"github.com/lxc/incus/v7/internal/jmap"
 	"github.com/lxc/incus/v7/internal/server/auth"
 	"github.com/lxc/incus/v7/internal/server/db"
@@ -31,6 +34,7 @@ import (
 	"github.com/lxc/incus/v7/internal/version"
 	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/util"
 	"github.com/lxc/incus/v7/shared/validate"
 )
@@ -39,7 +43,7 @@ var projectsCmd = APIEndpoint{
 	Path: "projects",
 
 	Get:  APIEndpointAction{Handler: projectsGet, AccessHandler: allowAuthenticated},
-	Post: APIEndpointAction{Handler: projectsPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanCreateProjects)},
+	Post: APIEndpointAction{Handler: projectsPost, AccessHandler: allowAuthenticated},
 }
 
 var projectCmd = APIEndpoint{
@@ -314,7 +318,8 @@ func projectUsedBy(ctx context.Context, tx *db.ClusterTx, project *cluster.Proje
 //
 //	Add a project
 //
-//	Creates a new project.
+//	Creates a new project, optionally from a project template.
+//	Users not allowed to create projects may only create them from self-service templates.
 //
 //	---
 //	consumes:
@@ -335,6 +340,8 @@ func projectUsedBy(ctx context.Context, tx *db.ClusterTx, project *cluster.Proje
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
 //	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 func projectsPost(d *Daemon, r *http.Request) response.Response {
@@ -343,18 +350,6 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 	// Parse the request.
 	project := api.ProjectsPost{}
 
-	// Set default features.
-	if project.Config == nil {
-		project.Config = map[string]string{}
-	}
-
-	for featureName, featureInfo := range cluster.ProjectFeatures {
-		_, ok := project.Config[featureName]
-		if !ok && featureInfo.DefaultEnabled {
-			project.Config[featureName] = "true"
-		}
-	}
-
 	err := json.NewDecoder(r.Body).Decode(&project)
 	if err != nil {
 		return response.BadRequest(err)
@@ -371,12 +366,64 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Users not allowed to create projects may only create them from self-service templates.
+	canCreateProjects, err := projectTemplateCanCreateProjects(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !canCreateProjects && project.Template == "" {
+		return response.Forbidden(nil)
+	}
+
+	var template *api.ProjectTemplate
+	if project.Template != "" {
+		template, err = projectTemplateLoad(r.Context(), s, project.Template, canCreateProjects)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		config := map[string]string{}
+		maps.Copy(config, template.Config)
+
+		// The configuration of the template can't be overridden by self-service users.
+		if canCreateProjects {
+			maps.Copy(config, project.Config)
+		}
+
+		project.Config = config
+	}
+
+	// Make sure self-service users can be granted access to the project before creating it.
+	var grantMethod string
+	if !canCreateProjects {
+		grantMethod, err = projectSelfServiceGrantMethod(r.Context(), s, r, project.Name)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	// Set default features.
+	if project.Config == nil {
+		project.Config = map[string]string{}
+	}
+
+	for featureName, featureInfo := range cluster.ProjectFeatures {
+		_, ok := project.Config[featureName]
+		if !ok && featureInfo.DefaultEnabled {
+			project.Config[featureName] = "true"
+		}
+	}
+
 	// Validate the configuration.
 	err = projectValidateConfig(s, project.Config)
 	if err != nil {
 		return response.BadRequest(err)
 	}
 
+	reverter := revert.New()
+	defer reverter.Fail()
+
 	var id int64
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		id, err = cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Description: project.Description, Name: project.Name})
@@ -414,8 +461,38 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 		logger.Error("Failed to add project to authorizer", logger.Ctx{"name": project.Name, "error": err})
 	}
 
+	var lcCtx map[string]any
+	if template != nil {
+		reverter.Add(func() {
+			err := s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return cluster.DeleteProject(ctx, tx.Tx(), project.Name)
+			})
+			if err != nil {
+				logger.Warn("Failed removing project", logger.Ctx{"name": project.Name, "err": err})
+			}
+
+			_ = s.Authorizer.DeleteProject(context.Background(), id, project.Name)
+		})
+
+		err = projectTemplateApply(r.Context(), s, r, project.Name, template, reverter)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed applying project template %q: %w", template.Name, err))
+		}
+
+		if !canCreateProjects {
+			err = projectSelfServiceGrant(r.Context(), s, r, project.Name, grantMethod)
+			if err != nil {
+				return response.SmartError(fmt.Errorf("Failed granting access to project %q: %w", project.Name, err))
+			}
+		}
+
+		lcCtx = map[string]any{"template": template.Name}
+	}
+
+	reverter.Success()
+
 	requestor := request.CreateRequestor(r)
-	lc := lifecycle.ProjectCreated.Event(project.Name, requestor, nil)
+	lc := lifecycle.ProjectCreated.Event(project.Name, requestor, lcCtx)
 	s.Events.SendLifecycle(project.Name, lc)
 
 	return response.SyncResponseLocation(true, nil, lc.Source)
@@ -539,6 +616,8 @@ func projectGet(d *Daemon, r *http.Request) response.Response {
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
+//	  "202":
+//	    $ref: "#/responses/Operation"
 //	  "400":
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
@@ -598,10 +677,7 @@ func projectPut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	requestor := request.CreateRequestor(r)
-	s.Events.SendLifecycle(project.Name, lifecycle.ProjectUpdated.Event(project.Name, requestor, nil))
-
-	return projectChange(r.Context(), s, project, req)
+	return projectChange(r.Context(), s, r, project, req)
 }
 
 // swagger:operation PATCH /1.0/projects/{name} projects project_patch
@@ -630,6 +706,8 @@ func projectPut(d *Daemon, r *http.Request) response.Response {
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
+//	  "202":
+//	    $ref: "#/responses/Operation"
 //	  "400":
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
@@ -719,14 +797,11 @@ func projectPatch(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
-	requestor := request.CreateRequestor(r)
-	s.Events.SendLifecycle(project.Name, lifecycle.ProjectUpdated.Event(project.Name, requestor, nil))
-
-	return projectChange(r.Context(), s, project, req)
+	return projectChange(r.Context(), s, r, project, req)
 }
 
 // Common logic between PUT and PATCH.
-func projectChange(ctx context.Context, s *state.State, project *api.Project, req api.ProjectPut) response.Response {
+func projectChange(ctx context.Context, s *state.State, r *http.Request, project *api.Project, req api.ProjectPut) response.Response {
 	// Make a list of config keys that have changed.
 	configChanged := []string{}
 	for key := range project.Config {
@@ -784,49 +859,115 @@ func projectChange(ctx context.Context, s *state.State, project *api.Project, re
 		return response.BadRequest(err)
 	}
 
-	// Update the database entry.
-	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
-		err := projecthelpers.AllowProjectUpdate(tx, project.Name, req.Config, configChanged)
+	// Only users allowed to edit the server may change the protection policy.
+	if slices.ContainsFunc(configChanged, projecthelpers.IsProtectionConfigKey) {
+		err = s.Authorizer.CheckPermission(ctx, r, auth.ObjectServer(), auth.EntitlementCanEdit)
 		if err != nil {
-			return err
+			return response.SmartError(err)
 		}
+	}
 
-		err = cluster.UpdateProject(ctx, tx.Tx(), project.Name, req)
-		if err != nil {
-			return fmt.Errorf("Persist profile changes: %w", err)
-		}
+	// Update the database entry.
+	update := func(ctx context.Context) error {
+		return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return projectUpdate(ctx, tx, project.Name, req, configChanged)
+		})
+	}
 
-		if slices.Contains(configChanged, "features.profiles") {
-			if util.IsTrue(req.Config["features.profiles"]) {
-				err = projectCreateDefaultProfile(ctx, tx, project.Name)
+	// Hold changes to the restrictions until approved if the project requires it.
+	if projecthelpers.ApprovalRequired(project.Config, projecthelpers.ApprovalProjectsRestrictions) && slices.ContainsFunc(configChanged, projecthelpers.IsRestrictionConfigKey) {
+		run := func(op *operations.Operation) error {
+			// Refuse to apply the change over any change made to the project since it was requested.
+			err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				dbProject, err := cluster.GetProject(ctx, tx.Tx(), project.Name)
 				if err != nil {
 					return err
 				}
-			} else {
-				// Delete the project-specific default profile.
-				err = cluster.DeleteProfile(ctx, tx.Tx(), project.Name, api.ProjectDefaultName)
+
+				current, err := dbProject.ToAPI(ctx, tx.Tx())
 				if err != nil {
-					return fmt.Errorf("Delete project default profile: %w", err)
+					return err
+				}
+
+				if current.Description != project.Description || !maps.Equal(current.Config, project.Config) {
+					return fmt.Errorf("Project %q was changed since the update was requested", project.Name)
 				}
+
+				return nil
+			})
+			if err != nil {
+				return err
 			}
-		}
 
-		if slices.Contains(configChanged, "features.images") && util.IsFalse(req.Config["features.images"]) && util.IsTrue(req.Config["features.profiles"]) {
-			err = cluster.InitProjectWithoutImages(ctx, tx.Tx(), project.Name)
+			err = update(context.TODO())
 			if err != nil {
 				return err
 			}
+
+			s.Events.SendLifecycle(project.Name, lifecycle.ProjectUpdated.Event(project.Name, op.Requestor(), nil))
+
+			return nil
 		}
 
-		return nil
-	})
+		resources := map[string][]api.URL{}
+		resources["projects"] = []api.URL{*api.NewURL().Path(version.APIVersion, "projects", project.Name)}
+
+		op, err := operations.OperationCreate(s, project.Name, operations.OperationClassTask, operationtype.ProjectUpdate, resources, nil, run, nil, nil, r)
+		if err != nil {
+			return response.InternalError(err)
+		}
+
+		return approvalOperationResponse(s, r, op, projecthelpers.ApprovalProjectsRestrictions, fmt.Sprintf("Changing restrictions of project %q", project.Name))
+	}
+
+	err = update(ctx)
 	if err != nil {
 		return response.SmartError(err)
 	}
 
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(project.Name, lifecycle.ProjectUpdated.Event(project.Name, requestor, nil))
+
 	return response.EmptySyncResponse
 }
 
+// projectUpdate applies the requested changes to the project in the database.
+func projectUpdate(ctx context.Context, tx *db.ClusterTx, projectName string, req api.ProjectPut, configChanged []string) error {
+	err := projecthelpers.AllowProjectUpdate(tx, projectName, req.Config, configChanged)
+	if err != nil {
+		return err
+	}
+
+	err = cluster.UpdateProject(ctx, tx.Tx(), projectName, req)
+	if err != nil {
+		return fmt.Errorf("Persist profile changes: %w", err)
+	}
+
+	if slices.Contains(configChanged, "features.profiles") {
+		if util.IsTrue(req.Config["features.profiles"]) {
+			err = projectCreateDefaultProfile(ctx, tx, projectName)
+			if err != nil {
+				return err
+			}
+		} else {
+			// Delete the project-specific default profile.
+			err = cluster.DeleteProfile(ctx, tx.Tx(), projectName, api.ProjectDefaultName)
+			if err != nil {
+				return fmt.Errorf("Delete project default profile: %w", err)
+			}
+		}
+	}
+
+	if slices.Contains(configChanged, "features.images") && util.IsFalse(req.Config["features.images"]) && util.IsTrue(req.Config["features.profiles"]) {
+		err = cluster.InitProjectWithoutImages(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // swagger:operation POST /1.0/projects/{name} projects project_post
 //
 //	Rename the project
@@ -1033,6 +1174,12 @@ func projectDelete(d *Daemon, r *http.Request) response.Response {
 
 	// Handle requests to empty the project.
 	if force {
+		// Emptying the project would delete the instances and custom volumes it protects.
+		err = projecthelpers.AllowDeletion(&api.Project{Name: name, ProjectPut: api.ProjectPut{Config: projectConfig}})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
 		// Parse used by list.
 		defaultProfile := api.NewURL().Path(version.APIVersion, "profiles", api.ProjectDefaultName).Project(name).String()
 		entries := map[string][]string{}
@@ -1280,7 +1427,16 @@ func projectDelete(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	var groupDeleted bool
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		// Remove the authorization group giving access to the project if it was created from a self-service template.
+		groupDeleted, err = projectSelfServiceRevoke(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
 		return cluster.DeleteProject(ctx, tx.Tx(), name)
 	})
 	if err != nil {
@@ -1295,6 +1451,10 @@ func projectDelete(d *Daemon, r *http.Request) response.Response {
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(name, lifecycle.ProjectDeleted.Event(name, requestor, nil))
 
+	if groupDeleted {
+		s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.AuthGroupDeleted.Event(projectSelfServiceGroup(name), requestor, nil))
+	}
+
 	return response.EmptySyncResponse
 }
 
@@ -1398,6 +1558,17 @@ func isEitherAllowOrBlockOrManaged(value string) error {
 func projectValidateConfig(s *state.State, config map[string]string) error {
 	// Validate the project configuration.
 	projectConfigKeys := map[string]func(value string) error{
+		// gendoc:generate(entity=project, group=approvals, key=approvals.required)
+		// Comma-separated list of the privileged operations which are held until approved by another user
+		// allowed to edit the project.
+		// Possible values are `instances.privileged` (creating, updating or restoring an instance, or updating one of its profiles, so that it becomes privileged),
+		// `instances.delete-protected` (deleting an instance with {config:option}`instance-security:security.protection.delete` set)
+		// and `projects.restrictions` (changing the `restricted*` and `approvals.*` options of the project).
+		// ---
+		//  type: string
+		//  shortdesc: Privileged operations that require approval
+		"approvals.required": validate.Optional(validate.IsListOf(validate.IsOneOf(projecthelpers.ApprovalTypes...))),
+
 		// gendoc:generate(entity=project, group=specific, key=backups.compression_algorithm)
 		// Specify which compression algorithm to use for backups in this project.
 		// Possible values are `bzip2`, `gzip`, `lz4`, `lzma`, `xz`, `zstd` or `none`.
@@ -1552,6 +1723,29 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Maximum number of networks that the project can have
 		"limits.networks": validate.Optional(validate.IsUint32),
 
+		// gendoc:generate(entity=project, group=limits, key=limits.network.ingress)
+		// This value is the maximum value for the sum of the individual `limits.ingress` (or `limits.max`) configurations set on the NIC devices of the instances of the project.
+		// It is checked when the configuration of instances and profiles changes and doesn't cap the actual traffic of the project as a whole.
+		// ---
+		//  type: string
+		//  shortdesc: Maximum sum of the incoming bandwidth limits of all NICs in the project
+		"limits.network.ingress": validate.Optional(validate.IsBitSize),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.network.egress)
+		// This value is the maximum value for the sum of the individual `limits.egress` (or `limits.max`) configurations set on the NIC devices of the instances of the project.
+		// It is checked when the configuration of instances and profiles changes and doesn't cap the actual traffic of the project as a whole.
+		// ---
+		//  type: string
+		//  shortdesc: Maximum sum of the outgoing bandwidth limits of all NICs in the project
+		"limits.network.egress": validate.Optional(validate.IsBitSize),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.network.addresses)
+		// This value is the maximum number of listen addresses used by the network forwards and load balancers of the project networks.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of network forward and load balancer addresses in the project
+		"limits.network.addresses": validate.Optional(validate.IsUint32),
+
 		// gendoc:generate(entity=project, group=specific, key=network.hwaddr_pattern)
 		// Specify a MAC address template, e.g. `10:66:6a:xx:xx:xx`, to use within the cluster.
 		// Every `x` in the template will be replaced by a random character in `0`–`f`.
@@ -1562,6 +1756,37 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// shortdesc: MAC address template
 		"network.hwaddr_pattern": validate.Optional(validate.IsMACPattern),
 
+		// gendoc:generate(entity=project, group=protection, key=protection.delete)
+		// When enabled, the instances and custom storage volumes of the project can't be deleted or rebuilt,
+		// regardless of {config:option}`instance-security:security.protection.delete`.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to prevent deleting instances and custom storage volumes
+		"protection.delete": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=project, group=protection, key=protection.restore.snapshot)
+		// When enabled, a snapshot of the current state of instances and custom storage volumes is taken
+		// before restoring them from a snapshot.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to take a snapshot before restoring from a snapshot
+		"protection.restore.snapshot": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=project, group=protection, key=protection.snapshots.min_age)
+		// Snapshots of instances and custom storage volumes can't be deleted, whether directly, through their
+		// expiry or by deleting their parent, until they reach this age.
+		// Specify an expression like `7d` (7 days) or `1w 2d` (9 days), see {config:option}`instance-snapshots:snapshots.expiry`.
+		// ---
+		//  type: string
+		//  shortdesc: Minimum age of snapshots before they can be deleted
+		"protection.snapshots.min_age": func(value string) error {
+			// Validate expression
+			_, err := internalInstance.GetExpiry(time.Time{}, value)
+			return err
+		},
+
 		// gendoc:generate(entity=project, group=restricted, key=restricted)
 		// This option must be enabled to allow the `restricted.*` keys to take effect.
 		// To temporarily remove the restrictions, you can disable this option instead of clearing the related keys.
@@ -1863,6 +2088,22 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  type: string
 		//  shortdesc: Which storage pool names are allowed for use in this project
 		"restricted.storage-pools.access": validate.Optional(validate.IsListOf(validate.IsAny)),
+
+		// gendoc:generate(entity=project, group=specific, key=security.session_recording)
+		// When enabled, the `incus exec` and console sessions of all instances in the project are recorded.
+		// See {config:option}`instance-security:security.session_recording`.
+		// ---
+		//  type: bool
+		//  shortdesc: Whether to record exec and console sessions of all instances in the project
+		"security.session_recording": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=project, group=specific, key=boot.schedule.suspended)
+		// When enabled, the scheduled starts and stops of the instances in the project are skipped.
+		// See {config:option}`instance-boot:boot.schedule.start` and {config:option}`instance-boot:boot.schedule.stop`.
+		// ---
+		//  type: bool
+		//  shortdesc: Whether to suspend the power schedules of all instances in the project
+		"boot.schedule.suspended": validate.Optional(validate.IsBool),
 	}
 
 	// Add the storage pool keys.
@@ -0,0 +1,1097 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/cluster"
+	clusterRequest "github.com/lxc/incus/v7/internal/server/cluster/request"
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	deviceConfig "github.com/lxc/incus/v7/internal/server/device/config"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/network"
+	"github.com/lxc/incus/v7/internal/server/network/acl"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	"github.com/lxc/incus/v7/internal/server/state"
+	localUtil "github.com/lxc/incus/v7/internal/server/util"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/revert"
+	"github.com/lxc/incus/v7/shared/util"
+	"github.com/lxc/incus/v7/shared/validate"
+)
+
+var projectTemplatesCmd = APIEndpoint{
+	Path: "project-templates",
+
+	Get:  APIEndpointAction{Handler: projectTemplatesGet, AccessHandler: allowAuthenticated},
+	Post: APIEndpointAction{Handler: projectTemplatesPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var projectTemplateCmd = APIEndpoint{
+	Path: "project-templates/{name}",
+
+	Delete: APIEndpointAction{Handler: projectTemplateDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Get:    APIEndpointAction{Handler: projectTemplateGet, AccessHandler: allowAuthenticated},
+	Patch:  APIEndpointAction{Handler: projectTemplatePatch, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Post:   APIEndpointAction{Handler: projectTemplatePost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Put:    APIEndpointAction{Handler: projectTemplatePut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// swagger:operation GET /1.0/project-templates project-templates project_templates_get
+//
+//	Get the project templates
+//
+//	Returns a list of project templates (URLs).
+//	Users not allowed to create projects only get the self-service templates.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/project-templates/tenant",
+//	              "/1.0/project-templates/customer"
+//	            ]
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/project-templates?recursion=1 project-templates project_templates_get_recursion1
+//
+//	Get the project templates
+//
+//	Returns a list of project templates (structs).
+//	Users not allowed to create projects only get the self-service templates.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of project templates
+//	          items:
+//	            $ref: "#/definitions/ProjectTemplate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectTemplatesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	recursion := localUtil.IsRecursionRequest(r)
+
+	canCreateProjects, err := projectTemplateCanCreateProjects(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	templates := []api.ProjectTemplate{}
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbTemplates, err := dbCluster.GetProjectTemplates(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, dbTemplate := range dbTemplates {
+			if !canCreateProjects && !dbTemplate.SelfService {
+				continue
+			}
+
+			template, err := dbTemplate.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			templates = append(templates, *template)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !recursion {
+		urls := make([]string, 0, len(templates))
+		for _, template := range templates {
+			urls = append(urls, api.NewURL().Path(version.APIVersion, "project-templates", template.Name).String())
+		}
+
+		return response.SyncResponse(true, urls)
+	}
+
+	return response.SyncResponse(true, templates)
+}
+
+// swagger:operation POST /1.0/project-templates project-templates project_templates_post
+//
+//	Add a project template
+//
+//	Creates a new project template.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: template
+//	    description: Project template
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ProjectTemplatesPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectTemplatesPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := api.ProjectTemplatesPost{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = validate.IsAPIName(req.Name, false)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid project template name: %w", err))
+	}
+
+	err = projectTemplateValidate(s, req.ProjectTemplatePut)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		exists, err := dbCluster.ProjectTemplateExists(ctx, tx.Tx(), req.Name)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return api.StatusErrorf(http.StatusConflict, "A project template named %q already exists", req.Name)
+		}
+
+		id, err := dbCluster.CreateProjectTemplate(ctx, tx.Tx(), projectTemplateToDB(req.Name, req.ProjectTemplatePut))
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.CreateProjectTemplateConfig(ctx, tx.Tx(), id, req.Config)
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating project template %q: %w", req.Name, err))
+	}
+
+	requestor := request.CreateRequestor(r)
+	lc := lifecycle.ProjectTemplateCreated.Event(req.Name, requestor, nil)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation GET /1.0/project-templates/{name} project-templates project_template_get
+//
+//	Get the project template
+//
+//	Gets a specific project template.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Project template name
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: Project template
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectTemplate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectTemplateGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	canCreateProjects, err := projectTemplateCanCreateProjects(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	template, err := projectTemplateLoad(r.Context(), s, name, canCreateProjects)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, template, template.Writable())
+}
+
+// swagger:operation PUT /1.0/project-templates/{name} project-templates project_template_put
+//
+//	Update the project template
+//
+//	Updates the entire project template.
+//	Existing projects created from the template are not affected.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Project template name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: template
+//	    description: Project template
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ProjectTemplatePut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectTemplatePut(d *Daemon, r *http.Request) response.Response {
+	return projectTemplateUpdate(d, r, false)
+}
+
+// swagger:operation PATCH /1.0/project-templates/{name} project-templates project_template_patch
+//
+//	Partially update the project template
+//
+//	Updates a subset of the project template.
+//	Existing projects created from the template are not affected.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Project template name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: template
+//	    description: Project template
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ProjectTemplatePut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectTemplatePatch(d *Daemon, r *http.Request) response.Response {
+	return projectTemplateUpdate(d, r, true)
+}
+
+// projectTemplateUpdate replaces or partially updates a project template.
+func projectTemplateUpdate(d *Daemon, r *http.Request, patch bool) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	template, err := projectTemplateLoad(r.Context(), s, name, true)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = localUtil.EtagCheck(r, template.Writable())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	req := api.ProjectTemplatePut{}
+	if patch {
+		// Start from the current template so that only the provided fields are changed.
+		req = template.Writable()
+		req.Config = nil
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if patch {
+		config := maps.Clone(template.Config)
+		maps.Copy(config, req.Config)
+		req.Config = config
+	}
+
+	err = projectTemplateValidate(s, req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, err := dbCluster.GetProjectTemplateID(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		err = dbCluster.UpdateProjectTemplate(ctx, tx.Tx(), name, projectTemplateToDB(name, req))
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.UpdateProjectTemplateConfig(ctx, tx.Tx(), id, req.Config)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.ProjectTemplateUpdated.Event(name, requestor, nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/project-templates/{name} project-templates project_template_post
+//
+//	Rename the project template
+//
+//	Renames an existing project template.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Project template name
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: template
+//	    description: Project template rename request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ProjectTemplatePost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectTemplatePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.ProjectTemplatePost{}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = validate.IsAPIName(req.Name, false)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid project template name: %w", err))
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		exists, err := dbCluster.ProjectTemplateExists(ctx, tx.Tx(), req.Name)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return api.StatusErrorf(http.StatusConflict, "A project template named %q already exists", req.Name)
+		}
+
+		return dbCluster.RenameProjectTemplate(ctx, tx.Tx(), name, req.Name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	lc := lifecycle.ProjectTemplateRenamed.Event(req.Name, requestor, map[string]any{"old_name": name})
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation DELETE /1.0/project-templates/{name} project-templates project_template_delete
+//
+//	Delete the project template
+//
+//	Removes the project template.
+//	Existing projects created from the template are not affected.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Project template name
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectTemplateDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteProjectTemplate(ctx, tx.Tx(), name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.ProjectTemplateDeleted.Event(name, requestor, nil))
+
+	return response.EmptySyncResponse
+}
+
+// projectTemplateCanCreateProjects returns whether the requestor is allowed to create projects without templates,
+// in which case it can also see all project templates.
+func projectTemplateCanCreateProjects(s *state.State, r *http.Request) (bool, error) {
+	err := s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectServer(), auth.EntitlementCanCreateProjects)
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusForbidden) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// projectTemplateLoad loads a project template, hiding those which aren't self-service unless requested.
+func projectTemplateLoad(ctx context.Context, s *state.State, name string, all bool) (*api.ProjectTemplate, error) {
+	var template *api.ProjectTemplate
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbTemplate, err := dbCluster.GetProjectTemplate(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		if !all && !dbTemplate.SelfService {
+			return api.StatusErrorf(http.StatusNotFound, "Project template not found")
+		}
+
+		template, err = dbTemplate.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// projectTemplateToDB converts a project template to its database record.
+func projectTemplateToDB(name string, template api.ProjectTemplatePut) dbCluster.ProjectTemplate {
+	return dbCluster.ProjectTemplate{
+		Name:           name,
+		Description:    template.Description,
+		Profiles:       template.Profiles,
+		Networks:       template.Networks,
+		NetworkACLList: template.NetworkACLs,
+		SelfService:    template.SelfService,
+	}
+}
+
+// projectTemplateValidate validates a project template.
+// The devices of the profiles are only validated when instantiating the template as they may refer to its networks.
+func projectTemplateValidate(s *state.State, template api.ProjectTemplatePut) error {
+	err := projectValidateConfig(s, template.Config)
+	if err != nil {
+		return err
+	}
+
+	err = projectTemplateCheckFeatures(template.Config, template)
+	if err != nil {
+		return err
+	}
+
+	profileNames := []string{}
+	for _, profile := range template.Profiles {
+		err = validate.IsAPIName(profile.Name, false)
+		if err != nil {
+			return fmt.Errorf("Invalid profile name: %w", err)
+		}
+
+		if slices.Contains(profileNames, profile.Name) {
+			return fmt.Errorf("Duplicate profile %q", profile.Name)
+		}
+
+		profileNames = append(profileNames, profile.Name)
+
+		err = instance.ValidConfig(s.OS, profile.Config, false, instancetype.Any)
+		if err != nil {
+			return fmt.Errorf("Invalid config of profile %q: %w", profile.Name, err)
+		}
+	}
+
+	networkNames := []string{}
+	for _, net := range template.Networks {
+		if net.Name == "none" {
+			return errors.New("Invalid network name: 'none' is a reserved name")
+		}
+
+		err = validate.IsAPIName(net.Name, false)
+		if err != nil {
+			return fmt.Errorf("Invalid network name: %w", err)
+		}
+
+		if slices.Contains(networkNames, net.Name) {
+			return fmt.Errorf("Duplicate network %q", net.Name)
+		}
+
+		networkNames = append(networkNames, net.Name)
+
+		netType, err := projectTemplateNetworkType(net)
+		if err != nil {
+			return err
+		}
+
+		err = netType.ValidateName(net.Name)
+		if err != nil {
+			return fmt.Errorf("Invalid network name: %w", err)
+		}
+
+		if !netType.Info().Projects {
+			return fmt.Errorf("Network type %q does not support non-default projects", netType.Type())
+		}
+	}
+
+	aclNames := []string{}
+	for _, aclInfo := range template.NetworkACLs {
+		err = acl.ValidName(aclInfo.Name)
+		if err != nil {
+			return fmt.Errorf("Invalid network ACL name: %w", err)
+		}
+
+		if slices.Contains(aclNames, aclInfo.Name) {
+			return fmt.Errorf("Duplicate network ACL %q", aclInfo.Name)
+		}
+
+		aclNames = append(aclNames, aclInfo.Name)
+	}
+
+	return nil
+}
+
+// projectTemplateCheckFeatures checks that the project features needed by the resources of the template are enabled
+// in the given project configuration.
+func projectTemplateCheckFeatures(config map[string]string, template api.ProjectTemplatePut) error {
+	featureEnabled := func(feature string) bool {
+		value, ok := config[feature]
+		if !ok {
+			return dbCluster.ProjectFeatures[feature].DefaultEnabled
+		}
+
+		return util.IsTrue(value)
+	}
+
+	if len(template.Profiles) > 0 && !featureEnabled("features.profiles") {
+		return errors.New("Project templates with profiles require features.profiles")
+	}
+
+	if (len(template.Networks) > 0 || len(template.NetworkACLs) > 0) && !featureEnabled("features.networks") {
+		return errors.New("Project templates with networks or network ACLs require features.networks")
+	}
+
+	return nil
+}
+
+// projectTemplateNetworkType returns the type of a network of a project template.
+func projectTemplateNetworkType(net api.NetworksPost) (network.Type, error) {
+	netTypeName := net.Type
+	if netTypeName == "" {
+		// Only OVN networks are allowed inside network enabled projects.
+		netTypeName = "ovn"
+	}
+
+	return network.LoadByType(netTypeName)
+}
+
+// projectTemplateApply creates the resources of the template in a newly created project.
+// The reverter undoes what was created if a later step fails.
+func projectTemplateApply(ctx context.Context, s *state.State, r *http.Request, projectName string, template *api.ProjectTemplate, reverter *revert.Reverter) error {
+	var project *api.Project
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		project, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = projectTemplateCheckFeatures(project.Config, template.ProjectTemplatePut)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "%w", err)
+	}
+
+	// Create the network ACLs first as the networks may use them.
+	for _, aclInfo := range template.NetworkACLs {
+		err = acl.Create(s, projectName, &aclInfo)
+		if err != nil {
+			return fmt.Errorf("Failed creating network ACL %q: %w", aclInfo.Name, err)
+		}
+
+		reverter.Add(func() {
+			netACL, err := acl.LoadByName(s, projectName, aclInfo.Name)
+			if err == nil {
+				_ = netACL.Delete()
+			}
+
+			_ = s.Authorizer.DeleteNetworkACL(context.Background(), projectName, aclInfo.Name)
+		})
+
+		err = s.Authorizer.AddNetworkACL(ctx, projectName, aclInfo.Name)
+		if err != nil {
+			logger.Error("Failed to add network ACL to authorizer", logger.Ctx{"name": aclInfo.Name, "project": projectName, "error": err})
+		}
+	}
+
+	// Then create the networks as the profiles may use them.
+	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
+
+	for _, req := range template.Networks {
+		netType, err := projectTemplateNetworkType(req)
+		if err != nil {
+			return err
+		}
+
+		req.Type = netType.Type()
+		req.Config = maps.Clone(req.Config)
+		if req.Config == nil {
+			req.Config = map[string]string{}
+		}
+
+		n, err := networksCreate(ctx, s, projectName, req, netType, clientType)
+		if err != nil {
+			return fmt.Errorf("Failed creating network %q: %w", req.Name, err)
+		}
+
+		reverter.Add(func() {
+			err := n.Delete(clientType)
+			if err != nil {
+				logger.Warn("Failed deleting network", logger.Ctx{"name": n.Name(), "project": projectName, "err": err})
+			}
+
+			err = networkDeleteCluster(context.Background(), s, n)
+			if err != nil {
+				logger.Warn("Failed removing network", logger.Ctx{"name": n.Name(), "project": projectName, "err": err})
+			}
+		})
+	}
+
+	// Finally create the profiles, updating the default one.
+	for _, profile := range template.Profiles {
+		// At this point we don't know the instance type, so just use instancetype.Any type for validation.
+		err = instance.ValidDevices(s, *project, instancetype.Any, deviceConfig.NewDevices(profile.Devices), nil)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid devices of profile %q: %w", profile.Name, err)
+		}
+
+		created := false
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			devices, err := dbCluster.APIToDevices(profile.Devices)
+			if err != nil {
+				return err
+			}
+
+			id, err := dbCluster.GetProfileID(ctx, tx.Tx(), projectName, profile.Name)
+			if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return err
+			}
+
+			if err != nil {
+				created = true
+
+				id, err = dbCluster.CreateProfile(ctx, tx.Tx(), dbCluster.Profile{Project: projectName, Name: profile.Name, Description: profile.Description})
+				if err != nil {
+					return err
+				}
+
+				err = dbCluster.CreateProfileConfig(ctx, tx.Tx(), id, profile.Config)
+				if err != nil {
+					return err
+				}
+
+				return dbCluster.CreateProfileDevices(ctx, tx.Tx(), id, devices)
+			}
+
+			err = dbCluster.UpdateProfile(ctx, tx.Tx(), projectName, profile.Name, dbCluster.Profile{Project: projectName, Name: profile.Name, Description: profile.Description})
+			if err != nil {
+				return err
+			}
+
+			err = dbCluster.UpdateProfileConfig(ctx, tx.Tx(), id, profile.Config)
+			if err != nil {
+				return err
+			}
+
+			return dbCluster.UpdateProfileDevices(ctx, tx.Tx(), id, devices)
+		})
+		if err != nil {
+			return fmt.Errorf("Failed creating profile %q: %w", profile.Name, err)
+		}
+
+		if created {
+			err = s.Authorizer.AddProfile(ctx, projectName, profile.Name)
+			if err != nil {
+				logger.Error("Failed to add profile to authorizer", logger.Ctx{"name": profile.Name, "project": projectName, "error": err})
+			}
+		}
+	}
+
+	return nil
+}
+
+// projectSelfServiceGroup returns the name of the authorization group giving access to a self-service project.
+func projectSelfServiceGroup(projectName string) string {
+	return "self-service-" + projectName
+}
+
+// projectSelfServiceRevoke removes the authorization group created for a self-service project, if any.
+// A group of the same name granting anything else than access to the project is left alone.
+// It returns whether the group was removed.
+func projectSelfServiceRevoke(ctx context.Context, tx *db.ClusterTx, projectName string) (bool, error) {
+	group, err := dbCluster.GetAuthGroup(ctx, tx.Tx(), projectSelfServiceGroup(projectName))
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	permissions, err := dbCluster.GetAuthGroupPermissions(ctx, tx.Tx(), group.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, permission := range permissions {
+		if permission.Object != auth.ObjectProject(projectName).String() {
+			return false, nil
+		}
+	}
+
+	err = dbCluster.DeleteAuthGroup(ctx, tx.Tx(), group.Name)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// projectSelfServiceGrantMethod returns the authorization driver through which the requestor gets granted access to
+// the projects it creates from self-service templates. It returns an error if access can't be granted, so that the
+// project doesn't get created.
+func projectSelfServiceGrantMethod(ctx context.Context, s *state.State, r *http.Request, projectName string) (string, error) {
+	requestor := request.CreateRequestor(r)
+
+	// The scope of API tokens is set by the administrators and isn't extended.
+	if requestor.Protocol == api.AuthenticationMethodToken {
+		return "", api.StatusErrorf(http.StatusForbidden, "API tokens can't be granted access to self-service projects")
+	}
+
+	switch s.Authorizer.Driver() {
+	case auth.DriverTLS:
+		if requestor.Protocol == api.AuthenticationMethodTLS {
+			return auth.DriverTLS, nil
+		}
+
+	case auth.DriverRBAC:
+		var method string
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			// Certificates without an identity are authorized by the TLS driver.
+			exists, err := dbCluster.IdentityExists(ctx, tx.Tx(), requestor.Protocol, requestor.Username)
+			if err != nil {
+				return err
+			}
+
+			if !exists && requestor.Protocol == api.AuthenticationMethodTLS {
+				method = auth.DriverTLS
+				return nil
+			}
+
+			group := projectSelfServiceGroup(projectName)
+			exists, err = dbCluster.AuthGroupExists(ctx, tx.Tx(), group)
+			if err != nil {
+				return err
+			}
+
+			if exists {
+				return api.StatusErrorf(http.StatusConflict, "Authorization group %q already exists", group)
+			}
+
+			method = auth.DriverRBAC
+
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return method, nil
+	}
+
+	return "", api.StatusErrorf(http.StatusForbidden, "Access to self-service projects can't be granted with the %q authorization driver", s.Authorizer.Driver())
+}
+
+// projectSelfServiceGrant gives the client which created a project from a self-service template access to it,
+// using the method returned by projectSelfServiceGrantMethod.
+func projectSelfServiceGrant(ctx context.Context, s *state.State, r *http.Request, projectName string, method string) error {
+	if method == auth.DriverRBAC {
+		return projectSelfServiceGrantRBAC(ctx, s, r, projectName)
+	}
+
+	requestor := request.CreateRequestor(r)
+
+	var cert *api.Certificate
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbCert, err := dbCluster.GetCertificate(ctx, tx.Tx(), requestor.Username)
+		if err != nil {
+			return err
+		}
+
+		if !dbCert.Restricted {
+			return nil
+		}
+
+		cert, err = dbCert.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		cert.Projects = append(cert.Projects, projectName)
+
+		return dbCluster.UpdateCertificateProjects(ctx, tx.Tx(), dbCert.ID, cert.Projects)
+	})
+	if err != nil {
+		// Clients trusted through a CA aren't restricted.
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if cert == nil {
+		return nil
+	}
+
+	// Notify other members so that they reload their certificate cache.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return err
+	}
+
+	err = notifier(func(client incus.InstanceServer) error {
+		return client.UpdateCertificate(url.PathEscape(cert.Fingerprint), cert.Writable(), "")
+	})
+	if err != nil {
+		return err
+	}
+
+	s.UpdateCertificateCache()
+
+	return nil
+}
+
+// projectSelfServiceGrantRBAC makes the requestor an operator of a self-service project through a dedicated
+// authorization group. The operator role doesn't allow changing the project configuration set by the template.
+func projectSelfServiceGrantRBAC(ctx context.Context, s *state.State, r *http.Request, projectName string) error {
+	requestor := request.CreateRequestor(r)
+
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		groupID, err := dbCluster.CreateAuthGroup(ctx, tx.Tx(), dbCluster.AuthGroup{
+			Name:        projectSelfServiceGroup(projectName),
+			Description: fmt.Sprintf("Access to self-service project %q", projectName),
+		})
+		if err != nil {
+			return err
+		}
+
+		permissions := []dbCluster.AuthGroupPermission{{Entitlement: "operator", Object: auth.ObjectProject(projectName).String()}}
+
+		err = dbCluster.UpdateAuthGroupPermissions(ctx, tx.Tx(), groupID, permissions)
+		if err != nil {
+			return err
+		}
+
+		var groups []string
+
+		identity, err := dbCluster.GetIdentity(ctx, tx.Tx(), requestor.Protocol, requestor.Username)
+		if err != nil {
+			if !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return err
+			}
+
+			identity = &dbCluster.Identity{AuthMethod: requestor.Protocol, Identifier: requestor.Username}
+
+			id, err := dbCluster.CreateIdentity(ctx, tx.Tx(), *identity)
+			if err != nil {
+				return err
+			}
+
+			identity.ID = int(id)
+		} else {
+			groups, err = dbCluster.GetIdentityAuthGroups(ctx, tx.Tx(), identity.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		groups = append(groups, projectSelfServiceGroup(projectName))
+
+		return dbCluster.UpdateIdentityAuthGroups(ctx, tx.Tx(), int64(identity.ID), groups)
+	})
+}
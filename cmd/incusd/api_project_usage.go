@@ -0,0 +1,431 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v7/internal/instance"
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v7/internal/server/project"
+	"github.com/lxc/incus/v7/internal/server/project/usage"
+	"github.com/lxc/incus/v7/internal/server/response"
+	"github.com/lxc/incus/v7/internal/server/state"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v7/internal/server/storage/drivers"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+var projectUsageCmd = APIEndpoint{
+	Path: "projects/{name}/usage",
+
+	Get: APIEndpointAction{Handler: projectUsageGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
+}
+
+// projectsUsageSampleSchedule is how often the resource usage of the instances is sampled.
+const projectsUsageSampleSchedule = 5 * time.Minute
+
+// projectsUsageRecordInterval is how often the sampled resource usage is recorded in the database.
+const projectsUsageRecordInterval = time.Hour
+
+// projectUsageDefaultPeriod is the period covered when no start is requested.
+const projectUsageDefaultPeriod = 30 * 24 * time.Hour
+
+// swagger:operation GET /1.0/projects/{name}/usage projects project_usage_get
+//
+//	Get the project resource usage
+//
+//	Gets the resources consumed by the instances of a project over a period of time.
+//	The usage is recorded hourly, so the period is rounded to the recorded hours.
+//
+//	---
+//	produces:
+//	  - application/json
+//	  - text/csv
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Project name
+//	    type: string
+//	    required: true
+//	  - in: query
+//	    name: from
+//	    description: Start of the period (RFC3339, defaults to 30 days before the end)
+//	    type: string
+//	    example: 2021-03-01T00:00:00Z
+//	  - in: query
+//	    name: to
+//	    description: End of the period (RFC3339, defaults to now)
+//	    type: string
+//	    example: 2021-04-01T00:00:00Z
+//	  - in: query
+//	    name: format
+//	    description: Response format (json or csv)
+//	    type: string
+//	    example: csv
+//	responses:
+//	  "200":
+//	    description: Project usage
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectUsage"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectUsageGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := pathVar(r, "name")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	to := time.Now().UTC()
+	if r.FormValue("to") != "" {
+		to, err = time.Parse(time.RFC3339, r.FormValue("to"))
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid end of period: %w", err))
+		}
+	}
+
+	from := to.Add(-projectUsageDefaultPeriod)
+	if r.FormValue("from") != "" {
+		from, err = time.Parse(time.RFC3339, r.FormValue("from"))
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid start of period: %w", err))
+		}
+	}
+
+	if !from.Before(to) {
+		return response.BadRequest(errors.New("The start of the period must be before its end"))
+	}
+
+	format := r.FormValue("format")
+	if format != "" && format != "json" && format != "csv" {
+		return response.BadRequest(fmt.Errorf("Invalid format %q", format))
+	}
+
+	projectUsage := api.ProjectUsage{
+		From:      from.UTC(),
+		To:        to.UTC(),
+		Instances: map[string]api.ProjectUsageResources{},
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check that the project exists.
+		_, err := dbCluster.GetProjectID(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		records, err := dbCluster.GetProjectUsage(ctx, tx.Tx(), name, projectUsage.From, projectUsage.To)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			resources := projectUsage.Instances[record.Instance]
+			projectUsageAdd(&resources, record)
+			projectUsage.Instances[record.Instance] = resources
+
+			projectUsageAdd(&projectUsage.Total, record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if format == "csv" {
+		return projectUsageCSV(name, projectUsage)
+	}
+
+	return response.SyncResponse(true, &projectUsage)
+}
+
+// projectUsageAdd adds a usage record to the resources consumed.
+func projectUsageAdd(resources *api.ProjectUsageResources, record dbCluster.ProjectUsage) {
+	resources.CPUSeconds += record.CPUSeconds
+	resources.MemoryGiBHours += record.MemoryGiBHours
+	resources.DiskGiBHours += record.DiskGiBHours
+	resources.NetworkReceivedBytes += record.NetworkReceivedBytes
+	resources.NetworkSentBytes += record.NetworkSentBytes
+}
+
+// projectUsageCSV renders the resources consumed by each instance of a project as CSV.
+func projectUsageCSV(name string, projectUsage api.ProjectUsage) response.Response {
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 6, 64)
+	}
+
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"-usage.csv"))
+
+		writer := csv.NewWriter(w)
+
+		err := writer.Write([]string{"project", "instance", "from", "to", "cpu_seconds", "memory_gib_hours", "disk_gib_hours", "network_received_bytes", "network_sent_bytes"})
+		if err != nil {
+			return err
+		}
+
+		for _, instName := range slices.Sorted(maps.Keys(projectUsage.Instances)) {
+			resources := projectUsage.Instances[instName]
+
+			err = writer.Write([]string{
+				name,
+				instName,
+				projectUsage.From.Format(time.RFC3339),
+				projectUsage.To.Format(time.RFC3339),
+				formatFloat(resources.CPUSeconds),
+				formatFloat(resources.MemoryGiBHours),
+				formatFloat(resources.DiskGiBHours),
+				strconv.FormatInt(resources.NetworkReceivedBytes, 10),
+				strconv.FormatInt(resources.NetworkSentBytes, 10),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+
+		return writer.Error()
+	})
+}
+
+// projectsUsageSample samples the resource usage of the instances of this server.
+// Stopped instances only account for the disk usage of their root disk and custom volumes.
+func projectsUsageSample(s *state.State) error {
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return err
+	}
+
+	hostInterfaces, _ := net.Interfaces()
+
+	sampled := map[int]bool{}
+
+	for _, inst := range instances {
+		sample := usage.Sample{
+			Time: time.Now(),
+			Disk: projectsUsageInstanceDisk(s, inst),
+		}
+
+		if inst.IsRunning() {
+			instState, err := inst.RenderState(hostInterfaces)
+			if err != nil {
+				logger.Debug("Failed sampling instance resource usage", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+				continue
+			}
+
+			sample.CPUTime = instState.CPU.Usage
+			sample.Memory = instState.Memory.Usage
+
+			for ifName, nic := range instState.Network {
+				if ifName == "lo" {
+					continue
+				}
+
+				sample.NetworkReceived += nic.Counters.BytesReceived
+				sample.NetworkSent += nic.Counters.BytesSent
+			}
+		}
+
+		sampled[inst.ID()] = true
+		usage.Record(inst.ID(), usage.Instance{Project: inst.Project().Name, Name: inst.Name()}, sample)
+	}
+
+	// Drop the state of the instances which were deleted or moved away.
+	usage.Prune(sampled)
+
+	return nil
+}
+
+// projectsUsageInstanceDisk returns the disk usage of the root disk and custom volumes of an instance.
+// It is taken from the storage pools, so that it doesn't depend on the instance running.
+func projectsUsageInstanceDisk(s *state.State, inst instance.Instance) int64 {
+	var used int64
+
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+	pool, err := storagePools.LoadByInstance(s, inst)
+	if err == nil {
+		var volUsage *storagePools.VolumeUsage
+
+		volUsage, err = pool.GetInstanceUsage(inst)
+		if err == nil {
+			used += volUsage.Used
+		}
+	}
+
+	if err != nil && !errors.Is(err, storageDrivers.ErrNotSupported) {
+		l.Debug("Failed getting instance disk usage", logger.Ctx{"err": err})
+	}
+
+	instProject := inst.Project()
+	volProject := project.StorageVolumeProjectFromRecord(&instProject, db.StoragePoolVolumeTypeCustom)
+
+	for _, dev := range inst.ExpandedDevices().Sorted() {
+		if dev.Config["type"] != "disk" || dev.Config["path"] == "/" || dev.Config["pool"] == "" {
+			continue
+		}
+
+		pool, err := storagePools.LoadByName(s, dev.Config["pool"])
+		if err != nil {
+			l.Debug("Failed loading storage pool", logger.Ctx{"pool": dev.Config["pool"], "err": err})
+			continue
+		}
+
+		volName, _ := internalInstance.SplitVolumeSource(dev.Config["source"])
+
+		volUsage, err := pool.GetCustomVolumeUsage(volProject, volName)
+		if err != nil {
+			if !errors.Is(err, storageDrivers.ErrNotSupported) {
+				l.Debug("Failed getting volume disk usage", logger.Ctx{"volume": dev.Config["source"], "err": err})
+			}
+
+			continue
+		}
+
+		used += volUsage.Used
+	}
+
+	return used
+}
+
+// projectsUsageRecord records the resource usage sampled since the previous call in the database.
+func projectsUsageRecord(ctx context.Context, s *state.State) error {
+	recordedAt := time.Now().UTC()
+
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		for inst, instUsage := range usage.Flush() {
+			if instUsage.IsZero() {
+				continue
+			}
+
+			err := dbCluster.CreateProjectUsage(ctx, tx.Tx(), inst.Project, dbCluster.ProjectUsage{
+				Instance:             inst.Name,
+				RecordedAt:           recordedAt,
+				CPUSeconds:           instUsage.CPUSeconds,
+				MemoryGiBHours:       instUsage.MemoryGiBHours,
+				DiskGiBHours:         instUsage.DiskGiBHours,
+				NetworkReceivedBytes: instUsage.NetworkReceivedBytes,
+				NetworkSentBytes:     instUsage.NetworkSentBytes,
+			})
+			if err != nil {
+				// The project may have been deleted in the meantime.
+				if api.StatusErrorCheck(err, http.StatusNotFound) {
+					continue
+				}
+
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func projectsUsageTask(d *Daemon) (task.Func, task.Schedule) {
+	var recorded time.Time
+
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		err := projectsUsageSample(s)
+		if err != nil {
+			logger.Warn("Failed sampling project resource usage", logger.Ctx{"err": err})
+			return
+		}
+
+		if recorded.IsZero() {
+			recorded = time.Now()
+			return
+		}
+
+		if time.Since(recorded) < projectsUsageRecordInterval {
+			return
+		}
+
+		err = projectsUsageRecord(ctx, s)
+		if err != nil {
+			logger.Warn("Failed recording project resource usage", logger.Ctx{"err": err})
+			return
+		}
+
+		recorded = time.Now()
+	}
+
+	return f, task.Every(projectsUsageSampleSchedule)
+}
+
+// pruneProjectsUsage removes the resource usage records which are older than the retention period.
+func pruneProjectsUsage(ctx context.Context, s *state.State) error {
+	// If we are clustered, let the leader handle the pruning.
+	if s.ServerClustered {
+		leader, err := s.Cluster.LeaderAddress()
+		if err != nil {
+			return err
+		}
+
+		if s.LocalConfig.ClusterAddress() != leader {
+			return nil
+		}
+	}
+
+	now := time.Now().UTC()
+	expiry, err := internalInstance.GetExpiry(now, s.GlobalConfig.UsageRetention())
+	if err != nil {
+		return err
+	}
+
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.DeleteProjectUsage(ctx, tx.Tx(), now.Add(-expiry.Sub(now)))
+	})
+}
+
+func pruneProjectsUsageTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := pruneProjectsUsage(ctx, d.State())
+		if err != nil {
+			logger.Error("Failed pruning project resource usage", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Daily()
+}
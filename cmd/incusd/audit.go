@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/audit"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// auditResponseWriter records the status code of a response for the audit log.
+type auditResponseWriter struct {
+	http.ResponseWriter
+
+	statusCode int
+}
+
+// WriteHeader records the status code and passes it to the wrapped writer.
+func (w *auditResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write passes the data to the wrapped writer, recording an implicit success status.
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush flushes the wrapped writer if supported.
+func (w *auditResponseWriter) Flush() {
+	flusher, ok := w.ResponseWriter.(http.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hijacks the connection of the wrapped writer, recording a protocol switch.
+func (w *auditResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("Response writer doesn't support hijacking")
+	}
+
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusSwitchingProtocols
+	}
+
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped writer.
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// auditRequest records a handled API request in the audit log and emits it as an audit event.
+func (d *Daemon) auditRequest(r *http.Request, endpoint string, protocol string, username string, startTime time.Time, statusCode int) {
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	address, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		address = r.RemoteAddr
+	}
+
+	// Record the original requestor of requests forwarded by other cluster members.
+	if protocol == "cluster" && r.Header.Get(request.HeaderForwardedUsername) != "" {
+		username = r.Header.Get(request.HeaderForwardedUsername)
+		protocol = r.Header.Get(request.HeaderForwardedProtocol)
+
+		forwardedAddress := r.Header.Get(request.HeaderForwardedAddress)
+		if forwardedAddress != "" {
+			address = forwardedAddress
+
+			host, _, err := net.SplitHostPort(forwardedAddress)
+			if err == nil {
+				address = host
+			}
+		}
+	}
+
+	record := api.EventAudit{
+		Timestamp:  startTime.UTC(),
+		Username:   username,
+		Protocol:   protocol,
+		Address:    address,
+		Method:     r.Method,
+		Endpoint:   endpoint,
+		URL:        audit.RedactURL(r.URL),
+		Project:    r.URL.Query().Get("project"),
+		StatusCode: statusCode,
+		Outcome:    audit.Outcome(statusCode),
+		Duration:   time.Since(startTime).Milliseconds(),
+	}
+
+	err = d.auditLogger.Write(&record)
+	if err != nil {
+		logger.Warn("Failed writing audit record", logger.Ctx{"err": err, "url": record.URL})
+		return
+	}
+
+	d.events.Send("", api.EventTypeAudit, record)
+}
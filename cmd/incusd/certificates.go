@@ -23,12 +23,15 @@ import (
 	"github.com/lxc/incus/v7/internal/server/db"
 	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
 	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/db/warningtype"
 	"github.com/lxc/incus/v7/internal/server/lifecycle"
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/internal/server/request"
 	"github.com/lxc/incus/v7/internal/server/response"
 	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/internal/server/task"
 	localUtil "github.com/lxc/incus/v7/internal/server/util"
+	"github.com/lxc/incus/v7/internal/server/warnings"
 	internalUtil "github.com/lxc/incus/v7/internal/util"
 	"github.com/lxc/incus/v7/internal/version"
 	"github.com/lxc/incus/v7/shared/api"
@@ -530,7 +533,7 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 
 	// Access check.
 	// Check if the user is already trusted.
-	trusted, _, _, err := d.Authenticate(nil, r)
+	trusted, _, _, _, err := d.Authenticate(nil, r)
 	if err != nil {
 		return response.SmartError(err)
 	}
@@ -1328,3 +1331,84 @@ func certificateValidate(cert *x509.Certificate) error {
 
 	return nil
 }
+
+// trustedCertificateExpiryWarning is how long before their expiry trusted certificates raise a warning.
+const trustedCertificateExpiryWarning = 30 * 24 * time.Hour
+
+// certificatesExpiryCheck warns about trusted certificates which are about to expire and removes the
+// records of expired issued client certificates.
+func certificatesExpiryCheck(ctx context.Context, s *state.State) error {
+	// If we are clustered, let the leader handle the check.
+	if s.ServerClustered {
+		leader, err := s.Cluster.LeaderAddress()
+		if err != nil {
+			return err
+		}
+
+		if s.LocalConfig.ClusterAddress() != leader {
+			return nil
+		}
+	}
+
+	var certs []dbCluster.Certificate
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		certs, err = dbCluster.GetCertificates(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, dbCert := range certs {
+		// Server certificates are renewed separately.
+		if dbCert.Type == certificate.TypeServer {
+			continue
+		}
+
+		certBlock, _ := pem.Decode([]byte(dbCert.Certificate))
+		if certBlock == nil {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(certBlock.Bytes)
+		if err != nil {
+			continue
+		}
+
+		if time.Until(cert.NotAfter) > trustedCertificateExpiryWarning {
+			err = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningtype.TrustedCertificateExpiring, dbCluster.TypeCertificate, dbCert.ID)
+			if err != nil {
+				logger.Warn("Failed to resolve warning", logger.Ctx{"fingerprint": dbCert.Fingerprint, "err": err})
+			}
+
+			continue
+		}
+
+		msg := fmt.Sprintf("Certificate %q (%s) expires on %s", dbCert.Name, dbCert.Fingerprint[:12], cert.NotAfter.UTC().Format(time.RFC3339))
+		if time.Now().After(cert.NotAfter) {
+			msg = fmt.Sprintf("Certificate %q (%s) expired on %s", dbCert.Name, dbCert.Fingerprint[:12], cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpsertWarningLocalNode(ctx, "", dbCluster.TypeCertificate, dbCert.ID, warningtype.TrustedCertificateExpiring, msg)
+		})
+		if err != nil {
+			logger.Warn("Failed to create warning", logger.Ctx{"fingerprint": dbCert.Fingerprint, "err": err})
+		}
+	}
+
+	return pruneExpiredIssuedCertificates(ctx, s.DB.Cluster)
+}
+
+func certificatesExpiryCheckTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := certificatesExpiryCheck(ctx, d.State())
+		if err != nil {
+			logger.Error("Failed checking certificates expiry", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Daily()
+}
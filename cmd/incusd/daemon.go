@@ -29,6 +29,7 @@ import (
 	"github.com/lxc/incus/v7/internal/linux"
 	"github.com/lxc/incus/v7/internal/rsync"
 	"github.com/lxc/incus/v7/internal/server/apparmor"
+	"github.com/lxc/incus/v7/internal/server/audit"
 	"github.com/lxc/incus/v7/internal/server/auth"
 	"github.com/lxc/incus/v7/internal/server/auth/oidc"
 	"github.com/lxc/incus/v7/internal/server/bgp"
@@ -119,6 +120,16 @@ type Daemon struct {
 	proxy func(req *http.Request) (*url.URL, error)
 
 	oidcVerifier *oidc.Verifier
+	oidcGroups   auth.IdentityProviderGroups
+
+	// Authority issuing short-lived client certificates, loaded on first use.
+	issuedCertificatesAuthority        *certificate.Authority
+	issuedCertificatesAuthorityChecked time.Time
+	issuedCertificatesAuthorityMu      sync.Mutex
+
+	// Revoked issued client certificates, refreshed in the background for use during the TLS handshake.
+	issuedCertificatesRevocation   *issuedCertificatesRevocation
+	issuedCertificatesRevocationMu sync.RWMutex
 
 	// Stores last heartbeat node information to detect node changes.
 	lastNodeList *cluster.APIHeartbeat
@@ -154,6 +165,9 @@ type Daemon struct {
 
 	loggingController *logging.Controller
 
+	// Audit log.
+	auditLogger *audit.Logger
+
 	// Authorization.
 	authorizer auth.Authorizer
 
@@ -204,6 +218,7 @@ func newDaemon(config *DaemonConfig, osInfo *sys.OS) *Daemon {
 		shutdownCancel: shutdownCancel,
 		shutdownDoneCh: make(chan error),
 		apiExtensions:  len(version.APIExtensions),
+		auditLogger:    audit.NewLogger(internalUtil.LogPath("audit.log"), internalUtil.VarPath("audit.key")),
 	}
 
 	d.serverCert = func() *localtls.CertInfo { return d.serverCertInt }
@@ -432,7 +447,7 @@ func allowPermission(objectType auth.ObjectType, entitlement auth.Entitlement, m
 
 // Convenience function around Authenticate.
 func (d *Daemon) checkTrustedClient(r *http.Request) error {
-	trusted, _, _, err := d.Authenticate(nil, r)
+	trusted, _, _, _, err := d.Authenticate(nil, r)
 	if !trusted || err != nil {
 		if err != nil {
 			return err
@@ -489,12 +504,12 @@ func (d *Daemon) getTrustedCertificates() (map[certificate.Type]map[string]x509.
 // will validate the TLS certificate.
 //
 // This does not perform authorization, only validates authentication.
-// Returns whether trusted or not, the username (or certificate fingerprint) of the trusted client, and the type of
-// client that has been authenticated (cluster, unix, or tls).
-func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, string, string, error) {
+// Returns whether trusted or not, the username (or certificate fingerprint) of the trusted client, the type of
+// client that has been authenticated (cluster, unix, or tls) and what its identity provider groups map to.
+func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, string, string, []string, error) {
 	trustedCerts, err := d.getTrustedCertificates()
 	if err != nil {
-		return false, "", "", err
+		return false, "", "", nil, err
 	}
 
 	// Allow internal cluster traffic by checking against the trusted certfificates.
@@ -502,7 +517,7 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 		for _, i := range r.TLS.PeerCertificates {
 			trusted, fingerprint := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeServer], d.endpoints.NetworkCert(), false)
 			if trusted {
-				return true, fingerprint, "cluster", nil
+				return true, fingerprint, "cluster", nil, nil
 			}
 		}
 	}
@@ -512,60 +527,83 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 		if w != nil {
 			cred, err := ucred.GetCredFromContext(r.Context())
 			if err != nil {
-				return false, "", "", err
+				return false, "", "", nil, err
 			}
 
 			u, err := user.LookupId(fmt.Sprintf("%d", cred.Uid))
 			if err != nil {
-				return true, fmt.Sprintf("uid=%d", cred.Uid), "unix", nil
+				return true, fmt.Sprintf("uid=%d", cred.Uid), "unix", nil, nil
 			}
 
-			return true, u.Username, "unix", nil
+			return true, u.Username, "unix", nil, nil
 		}
 
-		return true, "", "unix", nil
+		return true, "", "unix", nil, nil
 	}
 
 	// DevIncus unix socket credentials on main API.
 	if r.RemoteAddr == "@dev_incus" {
-		return false, "", "", errors.New("Main API query can't come from /dev/incus socket")
+		return false, "", "", nil, errors.New("Main API query can't come from /dev/incus socket")
 	}
 
 	// Cluster notification with wrong certificate.
 	if isClusterNotification(r) {
-		return false, "", "", errors.New("Cluster notification isn't using trusted server certificate")
+		return false, "", "", nil, errors.New("Cluster notification isn't using trusted server certificate")
 	}
 
 	// Cluster internal client with wrong certificate.
 	if isClusterInternal(r) {
-		return false, "", "", errors.New("Cluster internal client isn't using trusted server certificate")
+		return false, "", "", nil, errors.New("Cluster internal client isn't using trusted server certificate")
 	}
 
 	// Bad query, no TLS found.
 	if r.TLS == nil {
-		return false, "", "", errors.New("Bad/missing TLS on network query")
+		return false, "", "", nil, errors.New("Bad/missing TLS on network query")
 	}
 
 	// Load the certificates.
 	trustCACertificates := d.globalConfig.TrustCACertificates()
 
+	// Check for a scoped API token.
+	tokenSecret, ok := auth.TokenFromRequest(r)
+	if ok {
+		tokenName, err := d.authenticateToken(r.Context(), tokenSecret)
+		if err != nil {
+			return false, "", "", nil, err
+		}
+
+		return true, tokenName, api.AuthenticationMethodToken, nil, nil
+	}
+
 	// Check for JWT token signed by a TLS certificate.
 	jwtOk, _, cert := localUtil.CheckJwtToken(r, trustedCerts[certificate.TypeClient])
 	if jwtOk {
 		trusted, username := localUtil.CheckTrustState(*cert, trustedCerts[certificate.TypeClient], d.endpoints.NetworkCert(), trustCACertificates)
 		if trusted {
-			return true, username, api.AuthenticationMethodTLS, nil
+			return true, username, api.AuthenticationMethodTLS, nil, nil
 		}
 	}
 
 	// Check for JWT token signed by an OpenID Connect provider.
 	if d.oidcVerifier != nil && d.oidcVerifier.IsRequest(r) {
-		userName, err := d.oidcVerifier.Auth(d.shutdownCtx, w, r)
+		userName, groups, err := d.oidcVerifier.Auth(d.shutdownCtx, w, r)
 		if err != nil {
-			return false, "", "", err
+			return false, "", "", nil, err
 		}
 
-		return true, userName, api.AuthenticationMethodOIDC, nil
+		return true, userName, api.AuthenticationMethodOIDC, d.oidcGroups.Map(groups), nil
+	}
+
+	// Validate client certificates issued by the server, which carry the identity they were issued to.
+	if len(r.TLS.PeerCertificates) > 0 {
+		issued, err := d.getIssuedCertificate(r.Context(), r.TLS.PeerCertificates[0])
+		if err != nil {
+			return false, "", "", nil, err
+		}
+
+		if issued != nil {
+			return true, issued.Identifier, issued.AuthMethod, issued.Groups(), nil
+		}
 	}
 
 	// Validate metrics TLS certificates.
@@ -573,7 +611,7 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 		for _, i := range r.TLS.PeerCertificates {
 			trusted, username := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeMetrics], d.endpoints.NetworkCert(), trustCACertificates)
 			if trusted {
-				return true, username, api.AuthenticationMethodTLS, nil
+				return true, username, api.AuthenticationMethodTLS, nil, nil
 			}
 		}
 	}
@@ -582,12 +620,12 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 	for _, i := range r.TLS.PeerCertificates {
 		trusted, username := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeClient], d.endpoints.NetworkCert(), trustCACertificates)
 		if trusted {
-			return true, username, api.AuthenticationMethodTLS, nil
+			return true, username, api.AuthenticationMethodTLS, nil, nil
 		}
 	}
 
 	// Reject unauthorized.
-	return false, "", "", nil
+	return false, "", "", nil, nil
 }
 
 // State creates a new State instance linked to our internal db and os.
@@ -609,7 +647,7 @@ func (d *Daemon) State() *state.State {
 	d.globalConfigMu.Unlock()
 
 	return &state.State{
-		Authorizer:             d.authorizer,
+		Authorizer:             auth.WithTokens(d.authorizer, d.getAuthToken),
 		BGP:                    d.bgp,
 		Cluster:                d.gateway,
 		DB:                     d.db,
@@ -677,7 +715,19 @@ func (d *Daemon) createCmd(restAPI *http.ServeMux, apiVersion string, c APIEndpo
 		}
 
 		// Authentication
-		trusted, username, protocol, err := d.Authenticate(w, r)
+		trusted, username, protocol, identityProviderGroups, err := d.Authenticate(w, r)
+
+		// Record the request in the audit log, skipping internal cluster traffic.
+		if d.auditLogger.Enabled() && (apiVersion != "internal" || protocol != "cluster") {
+			auditWriter := &auditResponseWriter{ResponseWriter: w}
+			w = auditWriter
+
+			startTime := time.Now()
+			defer func() {
+				d.auditRequest(r, uri, protocol, username, startTime, auditWriter.statusCode)
+			}()
+		}
+
 		if err != nil {
 			var authError *oidc.AuthError
 			if errors.As(err, &authError) {
@@ -735,12 +785,21 @@ func (d *Daemon) createCmd(restAPI *http.ServeMux, apiVersion string, c APIEndpo
 			ctx := context.WithValue(r.Context(), request.CtxUsername, username)
 			ctx = context.WithValue(ctx, request.CtxProtocol, protocol)
 
+			if len(identityProviderGroups) > 0 {
+				ctx = context.WithValue(ctx, request.CtxIdentityProviderGroups, identityProviderGroups)
+			}
+
 			// Add forwarded requestor data.
 			if protocol == "cluster" {
 				// Add authentication/authorization context data.
 				ctx = context.WithValue(ctx, request.CtxForwardedAddress, r.Header.Get(request.HeaderForwardedAddress))
 				ctx = context.WithValue(ctx, request.CtxForwardedUsername, r.Header.Get(request.HeaderForwardedUsername))
 				ctx = context.WithValue(ctx, request.CtxForwardedProtocol, r.Header.Get(request.HeaderForwardedProtocol))
+
+				forwardedGroups := r.Header.Get(request.HeaderForwardedIdentityProviderGroups)
+				if forwardedGroups != "" {
+					ctx = context.WithValue(ctx, request.CtxForwardedIdentityProviderGroups, strings.Split(forwardedGroups, ","))
+				}
 			}
 
 			r = r.WithContext(ctx)
@@ -1369,21 +1428,33 @@ func (d *Daemon) init() error {
 
 	d.gateway.HeartbeatOfflineThreshold = d.globalConfig.OfflineThreshold()
 	oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim := d.globalConfig.OIDCServer()
+	oidcGroupsClaim, oidcGroupsMapping := d.globalConfig.OIDCGroups()
 	syslogSocketEnabled := d.localConfig.SyslogSocket()
 	openfgaAPIURL, openfgaAPIToken, openfgaStoreID := d.globalConfig.OpenFGA()
 	instancePlacementScriptlet := d.globalConfig.InstancesPlacementScriptlet()
 	authorizationScriptlet := d.globalConfig.AuthorizationScriptlet()
+	authorizationRBAC := d.globalConfig.AuthorizationRBAC()
+	auditEnabled, auditMaxSize, auditMaxFiles := d.globalConfig.Audit()
 
 	d.endpoints.NetworkUpdateTrustedProxy(d.globalConfig.HTTPSTrustedProxy())
 	ws.SetTrustedOrigins(d.globalConfig.HTTPSAllowedWebsocketOrigin())
 	d.globalConfigMu.Unlock()
 
+	// Reject revoked client certificates during the TLS handshake.
+	d.endpoints.NetworkUpdateRevocationCheck(d.checkCertificateRevocation)
+
 	d.loggingController = logging.NewLoggingController(d.internalListener)
 	err = d.loggingController.Setup(d.State())
 	if err != nil {
 		return err
 	}
 
+	// Setup the audit log.
+	err = d.auditLogger.Configure(auditEnabled, auditMaxSize, auditMaxFiles)
+	if err != nil {
+		return err
+	}
+
 	// Setup syslog listener.
 	if syslogSocketEnabled {
 		err = d.setupSyslogSocket(true)
@@ -1394,10 +1465,12 @@ func (d *Daemon) init() error {
 
 	// Setup OIDC authentication.
 	if oidcIssuer != "" && oidcClientID != "" {
-		d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim)
+		d.oidcVerifier, err = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcScope, oidcAudience, oidcClaim, oidcGroupsClaim)
 		if err != nil {
 			return err
 		}
+
+		d.oidcGroups = oidcGroupsMapping
 	}
 
 	// Setup OpenFGA authorization.
@@ -1416,6 +1489,14 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Setup the built-in role-based access control.
+	if authorizationRBAC {
+		err = d.setupAuthorizationRBAC(authorizationRBAC)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Setup BGP listener.
 	d.bgp = bgp.NewServer()
 	if bgpAddress != "" && bgpASN != 0 && bgpRouterID != "" {
@@ -1516,6 +1597,12 @@ func (d *Daemon) init() error {
 	// Cleanup leftover images.
 	pruneLeftoverImages(d.State())
 
+	// Reject the approvals of the operations lost with the previous daemon.
+	err = approvalsPruneOrphaned(d.shutdownCtx, d.State())
+	if err != nil {
+		logger.Warn("Failed pruning orphaned approvals", logger.Ctx{"err": err})
+	}
+
 	var instances []instance.Instance
 
 	if !d.os.MockMode {
@@ -1631,6 +1718,39 @@ func (d *Daemon) init() error {
 
 		// Remove expired tokens (hourly)
 		d.tasks.Add(autoRemoveExpiredTokensTask(d))
+
+		// Check storage pool health (every 5 minutes)
+		d.tasks.Add(storagePoolsHealthCheckTask(d))
+
+		// Reclaim unused storage space (daily)
+		d.tasks.Add(storagePoolsReclaimTask(d))
+
+		// Apply storage bucket lifecycle rules (daily)
+		d.tasks.Add(storageBucketsLifecycleTask(d))
+
+		// Run instance health checks (every 10 seconds)
+		d.tasks.Add(instanceHealthcheckTask(d))
+
+		// Adjust the memory balloon of VMs using automatic ballooning (every 10 seconds)
+		d.tasks.Add(instanceBalloonTask(d))
+
+		// Start and stop instances according to their power schedules (minutely check of configurable cron expression)
+		d.tasks.Add(instancePowerScheduleTask(d))
+
+		// Adjust the CPU and memory limits of autoscaled containers (every minute)
+		d.tasks.Add(instanceAutoscaleTask(d))
+
+		// Refresh the list of revoked issued client certificates (every minute)
+		d.tasks.Add(issuedCertificatesRevocationTask(d))
+
+		// Warn about expiring trusted certificates and remove expired issued ones (daily)
+		d.tasks.Add(certificatesExpiryCheckTask(d))
+
+		// Record the resource usage of the instances of each project (every 5 minutes, stored hourly)
+		d.tasks.Add(projectsUsageTask(d))
+
+		// Remove project resource usage older than the retention period (daily)
+		d.tasks.Add(pruneProjectsUsageTask(d))
 	}
 
 	// Start all background tasks
@@ -1716,6 +1836,13 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 		d.loggingController.Shutdown()
 	}
 
+	if d.auditLogger != nil {
+		err := d.auditLogger.Close()
+		if err != nil {
+			logger.Warn("Failed closing audit log", logger.Ctx{"err": err})
+		}
+	}
+
 	if d.gateway != nil {
 		d.stopClusterTasks()
 
@@ -1743,6 +1870,12 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 			// Make all future queries fail fast as DB is not available.
 			d.gateway.Kill()
 			_ = d.db.Cluster.Close()
+		} else {
+			// Record the resource usage accounted since the last record as it's only kept in memory.
+			recordErr := projectsUsageRecord(ctx, s)
+			if recordErr != nil {
+				logger.Warn("Failed recording project resource usage", logger.Ctx{"err": recordErr})
+			}
 		}
 
 		if err == nil {
@@ -2211,6 +2344,109 @@ func (d *Daemon) setupAuthorizationScriptlet(scriptlet string) error {
 	return nil
 }
 
+// Setup the built-in role-based access control.
+func (d *Daemon) setupAuthorizationRBAC(enabled bool) error {
+	var err error
+
+	if !enabled {
+		// Reset to default authorizer.
+		_, ok := d.authorizer.(*auth.RBAC)
+		if ok {
+			d.authorizer, err = auth.LoadAuthorizer(d.shutdownCtx, auth.DriverTLS, logger.Log, d.clientCerts)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	getIdentities := func(ctx context.Context, authenticationMethod string, identifier string) ([]auth.Identity, error) {
+		var identities []auth.Identity
+
+		err := d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			dbIdentities, err := dbCluster.GetIdentitiesPermissions(ctx, tx.Tx(), authenticationMethod, identifier)
+			if err != nil {
+				return err
+			}
+
+			identities = make([]auth.Identity, 0, len(dbIdentities))
+			for _, dbIdentity := range dbIdentities {
+				identity := auth.Identity{
+					AuthenticationMethod: dbIdentity.AuthMethod,
+					Identifier:           dbIdentity.Identifier,
+					Permissions:          make([]auth.Permission, 0, len(dbIdentity.Permissions)),
+				}
+
+				for _, permission := range dbIdentity.Permissions {
+					identity.Permissions = append(identity.Permissions, auth.Permission{
+						Entitlement: auth.Entitlement(permission.Entitlement),
+						Object:      auth.Object(permission.Object),
+					})
+				}
+
+				identities = append(identities, identity)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return identities, nil
+	}
+
+	getGroups := func(ctx context.Context, groupNames []string) ([]auth.Permission, error) {
+		var permissions []auth.Permission
+
+		err := d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			dbPermissions, err := dbCluster.GetAuthGroupsPermissions(ctx, tx.Tx(), groupNames)
+			if err != nil {
+				return err
+			}
+
+			permissions = make([]auth.Permission, 0, len(dbPermissions))
+			for _, permission := range dbPermissions {
+				permissions = append(permissions, auth.Permission{
+					Entitlement: auth.Entitlement(permission.Entitlement),
+					Object:      auth.Object(permission.Object),
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return permissions, nil
+	}
+
+	updateObjects := func(ctx context.Context, update func(object auth.Object) (auth.Object, bool)) error {
+		return d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return dbCluster.UpdateAuthGroupPermissionsObjects(ctx, tx.Tx(), func(object string) (string, bool) {
+				newObject, ok := update(auth.Object(object))
+				return newObject.String(), ok
+			})
+		})
+	}
+
+	// Fail if not using the default tls or RBAC authorizer.
+	switch d.authorizer.(type) {
+	case *auth.TLS, *auth.RBAC:
+		d.authorizer, err = auth.LoadAuthorizer(d.shutdownCtx, auth.DriverRBAC, logger.Log, d.clientCerts, auth.WithIdentitiesFunc(getIdentities), auth.WithGroupsFunc(getGroups), auth.WithUpdateObjectsFunc(updateObjects))
+		if err != nil {
+			return err
+		}
+
+	default:
+		return errors.New("Attempting to setup RBAC authorization while another authorizer is already set")
+	}
+
+	return nil
+}
+
 // Syslog listener.
 func (d *Daemon) setupSyslogSocket(enable bool) error {
 	// Always cancel the context to ensure that no goroutines leak.
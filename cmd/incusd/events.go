@@ -22,8 +22,8 @@ import (
 )
 
 var (
-	eventTypes           = []string{api.EventTypeLogging, api.EventTypeOperation, api.EventTypeLifecycle, api.EventTypeNetworkACL}
-	privilegedEventTypes = []string{api.EventTypeLogging}
+	eventTypes           = []string{api.EventTypeLogging, api.EventTypeOperation, api.EventTypeLifecycle, api.EventTypeNetworkACL, api.EventTypeAudit}
+	privilegedEventTypes = []string{api.EventTypeLogging, api.EventTypeAudit}
 )
 
 var eventsCmd = APIEndpoint{
@@ -92,6 +92,11 @@ func eventsSocket(s *state.State, r *http.Request, w http.ResponseWriter) error
 				continue
 			}
 
+			// Audit events must be explicitly requested.
+			if entry == api.EventTypeAudit {
+				continue
+			}
+
 			types = append(types, entry)
 		}
 	}
@@ -103,7 +108,7 @@ func eventsSocket(s *state.State, r *http.Request, w http.ResponseWriter) error
 		}
 	}
 
-	if slices.Contains(types, api.EventTypeLogging) && !canViewPrivilegedEvents {
+	if (slices.Contains(types, api.EventTypeLogging) || slices.Contains(types, api.EventTypeAudit)) && !canViewPrivilegedEvents {
 		return api.StatusErrorf(http.StatusForbidden, "Forbidden")
 	}
 
@@ -188,7 +193,7 @@ func eventsSocket(s *state.State, r *http.Request, w http.ResponseWriter) error
 //	    example: default
 //	  - in: query
 //	    name: type
-//	    description: Event type(s), comma separated (valid types are logging, operation or lifecycle)
+//	    description: Event type(s), comma separated (valid types are logging, operation, lifecycle, network-acl or audit)
 //	    type: string
 //	    example: logging,lifecycle
 //	  - in: query
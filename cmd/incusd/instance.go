@@ -604,6 +604,12 @@ func pruneExpiredInstanceSnapshots(ctx context.Context, snapshots []instance.Ins
 			return err
 		}
 
+		// Keep the snapshot until the project protection policy allows deleting it.
+		p := snapshot.Project()
+		if project.AllowSnapshotDeletion(&p, snapshot.CreationDate()) != nil {
+			continue
+		}
+
 		_, loaded := instSnapshotsPruneRunning.LoadOrStore(snapshot.ID(), struct{}{})
 		if loaded {
 			continue // Deletion of this snapshot is already running, skip.
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/linux"
+	"github.com/lxc/incus/v7/internal/server/db"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/autoscale"
+	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	projecthelpers "github.com/lxc/incus/v7/internal/server/project"
+	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// instanceAutoscaleSchedule is how often the resource usage of the autoscaled instances is sampled.
+const instanceAutoscaleSchedule = time.Minute
+
+func instanceAutoscaleTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		instances, err := instance.LoadNodeAll(s, instancetype.Container)
+		if err != nil {
+			logger.Warn("Failed loading instances for autoscaling", logger.Ctx{"err": err})
+			return
+		}
+
+		hostMemory, err := linux.DeviceTotalMemory()
+		if err != nil {
+			logger.Warn("Failed getting host memory for autoscaling", logger.Ctx{"err": err})
+			return
+		}
+
+		scaled := map[int]bool{}
+
+		for _, inst := range instances {
+			c, err := autoscale.ParseConfig(inst.ExpandedConfig())
+			if err != nil || c == nil {
+				continue
+			}
+
+			if !inst.IsRunning() || inst.IsFrozen() {
+				continue
+			}
+
+			scaled[inst.ID()] = true
+
+			err = instanceAutoscale(s, inst, c, hostMemory)
+			if err != nil {
+				logger.Warn("Failed autoscaling instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			}
+		}
+
+		// Drop the state of the instances which stopped or are no longer autoscaled.
+		autoscale.Prune(scaled)
+	}
+
+	return f, task.Every(instanceAutoscaleSchedule)
+}
+
+// instanceAutoscale samples the resource usage of an instance and adjusts its limits if needed.
+func instanceAutoscale(s *state.State, inst instance.Instance, c *autoscale.Config, hostMemory int64) error {
+	ct, ok := inst.(instance.Container)
+	if !ok {
+		return nil
+	}
+
+	sample, err := ct.AutoscaleSample()
+	if err != nil {
+		return err
+	}
+
+	cpuUsage, ok := autoscale.Record(inst.ID(), *sample)
+	if !ok {
+		return nil
+	}
+
+	changes := map[string]string{}
+	resources := map[string]string{}
+
+	if autoscale.Due(inst.ID(), autoscale.ResourceCPU, sample.Time) {
+		current := autoscale.CPUAllowance(inst.ExpandedConfig()["limits.cpu.allowance"], sample.CPUs)
+
+		target := autoscale.CPUTarget(c, sample.CPUs, current, cpuUsage)
+		if target != current {
+			changes["limits.cpu.allowance"] = autoscale.FormatCPUAllowance(target)
+			resources["limits.cpu.allowance"] = autoscale.ResourceCPU
+		}
+	}
+
+	if autoscale.Due(inst.ID(), autoscale.ResourceMemory, sample.Time) {
+		target := autoscale.MemoryTarget(c, hostMemory, *sample)
+		if target != sample.MemoryLimit {
+			changes["limits.memory"] = autoscale.FormatMemoryLimit(target)
+			resources["limits.memory"] = autoscale.ResourceMemory
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	oldConfig := inst.ExpandedConfig()
+
+	err = instanceAutoscaleApply(s, inst, changes)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range changes {
+		autoscale.Changed(inst.ID(), resources[key], sample.Time)
+
+		logger.Info("Autoscaled instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "key": key, "old": oldConfig[key], "new": value})
+		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceAutoscaled.Event(inst, map[string]any{"key": key, "old": oldConfig[key], "new": value}))
+	}
+
+	return nil
+}
+
+// instanceAutoscaleApply applies new limits to an instance, provided they fit in the project limits.
+// The limits are written to the local config of the instance, taking precedence over its profiles.
+func instanceAutoscaleApply(s *state.State, inst instance.Instance, changes map[string]string) error {
+	unlock, err := instanceOperationLock(s.ShutdownCtx, inst.Project().Name, inst.Name())
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	// Reload the instance to avoid overwriting changes made since it was sampled.
+	inst, err = instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name())
+	if err != nil {
+		return err
+	}
+
+	config := maps.Clone(inst.LocalConfig())
+	for key, value := range changes {
+		config[key] = value
+	}
+
+	profileNames := make([]string, 0, len(inst.Profiles()))
+	for _, profile := range inst.Profiles() {
+		profileNames = append(profileNames, profile.Name)
+	}
+
+	req := api.InstancePut{
+		Config:   config,
+		Devices:  inst.LocalDevices().CloneNative(),
+		Profiles: profileNames,
+	}
+
+	err = s.DB.Cluster.Transaction(s.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return projecthelpers.AllowInstanceUpdate(tx, inst.Project().Name, inst.Name(), req, inst.LocalConfig())
+	})
+	if err != nil {
+		return fmt.Errorf("Autoscaling not allowed by the project: %w", err)
+	}
+
+	args := db.InstanceArgs{
+		Architecture: inst.Architecture(),
+		Config:       config,
+		Description:  inst.Description(),
+		Devices:      inst.LocalDevices(),
+		Ephemeral:    inst.IsEphemeral(),
+		Profiles:     inst.Profiles(),
+		Project:      inst.Project().Name,
+		ExpiryDate:   inst.ExpiryDate(),
+	}
+
+	return inst.Update(args, false)
+}
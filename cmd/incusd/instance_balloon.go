@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/linux"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// instanceBalloonSchedule is how often the memory balloon of the VMs using automatic ballooning is adjusted.
+const instanceBalloonSchedule = 10 * time.Second
+
+// instanceBalloonPressureRatio is the fraction of available host memory below which the host is considered under pressure.
+const instanceBalloonPressureRatio = 0.1
+
+// instanceBalloonHostPressure returns whether the host is running low on memory.
+func instanceBalloonHostPressure() (bool, error) {
+	total, err := linux.DeviceTotalMemory()
+	if err != nil {
+		return false, err
+	}
+
+	available, err := linux.GetMeminfo("MemAvailable")
+	if err != nil {
+		return false, err
+	}
+
+	return float64(available) < float64(total)*instanceBalloonPressureRatio, nil
+}
+
+func instanceBalloonTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		instances, err := instance.LoadNodeAll(s, instancetype.VM)
+		if err != nil {
+			logger.Warn("Failed loading instances for memory ballooning", logger.Ctx{"err": err})
+			return
+		}
+
+		hostPressure, err := instanceBalloonHostPressure()
+		if err != nil {
+			logger.Warn("Failed checking host memory pressure", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, inst := range instances {
+			if inst.ExpandedConfig()["limits.memory.balloon"] != "auto" || !inst.IsRunning() {
+				continue
+			}
+
+			vm, ok := inst.(instance.VM)
+			if !ok {
+				continue
+			}
+
+			err := vm.BalloonAdjust(hostPressure)
+			if err != nil {
+				logger.Warn("Failed adjusting memory balloon", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			}
+		}
+	}
+
+	return f, task.Every(instanceBalloonSchedule)
+}
@@ -216,7 +216,7 @@ func (s *consoleWs) do(op *operations.Operation) error {
 
 	switch s.protocol {
 	case instance.ConsoleTypeConsole:
-		return s.doConsole()
+		return s.doConsole(op)
 	case instance.ConsoleTypeVGA:
 		return s.doVGA()
 	default:
@@ -224,7 +224,7 @@ func (s *consoleWs) do(op *operations.Operation) error {
 	}
 }
 
-func (s *consoleWs) doConsole() error {
+func (s *consoleWs) doConsole(op *operations.Operation) error {
 	defer logger.Debug("Console websocket finished")
 	<-s.allConnected
 
@@ -244,6 +244,14 @@ func (s *consoleWs) doConsole() error {
 		_ = linux.SetPtySize(int(console.Fd()), s.width, s.height)
 	}
 
+	// Start recording the session if required.
+	rec, err := sessionRecordingStart(s.instance, op.ID(), "console", nil, s.width, s.height)
+	if err != nil {
+		return err
+	}
+
+	defer rec.Finish(s.state, s.instance)
+
 	consoleDoneCh := make(chan struct{})
 
 	// Wait for control socket to connect and then read messages from the remote side in a loop.
@@ -302,6 +310,7 @@ func (s *consoleWs) doConsole() error {
 					continue
 				}
 
+				rec.Resize(winchWidth, winchHeight)
 				logger.Debugf("Set window size to: %dx%d", winchWidth, winchHeight)
 			}
 		}
@@ -318,7 +327,7 @@ func (s *consoleWs) doConsole() error {
 		defer l.Debug("Finished mirroring websocket to console")
 
 		l.Debug("Started mirroring websocket")
-		readDone, writeDone := ws.Mirror(conn, console)
+		readDone, writeDone := ws.Mirror(conn, rec.ReadWriteCloser(console))
 
 		<-readDone
 		l.Debug("Finished mirroring console to websocket")
@@ -2,16 +2,19 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	internalInstance "github.com/lxc/incus/v7/internal/instance"
 	"github.com/lxc/incus/v7/internal/server/db/operationtype"
 	"github.com/lxc/incus/v7/internal/server/instance"
 	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/project"
 	"github.com/lxc/incus/v7/internal/server/request"
 	"github.com/lxc/incus/v7/internal/server/response"
 	"github.com/lxc/incus/v7/internal/version"
 	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/util"
 )
 
 // swagger:operation DELETE /1.0/instances/{name} instances instance_delete
@@ -80,9 +83,46 @@ func instanceDelete(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("Instance is running"))
 	}
 
+	// The project protection policy can't be bypassed, even through an approval.
+	err = instanceDeleteAllowed(inst)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Protected instances may be deleted once approved if the project requires it.
+	approval := false
+	if util.IsTrue(inst.ExpandedConfig()["security.protection.delete"]) {
+		approval, err = approvalRequired(r.Context(), s, projectName, project.ApprovalInstancesDeleteProtected)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	run := func(op *operations.Operation) error {
+		// The operation may only run long after the request once approved, so check the instance again.
+		unlock, err := instanceOperationLock(s.ShutdownCtx, projectName, name)
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		inst, err := instance.LoadByProjectAndName(s, projectName, name)
+		if err != nil {
+			return err
+		}
+
+		if inst.IsRunning() {
+			return errors.New("Instance is running")
+		}
+
+		err = instanceDeleteAllowed(inst)
+		if err != nil {
+			return err
+		}
+
 		inst.SetOperation(op)
-		return inst.Delete(false, true)
+		return inst.Delete(approval, true)
 	}
 
 	resources := map[string][]api.URL{}
@@ -93,5 +133,9 @@ func instanceDelete(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
+	if approval {
+		return approvalOperationResponse(s, r, op, project.ApprovalInstancesDeleteProtected, fmt.Sprintf("Deleting protected instance %q", name))
+	}
+
 	return operations.OperationResponse(op)
 }
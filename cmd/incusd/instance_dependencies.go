@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/cluster"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/healthcheck"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+// instanceDependsOnDefaultTimeout is how long to wait for the dependencies of an instance by default.
+const instanceDependsOnDefaultTimeout = 300 * time.Second
+
+// instanceDependsOnPollInterval is how often the state of the dependencies is checked while waiting.
+const instanceDependsOnPollInterval = 2 * time.Second
+
+// instanceDependencies returns the names of the instances the instance depends on.
+func instanceDependencies(inst instance.Instance) []string {
+	return util.SplitNTrimSpace(inst.ExpandedConfig()["boot.depends_on"], ",", -1, true)
+}
+
+// instanceDependsOnTimeout returns how long to wait for the dependencies of the instance.
+func instanceDependsOnTimeout(inst instance.Instance) time.Duration {
+	value, err := strconv.Atoi(inst.ExpandedConfig()["boot.depends_on.timeout"])
+	if err != nil {
+		return instanceDependsOnDefaultTimeout
+	}
+
+	return time.Duration(value) * time.Second
+}
+
+// instanceDependencyKey returns the key identifying an instance in the dependency graph.
+func instanceDependencyKey(projectName string, instName string) string {
+	return projectName + "/" + instName
+}
+
+// instancesDependencyLevels groups the instances into levels such that each instance only
+// depends on instances (among the given ones) of earlier levels.
+// Instances which are part of a dependency cycle are returned as a last level along with an error.
+func instancesDependencyLevels(instances []instance.Instance) ([][]instance.Instance, error) {
+	byKey := make(map[string]instance.Instance, len(instances))
+	for _, inst := range instances {
+		byKey[instanceDependencyKey(inst.Project().Name, inst.Name())] = inst
+	}
+
+	// Count the unresolved dependencies of each instance and record the reverse edges.
+	pending := make(map[string]int, len(instances))
+	dependents := map[string][]string{}
+	for key, inst := range byKey {
+		pending[key] = 0
+
+		for _, dep := range instanceDependencies(inst) {
+			depKey := instanceDependencyKey(inst.Project().Name, dep)
+
+			_, ok := byKey[depKey]
+			if !ok {
+				continue
+			}
+
+			pending[key]++
+			dependents[depKey] = append(dependents[depKey], key)
+		}
+	}
+
+	var levels [][]instance.Instance
+	for len(pending) > 0 {
+		var level []instance.Instance
+		for key, count := range pending {
+			if count == 0 {
+				level = append(level, byKey[key])
+			}
+		}
+
+		if len(level) == 0 {
+			break
+		}
+
+		for _, inst := range level {
+			key := instanceDependencyKey(inst.Project().Name, inst.Name())
+			delete(pending, key)
+
+			for _, dependent := range dependents[key] {
+				pending[dependent]--
+			}
+		}
+
+		sort.Sort(instanceAutostartList(level))
+		levels = append(levels, level)
+	}
+
+	if len(pending) == 0 {
+		return levels, nil
+	}
+
+	// The remaining instances are part of (or depend on) a cycle.
+	cycle := make([]instance.Instance, 0, len(pending))
+	names := make([]string, 0, len(pending))
+	for key := range pending {
+		cycle = append(cycle, byKey[key])
+		names = append(names, key)
+	}
+
+	sort.Sort(instanceAutostartList(cycle))
+	sort.Strings(names)
+	levels = append(levels, cycle)
+
+	return levels, fmt.Errorf("Dependency cycle detected between instances %s", strings.Join(names, ", "))
+}
+
+// instanceDependencyReady returns whether an instance is running and, if it has a health check, healthy.
+func instanceDependencyReady(s *state.State, projectName string, instName string) (bool, error) {
+	client, err := cluster.ConnectIfInstanceIsRemote(s, projectName, instName, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if client != nil {
+		instState, _, err := client.GetInstanceState(instName)
+		if err != nil {
+			return false, err
+		}
+
+		return instState.StatusCode == api.Running && (instState.Health == nil || instState.Health.Status == healthcheck.StatusHealthy), nil
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, instName)
+	if err != nil {
+		return false, err
+	}
+
+	if !inst.IsRunning() || inst.IsFrozen() {
+		return false, nil
+	}
+
+	if inst.ExpandedConfig()["healthcheck.type"] != "" {
+		return healthcheck.Get(inst.ID()).Status == healthcheck.StatusHealthy, nil
+	}
+
+	return true, nil
+}
+
+// instanceWaitDependencies waits until all the dependencies of the instance are ready or until deadline.
+func instanceWaitDependencies(ctx context.Context, s *state.State, inst instance.Instance, deadline time.Time) error {
+	deps := instanceDependencies(inst)
+	if len(deps) == 0 {
+		return nil
+	}
+
+	for {
+		var notReady []string
+		for _, dep := range deps {
+			ready, err := instanceDependencyReady(s, inst.Project().Name, dep)
+			if err != nil && api.StatusErrorCheck(err, http.StatusNotFound) {
+				return fmt.Errorf("Dependency %q of instance %q not found", dep, inst.Name())
+			}
+
+			if !ready {
+				notReady = append(notReady, dep)
+			}
+		}
+
+		if len(notReady) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out waiting for dependencies of instance %q: %s", inst.Name(), strings.Join(notReady, ", "))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(instanceDependsOnPollInterval):
+		}
+	}
+}
+
+// instancesStartDependent auto-starts instances with dependencies, level by level.
+// Instances whose dependencies don't become ready in time are started anyway.
+func instancesStartDependent(s *state.State, instances []instance.Instance) {
+	levels, err := instancesDependencyLevels(instances)
+	if err != nil {
+		logger.Error("Failed resolving instance dependencies", logger.Ctx{"err": err})
+	}
+
+	for _, level := range levels {
+		instancesStartLevel(s, level)
+	}
+}
+
+// instancesStartLevel auto-starts instances which don't depend on each other in parallel.
+func instancesStartLevel(s *state.State, instances []instance.Instance) {
+	done := make(chan struct{}, len(instances))
+
+	for _, inst := range instances {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			if !instanceShouldAutoStart(inst) || inst.IsRunning() {
+				return
+			}
+
+			err := instanceWaitDependencies(s.ShutdownCtx, s, inst, time.Now().Add(instanceDependsOnTimeout(inst)))
+			if err != nil {
+				logger.Warn("Starting instance without its dependencies being ready", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			}
+
+			_ = instanceStart(s, inst)
+		}()
+	}
+
+	for range instances {
+		<-done
+	}
+}
+
+// instanceDependencyOrder returns all the dependencies of the instance (recursively) in the order
+// they must be started in.
+func instanceDependencyOrder(s *state.State, inst instance.Instance) ([]instance.Instance, error) {
+	projectName := inst.Project().Name
+
+	// Resolve the dependencies depth-first so that each one comes after its own dependencies.
+	var order []instance.Instance
+	visiting := map[string]bool{}
+	visited := map[string]bool{}
+
+	var walk func(current instance.Instance, path []string) error
+	walk = func(current instance.Instance, path []string) error {
+		path = append(path, current.Name())
+		visiting[current.Name()] = true
+
+		for _, dep := range instanceDependencies(current) {
+			if visiting[dep] {
+				return fmt.Errorf("Dependency cycle detected: %s", strings.Join(append(path, dep), " -> "))
+			}
+
+			if visited[dep] {
+				continue
+			}
+
+			depInst, err := instance.LoadByProjectAndName(s, projectName, dep)
+			if err != nil {
+				return fmt.Errorf("Failed loading dependency %q of instance %q: %w", dep, current.Name(), err)
+			}
+
+			err = walk(depInst, path)
+			if err != nil {
+				return err
+			}
+
+			order = append(order, depInst)
+		}
+
+		visiting[current.Name()] = false
+		visited[current.Name()] = true
+
+		return nil
+	}
+
+	err := walk(inst, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// instanceDependenciesCheckPermission checks that the requestor is allowed to start each of the dependencies.
+func instanceDependenciesCheckPermission(ctx context.Context, authorizer auth.Authorizer, r *http.Request, projectName string, names []string) error {
+	for _, name := range names {
+		err := authorizer.CheckPermission(ctx, r, auth.ObjectInstance(projectName, name), auth.EntitlementCanUpdateState)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusForbidden) {
+				return api.StatusErrorf(http.StatusForbidden, "Not allowed to start dependency %q: %v", name, err)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// instanceStartDependencies starts the given dependencies of the instance in order, waiting for each
+// of them to be ready. The dependencies are returned by instanceDependencyOrder.
+func instanceStartDependencies(s *state.State, r *http.Request, inst instance.Instance, order []instance.Instance, op *operations.Operation) error {
+	deadline := time.Now().Add(instanceDependsOnTimeout(inst))
+
+	for _, dep := range order {
+		if op != nil {
+			_ = op.ExtendMetadata(map[string]any{"dependency_progress": fmt.Sprintf("Starting dependency %q", dep.Name())})
+		}
+
+		err := instanceWaitDependencies(s.ShutdownCtx, s, dep, deadline)
+		if err != nil {
+			return err
+		}
+
+		err = instanceStartDependency(s, r, dep)
+		if err != nil {
+			return fmt.Errorf("Failed starting dependency %q: %w", dep.Name(), err)
+		}
+	}
+
+	if op != nil {
+		_ = op.ExtendMetadata(map[string]any{"dependency_progress": "Waiting for dependencies"})
+	}
+
+	return instanceWaitDependencies(s.ShutdownCtx, s, inst, deadline)
+}
+
+// instanceStartDependency starts a dependency, on whichever cluster member it's located.
+func instanceStartDependency(s *state.State, r *http.Request, inst instance.Instance) error {
+	client, err := cluster.ConnectIfInstanceIsRemote(s, inst.Project().Name, inst.Name(), r)
+	if err != nil {
+		return err
+	}
+
+	if client != nil {
+		instState, _, err := client.GetInstanceState(inst.Name())
+		if err != nil {
+			return err
+		}
+
+		if instState.StatusCode == api.Running {
+			return nil
+		}
+
+		startOp, err := client.UpdateInstanceState(inst.Name(), api.InstanceStatePut{Action: "start"}, "")
+		if err != nil {
+			return err
+		}
+
+		return startOp.Wait()
+	}
+
+	if inst.IsRunning() {
+		return nil
+	}
+
+	err = inst.Start(false)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// dependencyTestAuthorizer allows updating the state of the listed instances only.
+type dependencyTestAuthorizer struct {
+	auth.Authorizer
+
+	allowed map[string]bool
+}
+
+func (a *dependencyTestAuthorizer) CheckPermission(ctx context.Context, r *http.Request, object auth.Object, entitlement auth.Entitlement) error {
+	if entitlement == auth.EntitlementCanUpdateState && a.allowed[object.String()] {
+		return nil
+	}
+
+	return api.StatusErrorf(http.StatusForbidden, "Permission denied")
+}
+
+// Test that starting dependencies requires permission on each of them.
+func TestInstanceDependenciesCheckPermission(t *testing.T) {
+	authorizer := &dependencyTestAuthorizer{allowed: map[string]bool{
+		auth.ObjectInstance("default", "db").String(): true,
+	}}
+
+	r := &http.Request{}
+
+	err := instanceDependenciesCheckPermission(context.Background(), authorizer, r, "default", []string{"db"})
+	assert.NoError(t, err)
+
+	err = instanceDependenciesCheckPermission(context.Background(), authorizer, r, "default", []string{"db", "cache"})
+	assert.True(t, api.StatusErrorCheck(err, http.StatusForbidden))
+	assert.ErrorContains(t, err, `"cache"`)
+
+	// Permissions don't carry over to instances with the same name in other projects.
+	err = instanceDependenciesCheckPermission(context.Background(), authorizer, r, "other", []string{"db"})
+	assert.True(t, api.StatusErrorCheck(err, http.StatusForbidden))
+}
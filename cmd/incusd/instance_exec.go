@@ -282,6 +282,16 @@ func (s *execWs) do(op *operations.Operation) error {
 		stderr = ttys[execWSStderr]
 	}
 
+	// Start recording the session if required.
+	rec, err := sessionRecordingStart(s.instance, op.ID(), "exec", s.req.Command, s.req.Width, s.req.Height)
+	if err != nil {
+		for _, f := range append(ttys, ptys...) {
+			_ = f.Close()
+		}
+
+		return err
+	}
+
 	waitAttachedChildIsDead, markAttachedChildIsDead := context.WithCancel(context.Background())
 	var wgEOF sync.WaitGroup
 
@@ -313,6 +323,8 @@ func (s *execWs) do(op *operations.Operation) error {
 			_ = pty.Close()
 		}
 
+		rec.Finish(s.s, s.instance)
+
 		// Make VM disconnections (shutdown/reboot) match containers.
 		if errors.Is(cmdErr, drivers.ErrExecDisconnected) {
 			cmdResult = 129
@@ -425,6 +437,8 @@ func (s *execWs) do(op *operations.Operation) error {
 					l.Debug("Failed to set window size", logger.Ctx{"err": err, "width": winchWidth, "height": winchHeight})
 					continue
 				}
+
+				rec.Resize(winchWidth, winchHeight)
 			} else if command.Command == "signal" {
 				err := cmd.Signal(unix.Signal(command.Signal))
 				if err != nil {
@@ -452,10 +466,10 @@ func (s *execWs) do(op *operations.Operation) error {
 			if s.instance.Type() == instancetype.Container {
 				// For containers, we are running the command via the locally managed PTY and so
 				// need to use the same PTY handle for both read and write.
-				readDone, writeDone = ws.Mirror(conn, linux.NewExecWrapper(waitAttachedChildIsDead, ptys[0]))
+				readDone, writeDone = ws.Mirror(conn, rec.ReadWriteCloser(linux.NewExecWrapper(waitAttachedChildIsDead, ptys[0])))
 			} else {
-				readDone = ws.MirrorRead(conn, ptys[execWSStdout])
-				writeDone = ws.MirrorWrite(conn, ttys[execWSStdin])
+				readDone = ws.MirrorRead(conn, rec.Reader(ptys[execWSStdout]))
+				writeDone = ws.MirrorWrite(conn, rec.Writer(ttys[execWSStdin]))
 			}
 
 			readErr = <-readDone
@@ -509,10 +523,10 @@ func (s *execWs) do(op *operations.Operation) error {
 				}
 
 				if i == execWSStdin {
-					err = <-ws.MirrorWrite(conn, ttys[i])
+					err = <-ws.MirrorWrite(conn, rec.Writer(ttys[i]))
 					_ = ttys[i].Close()
 				} else {
-					err = <-ws.MirrorRead(conn, linux.NewExecWrapper(waitAttachedChildIsDead, ptys[i]))
+					err = <-ws.MirrorRead(conn, rec.Reader(linux.NewExecWrapper(waitAttachedChildIsDead, ptys[i])))
 					_ = ptys[i].Close()
 					wgEOF.Done()
 				}
@@ -779,6 +793,15 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
+		// Record the session if required.
+		// Without websockets there is no input, and the output is only kept when using record-output.
+		rec, err := sessionRecordingStart(inst, op.ID(), "exec", post.Command, 0, 0)
+		if err != nil {
+			return err
+		}
+
+		defer rec.Finish(s, inst)
+
 		// Run the command.
 		cmd, err := inst.Exec(post, nil, stdout, stderr)
 		if err != nil {
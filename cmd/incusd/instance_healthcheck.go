@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/db/warningtype"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/healthcheck"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/internal/server/warnings"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// instanceHealthcheckSchedule is how often the due health checks are started.
+const instanceHealthcheckSchedule = 10 * time.Second
+
+func instanceHealthcheckTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		// Only load the local instances which have a health check configured.
+		var instances []instance.Instance
+
+		filter := dbCluster.InstanceFilter{Node: &s.ServerName}
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
+				if db.ExpandInstanceConfig(dbInst.Config, dbInst.Profiles)["healthcheck.type"] == "" {
+					return nil
+				}
+
+				inst, err := instance.Load(s, dbInst, p)
+				if err != nil {
+					return fmt.Errorf("Failed loading instance %q (project %q) for health checks: %w", dbInst.Name, dbInst.Project, err)
+				}
+
+				instances = append(instances, inst)
+
+				return nil
+			}, filter)
+		})
+		if err != nil {
+			logger.Warn("Failed loading instances for health checks", logger.Ctx{"err": err})
+			return
+		}
+
+		now := time.Now()
+		checked := map[int]bool{}
+
+		for _, inst := range instances {
+			c, err := healthcheck.ParseConfig(inst.ExpandedConfig())
+			if err != nil || c == nil {
+				continue
+			}
+
+			if !inst.IsRunning() || inst.IsFrozen() {
+				continue
+			}
+
+			checked[inst.ID()] = true
+
+			if !healthcheck.Begin(inst.ID(), c.Interval, now) {
+				continue
+			}
+
+			go instanceHealthcheckRun(s, inst, c)
+		}
+
+		// Drop the state of the instances which stopped or no longer have a health check.
+		healthcheck.Prune(checked)
+	}
+
+	return f, task.Every(instanceHealthcheckSchedule)
+}
+
+// instanceHealthcheckRun runs a single health check of an instance, records its result and
+// handles the transitions of the health status.
+func instanceHealthcheckRun(s *state.State, inst instance.Instance, c *healthcheck.Config) {
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+	ctx, cancel := context.WithTimeout(s.ShutdownCtx, c.Timeout)
+	output, checkErr := instanceHealthcheckProbe(ctx, inst, c)
+	cancel()
+
+	oldStatus, newStatus := healthcheck.Record(inst.ID(), c, time.Now(), output, checkErr)
+
+	if checkErr != nil {
+		output = checkErr.Error()
+	}
+
+	if oldStatus != newStatus {
+		switch newStatus {
+		case healthcheck.StatusHealthy:
+			l.Info("Instance is healthy")
+			s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceHealthy.Event(inst, map[string]any{"output": healthcheck.Truncate(output)}))
+
+			// Resolve any previous warning.
+			err := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, inst.Project().Name, warningtype.InstanceUnhealthy, dbCluster.TypeInstance, inst.ID())
+			if err != nil {
+				l.Warn("Failed to resolve instance unhealthy warning", logger.Ctx{"err": err})
+			}
+
+		case healthcheck.StatusUnhealthy:
+			l.Warn("Instance is unhealthy", logger.Ctx{"output": output, "action": c.Action})
+			s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceUnhealthy.Event(inst, map[string]any{"output": healthcheck.Truncate(output), "action": c.Action}))
+		}
+	}
+
+	if newStatus != healthcheck.StatusUnhealthy || c.Action == healthcheck.ActionNone {
+		return
+	}
+
+	allowed, exhausted := healthcheck.BeginRemediation(inst.ID(), c, time.Now())
+	if exhausted {
+		l.Error("Instance remains unhealthy, giving up on automatic recovery", logger.Ctx{"action": c.Action, "maxRestarts": c.MaxRestarts})
+
+		err := s.DB.Cluster.Transaction(s.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpsertWarningLocalNode(ctx, inst.Project().Name, dbCluster.TypeInstance, inst.ID(), warningtype.InstanceUnhealthy, fmt.Sprintf("Instance remains unhealthy after %d automatic recoveries: %s", c.MaxRestarts, healthcheck.Truncate(output)))
+		})
+		if err != nil {
+			l.Warn("Failed to create instance unhealthy warning", logger.Ctx{"err": err})
+		}
+	}
+
+	if !allowed {
+		return
+	}
+
+	// Start over with a fresh health state (and start period) once done.
+	defer healthcheck.EndRemediation(inst.ID())
+
+	err := instanceHealthcheckRemediate(s, inst, c.Action)
+	if err != nil {
+		l.Error("Failed recovering unhealthy instance", logger.Ctx{"action": c.Action, "err": err})
+	}
+}
+
+// instanceHealthcheckProbe performs the configured check against the instance.
+func instanceHealthcheckProbe(ctx context.Context, inst instance.Instance, c *healthcheck.Config) (string, error) {
+	if c.Type == healthcheck.TypeExec {
+		return instanceHealthcheckExec(ctx, inst, c.Command)
+	}
+
+	addresses, err := instanceHealthcheckAddresses(inst)
+	if err != nil {
+		return "", err
+	}
+
+	// Only ever connect to one of the instance's own addresses as the check runs from the host.
+	address := addresses[0]
+	if c.Address != "" {
+		wanted := net.ParseIP(c.Address)
+		if !slices.ContainsFunc(addresses, func(addr string) bool { return net.ParseIP(addr).Equal(wanted) }) {
+			return "", fmt.Errorf("Address %q isn't a global address of the instance", c.Address)
+		}
+
+		address = c.Address
+	}
+
+	if c.Type == healthcheck.TypeHTTP {
+		return healthcheck.CheckHTTP(ctx, address, c.Port, c.Path)
+	}
+
+	return healthcheck.CheckTCP(ctx, address, c.Port)
+}
+
+// instanceHealthcheckExec runs the check command inside of the instance.
+func instanceHealthcheckExec(ctx context.Context, inst instance.Instance, command string) (string, error) {
+	output, err := os.CreateTemp("", "incus_healthcheck_")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = output.Close()
+		_ = os.Remove(output.Name())
+	}()
+
+	req := api.InstanceExecPost{
+		Command: []string{"sh", "-c", command},
+		Environment: map[string]string{
+			"PATH": "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+			"HOME": "/root",
+			"USER": "root",
+			"LANG": "C.UTF-8",
+		},
+	}
+
+	cmd, err := inst.Exec(req, nil, output, output)
+	if err != nil {
+		return "", err
+	}
+
+	type result struct {
+		exitStatus int
+		err        error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		exitStatus, err := cmd.Wait()
+		done <- result{exitStatus: exitStatus, err: err}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		_ = cmd.Signal(unix.SIGKILL)
+		return "", errors.New("Health check command timed out")
+	}
+
+	_, _ = output.Seek(0, io.SeekStart)
+	buf, _ := io.ReadAll(io.LimitReader(output, 4096))
+
+	if res.err != nil {
+		return string(buf), res.err
+	}
+
+	if res.exitStatus != 0 {
+		return string(buf), fmt.Errorf("Health check command exited with status %d", res.exitStatus)
+	}
+
+	return string(buf), nil
+}
+
+// instanceHealthcheckAddresses returns the global addresses of the instance, IPv4 addresses first.
+func instanceHealthcheckAddresses(inst instance.Instance) ([]string, error) {
+	hostInterfaces, _ := net.Interfaces()
+
+	instState, err := inst.RenderState(hostInterfaces)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting instance state: %w", err)
+	}
+
+	names := make([]string, 0, len(instState.Network))
+	for name := range instState.Network {
+		if name != "lo" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var inet, inet6 []string
+	for _, name := range names {
+		for _, addr := range instState.Network[name].Addresses {
+			if addr.Scope != "global" {
+				continue
+			}
+
+			if addr.Family == "inet" {
+				inet = append(inet, addr.Address)
+			} else {
+				inet6 = append(inet6, addr.Address)
+			}
+		}
+	}
+
+	addresses := slices.Concat(inet, inet6)
+	if len(addresses) == 0 {
+		return nil, errors.New("Instance doesn't have any global address")
+	}
+
+	return addresses, nil
+}
+
+// instanceHealthcheckRemediate restarts an unhealthy instance or moves it to another cluster member.
+func instanceHealthcheckRemediate(s *state.State, inst instance.Instance, action string) error {
+	// Moving the instance is only possible in a cluster and when it can be migrated at all.
+	if action == healthcheck.ActionEvacuate {
+		migrate := inst.CanMigrate()
+		if !s.ServerClustered || (migrate != "migrate" && migrate != "live-migrate") {
+			action = healthcheck.ActionRestart
+		}
+	}
+
+	opType := operationtype.InstanceRestart
+	if action == healthcheck.ActionEvacuate {
+		opType = operationtype.InstanceMigrate
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", inst.Name())}
+
+	run := func(op *operations.Operation) error {
+		inst.SetOperation(op)
+
+		// Get the shutdown timeout for the instance.
+		timeout, err := strconv.Atoi(inst.ExpandedConfig()["boot.host_shutdown_timeout"])
+		if err != nil {
+			timeout = evacuateHostShutdownDefaultTimeout
+		}
+
+		if action == healthcheck.ActionRestart {
+			err := inst.Restart(time.Duration(timeout) * time.Second)
+			if err != nil {
+				// The instance is hung, force the restart.
+				return inst.Restart(0)
+			}
+
+			return nil
+		}
+
+		sourceMemberInfo, targetMemberInfo, err := evacuateClusterSelectTarget(s.ShutdownCtx, s, inst)
+		if err != nil {
+			return err
+		}
+
+		// The instance isn't working properly, always do a cold migration.
+		err = evacuateStopInstance(inst, "stop")
+		if err != nil {
+			return err
+		}
+
+		return evacuateMigrateInstance(nil)(s.ShutdownCtx, s, inst, sourceMemberInfo, targetMemberInfo, false, true, op)
+	}
+
+	op, err := operations.OperationCreate(s, inst.Project().Name, operations.OperationClassTask, opType, resources, nil, run, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	err = op.Start()
+	if err != nil {
+		return err
+	}
+
+	return op.Wait(s.ShutdownCtx)
+}
@@ -662,6 +662,7 @@ func validLogFileName(fname string) bool {
 	 */
 	return slices.Contains([]string{"lxc.log", "qemu.log", "qemu.early.log", "qemu.qmp.log"}, fname) ||
 		strings.HasPrefix(fname, "migration_") ||
+		strings.HasPrefix(fname, "session_") ||
 		strings.HasPrefix(fname, "snapshot_")
 }
 
@@ -52,6 +52,8 @@ import (
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
+//	  "202":
+//	    $ref: "#/responses/Operation"
 //	  "400":
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
@@ -230,6 +232,19 @@ func instancePatch(d *Daemon, r *http.Request) response.Response {
 		Project:      projectName,
 	}
 
+	// Hold the update until approved if it makes the instance privileged.
+	approval, err := instancePrivilegedApprovalRequired(r.Context(), s, projectName, c.ExpandedConfig(), req.Config, apiProfiles)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if approval {
+		// Release the instance lock as it is taken again once approved.
+		unlock()
+
+		return instanceUpdateApprovalResponse(s, r, projectName, name, c.ETag(), args)
+	}
+
 	err = c.Update(args, true)
 	if err != nil {
 		return response.SmartError(err)
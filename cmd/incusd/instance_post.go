@@ -176,6 +176,19 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 			return response.SmartError(err)
 		}
 
+		// Moving the instance out of the project would escape its protection policy.
+		if req.Project != projectName {
+			p, err := projectProtectionLoad(r.Context(), s, projectName)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			err = project.AllowDeletion(p)
+			if err != nil {
+				return response.SmartError(err)
+			}
+		}
+
 		instProject = req.Project
 	}
 
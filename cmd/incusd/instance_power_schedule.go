@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	instanceDrivers "github.com/lxc/incus/v7/internal/server/instance/drivers"
+	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+// powerScheduleAliases contains the mapping of power schedule aliases to cron syntax.
+// Unlike snapshot schedules, power schedules aren't obfuscated so instances start and stop at predictable times.
+var powerScheduleAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@annually": "0 0 1 1 *",
+	"@yearly":   "0 0 1 1 *",
+}
+
+// powerScheduleIsNow returns whether any of the comma separated cron expressions in spec matches the current minute.
+func powerScheduleIsNow(spec string) bool {
+	for _, curSpec := range util.SplitNTrimSpace(strings.ToLower(spec), ", ", -1, true) {
+		alias, ok := powerScheduleAliases[curSpec]
+		if ok {
+			curSpec = alias
+		}
+
+		isNow, err := cronSpecIsNow(curSpec)
+		if err == nil && isNow {
+			return true
+		}
+	}
+
+	return false
+}
+
+func instancePowerScheduleTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		// Instances are only started or stopped by the cluster member they are located on.
+		instances, err := instance.LoadNodeAll(s, instancetype.Any)
+		if err != nil {
+			logger.Warn("Failed loading instances for power schedules", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, inst := range instances {
+			if inst.IsSnapshot() || util.IsTrue(inst.Project().Config["boot.schedule.suspended"]) {
+				continue
+			}
+
+			action := ""
+			schedule := ""
+
+			if inst.IsRunning() {
+				schedule = inst.ExpandedConfig()["boot.schedule.stop"]
+				if schedule != "" && powerScheduleIsNow(schedule) {
+					action = "stop"
+				}
+			} else {
+				schedule = inst.ExpandedConfig()["boot.schedule.start"]
+				if schedule != "" && powerScheduleIsNow(schedule) {
+					// Don't start instances on an evacuated cluster member.
+					if s.ServerClustered && s.DB.Cluster.LocalNodeIsEvacuated() {
+						continue
+					}
+
+					action = "start"
+				}
+			}
+
+			if action == "" {
+				continue
+			}
+
+			go func() {
+				err := instancePowerScheduleRun(s, inst, action, schedule)
+				if err != nil {
+					logger.Error("Failed running scheduled instance power action", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "action": action, "err": err})
+				}
+			}()
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
+
+// instancePowerScheduleRun starts or stops an instance as requested by its power schedule.
+func instancePowerScheduleRun(s *state.State, inst instance.Instance, action string, schedule string) error {
+	opType := operationtype.InstanceStart
+	if action == "stop" {
+		opType = operationtype.InstanceStop
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", inst.Name())}
+
+	run := func(op *operations.Operation) error {
+		inst.SetOperation(op)
+
+		if action == "start" {
+			err := inst.Start(false)
+			if err != nil {
+				return fmt.Errorf("Failed starting instance: %w", err)
+			}
+		} else {
+			// Get the shutdown timeout for the instance.
+			timeout, err := strconv.Atoi(inst.ExpandedConfig()["boot.host_shutdown_timeout"])
+			if err != nil {
+				timeout = evacuateHostShutdownDefaultTimeout
+			}
+
+			// Start with a clean shutdown and fallback to a forced stop.
+			err = inst.Shutdown(time.Duration(timeout) * time.Second)
+			if err != nil {
+				err = inst.Stop(false)
+				if err != nil && !errors.Is(err, instanceDrivers.ErrInstanceIsStopped) {
+					return fmt.Errorf("Failed stopping instance: %w", err)
+				}
+			}
+		}
+
+		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstancePowerScheduled.Event(inst, map[string]any{"action": action, "schedule": schedule}))
+
+		return nil
+	}
+
+	op, err := operations.OperationCreate(s, inst.Project().Name, operations.OperationClassTask, opType, resources, nil, run, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	err = op.Start()
+	if err != nil {
+		return err
+	}
+
+	return op.Wait(s.ShutdownCtx)
+}
@@ -161,21 +161,32 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 			return response.SmartError(err)
 		}
 
+		args := db.InstanceArgs{
+			Architecture: architecture,
+			Config:       configRaw.Config,
+			Description:  configRaw.Description,
+			Devices:      deviceConfig.NewDevices(configRaw.Devices),
+			Ephemeral:    configRaw.Ephemeral,
+			Profiles:     apiProfiles,
+			Project:      projectName,
+		}
+
+		// Hold the update until approved if it makes the instance privileged.
+		approval, err := instancePrivilegedApprovalRequired(r.Context(), s, projectName, inst.ExpandedConfig(), configRaw.Config, apiProfiles)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if approval {
+			// The instance lock is released on return and taken again once approved.
+			return instanceUpdateApprovalResponse(s, r, projectName, name, inst.ETag(), args)
+		}
+
 		// Update container configuration
 		do = func(op *operations.Operation) error {
 			inst.SetOperation(op)
 			defer unlock()
 
-			args := db.InstanceArgs{
-				Architecture: architecture,
-				Config:       configRaw.Config,
-				Description:  configRaw.Description,
-				Devices:      deviceConfig.NewDevices(configRaw.Devices),
-				Ephemeral:    configRaw.Ephemeral,
-				Profiles:     apiProfiles,
-				Project:      projectName,
-			}
-
 			err = inst.Update(args, true)
 			if err != nil {
 				return err
@@ -191,6 +202,29 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 			return response.BadRequest(errors.New("Cannot use option disk_only and stateful together on snapshot restore"))
 		}
 
+		// Hold the restore until approved if the snapshot's config makes the instance privileged.
+		if !configRaw.DiskOnly {
+			snapName := configRaw.Restore
+			if !internalInstance.IsSnapshot(snapName) {
+				snapName = name + internalInstance.SnapshotDelimiter + snapName
+			}
+
+			snap, err := instance.LoadByProjectAndName(s, projectName, snapName)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			approval, err := instancePrivilegedApprovalRequired(r.Context(), s, projectName, inst.ExpandedConfig(), snap.LocalConfig(), snap.Profiles())
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			if approval {
+				// The instance lock is released on return and taken again once approved.
+				return instanceRestoreApprovalResponse(s, r, projectName, name, configRaw)
+			}
+		}
+
 		do = func(op *operations.Operation) error {
 			defer unlock()
 
@@ -257,6 +291,11 @@ func instanceSnapRestore(s *state.State, projectName string, name string, snap s
 	// Generate a new `volatile.uuid.generation` to differentiate this instance restored from a snapshot from the original instance.
 	source.LocalConfig()["volatile.uuid.generation"] = uuid.New().String()
 
+	err = instanceSnapshotBeforeRestore(s, inst)
+	if err != nil {
+		return err
+	}
+
 	err = inst.Restore(source, stateful, diskOnly)
 	if err != nil {
 		return err
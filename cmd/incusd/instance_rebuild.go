@@ -13,6 +13,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/db/operationtype"
 	"github.com/lxc/incus/v7/internal/server/instance"
 	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/project"
 	"github.com/lxc/incus/v7/internal/server/request"
 	"github.com/lxc/incus/v7/internal/server/response"
 	"github.com/lxc/incus/v7/internal/version"
@@ -132,6 +133,11 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("Instance must be stopped to be rebuilt"))
 	}
 
+	err = project.AllowDeletion(targetProject)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	run := func(op *operations.Operation) error {
 		if req.Source.Type == "none" {
 			return instanceRebuildFromEmpty(inst, op)
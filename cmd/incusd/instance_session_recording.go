@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/recording"
+	"github.com/lxc/incus/v7/internal/server/lifecycle"
+	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+// sessionRecording is an in-progress recording of an exec or console session.
+// All of its methods are no-ops on a nil recording so callers don't need to check whether recording is enabled.
+type sessionRecording struct {
+	rec *recording.Recorder
+
+	id          string
+	sessionType string
+	fileName    string
+}
+
+// sessionRecordingEnabled returns whether the sessions of an instance must be recorded.
+func sessionRecordingEnabled(inst instance.Instance) bool {
+	return util.IsTrue(inst.ExpandedConfig()["security.session_recording"]) || util.IsTrue(inst.Project().Config["security.session_recording"])
+}
+
+// sessionRecordingStart starts recording a session of the given type if recording is enabled for the instance.
+// Returns nil if the session isn't recorded.
+func sessionRecordingStart(inst instance.Instance, id string, sessionType string, command []string, width int, height int) (*sessionRecording, error) {
+	if !sessionRecordingEnabled(inst) {
+		return nil, nil
+	}
+
+	fileName := fmt.Sprintf("session_%s_%s%s", sessionType, id, recording.FileExtension)
+
+	header := recording.Header{
+		Width:   width,
+		Height:  height,
+		Command: strings.Join(command, " "),
+		Title:   fmt.Sprintf("%s %s", inst.Name(), sessionType),
+	}
+
+	rec, err := recording.Create(filepath.Join(inst.LogPath(), fileName), header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionRecording{rec: rec, id: id, sessionType: sessionType, fileName: fileName}, nil
+}
+
+// Reader returns a reader recording everything read from rd as session output.
+func (r *sessionRecording) Reader(rd io.Reader) io.Reader {
+	if r == nil {
+		return rd
+	}
+
+	return r.rec.Reader(rd)
+}
+
+// Writer returns a writer recording everything written to w as session input.
+func (r *sessionRecording) Writer(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+
+	return r.rec.Writer(w)
+}
+
+// ReadWriteCloser returns a wrapper around rwc recording reads as session output and writes as session input.
+func (r *sessionRecording) ReadWriteCloser(rwc io.ReadWriteCloser) io.ReadWriteCloser {
+	if r == nil {
+		return rwc
+	}
+
+	return r.rec.ReadWriteCloser(rwc)
+}
+
+// Resize records a change of the terminal size.
+func (r *sessionRecording) Resize(width int, height int) {
+	if r == nil {
+		return
+	}
+
+	r.rec.Resize(width, height)
+}
+
+// Finish closes the recording and emits the lifecycle event for it.
+func (r *sessionRecording) Finish(s *state.State, inst instance.Instance) {
+	if r == nil {
+		return
+	}
+
+	err := r.rec.Close()
+	if err != nil {
+		logger.Error("Failed writing session recording", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "recording": r.id, "err": err})
+	}
+
+	logURL := api.NewURL().Path(version.APIVersion, "instances", inst.Name(), "logs", r.fileName).Project(inst.Project().Name)
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceSessionRecorded.Event(inst, logger.Ctx{"id": r.id, "type": r.sessionType, "log": logURL.String()}))
+}
@@ -832,6 +832,13 @@ func snapshotPost(s *state.State, r *http.Request, snapInst instance.Instance) r
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 func snapshotDelete(s *state.State, r *http.Request, snapInst instance.Instance) response.Response {
+	p := snapInst.Project()
+
+	err := project.AllowSnapshotDeletion(&p, snapInst.CreationDate())
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	remove := func(op *operations.Operation) error {
 		snapInst.SetOperation(op)
 		return snapInst.Delete(false, true)
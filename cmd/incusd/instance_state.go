@@ -187,6 +187,25 @@ func instanceStatePut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Resolve the instances this one depends on and check that they may be started too.
+	var dependencies []instance.Instance
+	if req.WithDependencies && internalInstance.InstanceAction(req.Action) == internalInstance.Start {
+		dependencies, err = instanceDependencyOrder(s, inst)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		names := make([]string, 0, len(dependencies))
+		for _, dep := range dependencies {
+			names = append(names, dep.Name())
+		}
+
+		err = instanceDependenciesCheckPermission(r.Context(), s.Authorizer, r, projectName, names)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Actually perform the change.
 	opType, err := instanceActionToOpType(req.Action)
 	if err != nil {
@@ -196,6 +215,14 @@ func instanceStatePut(d *Daemon, r *http.Request) response.Response {
 	do := func(op *operations.Operation) error {
 		inst.SetOperation(op)
 
+		// Start the instances this one depends on first.
+		if len(dependencies) > 0 {
+			err := instanceStartDependencies(s, r, inst, dependencies, op)
+			if err != nil {
+				return err
+			}
+		}
+
 		return doInstanceStatePut(inst, req)
 	}
 
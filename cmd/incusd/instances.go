@@ -345,7 +345,18 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 	instancesStartMu.Lock()
 	defer instancesStartMu.Unlock()
 
-	bulkInstances, sequentialInstances := bulkStartInstances(instances)
+	// Instances with dependencies are started last, once the instances they depend on were.
+	var dependentInstances []instance.Instance
+	independentInstances := make([]instance.Instance, 0, len(instances))
+	for _, inst := range instances {
+		if len(instanceDependencies(inst)) > 0 {
+			dependentInstances = append(dependentInstances, inst)
+		} else {
+			independentInstances = append(independentInstances, inst)
+		}
+	}
+
+	bulkInstances, sequentialInstances := bulkStartInstances(independentInstances)
 
 	// Limit the number of concurrent tasks.
 	numParallel := max(runtime.NumCPU()/4, 1)
@@ -370,6 +381,8 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 	for _, inst := range sequentialInstances {
 		_ = instanceStart(s, inst)
 	}
+
+	instancesStartDependent(s, dependentInstances)
 }
 
 type instanceStopList []instance.Instance
@@ -468,6 +481,23 @@ func instancesOnDisk(s *state.State) ([]instance.Instance, error) {
 func instancesShutdown(instances []instance.Instance) {
 	sort.Sort(instanceStopList(instances))
 
+	// Stop the instances which depend on others first, regardless of their stop priority.
+	levels, err := instancesDependencyLevels(instances)
+	if err != nil {
+		logger.Warn("Failed resolving instance dependencies", logger.Ctx{"err": err})
+	}
+
+	instLevels := make(map[instance.Instance]int, len(instances))
+	for level, levelInstances := range levels {
+		for _, inst := range levelInstances {
+			instLevels[inst] = level
+		}
+	}
+
+	sort.SliceStable(instances, func(i, j int) bool {
+		return instLevels[instances[i]] > instLevels[instances[j]]
+	})
+
 	// Limit shutdown concurrency to number of instances or number of CPU cores (which ever is less).
 	var wg sync.WaitGroup
 	instShutdownCh := make(chan instance.Instance)
@@ -557,7 +587,7 @@ func instancesShutdown(instances []instance.Instance) {
 		}(instShutdownCh)
 	}
 
-	var currentBatchPriority int
+	var currentBatchPriority, currentBatchLevel int
 	for i, inst := range instances {
 		// Skip stopped instances.
 		if !inst.IsRunning() {
@@ -566,9 +596,10 @@ func instancesShutdown(instances []instance.Instance) {
 
 		priority, _ := strconv.Atoi(inst.ExpandedConfig()["boot.stop.priority"])
 
-		// Shutdown instances in priority batches, logging at the start of each batch.
-		if i == 0 || priority != currentBatchPriority {
+		// Shutdown instances in dependency level and priority batches, logging at the start of each batch.
+		if i == 0 || priority != currentBatchPriority || instLevels[inst] != currentBatchLevel {
 			currentBatchPriority = priority
+			currentBatchLevel = instLevels[inst]
 
 			// Wait for instances with higher priority to finish before starting next batch.
 			wg.Wait()
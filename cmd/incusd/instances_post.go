@@ -147,6 +147,11 @@ func createFromImage(s *state.State, r *http.Request, p api.Project, profiles []
 		return instanceCreateFinish(s, req, args, op)
 	}
 
+	approval, err := instancePrivilegedApprovalRequired(r.Context(), s, p.Name, nil, req.Config, profiles)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	resources := map[string][]api.URL{}
 	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", req.Name)}
 
@@ -155,6 +160,10 @@ func createFromImage(s *state.State, r *http.Request, p api.Project, profiles []
 		return response.InternalError(err)
 	}
 
+	if approval {
+		return approvalOperationResponse(s, r, op, project.ApprovalInstancesPrivileged, fmt.Sprintf("Creating privileged instance %q", req.Name))
+	}
+
 	return operations.OperationResponse(op)
 }
 
@@ -200,6 +209,11 @@ func createFromNone(s *state.State, r *http.Request, projectName string, profile
 		return instanceCreateFinish(s, req, args, op)
 	}
 
+	approval, err := instancePrivilegedApprovalRequired(r.Context(), s, projectName, nil, req.Config, profiles)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	resources := map[string][]api.URL{}
 	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", req.Name)}
 
@@ -208,6 +222,10 @@ func createFromNone(s *state.State, r *http.Request, projectName string, profile
 		return response.InternalError(err)
 	}
 
+	if approval {
+		return approvalOperationResponse(s, r, op, project.ApprovalInstancesPrivileged, fmt.Sprintf("Creating privileged instance %q", req.Name))
+	}
+
 	return operations.OperationResponse(op)
 }
 
@@ -236,6 +254,16 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 		return response.BadRequest(fmt.Errorf("Instance type not supported %q", req.Type))
 	}
 
+	// Migrations can't be held until approved as the source is waiting on them.
+	approval, err := instancePrivilegedApprovalRequired(ctx, s, projectName, nil, req.Config, profiles)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if approval {
+		return response.Forbidden(errors.New("Privileged instances in this project require approval and can't be migrated in"))
+	}
+
 	// Prepare the instance creation request.
 	args := db.InstanceArgs{
 		Project:      projectName,
@@ -760,6 +788,11 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 		return instanceCreateFinish(s, req, args, op)
 	}
 
+	approval, err := instancePrivilegedApprovalRequired(r.Context(), s, targetProject, nil, req.Config, profiles)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	resources := map[string][]api.URL{}
 	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", req.Name), *api.NewURL().Path(version.APIVersion, "instances", req.Source.Source)}
 
@@ -768,6 +801,10 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 		return response.InternalError(err)
 	}
 
+	if approval {
+		return approvalOperationResponse(s, r, op, project.ApprovalInstancesPrivileged, fmt.Sprintf("Copying privileged instance %q", req.Name))
+	}
+
 	return operations.OperationResponse(op)
 }
 
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lxc/incus/v7/internal/server/audit"
+	internalUtil "github.com/lxc/incus/v7/internal/util"
+)
+
+type cmdAudit struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra command for inclusion.
+func (c *cmdAudit) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "audit"
+	cmd.Short = "Audit log administration commands"
+	cmd.Long = `Description:
+  Tools for inspecting the API audit log.
+`
+
+	// Verify
+	verify := cmdAuditVerify{global: c.global}
+	cmd.AddCommand(verify.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+type cmdAuditVerify struct {
+	global *cmdGlobal
+
+	flagKey string
+}
+
+func (c *cmdAuditVerify) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "verify [<path>...]"
+	cmd.Short = "Verify the integrity of the audit log"
+	cmd.Long = `Description:
+  Verify the integrity of the audit log.
+
+  The files are checked as a single chain of records, from the oldest to the
+  most recent one. When no path is given, the local audit log and its rotated
+  files are checked.
+
+  The records are authenticated with the key of the server which wrote them,
+  read from the local server unless another key file is given.
+`
+	cmd.Flags().StringVar(&c.flagKey, "key", "", "Path to the audit key"+"``")
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdAuditVerify) run(_ *cobra.Command, args []string) error {
+	paths := args
+	if len(paths) == 0 {
+		path := internalUtil.LogPath("audit.log")
+		paths = []string{path}
+
+		for i := 1; ; i++ {
+			rotated := fmt.Sprintf("%s.%d", path, i)
+			_, err := os.Stat(rotated)
+			if err != nil {
+				break
+			}
+
+			paths = append(paths, rotated)
+		}
+
+		slices.Reverse(paths)
+	}
+
+	keyPath := c.flagKey
+	if keyPath == "" {
+		keyPath = internalUtil.VarPath("audit.key")
+	}
+
+	key, err := audit.LoadKey(keyPath)
+	if err != nil {
+		return err
+	}
+
+	verifier := &audit.Verifier{Key: key}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			if len(args) == 0 && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return err
+		}
+
+		err = verifier.Verify(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("Audit log %q failed verification: %w", path, err)
+		}
+	}
+
+	fmt.Printf("Verified %d audit records\n", verifier.Count)
+
+	return nil
+}
@@ -36,6 +36,10 @@ func (c *cmdAdmin) command() *cobra.Command {
 	cmd.Hidden = true
 	cmd.Use = "admin"
 
+	// Audit
+	auditCmd := cmdAudit{global: c.global}
+	cmd.AddCommand(auditCmd.command())
+
 	// Cluster
 	clusterCmd := cmdCluster{global: c.global}
 	cmd.AddCommand(clusterCmd.command())
@@ -42,9 +42,15 @@ func newMigrationSource(inst instance.Instance, stateful bool, instanceOnly bool
 	secretNames := []string{api.SecretNameControl, api.SecretNameFilesystem}
 	if stateful && inst.IsRunning() {
 		if inst.Type() == instancetype.Container {
-			_, err := exec.LookPath("criu")
+			c, ok := inst.(instance.Container)
+			if !ok {
+				return nil, errors.New("Instance is not a container")
+			}
+
+			// Check that the container can be live-migrated before anything happens to it.
+			err := c.LiveMigrationCheck()
 			if err != nil {
-				return nil, migration.ErrNoLiveMigrationSource
+				return nil, err
 			}
 		}
 
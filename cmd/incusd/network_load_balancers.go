@@ -360,6 +360,16 @@ func networkLoadBalancersPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Network driver %q does not support load balancers", n.Type()))
 	}
 
+	// Check the project network address limit, cluster notifications were already checked by the originating member.
+	if !isClusterNotification(r) {
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return project.AllowNetworkAddressCreation(tx, projectName)
+		})
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
 
 	err = n.LoadBalancerCreate(req, clientType)
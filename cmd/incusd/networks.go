@@ -526,31 +526,47 @@ func networksPost(d *Daemon, r *http.Request) response.Response {
 		return resp
 	}
 
+	n, err := networksCreate(r.Context(), s, projectName, req, netType, clientType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(projectName, lifecycle.NetworkCreated.Event(n, requestor, nil))
+
+	return resp
+}
+
+// networksCreate creates a new network on all cluster members, or finalizes the creation of a network which
+// was previously defined on each of them.
+func networksCreate(ctx context.Context, s *state.State, projectName string, req api.NetworksPost, netType network.Type, clientType clusterRequest.ClientType) (network.Network, error) {
 	var netInfo *api.Network
 
-	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
 		// Load existing network if exists, if not don't fail.
 		_, netInfo, _, err = tx.GetNetworkInAnyState(ctx, projectName, req.Name)
 
 		return err
 	})
 	if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
-		return response.InternalError(err)
+		return nil, err
 	}
 
 	// Check if we're clustered.
 	count, err := cluster.Count(s)
 	if err != nil {
-		return response.SmartError(err)
+		return nil, err
 	}
 
 	// No targetNode was specified and we're clustered or there is an existing partially created single node
 	// network, either way finalize the config in the db and actually create the network on all cluster nodes.
 	if count > 1 || (netInfo != nil && netInfo.Status != api.NetworkStatusCreated) {
 		// Simulate adding pending node network config when the driver doesn't support per-node config.
-		if !netTypeInfo.NodeSpecificConfig && clientType != clusterRequest.ClientTypeJoiner {
+		if !netType.Info().NodeSpecificConfig && clientType != clusterRequest.ClientTypeJoiner {
 			// Create pending entry for each node.
-			err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 				members, err := tx.GetNodes(ctx)
 				if err != nil {
 					return fmt.Errorf("Failed getting cluster members: %w", err)
@@ -568,35 +584,32 @@ func networksPost(d *Daemon, r *http.Request) response.Response {
 				return nil
 			})
 			if err != nil {
-				return response.SmartError(err)
+				return nil, err
 			}
 
 			// Create the authorization entry and advertise the network as existing.
-			err = s.Authorizer.AddNetwork(r.Context(), projectName, req.Name)
+			err = s.Authorizer.AddNetwork(ctx, projectName, req.Name)
 			if err != nil {
 				logger.Error("Failed to add network to authorizer", logger.Ctx{"name": req.Name, "project": projectName, "error": err})
 			}
 		}
 
-		err = networksPostCluster(r.Context(), s, projectName, netInfo, req, clientType, netType)
+		err = networksPostCluster(ctx, s, projectName, netInfo, req, clientType, netType)
 		if err != nil {
-			return response.SmartError(err)
+			return nil, err
 		}
 
 		n, err := network.LoadByName(s, projectName, req.Name)
 		if err != nil {
-			return response.SmartError(fmt.Errorf("Failed loading network: %w", err))
+			return nil, fmt.Errorf("Failed loading network: %w", err)
 		}
 
-		requestor := request.CreateRequestor(r)
-		s.Events.SendLifecycle(projectName, lifecycle.NetworkCreated.Event(n, requestor, nil))
-
-		return resp
+		return n, nil
 	}
 
 	// Non-clustered network creation.
 	if netInfo != nil {
-		return response.Conflict(fmt.Errorf("Network %q already exists", req.Name))
+		return nil, api.StatusErrorf(http.StatusConflict, "Network %q already exists", req.Name)
 	}
 
 	reverter := revert.New()
@@ -606,46 +619,43 @@ func networksPost(d *Daemon, r *http.Request) response.Response {
 	if clientType != clusterRequest.ClientTypeJoiner {
 		err = netType.FillConfig(req.Config)
 		if err != nil {
-			return response.SmartError(err)
+			return nil, err
 		}
 	}
 
-	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		// Create the database entry.
 		_, err = tx.CreateNetwork(ctx, projectName, req.Name, req.Description, netType.DBType(), req.Config)
 
 		return err
 	})
 	if err != nil {
-		return response.SmartError(fmt.Errorf("Error inserting %q into database: %w", req.Name, err))
+		return nil, fmt.Errorf("Error inserting %q into database: %w", req.Name, err)
 	}
 
 	reverter.Add(func() {
-		_ = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_ = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 			return tx.DeleteNetwork(ctx, projectName, req.Name)
 		})
 	})
 
 	n, err := network.LoadByName(s, projectName, req.Name)
 	if err != nil {
-		return response.SmartError(fmt.Errorf("Failed loading network: %w", err))
+		return nil, fmt.Errorf("Failed loading network: %w", err)
 	}
 
-	err = doNetworksCreate(r.Context(), s, n, clientType)
+	err = doNetworksCreate(ctx, s, n, clientType)
 	if err != nil {
-		return response.SmartError(err)
+		return nil, err
 	}
 
-	err = s.Authorizer.AddNetwork(r.Context(), projectName, req.Name)
+	err = s.Authorizer.AddNetwork(ctx, projectName, req.Name)
 	if err != nil {
 		logger.Error("Failed to add network to authorizer", logger.Ctx{"name": req.Name, "project": projectName, "error": err})
 	}
 
-	requestor := request.CreateRequestor(r)
-	s.Events.SendLifecycle(projectName, lifecycle.NetworkCreated.Event(n, requestor, nil))
-
 	reverter.Success()
-	return resp
+	return n, nil
 }
 
 // networkPartiallyCreated returns true of supplied network has properties that indicate it has had previous
@@ -1195,40 +1205,49 @@ func networkDelete(d *Daemon, r *http.Request) response.Response {
 		return response.EmptySyncResponse
 	}
 
+	err = networkDeleteCluster(r.Context(), s, n)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(projectName, lifecycle.NetworkDeleted.Event(n, requestor, nil))
+
+	return response.EmptySyncResponse
+}
+
+// networkDeleteCluster deletes the network from the other cluster members and removes it from the database once
+// it has been deleted locally.
+func networkDeleteCluster(ctx context.Context, s *state.State, n network.Network) error {
 	// If we are clustered, also notify all other nodes, if any.
 	if s.ServerClustered {
 		notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAll)
 		if err != nil {
-			return response.SmartError(err)
+			return err
 		}
 
 		err = notifier(func(client incus.InstanceServer) error {
 			return client.UseProject(n.Project()).DeleteNetwork(n.Name())
 		})
 		if err != nil {
-			return response.SmartError(err)
+			return err
 		}
 	}
 
-	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		// Remove the network from the database.
-		err = tx.DeleteNetwork(ctx, n.Project(), n.Name())
-
-		return err
+		return tx.DeleteNetwork(ctx, n.Project(), n.Name())
 	})
 	if err != nil {
-		return response.SmartError(err)
+		return err
 	}
 
-	err = s.Authorizer.DeleteNetwork(r.Context(), projectName, networkName)
+	err = s.Authorizer.DeleteNetwork(ctx, n.Project(), n.Name())
 	if err != nil {
-		logger.Error("Failed to remove network from authorizer", logger.Ctx{"name": networkName, "project": projectName, "error": err})
+		logger.Error("Failed to remove network from authorizer", logger.Ctx{"name": n.Name(), "project": n.Project(), "error": err})
 	}
 
-	requestor := request.CreateRequestor(r)
-	s.Events.SendLifecycle(projectName, lifecycle.NetworkDeleted.Event(n, requestor, nil))
-
-	return response.EmptySyncResponse
+	return nil
 }
 
 // swagger:operation POST /1.0/networks/{name} networks network_post
@@ -23,6 +23,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/project"
 	"github.com/lxc/incus/v7/internal/server/request"
 	"github.com/lxc/incus/v7/internal/server/response"
+	"github.com/lxc/incus/v7/internal/server/state"
 	localUtil "github.com/lxc/incus/v7/internal/server/util"
 	"github.com/lxc/incus/v7/internal/version"
 	"github.com/lxc/incus/v7/shared/api"
@@ -603,27 +604,43 @@ func profilePut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	err = doProfileUpdate(r.Context(), s, *p, name, profile, req)
+	// Hold the update until approved if it makes instances using the profile privileged.
+	approval, err := profilePrivilegedApprovalRequired(r.Context(), s, p.Name, name, req.Config)
+	if err != nil {
+		return response.SmartError(err)
+	}
 
-	if err == nil && !isClusterNotification(r) {
-		// Notify all other nodes. If a node is down, it will be ignored.
-		notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
-		if err != nil {
-			return response.SmartError(err)
-		}
+	if approval {
+		return profileUpdateApprovalResponse(s, r, *p, name, etag, req)
+	}
 
-		err = notifier(func(client incus.InstanceServer) error {
-			return client.UseProject(p.Name).UpdateProfile(name, profile.ProfilePut, "")
-		})
-		if err != nil {
-			return response.SmartError(err)
-		}
+	err = profileUpdate(r.Context(), s, *p, name, profile, req)
+	if err != nil {
+		return response.SmartError(err)
 	}
 
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(p.Name, lifecycle.ProfileUpdated.Event(name, p.Name, requestor, nil))
 
-	return response.SmartError(err)
+	return response.EmptySyncResponse
+}
+
+// profileUpdate updates the profile and notifies the other cluster members about the change.
+func profileUpdate(ctx context.Context, s *state.State, p api.Project, name string, profile *api.Profile, req api.ProfilePut) error {
+	err := doProfileUpdate(ctx, s, p, name, profile, req)
+	if err != nil {
+		return err
+	}
+
+	// Notify all other nodes. If a node is down, it will be ignored.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return err
+	}
+
+	return notifier(func(client incus.InstanceServer) error {
+		return client.UseProject(p.Name).UpdateProfile(name, profile.ProfilePut, "")
+	})
 }
 
 // swagger:operation PATCH /1.0/profiles/{name} profiles profile_patch
@@ -754,6 +771,16 @@ func profilePatch(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	// Hold the update until approved if it makes instances using the profile privileged.
+	approval, err := profilePrivilegedApprovalRequired(r.Context(), s, p.Name, name, req.Config)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if approval {
+		return profileUpdateApprovalResponse(s, r, *p, name, etag, req)
+	}
+
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(p.Name, lifecycle.ProfileUpdated.Event(name, p.Name, requestor, nil))
 
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v7/internal/instance"
+	"github.com/lxc/incus/v7/internal/server/db"
+	dbCluster "github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/project"
+	"github.com/lxc/incus/v7/internal/server/state"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// projectProtectionLoad loads the project whose protection policy applies to the resources it stores.
+func projectProtectionLoad(ctx context.Context, s *state.State, projectName string) (*api.Project, error) {
+	var p *api.Project
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", projectName, err)
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// instanceDeleteAllowed returns an error if the protection policy of the project prevents deleting the
+// instance, either directly or because its snapshots are too recent.
+func instanceDeleteAllowed(inst instance.Instance) error {
+	p := inst.Project()
+
+	err := project.AllowDeletion(&p)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := inst.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		err = project.AllowSnapshotDeletion(&p, snapshot.CreationDate())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storageVolumeDeleteAllowed returns an error if the protection policy of the project prevents deleting the
+// custom volume, either directly or because its snapshots are too recent.
+func storageVolumeDeleteAllowed(ctx context.Context, s *state.State, p *api.Project, poolID int64, volumeName string) error {
+	err := project.AllowDeletion(p)
+	if err != nil {
+		return err
+	}
+
+	var snapshots []db.StorageVolumeArgs
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		snapshots, err = tx.GetLocalStoragePoolVolumeSnapshotsWithType(ctx, p.Name, volumeName, db.StoragePoolVolumeTypeCustom, poolID)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		err = project.AllowSnapshotDeletion(p, snapshot.CreationDate)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// instanceSnapshotBeforeRestore takes a snapshot of the instance before restoring it if the protection policy
+// of the project requires it.
+func instanceSnapshotBeforeRestore(s *state.State, inst instance.Instance) error {
+	p := inst.Project()
+	if !project.SnapshotBeforeRestore(&p) {
+		return nil
+	}
+
+	snapshotName, err := instance.NextSnapshotName(s, inst, "snap%d")
+	if err != nil {
+		return err
+	}
+
+	expiry, err := internalInstance.GetExpiry(time.Now(), inst.ExpandedConfig()["snapshots.expiry"])
+	if err != nil {
+		return err
+	}
+
+	err = inst.Snapshot(snapshotName, expiry, false)
+	if err != nil {
+		return fmt.Errorf("Failed taking snapshot %q before restore: %w", snapshotName, err)
+	}
+
+	return nil
+}
+
+// storageVolumeSnapshotBeforeRestore takes a snapshot of the custom volume before restoring it if the
+// protection policy of the project requires it.
+func storageVolumeSnapshotBeforeRestore(ctx context.Context, s *state.State, pool storagePools.Pool, p *api.Project, dbVolume *db.StorageVolume, op *operations.Operation) error {
+	if !project.SnapshotBeforeRestore(p) {
+		return nil
+	}
+
+	volume := db.StorageVolumeArgs{
+		Name:        dbVolume.Name,
+		PoolName:    pool.Name(),
+		ProjectName: p.Name,
+		Config:      dbVolume.Config,
+	}
+
+	snapshotName, err := volumeDetermineNextSnapshotName(ctx, s, volume, "snap%d")
+	if err != nil {
+		return err
+	}
+
+	expiry, err := internalInstance.GetExpiry(time.Now(), dbVolume.Config["snapshots.expiry"])
+	if err != nil {
+		return err
+	}
+
+	err = pool.CreateCustomVolumeSnapshot(p.Name, dbVolume.Name, snapshotName, expiry, false, op)
+	if err != nil {
+		return fmt.Errorf("Failed taking snapshot %q before restore: %w", snapshotName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/db"
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/state"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	"github.com/lxc/incus/v7/internal/server/storage/s3/local"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+// localBucketLifecycle returns the lifecycle rules configured on a local storage bucket.
+func localBucketLifecycle(config map[string]string) local.LifecycleRules {
+	rules := local.LifecycleRules{}
+
+	rules.ExpirationDays, _ = strconv.Atoi(config["lifecycle.expiration"])
+	rules.NoncurrentExpirationDays, _ = strconv.Atoi(config["lifecycle.noncurrent_expiration"])
+	rules.NoncurrentVersions, _ = strconv.Atoi(config["lifecycle.noncurrent_versions"])
+
+	return rules
+}
+
+// storageBucketsApplyLifecycle applies the lifecycle rules of the local storage buckets on this server.
+func storageBucketsApplyLifecycle(ctx context.Context, s *state.State, op *operations.Operation) error {
+	var buckets []*db.StorageBucket
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		buckets, err = tx.GetStoragePoolBuckets(ctx, true)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading storage buckets: %w", err)
+	}
+
+	now := time.Now()
+
+	for _, bucket := range buckets {
+		// Buckets on remote storage pools aren't served by the built-in S3 server.
+		if bucket.Location == "" {
+			continue
+		}
+
+		rules := localBucketLifecycle(bucket.Config)
+		if rules.Empty() {
+			continue
+		}
+
+		l := logger.AddContext(logger.Ctx{"project": bucket.Project, "pool": bucket.PoolName, "bucket": bucket.Name})
+
+		pool, err := storagePools.LoadByName(s, bucket.PoolName)
+		if err != nil {
+			l.Warn("Failed loading storage pool", logger.Ctx{"err": err})
+			continue
+		}
+
+		bucketDir, unmount, err := pool.MountLocalBucket(bucket.Project, bucket.Name, op)
+		if err != nil {
+			l.Warn("Failed mounting storage bucket", logger.Ctx{"err": err})
+			continue
+		}
+
+		srv := local.NewServer(bucketDir, nil)
+		srv.Versioning = util.IsTrue(bucket.Config["versioning"])
+		srv.Lifecycle = rules
+
+		err = srv.ApplyLifecycle(now)
+		if err != nil {
+			l.Warn("Failed applying storage bucket lifecycle rules", logger.Ctx{"err": err})
+		}
+
+		err = unmount()
+		if err != nil {
+			l.Warn("Failed unmounting storage bucket", logger.Ctx{"err": err})
+		}
+	}
+
+	return nil
+}
+
+func storageBucketsLifecycleTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		opRun := func(op *operations.Operation) error {
+			return storageBucketsApplyLifecycle(ctx, s, op)
+		}
+
+		op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.StorageBucketsLifecycle, nil, nil, opRun, nil, nil, nil)
+		if err != nil {
+			logger.Error("Failed creating storage bucket lifecycle operation", logger.Ctx{"err": err})
+			return
+		}
+
+		logger.Debug("Applying storage bucket lifecycle rules")
+		err = op.Start()
+		if err != nil {
+			logger.Error("Failed starting storage bucket lifecycle operation", logger.Ctx{"err": err})
+			return
+		}
+
+		err = op.Wait(ctx)
+		if err != nil {
+			logger.Error("Failed applying storage bucket lifecycle rules", logger.Ctx{"err": err})
+			return
+		}
+
+		logger.Debug("Done applying storage bucket lifecycle rules")
+	}
+
+	return f, task.Daily()
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/db"
+	"github.com/lxc/incus/v7/internal/server/project"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	"github.com/lxc/incus/v7/internal/server/storage/s3/local"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Limits on the validity of presigned URLs (matching the SigV4 limits).
+const (
+	bucketPresignDefaultExpiry = time.Hour
+	bucketPresignMaxExpiry     = 7 * 24 * time.Hour
+)
+
+var storagePoolBucketPresignCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/buckets/{bucketName}/presign",
+
+	Post: APIEndpointAction{Handler: storagePoolBucketPresignPost, AccessHandler: allowPermission(auth.ObjectTypeStorageBucket, auth.EntitlementCanView, "poolName", "bucketName", "location")},
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/buckets/{bucketName}/presign storage storage_pool_bucket_presign_post
+//
+//	Generate a presigned object URL
+//
+//	Generates a time-limited URL which allows accessing a single object of the
+//	bucket without credentials.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: poolName
+//	    description: Storage pool name
+//	    type: string
+//	    required: true
+//	  - in: path
+//	    name: bucketName
+//	    description: Storage bucket name
+//	    type: string
+//	    required: true
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: presign
+//	    description: Presigned URL request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/StorageBucketPresignPost"
+//	responses:
+//	  "200":
+//	    description: Presigned URL
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/StorageBucketPresign"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolBucketPresignPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	bucketProjectName, err := project.StorageBucketProject(r.Context(), s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.StorageBucketPresignPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Object == "" || strings.HasPrefix(req.Object, "/") {
+		return response.BadRequest(fmt.Errorf("Invalid object name %q", req.Object))
+	}
+
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+
+	req.Method = strings.ToUpper(req.Method)
+	if req.Method != http.MethodGet && req.Method != http.MethodPut {
+		return response.BadRequest(fmt.Errorf("Unsupported method %q (must be GET or PUT)", req.Method))
+	}
+
+	// Presigned PUT URLs allow overwriting objects, so they require the permission to edit the bucket.
+	if req.Method == http.MethodPut {
+		resp := allowPermission(auth.ObjectTypeStorageBucket, auth.EntitlementCanEdit, "poolName", "bucketName", "location")(d, r)
+		if resp != response.EmptySyncResponse {
+			return resp
+		}
+	}
+
+	poolName, err := pathVar(r, "poolName")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
+	}
+
+	if !pool.Driver().Info().Buckets {
+		return response.BadRequest(errors.New("Storage pool does not support buckets"))
+	}
+
+	// Only the built-in S3 server understands the signatures generated here.
+	if pool.Driver().Info().Remote {
+		return response.BadRequest(errors.New("Presigned URLs are only supported on local storage pools"))
+	}
+
+	bucketName, err := pathVar(r, "bucketName")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	expiry := bucketPresignDefaultExpiry
+	if req.Expiry != 0 {
+		expiry = time.Duration(req.Expiry) * time.Second
+	}
+
+	if expiry <= 0 || expiry > bucketPresignMaxExpiry {
+		return response.BadRequest(fmt.Errorf("Expiry must be between 1 second and %d seconds", int64(bucketPresignMaxExpiry/time.Second)))
+	}
+
+	targetMember := request.QueryParam(r, "target")
+	memberSpecific := targetMember != ""
+
+	var bucket *db.StorageBucket
+	var bucketKeys []*db.StorageBucketKey
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err = tx.GetStoragePoolBucket(ctx, pool.ID(), bucketProjectName, memberSpecific, bucketName)
+		if err != nil {
+			return fmt.Errorf("Failed loading storage bucket: %w", err)
+		}
+
+		bucketKeys, err = tx.GetStoragePoolBucketKeys(ctx, bucket.ID)
+		if err != nil {
+			return fmt.Errorf("Failed loading storage bucket keys: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	key, err := bucketPresignKey(bucketKeys, req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	u := pool.GetBucketURL(bucket.Name)
+	if u == nil {
+		return response.InternalError(errors.New("Storage bucket URL isn't available"))
+	}
+
+	u = u.JoinPath(req.Object)
+
+	now := time.Now()
+	signed := local.PresignURL(u, req.Method, key.AccessKey, key.SecretKey, "us-east-1", expiry, now)
+
+	return response.SyncResponse(true, api.StorageBucketPresign{
+		URL:       signed.String(),
+		ExpiresAt: now.Add(expiry),
+	})
+}
+
+// bucketPresignKey returns the bucket key used to sign a presigned URL request.
+// When no key is requested, the first key allowed to perform the request is used.
+func bucketPresignKey(keys []*db.StorageBucketKey, req api.StorageBucketPresignPost) (*db.StorageBucketKey, error) {
+	allowed := func(key *db.StorageBucketKey) bool {
+		if req.Method == http.MethodPut && key.Role != string(local.RoleAdmin) {
+			return false
+		}
+
+		return strings.HasPrefix(req.Object, key.Prefix)
+	}
+
+	for _, key := range keys {
+		if req.Key != "" {
+			if key.Name != req.Key {
+				continue
+			}
+
+			if !allowed(key) {
+				return nil, fmt.Errorf("Bucket key %q isn't allowed to %s %q", key.Name, req.Method, req.Object)
+			}
+
+			return key, nil
+		}
+
+		if allowed(key) {
+			return key, nil
+		}
+	}
+
+	if req.Key != "" {
+		return nil, fmt.Errorf("Bucket key %q not found", req.Key)
+	}
+
+	return nil, fmt.Errorf("No bucket key is allowed to %s %q", req.Method, req.Object)
+}
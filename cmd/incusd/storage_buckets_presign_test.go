@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// presignTestAuthorizer only allows viewing objects.
+type presignTestAuthorizer struct {
+	auth.Authorizer
+}
+
+func (a *presignTestAuthorizer) CheckPermission(ctx context.Context, r *http.Request, object auth.Object, entitlement auth.Entitlement) error {
+	if entitlement == auth.EntitlementCanView {
+		return nil
+	}
+
+	return api.StatusErrorf(http.StatusForbidden, "Permission denied")
+}
+
+type presignTestSuite struct {
+	daemonTestSuite
+}
+
+// Test that generating a presigned PUT URL requires the permission to edit the bucket.
+func (s *presignTestSuite) TestPresignPut_ViewOnly() {
+	s.d.authorizer = &presignTestAuthorizer{Authorizer: s.d.authorizer}
+
+	body := bytes.NewBufferString(`{"object": "foo", "method": "PUT"}`)
+	r := httptest.NewRequest(http.MethodPost, "/1.0/storage-pools/"+daemonTestSuiteDefaultStoragePool+"/buckets/b1/presign", body)
+	r.SetPathValue("poolName", daemonTestSuiteDefaultStoragePool)
+	r.SetPathValue("bucketName", "b1")
+
+	resp := storagePoolBucketPresignPost(s.d, r)
+
+	w := httptest.NewRecorder()
+	s.Req.NoError(resp.Render(w))
+	s.Req.Equal(http.StatusForbidden, w.Code)
+}
+
+func TestPresignTestSuite(t *testing.T) {
+	suite.Run(t, &presignTestSuite{})
+}
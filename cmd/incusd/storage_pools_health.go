@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/db"
+	"github.com/lxc/incus/v7/internal/server/db/cluster"
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/db/warningtype"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/response"
+	"github.com/lxc/incus/v7/internal/server/state"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v7/internal/server/storage/drivers"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/internal/server/warnings"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+var storagePoolHealthCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/health",
+
+	Get: APIEndpointAction{Handler: storagePoolHealthGet, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanView, "poolName")},
+}
+
+var storagePoolScrubCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/scrub",
+
+	Post: APIEndpointAction{Handler: storagePoolScrubPost, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanEdit, "poolName")},
+}
+
+// swagger:operation GET /1.0/storage-pools/{poolName}/health storage storage_pool_health_get
+//
+//	Get the storage pool health
+//
+//	Gets the health of the storage pool, including device errors and scrub status.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: poolName
+//	    description: Storage pool name
+//	    type: string
+//	    required: true
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Storage pool health
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/StoragePoolHealth"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolHealthGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := pathVar(r, "poolName")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	health, err := pool.Health()
+	if err != nil {
+		if errors.Is(err, storageDrivers.ErrNotSupported) {
+			return response.NotImplemented(fmt.Errorf("Storage pool driver %q doesn't support health reporting", pool.Driver().Info().Name))
+		}
+
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, health)
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/scrub storage storage_pool_scrub_post
+//
+//	Scrub the storage pool
+//
+//	Starts a scrub (data integrity check) of the storage pool.
+//	The operation completes once the scrub has been started, its progress
+//	can then be tracked through the storage pool health.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: poolName
+//	    description: Storage pool name
+//	    type: string
+//	    required: true
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolScrubPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := pathVar(r, "poolName")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	run := func(op *operations.Operation) error {
+		err := pool.Scrub(op)
+		if errors.Is(err, storageDrivers.ErrNotSupported) {
+			return fmt.Errorf("Storage pool driver %q doesn't support scrubbing", pool.Driver().Info().Name)
+		}
+
+		return err
+	}
+
+	resources := map[string][]api.URL{}
+	resources["storage_pools"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName)}
+
+	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.StoragePoolScrub, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// storagePoolsHealthCheck checks the health of all local storage pools and raises or resolves warnings.
+func storagePoolsHealthCheck(ctx context.Context, s *state.State) error {
+	var poolNames []string
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+
+		return err
+	})
+	if err != nil {
+		if response.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed loading storage pools: %w", err)
+	}
+
+	for _, poolName := range poolNames {
+		pool, err := storagePools.LoadByName(s, poolName)
+		if err != nil {
+			logger.Warn("Failed loading storage pool", logger.Ctx{"pool": poolName, "err": err})
+			continue
+		}
+
+		// Skip pools that aren't available on this server.
+		if pool.LocalStatus() != api.StoragePoolStatusCreated {
+			continue
+		}
+
+		health, err := pool.Health()
+		if err != nil {
+			if !errors.Is(err, storageDrivers.ErrNotSupported) {
+				logger.Warn("Failed getting storage pool health", logger.Ctx{"pool": poolName, "err": err})
+			}
+
+			continue
+		}
+
+		switch health.Status {
+		case api.StoragePoolHealthStatusHealthy:
+			err = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningtype.StoragePoolDegraded, cluster.TypeStoragePool, int(pool.ID()))
+			if err != nil {
+				logger.Warn("Failed resolving storage pool health warning", logger.Ctx{"pool": poolName, "err": err})
+			}
+
+		case api.StoragePoolHealthStatusDegraded, api.StoragePoolHealthStatusFailed:
+			message := fmt.Sprintf("Storage pool is %s", health.Status)
+			if len(health.Messages) > 0 {
+				message = fmt.Sprintf("%s: %s", message, strings.Join(health.Messages, ", "))
+			}
+
+			logger.Warn("Storage pool health degraded", logger.Ctx{"pool": poolName, "status": health.Status, "messages": health.Messages})
+
+			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.UpsertWarningLocalNode(ctx, "", cluster.TypeStoragePool, int(pool.ID()), warningtype.StoragePoolDegraded, message)
+			})
+			if err != nil {
+				logger.Warn("Failed recording storage pool health warning", logger.Ctx{"pool": poolName, "err": err})
+			}
+		}
+	}
+
+	return nil
+}
+
+func storagePoolsHealthCheckTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		opRun := func(op *operations.Operation) error {
+			return storagePoolsHealthCheck(ctx, s)
+		}
+
+		op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.StoragePoolHealthCheck, nil, nil, opRun, nil, nil, nil)
+		if err != nil {
+			logger.Error("Failed creating storage pool health check operation", logger.Ctx{"err": err})
+			return
+		}
+
+		logger.Debug("Checking storage pool health")
+		err = op.Start()
+		if err != nil {
+			logger.Error("Failed starting storage pool health check operation", logger.Ctx{"err": err})
+			return
+		}
+
+		err = op.Wait(ctx)
+		if err != nil {
+			logger.Error("Failed checking storage pool health", logger.Ctx{"err": err})
+			return
+		}
+
+		logger.Debug("Done checking storage pool health")
+	}
+
+	return f, task.Every(5 * time.Minute)
+}
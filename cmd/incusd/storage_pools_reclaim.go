@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lxc/incus/v7/internal/server/auth"
+	"github.com/lxc/incus/v7/internal/server/db"
+	"github.com/lxc/incus/v7/internal/server/db/operationtype"
+	"github.com/lxc/incus/v7/internal/server/operations"
+	"github.com/lxc/incus/v7/internal/server/request"
+	"github.com/lxc/incus/v7/internal/server/response"
+	"github.com/lxc/incus/v7/internal/server/state"
+	storagePools "github.com/lxc/incus/v7/internal/server/storage"
+	"github.com/lxc/incus/v7/internal/server/task"
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+var storagePoolReclaimCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/reclaim",
+
+	Post: APIEndpointAction{Handler: storagePoolReclaimPost, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanEdit, "poolName")},
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/reclaim storage storage_pool_reclaim_post
+//
+//	Reclaim unused space
+//
+//	Releases the unused space of the storage pool volumes back to the pool.
+//	Filesystem volumes are trimmed, raw disk files have their zeroed ranges
+//	deallocated and qcow2 volumes have the space past the end of the image discarded.
+//	The number of bytes reclaimed is stored as `reclaimed_bytes` in the operation metadata.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: poolName
+//	    description: Storage pool name
+//	    type: string
+//	    required: true
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolReclaimPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := pathVar(r, "poolName")
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	run := func(op *operations.Operation) error {
+		reclaimed, err := pool.Reclaim(op)
+		if err != nil {
+			return err
+		}
+
+		return op.UpdateMetadata(map[string]any{"reclaimed_bytes": reclaimed})
+	}
+
+	resources := map[string][]api.URL{}
+	resources["storage_pools"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName)}
+
+	op, err := operations.OperationCreate(s, request.ProjectParam(r), operations.OperationClassTask, operationtype.StoragePoolReclaim, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// storagePoolsReclaim releases the unused space of the volumes of all local storage pools.
+func storagePoolsReclaim(ctx context.Context, s *state.State, op *operations.Operation) error {
+	var poolNames []string
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+
+		return err
+	})
+	if err != nil {
+		if response.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed loading storage pools: %w", err)
+	}
+
+	for _, poolName := range poolNames {
+		pool, err := storagePools.LoadByName(s, poolName)
+		if err != nil {
+			logger.Warn("Failed loading storage pool", logger.Ctx{"pool": poolName, "err": err})
+			continue
+		}
+
+		// Skip pools that aren't available on this server.
+		if pool.LocalStatus() != api.StoragePoolStatusCreated {
+			continue
+		}
+
+		// Skip pools that can't reclaim space or that opted out of the daily reclaim.
+		if !pool.Driver().Info().Reclaim || util.IsFalse(pool.Driver().Config()["reclaim.auto"]) {
+			continue
+		}
+
+		reclaimed, err := pool.Reclaim(op)
+		if err != nil {
+			logger.Warn("Failed reclaiming storage pool space", logger.Ctx{"pool": poolName, "err": err})
+			continue
+		}
+
+		if reclaimed > 0 {
+			logger.Info("Reclaimed storage pool space", logger.Ctx{"pool": poolName, "bytes": reclaimed})
+		}
+	}
+
+	return nil
+}
+
+func storagePoolsReclaimTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		opRun := func(op *operations.Operation) error {
+			return storagePoolsReclaim(ctx, s, op)
+		}
+
+		op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.StoragePoolsReclaim, nil, nil, opRun, nil, nil, nil)
+		if err != nil {
+			logger.Error("Failed creating storage pool reclaim operation", logger.Ctx{"err": err})
+			return
+		}
+
+		logger.Debug("Reclaiming storage pool space")
+		err = op.Start()
+		if err != nil {
+			logger.Error("Failed starting storage pool reclaim operation", logger.Ctx{"err": err})
+			return
+		}
+
+		err = op.Wait(ctx)
+		if err != nil {
+			logger.Error("Failed reclaiming storage pool space", logger.Ctx{"err": err})
+			return
+		}
+
+		logger.Debug("Done reclaiming storage pool space")
+	}
+
+	return f, task.Daily(task.SkipFirst)
+}
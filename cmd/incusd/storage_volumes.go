@@ -1338,6 +1338,17 @@ func storagePoolVolumePost(d *Daemon, r *http.Request) response.Response {
 		if err != nil {
 			return response.SmartError(err)
 		}
+
+		// Moving the volume out of the project would escape its protection policy.
+		p, err := projectProtectionLoad(r.Context(), s, projectName)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = project.AllowDeletion(p)
+		if err != nil {
+			return response.SmartError(err)
+		}
 	}
 
 	// We need to restore the body of the request since it has already been read, and if we
@@ -2331,6 +2342,16 @@ func storagePoolVolumePut(d *Daemon, r *http.Request) response.Response {
 		// before applying config changes so that changes are applied to the
 		// restored volume.
 		if req.Restore != "" {
+			p, err := projectProtectionLoad(r.Context(), s, projectName)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			err = storageVolumeSnapshotBeforeRestore(r.Context(), s, pool, p, dbVolume, op)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
 			err = pool.RestoreCustomVolume(projectName, dbVolume.Name, req.Restore, op)
 			if err != nil {
 				return response.SmartError(err)
@@ -2680,6 +2701,18 @@ func storagePoolVolumeDelete(d *Daemon, r *http.Request) response.Response {
 
 	switch volumeType {
 	case db.StoragePoolVolumeTypeCustom:
+		var p *api.Project
+
+		p, err = projectProtectionLoad(r.Context(), s, volumeProjectName)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = storageVolumeDeleteAllowed(r.Context(), s, p, pool.ID(), volumeName)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
 		err = pool.DeleteCustomVolume(volumeProjectName, volumeName, op)
 	case db.StoragePoolVolumeTypeImage:
 		err = pool.DeleteImage(volumeName, op)
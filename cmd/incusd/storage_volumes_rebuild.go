@@ -151,6 +151,16 @@ func storagePoolVolumeTypeRebuildPost(d *Daemon, r *http.Request) response.Respo
 		return response.SmartError(err)
 	}
 
+	p, err := projectProtectionLoad(r.Context(), s, volumeProjectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = project.AllowDeletion(p)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	run := func(op *operations.Operation) error {
 		return pool.RebuildCustomVolume(volumeProjectName, volumeName, op)
 	}
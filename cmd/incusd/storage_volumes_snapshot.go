@@ -1329,14 +1329,19 @@ func storagePoolVolumeSnapshotTypeDelete(d *Daemon, r *http.Request) response.Re
 		return response.SmartError(err)
 	}
 
-	// Get the parent volume.
-	var parentDBVolume *db.StorageVolume
+	// Get the parent volume and the snapshot.
+	var parentDBVolume, snapshotDBVolume *db.StorageVolume
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		parentDBVolume, err = tx.GetStoragePoolVolume(ctx, pool.ID(), projectName, volumeType, volumeName, true)
 		if err != nil {
 			return err
 		}
 
+		snapshotDBVolume, err = tx.GetStoragePoolVolume(ctx, pool.ID(), projectName, volumeType, fullSnapshotName, true)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -1347,6 +1352,16 @@ func storagePoolVolumeSnapshotTypeDelete(d *Daemon, r *http.Request) response.Re
 		return response.BadRequest(fmt.Errorf("Direct snapshot removal is not allowed for dependent volumes"))
 	}
 
+	p, err := projectProtectionLoad(r.Context(), s, projectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = project.AllowSnapshotDeletion(p, snapshotDBVolume.CreatedAt)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	snapshotDelete := func(op *operations.Operation) error {
 		return pool.DeleteCustomVolumeSnapshot(projectName, fullSnapshotName, op)
 	}
@@ -1593,12 +1608,29 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 var customVolSnapshotsPruneRunning = sync.Map{}
 
 func pruneExpiredCustomVolumeSnapshots(ctx context.Context, s *state.State, expiredSnapshots []db.StorageVolumeArgs) error {
+	projects := map[string]*api.Project{}
+
 	for _, v := range expiredSnapshots {
 		err := ctx.Err()
 		if err != nil {
 			return err // Stop if context is cancelled.
 		}
 
+		// Keep the snapshot until the project protection policy allows deleting it.
+		p, found := projects[v.ProjectName]
+		if !found {
+			p, err = projectProtectionLoad(ctx, s, v.ProjectName)
+			if err != nil {
+				return err
+			}
+
+			projects[v.ProjectName] = p
+		}
+
+		if project.AllowSnapshotDeletion(p, v.CreationDate) != nil {
+			continue
+		}
+
 		_, loaded := customVolSnapshotsPruneRunning.LoadOrStore(v.ID, struct{}{})
 		if loaded {
 			continue // Deletion of this snapshot is already running, skip.
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lxc/incus/v7/internal/server/instance/autoscale"
+	"github.com/lxc/incus/v7/internal/server/instance/drivers/edk2"
 	scriptletLoad "github.com/lxc/incus/v7/internal/server/scriptlet/load"
 	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/units"
@@ -64,6 +66,26 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What order to start the instances in
 	"boot.autostart.priority": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=boot, key=boot.depends_on)
+	// Comma-separated list of instances (in the same project) which must be running
+	// before the instance is started. Instances with a health check must also be healthy.
+	//
+	// See {ref}`instances-dependencies` for more information.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Instances to start before this one
+	"boot.depends_on": validate.Optional(validate.IsListOf(validate.IsHostname)),
+
+	// gendoc:generate(entity=instance, group=boot, key=boot.depends_on.timeout)
+	// Number of seconds to wait for the dependencies to be ready when starting the instance.
+	// ---
+	//  type: integer
+	//  defaultdesc: 300
+	//  liveupdate: yes
+	//  shortdesc: How long to wait for the instance dependencies
+	"boot.depends_on.timeout": validate.Optional(validate.IsUint32),
+
 	// gendoc:generate(entity=instance, group=boot, key=boot.stop.priority)
 	// The instance with the highest value is shut down first.
 	// ---
@@ -93,6 +115,26 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: How long to wait for the instance to shut down
 	"boot.host_shutdown_timeout": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=boot, key=boot.schedule.start)
+	// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or leave empty to disable scheduled starts.
+	// Schedules are suspended for all instances of a project when {config:option}`project-specific:boot.schedule.suspended` is enabled.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: When to automatically start the instance
+	"boot.schedule.start": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
+
+	// gendoc:generate(entity=instance, group=boot, key=boot.schedule.stop)
+	// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or leave empty to disable scheduled stops.
+	// The instance is shut down cleanly, then forcefully stopped after {config:option}`instance-boot:boot.host_shutdown_timeout`.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: When to automatically stop the instance
+	"boot.schedule.stop": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
+
 	// gendoc:generate(entity=instance, group=cloud-init, key=cloud-init.network-config)
 	// The content is used as seed value for `cloud-init`.
 	// ---
@@ -178,6 +220,112 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What to do when evacuating the instance
 	"cluster.evacuate": validate.Optional(validate.IsOneOf("auto", "migrate", "live-migrate", "stop", "stateful-stop", "force-stop")),
 
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.type)
+	// Possible values are `exec` (run `healthcheck.command` inside the instance),
+	// `tcp` (connect to `healthcheck.port`) and `http` (`GET` request on `healthcheck.port` and `healthcheck.path`).
+	//
+	// See {ref}`instances-healthcheck` for more information.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Type of health check
+	"healthcheck.type": validate.Optional(validate.IsOneOf("exec", "tcp", "http")),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.command)
+	// The command is run through `sh -c` and must exit with a status of `0` for the instance to be healthy.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: `healthcheck.type` is `exec`
+	//  shortdesc: Command to run for `exec` health checks
+	"healthcheck.command": validate.IsAny,
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.address)
+	// Must be one of the global addresses of the instance.
+	// Defaults to the first global address of the instance, preferring IPv4.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: `healthcheck.type` is `tcp` or `http`
+	//  shortdesc: Address to connect to for `tcp` and `http` health checks
+	"healthcheck.address": validate.Optional(validate.IsNetworkAddress),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.port)
+	//
+	// ---
+	//  type: integer
+	//  liveupdate: yes
+	//  condition: `healthcheck.type` is `tcp` or `http`
+	//  shortdesc: Port to connect to for `tcp` and `http` health checks
+	"healthcheck.port": validate.Optional(validate.IsNetworkPort),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.path)
+	// Any `2xx` or `3xx` response is considered healthy.
+	// Only the response status is reported, not the body.
+	// ---
+	//  type: string
+	//  defaultdesc: `/`
+	//  liveupdate: yes
+	//  condition: `healthcheck.type` is `http`
+	//  shortdesc: HTTP path to request for `http` health checks
+	"healthcheck.path": validate.Optional(validate.IsAbsFilePath),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.interval)
+	// Health checks are scheduled with a granularity of 10 seconds.
+	// ---
+	//  type: integer
+	//  defaultdesc: `30`
+	//  liveupdate: yes
+	//  shortdesc: Number of seconds between two health checks
+	"healthcheck.interval": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.timeout)
+	//
+	// ---
+	//  type: integer
+	//  defaultdesc: `5`
+	//  liveupdate: yes
+	//  shortdesc: Number of seconds after which a health check fails
+	"healthcheck.timeout": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.retries)
+	//
+	// ---
+	//  type: integer
+	//  defaultdesc: `3`
+	//  liveupdate: yes
+	//  shortdesc: Number of consecutive failed checks after which the instance is unhealthy
+	"healthcheck.retries": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.start_period)
+	// Failed checks during this period aren't counted until the instance was healthy once.
+	// ---
+	//  type: integer
+	//  defaultdesc: `0`
+	//  liveupdate: yes
+	//  shortdesc: Number of seconds to give the instance to start up
+	"healthcheck.start_period": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.action)
+	// Possible values are `none` (only report the health status), `restart` (restart the instance)
+	// and `evacuate` (move the instance to another cluster member and start it there).
+	// ---
+	//  type: string
+	//  defaultdesc: `none`
+	//  liveupdate: yes
+	//  shortdesc: What to do when the instance becomes unhealthy
+	"healthcheck.action": validate.Optional(validate.IsOneOf("none", "restart", "evacuate")),
+
+	// gendoc:generate(entity=instance, group=healthcheck, key=healthcheck.max_restarts)
+	// Once reached, the instance is left alone and a warning is raised until it becomes healthy again.
+	// ---
+	//  type: integer
+	//  defaultdesc: `3`
+	//  liveupdate: yes
+	//  condition: `healthcheck.action` isn't `none`
+	//  shortdesc: Number of times the `healthcheck.action` is taken before giving up
+	"healthcheck.max_restarts": validate.Optional(validate.IsUint32),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu)
 	// A number or a specific range of CPUs to expose to the instance.
 	// For virtual machines, a CPU topology of the form `sockets=2,cores=4,threads=2` may also be provided.
@@ -329,6 +477,17 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Prevents the instance from being deleted
 	"security.protection.delete": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=security, key=security.session_recording)
+	// When enabled, the input and output of `incus exec` sessions and of text console sessions are recorded in the asciicast v2 format.
+	// Recordings are stored as `session_*.cast` files in the instance log directory.
+	// Recording can also be enabled for all instances of a project through {config:option}`project-specific:security.session_recording`.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  shortdesc: Whether to record exec and console sessions
+	"security.session_recording": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=security, key=security.selinux.type)
 	// Override the SELinux file type used for labeling instance storage.
 	// ---
@@ -602,6 +761,59 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: CPU scheduling priority compared to other instances
 	"limits.cpu.priority": validate.Optional(validate.IsPriority),
 
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.autoscale)
+	// When enabled, {config:option}`instance-resource-limits:limits.cpu.allowance` and {config:option}`instance-resource-limits:limits.memory`
+	// are adjusted automatically based on the CPU usage and memory pressure of the container.
+	//
+	// See {ref}`instance-options-limits-autoscale` for more information.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Whether to automatically scale the CPU and memory limits
+	"limits.autoscale": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.autoscale.cpu.min)
+	// Specify a percentage of a single CPU (for example, `50%`).
+	// ---
+	//  type: string
+	//  defaultdesc: `10%`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Lowest CPU allowance set by the autoscaler
+	"limits.autoscale.cpu.min": validate.Optional(autoscale.IsCPUAllowance),
+
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.autoscale.cpu.max)
+	// Specify a percentage of a single CPU (for example, `200%` for two CPUs).
+	// ---
+	//  type: string
+	//  defaultdesc: all available CPUs
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Highest CPU allowance set by the autoscaler
+	"limits.autoscale.cpu.max": validate.Optional(autoscale.IsCPUAllowance),
+
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.autoscale.memory.min)
+	// Various suffixes are supported (see {ref}`instances-limit-units`).
+	// ---
+	//  type: string
+	//  defaultdesc: `128MiB`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Lowest memory limit set by the autoscaler
+	"limits.autoscale.memory.min": validate.Optional(validate.IsSize),
+
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.autoscale.memory.max)
+	// Various suffixes are supported (see {ref}`instances-limit-units`).
+	// ---
+	//  type: string
+	//  defaultdesc: total host memory
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Highest memory limit set by the autoscaler
+	"limits.autoscale.memory.max": validate.Optional(validate.IsSize),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.hugepages.64KB)
 	// Fixed value (in bytes) to limit the number of 64 KB huge pages.
 	// Various suffixes are supported (see {ref}`instances-limit-units`).
@@ -1154,6 +1366,34 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 
 // InstanceConfigKeysVM is a map of config key to validator. (keys applying to VM only).
 var InstanceConfigKeysVM = map[string]func(value string) error{
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.hotplug)
+	// If this option is set to `false`, all vCPUs are present at boot and {config:option}`instance-resource-limits:limits.cpu` can't be changed while the VM is running.
+	// Alternatively, it can be set to a number of vCPUs which defines an upper limit for hotplugged vCPUs.
+	// The value must be greater than or equal to {config:option}`instance-resource-limits:limits.cpu`.
+	// ---
+	//  type: string
+	//  defaultdesc: `true`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Control upper limit for hotplugged vCPUs or disable CPU hotplug
+	"limits.cpu.hotplug": validate.Optional(validate.Or(validate.IsBool, validate.IsUint32)),
+
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.balloon)
+	// Possible values are:
+	//
+	// - `static`: The memory balloon is only used when live-updating {config:option}`instance-resource-limits:limits.memory`.
+	// - `reporting`: The guest reports its free memory pages which are then returned to the host.
+	// - `auto`: In addition to free page reporting, idle guest memory is reclaimed through the memory balloon when the host is under memory pressure, and returned to the guest when it needs it.
+	//
+	// See {ref}`instance-options-limits-memory-balloon` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: `static`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: How the memory balloon of the VM is managed
+	"limits.memory.balloon": validate.Optional(validate.IsOneOf("static", "reporting", "auto")),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.hotplug)
 	// If this option is set to `false`, disable memory hotplug entirely.
 	// Alternatively, it can be set to a bytes value which will define an upper limit for hotplugged memory.
@@ -1272,6 +1512,45 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether UEFI secure boot is enforced with the default Microsoft keys
 	"security.secureboot": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=security, key=security.secureboot.pk)
+	// PEM encoded certificate to enroll as the UEFI Platform Key instead of the default one.
+	// When set, {config:option}`instance-security:security.secureboot.kek` and {config:option}`instance-security:security.secureboot.db` must be set too.
+	// The keys are enrolled into the VM's NVRAM on the next start.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Custom Secure Boot Platform Key certificate
+	"security.secureboot.pk": validate.Optional(edk2.IsSecureBootCertificates),
+
+	// gendoc:generate(entity=instance, group=security, key=security.secureboot.kek)
+	// One or more PEM encoded certificates to enroll as UEFI Key Exchange Keys instead of the default ones.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Custom Secure Boot Key Exchange Key certificates
+	"security.secureboot.kek": validate.Optional(edk2.IsSecureBootCertificates),
+
+	// gendoc:generate(entity=instance, group=security, key=security.secureboot.db)
+	// One or more PEM encoded certificates allowed to sign boot images, replacing the default signature database.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Custom Secure Boot signature database certificates
+	"security.secureboot.db": validate.Optional(edk2.IsSecureBootCertificates),
+
+	// gendoc:generate(entity=instance, group=security, key=security.secureboot.dbx)
+	// PEM encoded certificates or hex encoded SHA-256 image hashes (one per line) that are forbidden from booting.
+	// Only used together with custom keys.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Custom Secure Boot forbidden signature database
+	"security.secureboot.dbx": validate.Optional(edk2.IsSecureBootForbiddenList),
+
 	// gendoc:generate(entity=instance, group=security, key=security.sev)
 	//
 	// ---
@@ -1358,6 +1637,13 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Real Time Clock change offset
 	"volatile.vm.rtc_offset": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.vm.watchdog_fired)
+	// Number of times the watchdog device of the virtual machine fired.
+	// ---
+	//  type: int64
+	//  shortdesc: Watchdog fire count
+	"volatile.vm.watchdog_fired": validate.Optional(validate.IsInt64),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.vsock_id)
 	//
 	// ---
@@ -0,0 +1,417 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Outcomes of an audited request.
+const (
+	OutcomeSuccess = "success"
+	OutcomeDenied  = "denied"
+	OutcomeFailure = "failure"
+)
+
+// Outcome returns the outcome of a request based on its response status code.
+func Outcome(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return OutcomeDenied
+	case statusCode >= http.StatusBadRequest:
+		return OutcomeFailure
+	default:
+		return OutcomeSuccess
+	}
+}
+
+// redactedValue replaces the values of the query parameters which aren't safe to record.
+const redactedValue = "redacted"
+
+// safeQueryParameters are the query parameters which are recorded as-is.
+var safeQueryParameters = []string{"project", "target", "recursion", "all-projects", "type", "public", "force", "wait"}
+
+// RedactURL returns the request URI of u, with the value of any query parameter which may hold a secret redacted.
+func RedactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.RequestURI()
+	}
+
+	query := u.Query()
+	for key, values := range query {
+		if slices.Contains(safeQueryParameters, key) {
+			continue
+		}
+
+		for i := range values {
+			values[i] = redactedValue
+		}
+	}
+
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+
+	return redacted.RequestURI()
+}
+
+// KeySize is the size in bytes of the key used to authenticate the audit records.
+const KeySize = 32
+
+// LoadKey reads the key used to authenticate the audit records.
+func LoadKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading audit key: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != KeySize {
+		return nil, fmt.Errorf("Invalid audit key in %q", path)
+	}
+
+	return key, nil
+}
+
+// loadOrCreateKey reads the key used to authenticate the audit records, generating it if missing.
+func loadOrCreateKey(path string) ([]byte, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return LoadKey(path)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key := make([]byte, KeySize)
+	_, err = rand.Read(key)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating audit key: %w", err)
+	}
+
+	err = os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed writing audit key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Hash returns the HMAC of an audit record using the given key, chaining it to the hash of the previous record.
+func Hash(key []byte, record api.EventAudit) (string, error) {
+	record.Hash = ""
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(record.PreviousHash))
+	_, _ = h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Logger writes hash chained audit records to a file, rotating it once it reaches its maximum size.
+// The records are authenticated with a key held by the server, so that they can't be forged without it.
+type Logger struct {
+	mu sync.Mutex
+
+	path     string
+	keyPath  string
+	key      []byte
+	maxSize  int64
+	maxFiles int
+
+	file     *os.File
+	size     int64
+	sequence uint64
+	lastHash string
+}
+
+// NewLogger returns a new audit logger writing to the given path and authenticating the records with the key stored in keyPath.
+// The logger is disabled until configured.
+func NewLogger(path string, keyPath string) *Logger {
+	return &Logger{path: path, keyPath: keyPath}
+}
+
+// Configure enables or disables the logger and sets its rotation settings.
+// When enabled, the hash chain is resumed from the last record of the existing log.
+func (l *Logger) Configure(enabled bool, maxSize int64, maxFiles int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.maxSize = maxSize
+	l.maxFiles = maxFiles
+
+	if !enabled {
+		return l.close()
+	}
+
+	if l.file != nil {
+		return nil
+	}
+
+	key, err := loadOrCreateKey(l.keyPath)
+	if err != nil {
+		return err
+	}
+
+	l.key = key
+
+	// Resume the chain from the current or the last rotated file.
+	for _, path := range []string{l.path, l.path + ".1"} {
+		record, err := lastRecord(path)
+		if err != nil {
+			return err
+		}
+
+		if record != nil {
+			l.sequence = record.Sequence
+			l.lastHash = record.Hash
+			break
+		}
+	}
+
+	return l.open()
+}
+
+// Enabled returns whether the logger is writing records.
+func (l *Logger) Enabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.file != nil
+}
+
+// Write chains the record to the previous one and appends it to the log.
+// The sequence number and hashes of the record are filled in.
+func (l *Logger) Write(record *api.EventAudit) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	if l.maxSize > 0 && l.size >= l.maxSize {
+		err := l.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	record.Sequence = l.sequence + 1
+	record.PreviousHash = l.lastHash
+
+	hash, err := Hash(l.key, *record)
+	if err != nil {
+		return err
+	}
+
+	record.Hash = hash
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	n, err := l.file.Write(append(data, '\n'))
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("Failed writing audit record: %w", err)
+	}
+
+	l.sequence = record.Sequence
+	l.lastHash = record.Hash
+
+	return nil
+}
+
+// Close stops the logger.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.close()
+}
+
+func (l *Logger) open() error {
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("Failed opening audit log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	l.file = file
+	l.size = info.Size()
+
+	return nil
+}
+
+func (l *Logger) close() error {
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+// rotate shifts the existing log files, dropping the oldest one, and starts a new file.
+func (l *Logger) rotate() error {
+	err := l.close()
+	if err != nil {
+		return err
+	}
+
+	if l.maxFiles > 0 {
+		err = os.Remove(fmt.Sprintf("%s.%d", l.path, l.maxFiles))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		for i := l.maxFiles - 1; i > 0; i-- {
+			err = os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+
+		err = os.Rename(l.path, l.path+".1")
+	} else {
+		err = os.Remove(l.path)
+	}
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return l.open()
+}
+
+// lastRecord returns the last record of an audit log file, if any.
+func lastRecord(path string) (*api.EventAudit, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var last string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			last = line
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading audit log %q: %w", path, err)
+	}
+
+	if last == "" {
+		return nil, nil
+	}
+
+	record := api.EventAudit{}
+	err = json.Unmarshal([]byte(last), &record)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing last record of audit log %q: %w", path, err)
+	}
+
+	return &record, nil
+}
+
+// Verifier checks the integrity of a sequence of audit records.
+type Verifier struct {
+	// Key is the key the records were authenticated with.
+	Key []byte
+
+	// Count is the number of verified records.
+	Count int
+
+	last *api.EventAudit
+}
+
+// Verify checks the records read from r, continuing the chain of previously verified records.
+// The first record ever verified anchors the chain.
+func (v *Verifier) Verify(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		record := api.EventAudit{}
+		err := json.Unmarshal([]byte(line), &record)
+		if err != nil {
+			return fmt.Errorf("Failed parsing audit record after sequence %d: %w", v.lastSequence(), err)
+		}
+
+		if v.last != nil {
+			if record.Sequence != v.last.Sequence+1 {
+				return fmt.Errorf("Audit record %d follows record %d", record.Sequence, v.last.Sequence)
+			}
+
+			if record.PreviousHash != v.last.Hash {
+				return fmt.Errorf("Audit record %d isn't chained to record %d", record.Sequence, v.last.Sequence)
+			}
+		}
+
+		hash, err := Hash(v.Key, record)
+		if err != nil {
+			return err
+		}
+
+		if !hmac.Equal([]byte(hash), []byte(record.Hash)) {
+			return fmt.Errorf("Audit record %d has been altered", record.Sequence)
+		}
+
+		v.last = &record
+		v.Count++
+	}
+
+	return scanner.Err()
+}
+
+func (v *Verifier) lastSequence() uint64 {
+	if v.last == nil {
+		return 0
+	}
+
+	return v.last.Sequence
+}
@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+func writeRecords(t *testing.T, l *Logger, count int) {
+	for i := range count {
+		record := &api.EventAudit{
+			Timestamp:  time.Now(),
+			Username:   "jane@example.com",
+			Protocol:   api.AuthenticationMethodOIDC,
+			Address:    "10.0.2.15",
+			Method:     "GET",
+			Endpoint:   "/1.0/instances/{name}",
+			URL:        fmt.Sprintf("/1.0/instances/c%d", i),
+			StatusCode: 200,
+			Outcome:    OutcomeSuccess,
+		}
+
+		require.NoError(t, l.Write(record))
+	}
+}
+
+func verifyFiles(key []byte, paths ...string) (int, error) {
+	v := &Verifier{Key: key}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return v.Count, err
+		}
+
+		err = v.Verify(f)
+		_ = f.Close()
+		if err != nil {
+			return v.Count, err
+		}
+	}
+
+	return v.Count, nil
+}
+
+func TestLoggerChain(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	keyPath := filepath.Join(dir, "audit.key")
+
+	l := NewLogger(path, keyPath)
+	require.NoError(t, l.Configure(true, 0, 0))
+	writeRecords(t, l, 5)
+	require.NoError(t, l.Close())
+
+	// Resuming the log continues the chain.
+	require.NoError(t, l.Configure(true, 0, 0))
+	writeRecords(t, l, 5)
+	require.NoError(t, l.Close())
+
+	key, err := LoadKey(keyPath)
+	require.NoError(t, err)
+
+	count, err := verifyFiles(key, path)
+	require.NoError(t, err)
+	assert.Equal(t, 10, count)
+
+	// Records can't be verified without the key.
+	_, err = verifyFiles(make([]byte, KeySize), path)
+	assert.ErrorContains(t, err, "has been altered")
+
+	// Tampering with a record is detected.
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	tampered := strings.Replace(string(content), "/1.0/instances/c3", "/1.0/instances/c9", 1)
+	err = (&Verifier{Key: key}).Verify(bytes.NewBufferString(tampered))
+	assert.ErrorContains(t, err, "has been altered")
+
+	// Removing a record is detected.
+	lines := strings.Split(string(content), "\n")
+	removed := strings.Join(append(lines[:4:4], lines[5:]...), "\n")
+	err = (&Verifier{Key: key}).Verify(bytes.NewBufferString(removed))
+	assert.ErrorContains(t, err, "follows record")
+}
+
+func TestLoggerRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	keyPath := filepath.Join(dir, "audit.key")
+
+	l := NewLogger(path, keyPath)
+	require.NoError(t, l.Configure(true, 1024, 2))
+	writeRecords(t, l, 30)
+	require.NoError(t, l.Close())
+
+	assert.FileExists(t, path+".1")
+	assert.FileExists(t, path+".2")
+	assert.NoFileExists(t, path+".3")
+
+	key, err := LoadKey(keyPath)
+	require.NoError(t, err)
+
+	// The remaining files still form a single chain.
+	_, err = verifyFiles(key, path+".2", path+".1", path)
+	require.NoError(t, err)
+
+	// Files verified out of order are detected.
+	_, err = verifyFiles(key, path+".1", path+".2", path)
+	assert.Error(t, err)
+}
+
+func TestLoggerDisabled(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	keyPath := filepath.Join(dir, "audit.key")
+
+	l := NewLogger(path, keyPath)
+	writeRecords(t, l, 1)
+	assert.False(t, l.Enabled())
+	assert.NoFileExists(t, path)
+}
+
+func TestOutcome(t *testing.T) {
+	assert.Equal(t, OutcomeSuccess, Outcome(200))
+	assert.Equal(t, OutcomeSuccess, Outcome(101))
+	assert.Equal(t, OutcomeDenied, Outcome(401))
+	assert.Equal(t, OutcomeDenied, Outcome(403))
+	assert.Equal(t, OutcomeFailure, Outcome(404))
+	assert.Equal(t, OutcomeFailure, Outcome(500))
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := map[string]string{
+		"/1.0/instances":                              "/1.0/instances",
+		"/1.0/instances?project=foo&recursion=1":      "/1.0/instances?project=foo&recursion=1",
+		"/1.0/operations/abc/websocket?secret=s3cr3t": "/1.0/operations/abc/websocket?secret=redacted",
+		"/1.0/events?project=foo&token=abc":           "/1.0/events?project=foo&token=redacted",
+	}
+
+	for raw, expected := range tests {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		assert.Equal(t, expected, RedactURL(u))
+	}
+}
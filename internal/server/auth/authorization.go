@@ -20,6 +20,9 @@ const (
 
 	// DriverScriptlet provides scriptlet-based authorization. It is compatible with any authentication method.
 	DriverScriptlet string = "scriptlet"
+
+	// DriverRBAC provides role-based authorization with groups stored in the database. It is compatible with any authentication method.
+	DriverRBAC string = "rbac"
 )
 
 // ErrUnknownDriver is the "Unknown driver" error.
@@ -29,6 +32,7 @@ var authorizers = map[string]func() authorizer{
 	DriverTLS:       func() authorizer { return &TLS{} },
 	DriverOpenFGA:   func() authorizer { return &FGA{} },
 	DriverScriptlet: func() authorizer { return &Scriptlet{} },
+	DriverRBAC:      func() authorizer { return &RBAC{} },
 }
 
 type authorizer interface {
@@ -112,6 +116,10 @@ type Opts struct {
 	config          map[string]any
 	projectsGetFunc func(ctx context.Context) (map[int64]string, error)
 	resourcesFunc   func() (*Resources, error)
+
+	identitiesFunc    func(ctx context.Context, authenticationMethod string, identifier string) ([]Identity, error)
+	groupsFunc        func(ctx context.Context, groupNames []string) ([]Permission, error)
+	updateObjectsFunc func(ctx context.Context, update func(object Object) (Object, bool)) error
 }
 
 // Resources represents a set of current API resources as Object slices for use when loading an Authorizer.
@@ -153,6 +161,30 @@ func WithResourcesFunc(f func() (*Resources, error)) func(*Opts) {
 	}
 }
 
+// WithIdentitiesFunc should be passed into LoadAuthorizer when DriverRBAC is used.
+// Empty authenticationMethod and identifier values must return all the identities.
+func WithIdentitiesFunc(f func(ctx context.Context, authenticationMethod string, identifier string) ([]Identity, error)) func(*Opts) {
+	return func(o *Opts) {
+		o.identitiesFunc = f
+	}
+}
+
+// WithGroupsFunc should be passed into LoadAuthorizer when DriverRBAC is used.
+// It returns the permissions granted by the named authorization groups, ignoring unknown groups.
+func WithGroupsFunc(f func(ctx context.Context, groupNames []string) ([]Permission, error)) func(*Opts) {
+	return func(o *Opts) {
+		o.groupsFunc = f
+	}
+}
+
+// WithUpdateObjectsFunc should be passed into LoadAuthorizer when DriverRBAC is used.
+// The update function returns the new object, or false if the permissions on the object should be removed.
+func WithUpdateObjectsFunc(f func(ctx context.Context, update func(object Object) (Object, bool)) error) func(*Opts) {
+	return func(o *Opts) {
+		o.updateObjectsFunc = f
+	}
+}
+
 // LoadAuthorizer instantiates, configures, and initializes an Authorizer.
 func LoadAuthorizer(ctx context.Context, driver string, l logger.Logger, certificateCache *certificate.Cache, options ...func(opts *Opts)) (Authorizer, error) {
 	opts := &Opts{}
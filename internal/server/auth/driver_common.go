@@ -35,6 +35,9 @@ type requestDetails struct {
 
 	forwardedUsername string
 	forwardedProtocol string
+
+	identityProviderGroups          []string
+	forwardedIdentityProviderGroups []string
 }
 
 func (r *requestDetails) isInternalOrUnix() bool {
@@ -65,6 +68,15 @@ func (r *requestDetails) authenticationProtocol() string {
 	return r.Protocol
 }
 
+// mappedIdentityProviderGroups returns what the identity provider groups of the user map to.
+func (r *requestDetails) mappedIdentityProviderGroups() []string {
+	if r.Protocol == "cluster" {
+		return r.forwardedIdentityProviderGroups
+	}
+
+	return r.identityProviderGroups
+}
+
 func (r *requestDetails) actualDetails() *common.RequestDetails {
 	return &common.RequestDetails{
 		Username:             r.username(),
@@ -119,6 +131,9 @@ func (c *commonAuthorizer) requestDetails(r *http.Request) (*requestDetails, err
 		}
 	}
 
+	identityProviderGroups, _ := r.Context().Value(request.CtxIdentityProviderGroups).([]string)
+	forwardedIdentityProviderGroups, _ := r.Context().Value(request.CtxForwardedIdentityProviderGroups).([]string)
+
 	values, err := url.ParseQuery(r.URL.RawQuery)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to parse request query parameters: %w", err)
@@ -134,6 +149,9 @@ func (c *commonAuthorizer) requestDetails(r *http.Request) (*requestDetails, err
 
 		forwardedUsername: forwardedUsername,
 		forwardedProtocol: forwardedProtocol,
+
+		identityProviderGroups:          identityProviderGroups,
+		forwardedIdentityProviderGroups: forwardedIdentityProviderGroups,
 	}, nil
 }
 
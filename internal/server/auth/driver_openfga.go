@@ -295,9 +295,10 @@ func (f *FGA) CheckPermission(ctx context.Context, r *http.Request, object Objec
 
 	objectUser := ObjectUser(username)
 	body := client.ClientCheckRequest{
-		User:     objectUser.String(),
-		Relation: string(entitlement),
-		Object:   object.String(),
+		User:             objectUser.String(),
+		Relation:         string(entitlement),
+		Object:           object.String(),
+		ContextualTuples: identityProviderTuples(objectUser, details),
 	}
 
 	f.logger.Debug("Checking OpenFGA relation", logCtx)
@@ -313,6 +314,30 @@ func (f *FGA) CheckPermission(ctx context.Context, r *http.Request, object Objec
 	return nil
 }
 
+// identityProviderTuples returns the contextual tuples granting the user what its identity provider groups map to.
+func identityProviderTuples(objectUser Object, details *requestDetails) []client.ClientContextualTupleKey {
+	groupNames, grants := identityProviderGrants(details.mappedIdentityProviderGroups())
+
+	tuples := make([]client.ClientContextualTupleKey, 0, len(groupNames)+len(grants))
+	for _, groupName := range groupNames {
+		tuples = append(tuples, client.ClientContextualTupleKey{
+			User:     objectUser.String(),
+			Relation: "member",
+			Object:   "group:" + groupName,
+		})
+	}
+
+	for _, grant := range grants {
+		tuples = append(tuples, client.ClientContextualTupleKey{
+			User:     objectUser.String(),
+			Relation: string(grant.Entitlement),
+			Object:   grant.Object.String(),
+		})
+	}
+
+	return tuples
+}
+
 // GetPermissionChecker returns a function that can be used to check whether a user has the required entitlement on an authorization object.
 func (f *FGA) GetPermissionChecker(ctx context.Context, r *http.Request, entitlement Entitlement, objectType ObjectType) (PermissionChecker, error) {
 	allowFunc := func(b bool) func(Object) bool {
@@ -342,9 +367,10 @@ func (f *FGA) GetPermissionChecker(ctx context.Context, r *http.Request, entitle
 
 	f.logger.Debug("Listing related objects for user", logCtx)
 	resp, err := f.client.ListObjects(ctx).Body(client.ClientListObjectsRequest{
-		User:     ObjectUser(username).String(),
-		Relation: string(entitlement),
-		Type:     string(objectType),
+		User:             ObjectUser(username).String(),
+		Relation:         string(entitlement),
+		Type:             string(objectType),
+		ContextualTuples: identityProviderTuples(ObjectUser(username), details),
 	}).Execute()
 	if err != nil {
 		return nil, fmt.Errorf("Failed to OpenFGA objects of type %q with relation %q for user %q: %w", objectType, entitlement, username, err)
@@ -0,0 +1,538 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lxc/incus/v7/internal/server/certificate"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/logger"
+)
+
+// Roles which can be granted on the server, projects and instances.
+// Each role includes the entitlements of the roles below it.
+const (
+	roleAdmin    Entitlement = "admin"
+	roleOperator Entitlement = "operator"
+	roleUser     Entitlement = "user"
+	roleViewer   Entitlement = "viewer"
+)
+
+// Permission represents an entitlement granted on an object.
+type Permission struct {
+	Entitlement Entitlement
+	Object      Object
+}
+
+// Identity represents an identity known to the RBAC driver along with the permissions granted to it.
+type Identity struct {
+	AuthenticationMethod string
+	Identifier           string
+	Permissions          []Permission
+}
+
+// rbacRelation describes how an entitlement on an object is obtained. This mirrors the OpenFGA model.
+type rbacRelation struct {
+	// grantable is whether the entitlement can be granted directly on the object.
+	grantable bool
+
+	// authenticated is whether the entitlement is given to all authenticated users.
+	authenticated bool
+
+	// implied lists the entitlements on the same object which imply this one.
+	implied []Entitlement
+
+	// inherited lists the entitlements on the parent object (the project or the server) which imply this one.
+	inherited []Entitlement
+}
+
+// rbacRoles returns the relations of the roles of an object type with a parent.
+func rbacRoles(relations map[Entitlement]rbacRelation, hasParent bool) map[Entitlement]rbacRelation {
+	inherited := func(role Entitlement) []Entitlement {
+		if !hasParent {
+			return nil
+		}
+
+		return []Entitlement{role}
+	}
+
+	relations[roleAdmin] = rbacRelation{grantable: true, inherited: inherited(roleAdmin)}
+	relations[roleOperator] = rbacRelation{grantable: true, implied: []Entitlement{roleAdmin}, inherited: inherited(roleOperator)}
+	relations[roleUser] = rbacRelation{grantable: true, implied: []Entitlement{roleOperator}, inherited: inherited(roleUser)}
+	relations[roleViewer] = rbacRelation{grantable: true, implied: []Entitlement{roleUser}, inherited: inherited(roleViewer)}
+
+	return relations
+}
+
+// rbacProjectResource returns the relations of a project level object type without roles.
+func rbacProjectResource(extra ...Entitlement) map[Entitlement]rbacRelation {
+	relations := map[Entitlement]rbacRelation{
+		EntitlementCanEdit: {grantable: true, inherited: []Entitlement{roleOperator}},
+		EntitlementCanView: {grantable: true, implied: []Entitlement{EntitlementCanEdit}, inherited: []Entitlement{roleViewer}},
+	}
+
+	for _, entitlement := range extra {
+		relations[entitlement] = rbacRelation{grantable: true, implied: []Entitlement{EntitlementCanEdit}}
+	}
+
+	return relations
+}
+
+// rbacServerResource returns the relations of a server level object type without roles.
+func rbacServerResource() map[Entitlement]rbacRelation {
+	return map[Entitlement]rbacRelation{
+		EntitlementCanEdit: {grantable: true, inherited: []Entitlement{roleAdmin}},
+		EntitlementCanView: {inherited: []Entitlement{roleViewer}},
+	}
+}
+
+var rbacModel = map[ObjectType]map[Entitlement]rbacRelation{
+	ObjectTypeServer: rbacRoles(map[Entitlement]rbacRelation{
+		EntitlementCanCreateCertificates:               {grantable: true, implied: []Entitlement{roleAdmin}},
+		EntitlementCanCreateNetworkIntegrations:        {grantable: true, implied: []Entitlement{roleAdmin}},
+		EntitlementCanCreateProjects:                   {grantable: true, implied: []Entitlement{roleAdmin}},
+		EntitlementCanCreateStoragePools:               {grantable: true, implied: []Entitlement{roleAdmin}},
+		EntitlementCanEdit:                             {implied: []Entitlement{roleAdmin}},
+		EntitlementCanOverrideClusterTargetRestriction: {grantable: true, implied: []Entitlement{roleAdmin}},
+		EntitlementCanViewPrivilegedEvents:             {grantable: true, implied: []Entitlement{roleAdmin}},
+		EntitlementCanViewMetrics:                      {authenticated: true},
+		EntitlementCanViewResources:                    {authenticated: true},
+		EntitlementCanViewSensitive:                    {grantable: true, implied: []Entitlement{roleViewer}},
+		EntitlementCanView:                             {authenticated: true},
+	}, false),
+	ObjectTypeProject: rbacRoles(map[Entitlement]rbacRelation{
+		EntitlementCanCreateImageAliases:       {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateImages:             {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateInstances:          {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateNetworkACLs:        {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateNetworkAddressSets: {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateNetworks:           {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateNetworkZones:       {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateProfiles:           {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateStorageBuckets:     {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanCreateStorageVolumes:     {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanEdit:                     {implied: []Entitlement{roleAdmin}},
+		EntitlementCanViewEvents:               {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanViewOperations:           {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanView:                     {implied: []Entitlement{roleViewer}},
+	}, true),
+	ObjectTypeInstance: rbacRoles(map[Entitlement]rbacRelation{
+		EntitlementCanAccessConsole:   {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanAccessFiles:     {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanConnectNBD:      {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanConnectSFTP:     {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanConnectTCP:      {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanEdit:            {implied: []Entitlement{roleOperator}},
+		EntitlementCanExec:            {grantable: true, implied: []Entitlement{roleUser}},
+		EntitlementCanManageBackups:   {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanManageSnapshots: {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanUpdateState:     {grantable: true, implied: []Entitlement{roleOperator}},
+		EntitlementCanView:            {implied: []Entitlement{roleViewer}},
+	}, true),
+	ObjectTypeCertificate:        rbacServerResource(),
+	ObjectTypeNetworkIntegration: rbacServerResource(),
+	ObjectTypeStoragePool: {
+		EntitlementCanEdit: {grantable: true, inherited: []Entitlement{roleAdmin}},
+		EntitlementCanView: {authenticated: true},
+	},
+	ObjectTypeImage:             rbacProjectResource(),
+	ObjectTypeImageAlias:        rbacProjectResource(),
+	ObjectTypeNetwork:           rbacProjectResource(),
+	ObjectTypeNetworkACL:        rbacProjectResource(),
+	ObjectTypeNetworkAddressSet: rbacProjectResource(),
+	ObjectTypeNetworkZone:       rbacProjectResource(),
+	ObjectTypeProfile:           rbacProjectResource(),
+	ObjectTypeStorageBucket:     rbacProjectResource(),
+	ObjectTypeStorageVolume:     rbacProjectResource(EntitlementCanManageBackups, EntitlementCanManageSnapshots, EntitlementCanAccessFiles, EntitlementCanConnectNBD, EntitlementCanConnectSFTP),
+}
+
+// ValidatePermission returns an error if the entitlement can't be granted on the object.
+func ValidatePermission(entitlement Entitlement, object Object) error {
+	err := object.validate()
+	if err != nil {
+		return fmt.Errorf("Invalid object %q: %w", object, err)
+	}
+
+	if object.Type() == ObjectTypeServer && object != ObjectServer() {
+		return fmt.Errorf("Invalid object %q: The server object is %q", object, ObjectServer())
+	}
+
+	relation, ok := rbacModel[object.Type()][entitlement]
+	if !ok || !relation.grantable {
+		return fmt.Errorf("Entitlement %q can't be granted on objects of type %q", entitlement, object.Type())
+	}
+
+	return nil
+}
+
+// rbacParent returns the object the entitlements of an object are inherited from.
+func rbacParent(object Object) Object {
+	if object.Type() != ObjectTypeProject && objectValidators[object.Type()].requireProject {
+		return ObjectProject(object.Project())
+	}
+
+	return ObjectServer()
+}
+
+// rbacPermissions is the set of entitlements granted to an identity, indexed by object.
+type rbacPermissions map[Object]map[Entitlement]bool
+
+func newRBACPermissions(permissions []Permission) rbacPermissions {
+	p := rbacPermissions{}
+	for _, permission := range permissions {
+		if p[permission.Object] == nil {
+			p[permission.Object] = map[Entitlement]bool{}
+		}
+
+		p[permission.Object][permission.Entitlement] = true
+	}
+
+	return p
+}
+
+// has returns whether the entitlement on the object is granted, either directly or through the roles.
+func (p rbacPermissions) has(object Object, entitlement Entitlement) bool {
+	relation, ok := rbacModel[object.Type()][entitlement]
+	if !ok {
+		return false
+	}
+
+	if relation.authenticated {
+		return true
+	}
+
+	if relation.grantable && p[object][entitlement] {
+		return true
+	}
+
+	for _, implied := range relation.implied {
+		if p.has(object, implied) {
+			return true
+		}
+	}
+
+	if len(relation.inherited) > 0 {
+		parent := rbacParent(object)
+		for _, inherited := range relation.inherited {
+			if p.has(parent, inherited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// role returns the highest role on the object, if any.
+func (p rbacPermissions) role(object Object) string {
+	for _, role := range []Entitlement{roleAdmin, roleOperator, roleUser, roleViewer} {
+		if p.has(object, role) {
+			return string(role)
+		}
+	}
+
+	return ""
+}
+
+// RBAC represents a role-based access control authorizer with identities, groups and permissions stored in the database.
+type RBAC struct {
+	commonAuthorizer
+	tls *TLS
+
+	identitiesFunc    func(ctx context.Context, authenticationMethod string, identifier string) ([]Identity, error)
+	groupsFunc        func(ctx context.Context, groupNames []string) ([]Permission, error)
+	updateObjectsFunc func(ctx context.Context, update func(object Object) (Object, bool)) error
+}
+
+func (r *RBAC) load(ctx context.Context, certificateCache *certificate.Cache, opts Opts) error {
+	if opts.identitiesFunc == nil || opts.groupsFunc == nil || opts.updateObjectsFunc == nil {
+		return errors.New("RBAC authorization driver requires access to the identities")
+	}
+
+	r.identitiesFunc = opts.identitiesFunc
+	r.groupsFunc = opts.groupsFunc
+	r.updateObjectsFunc = opts.updateObjectsFunc
+
+	r.tls = &TLS{}
+	err := r.tls.load(ctx, certificateCache, opts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// permissions returns the permissions of the identity making the request, including those granted through
+// its identity provider groups. It returns false if the identity is unknown and has no mapped groups.
+func (r *RBAC) permissions(ctx context.Context, details *requestDetails) (rbacPermissions, bool, error) {
+	identities, err := r.identitiesFunc(ctx, details.authenticationProtocol(), details.username())
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed to get permissions of identity %q: %w", details.username(), err)
+	}
+
+	var permissions []Permission
+	found := len(identities) > 0
+	if found {
+		permissions = identities[0].Permissions
+	}
+
+	groupNames, grants := identityProviderGrants(details.mappedIdentityProviderGroups())
+	if len(groupNames) > 0 {
+		groupPermissions, err := r.groupsFunc(ctx, groupNames)
+		if err != nil {
+			return nil, false, fmt.Errorf("Failed to get permissions of identity provider groups of %q: %w", details.username(), err)
+		}
+
+		permissions = append(permissions, groupPermissions...)
+		found = true
+	}
+
+	if len(grants) > 0 {
+		permissions = append(permissions, grants...)
+		found = true
+	}
+
+	return newRBACPermissions(permissions), found, nil
+}
+
+// CheckPermission returns an error if the user does not have the given Entitlement on the given Object.
+func (r *RBAC) CheckPermission(ctx context.Context, req *http.Request, object Object, entitlement Entitlement) error {
+	details, err := r.requestDetails(req)
+	if err != nil {
+		return api.StatusErrorf(http.StatusForbidden, "Failed to extract request details: %v", err)
+	}
+
+	if details.isInternalOrUnix() {
+		return nil
+	}
+
+	permissions, found, err := r.permissions(ctx, details)
+	if err != nil {
+		return err
+	}
+
+	// Use the TLS driver for the certificates which don't have an identity.
+	if !found && details.authenticationProtocol() == api.AuthenticationMethodTLS {
+		return r.tls.CheckPermission(ctx, req, object, entitlement)
+	}
+
+	if !permissions.has(object, entitlement) {
+		r.logger.Debug("Permission denied", logger.Ctx{"object": object, "entitlement": entitlement, "username": details.username(), "protocol": details.authenticationProtocol()})
+		return api.StatusErrorf(http.StatusForbidden, "User does not have entitlement %q on object %q", entitlement, object)
+	}
+
+	return nil
+}
+
+// GetPermissionChecker returns a function that can be used to check whether a user has the required entitlement on an authorization object.
+func (r *RBAC) GetPermissionChecker(ctx context.Context, req *http.Request, entitlement Entitlement, objectType ObjectType) (PermissionChecker, error) {
+	details, err := r.requestDetails(req)
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusForbidden, "Failed to extract request details: %v", err)
+	}
+
+	if details.isInternalOrUnix() {
+		return func(Object) bool { return true }, nil
+	}
+
+	permissions, found, err := r.permissions(ctx, details)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the TLS driver for the certificates which don't have an identity.
+	if !found && details.authenticationProtocol() == api.AuthenticationMethodTLS {
+		return r.tls.GetPermissionChecker(ctx, req, entitlement, objectType)
+	}
+
+	return func(object Object) bool {
+		return permissions.has(object, entitlement)
+	}, nil
+}
+
+// getAccess returns the identities which have a role on the object.
+func (r *RBAC) getAccess(ctx context.Context, object Object) (*api.Access, error) {
+	identities, err := r.identitiesFunc(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	access := api.Access{}
+	for _, identity := range identities {
+		role := newRBACPermissions(identity.Permissions).role(object)
+		if role == "" {
+			continue
+		}
+
+		access = append(access, api.AccessEntry{
+			Identifier: identity.Identifier,
+			Role:       role,
+			Provider:   identity.AuthenticationMethod,
+		})
+	}
+
+	return &access, nil
+}
+
+// GetInstanceAccess returns the list of entities who have access to the instance.
+func (r *RBAC) GetInstanceAccess(ctx context.Context, projectName string, instanceName string) (*api.Access, error) {
+	return r.getAccess(ctx, ObjectInstance(projectName, instanceName))
+}
+
+// GetProjectAccess returns the list of entities who have access to the project.
+func (r *RBAC) GetProjectAccess(ctx context.Context, projectName string) (*api.Access, error) {
+	return r.getAccess(ctx, ObjectProject(projectName))
+}
+
+// deleteObject removes the permissions granted on an object.
+func (r *RBAC) deleteObject(ctx context.Context, object Object) error {
+	return r.updateObjectsFunc(ctx, func(o Object) (Object, bool) {
+		return o, o != object
+	})
+}
+
+// renameObject moves the permissions granted on an object to its new name.
+func (r *RBAC) renameObject(ctx context.Context, oldObject Object, newObject Object) error {
+	return r.updateObjectsFunc(ctx, func(o Object) (Object, bool) {
+		if o == oldObject {
+			return newObject, true
+		}
+
+		return o, true
+	})
+}
+
+// inProject returns whether the object is the project or one of its resources.
+func inProject(object Object, projectName string) bool {
+	return objectValidators[object.Type()].requireProject && object.Project() == projectName
+}
+
+// DeleteProject removes the permissions granted on the project and its resources.
+func (r *RBAC) DeleteProject(ctx context.Context, _ int64, projectName string) error {
+	return r.updateObjectsFunc(ctx, func(o Object) (Object, bool) {
+		return o, !inProject(o, projectName)
+	})
+}
+
+// RenameProject moves the permissions granted on the project and its resources to the new project name.
+func (r *RBAC) RenameProject(ctx context.Context, _ int64, oldName string, newName string) error {
+	return r.updateObjectsFunc(ctx, func(o Object) (Object, bool) {
+		if !inProject(o, oldName) {
+			return o, true
+		}
+
+		newObject, err := NewObject(o.Type(), newName, o.Elements()...)
+		if err != nil {
+			return o, true
+		}
+
+		return newObject, true
+	})
+}
+
+// DeleteCertificate removes the permissions granted on the certificate.
+func (r *RBAC) DeleteCertificate(ctx context.Context, fingerprint string) error {
+	return r.deleteObject(ctx, ObjectCertificate(fingerprint))
+}
+
+// DeleteStoragePool removes the permissions granted on the storage pool.
+func (r *RBAC) DeleteStoragePool(ctx context.Context, storagePoolName string) error {
+	return r.deleteObject(ctx, ObjectStoragePool(storagePoolName))
+}
+
+// DeleteImage removes the permissions granted on the image.
+func (r *RBAC) DeleteImage(ctx context.Context, projectName string, fingerprint string) error {
+	return r.deleteObject(ctx, ObjectImage(projectName, fingerprint))
+}
+
+// DeleteImageAlias removes the permissions granted on the image alias.
+func (r *RBAC) DeleteImageAlias(ctx context.Context, projectName string, imageAliasName string) error {
+	return r.deleteObject(ctx, ObjectImageAlias(projectName, imageAliasName))
+}
+
+// RenameImageAlias moves the permissions granted on the image alias to its new name.
+func (r *RBAC) RenameImageAlias(ctx context.Context, projectName string, oldAliasName string, newAliasName string) error {
+	return r.renameObject(ctx, ObjectImageAlias(projectName, oldAliasName), ObjectImageAlias(projectName, newAliasName))
+}
+
+// DeleteInstance removes the permissions granted on the instance.
+func (r *RBAC) DeleteInstance(ctx context.Context, projectName string, instanceName string) error {
+	return r.deleteObject(ctx, ObjectInstance(projectName, instanceName))
+}
+
+// RenameInstance moves the permissions granted on the instance to its new name.
+func (r *RBAC) RenameInstance(ctx context.Context, projectName string, oldInstanceName string, newInstanceName string) error {
+	return r.renameObject(ctx, ObjectInstance(projectName, oldInstanceName), ObjectInstance(projectName, newInstanceName))
+}
+
+// DeleteNetwork removes the permissions granted on the network.
+func (r *RBAC) DeleteNetwork(ctx context.Context, projectName string, networkName string) error {
+	return r.deleteObject(ctx, ObjectNetwork(projectName, networkName))
+}
+
+// RenameNetwork moves the permissions granted on the network to its new name.
+func (r *RBAC) RenameNetwork(ctx context.Context, projectName string, oldNetworkName string, newNetworkName string) error {
+	return r.renameObject(ctx, ObjectNetwork(projectName, oldNetworkName), ObjectNetwork(projectName, newNetworkName))
+}
+
+// DeleteNetworkZone removes the permissions granted on the network zone.
+func (r *RBAC) DeleteNetworkZone(ctx context.Context, projectName string, networkZoneName string) error {
+	return r.deleteObject(ctx, ObjectNetworkZone(projectName, networkZoneName))
+}
+
+// DeleteNetworkIntegration removes the permissions granted on the network integration.
+func (r *RBAC) DeleteNetworkIntegration(ctx context.Context, networkIntegrationName string) error {
+	return r.deleteObject(ctx, ObjectNetworkIntegration(networkIntegrationName))
+}
+
+// RenameNetworkIntegration moves the permissions granted on the network integration to its new name.
+func (r *RBAC) RenameNetworkIntegration(ctx context.Context, oldNetworkIntegrationName string, newNetworkIntegrationName string) error {
+	return r.renameObject(ctx, ObjectNetworkIntegration(oldNetworkIntegrationName), ObjectNetworkIntegration(newNetworkIntegrationName))
+}
+
+// DeleteNetworkACL removes the permissions granted on the network ACL.
+func (r *RBAC) DeleteNetworkACL(ctx context.Context, projectName string, networkACLName string) error {
+	return r.deleteObject(ctx, ObjectNetworkACL(projectName, networkACLName))
+}
+
+// RenameNetworkACL moves the permissions granted on the network ACL to its new name.
+func (r *RBAC) RenameNetworkACL(ctx context.Context, projectName string, oldNetworkACLName string, newNetworkACLName string) error {
+	return r.renameObject(ctx, ObjectNetworkACL(projectName, oldNetworkACLName), ObjectNetworkACL(projectName, newNetworkACLName))
+}
+
+// DeleteNetworkAddressSet removes the permissions granted on the network address set.
+func (r *RBAC) DeleteNetworkAddressSet(ctx context.Context, projectName string, networkAddressSetName string) error {
+	return r.deleteObject(ctx, ObjectNetworkAddressSet(projectName, networkAddressSetName))
+}
+
+// RenameNetworkAddressSet moves the permissions granted on the network address set to its new name.
+func (r *RBAC) RenameNetworkAddressSet(ctx context.Context, projectName string, oldNetworkAddressSetName string, newNetworkAddressSetName string) error {
+	return r.renameObject(ctx, ObjectNetworkAddressSet(projectName, oldNetworkAddressSetName), ObjectNetworkAddressSet(projectName, newNetworkAddressSetName))
+}
+
+// DeleteProfile removes the permissions granted on the profile.
+func (r *RBAC) DeleteProfile(ctx context.Context, projectName string, profileName string) error {
+	return r.deleteObject(ctx, ObjectProfile(projectName, profileName))
+}
+
+// RenameProfile moves the permissions granted on the profile to its new name.
+func (r *RBAC) RenameProfile(ctx context.Context, projectName string, oldProfileName string, newProfileName string) error {
+	return r.renameObject(ctx, ObjectProfile(projectName, oldProfileName), ObjectProfile(projectName, newProfileName))
+}
+
+// DeleteStoragePoolVolume removes the permissions granted on the storage volume.
+func (r *RBAC) DeleteStoragePoolVolume(ctx context.Context, projectName string, storagePoolName string, storageVolumeType string, storageVolumeName string, storageVolumeLocation string) error {
+	return r.deleteObject(ctx, ObjectStorageVolume(projectName, storagePoolName, storageVolumeType, storageVolumeName, storageVolumeLocation))
+}
+
+// RenameStoragePoolVolume moves the permissions granted on the storage volume to its new name.
+func (r *RBAC) RenameStoragePoolVolume(ctx context.Context, projectName string, storagePoolName string, storageVolumeType string, oldStorageVolumeName string, newStorageVolumeName string, storageVolumeLocation string) error {
+	return r.renameObject(ctx, ObjectStorageVolume(projectName, storagePoolName, storageVolumeType, oldStorageVolumeName, storageVolumeLocation), ObjectStorageVolume(projectName, storagePoolName, storageVolumeType, newStorageVolumeName, storageVolumeLocation))
+}
+
+// DeleteStorageBucket removes the permissions granted on the storage bucket.
+func (r *RBAC) DeleteStorageBucket(ctx context.Context, projectName string, storagePoolName string, storageBucketName string, storageBucketLocation string) error {
+	return r.deleteObject(ctx, ObjectStorageBucket(projectName, storagePoolName, storageBucketName, storageBucketLocation))
+}
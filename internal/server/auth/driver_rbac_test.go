@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRBACPermissions(t *testing.T) {
+	permissions := newRBACPermissions([]Permission{
+		{Entitlement: roleOperator, Object: ObjectProject("foo")},
+		{Entitlement: roleUser, Object: ObjectInstance("bar", "c1")},
+		{Entitlement: EntitlementCanView, Object: ObjectProfile("bar", "default")},
+		{Entitlement: EntitlementCanCreateStoragePools, Object: ObjectServer()},
+	})
+
+	tests := []struct {
+		object      Object
+		entitlement Entitlement
+		allowed     bool
+	}{
+		// Entitlements available to all authenticated users.
+		{ObjectServer(), EntitlementCanView, true},
+		{ObjectStoragePool("default"), EntitlementCanView, true},
+
+		// Direct grants on the server.
+		{ObjectServer(), EntitlementCanCreateStoragePools, true},
+		{ObjectServer(), EntitlementCanCreateProjects, false},
+		{ObjectServer(), EntitlementCanViewSensitive, false},
+
+		// Project operator.
+		{ObjectProject("foo"), EntitlementCanView, true},
+		{ObjectProject("foo"), EntitlementCanCreateInstances, true},
+		{ObjectProject("foo"), EntitlementCanViewEvents, true},
+		{ObjectProject("foo"), EntitlementCanEdit, false},
+		{ObjectInstance("foo", "c1"), EntitlementCanEdit, true},
+		{ObjectInstance("foo", "c1"), EntitlementCanExec, true},
+		{ObjectStorageVolume("foo", "default", "custom", "vol1", ""), EntitlementCanManageSnapshots, true},
+		{ObjectNetwork("foo", "net1"), EntitlementCanEdit, true},
+
+		// Instance user.
+		{ObjectInstance("bar", "c1"), EntitlementCanExec, true},
+		{ObjectInstance("bar", "c1"), EntitlementCanView, true},
+		{ObjectInstance("bar", "c1"), EntitlementCanUpdateState, false},
+		{ObjectInstance("bar", "c1"), EntitlementCanEdit, false},
+		{ObjectInstance("bar", "c2"), EntitlementCanView, false},
+		{ObjectProject("bar"), EntitlementCanView, false},
+
+		// Direct grant on a project resource.
+		{ObjectProfile("bar", "default"), EntitlementCanView, true},
+		{ObjectProfile("bar", "default"), EntitlementCanEdit, false},
+
+		// Unknown entitlement.
+		{ObjectInstance("foo", "c1"), EntitlementCanCreateInstances, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.allowed, permissions.has(test.object, test.entitlement), "%s on %s", test.entitlement, test.object)
+	}
+
+	assert.Equal(t, "operator", permissions.role(ObjectInstance("foo", "c1")))
+	assert.Equal(t, "user", permissions.role(ObjectInstance("bar", "c1")))
+	assert.Empty(t, permissions.role(ObjectInstance("bar", "c2")))
+}
+
+func TestRBACServerAdmin(t *testing.T) {
+	permissions := newRBACPermissions([]Permission{{Entitlement: roleAdmin, Object: ObjectServer()}})
+
+	assert.True(t, permissions.has(ObjectServer(), EntitlementCanEdit))
+	assert.True(t, permissions.has(ObjectCertificate("abcd"), EntitlementCanEdit))
+	assert.True(t, permissions.has(ObjectProject("foo"), EntitlementCanEdit))
+	assert.True(t, permissions.has(ObjectInstance("foo", "c1"), EntitlementCanAccessConsole))
+	assert.True(t, permissions.has(ObjectStorageBucket("foo", "default", "b1", ""), EntitlementCanEdit))
+}
+
+func TestValidatePermission(t *testing.T) {
+	assert.NoError(t, ValidatePermission(roleAdmin, ObjectServer()))
+	assert.NoError(t, ValidatePermission(roleViewer, ObjectProject("foo")))
+	assert.NoError(t, ValidatePermission(EntitlementCanExec, ObjectInstance("foo", "c1")))
+	assert.NoError(t, ValidatePermission(EntitlementCanEdit, ObjectStoragePool("default")))
+
+	// Derived entitlements can't be granted.
+	assert.Error(t, ValidatePermission(EntitlementCanEdit, ObjectServer()))
+	assert.Error(t, ValidatePermission(EntitlementCanView, ObjectInstance("foo", "c1")))
+	assert.Error(t, ValidatePermission(EntitlementCanView, ObjectStoragePool("default")))
+
+	// Roles only exist on the server, projects and instances.
+	assert.Error(t, ValidatePermission(roleAdmin, ObjectNetwork("foo", "net1")))
+
+	// Invalid objects.
+	assert.Error(t, ValidatePermission(roleAdmin, Object("server:other")))
+	assert.Error(t, ValidatePermission(EntitlementCanExec, Object("instance:foo")))
+	assert.Error(t, ValidatePermission(EntitlementCanExec, Object("foo")))
+}
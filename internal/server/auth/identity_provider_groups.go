@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus/v7/shared/validate"
+)
+
+// IdentityProviderGroups maps the groups of an identity provider to what they grant in Incus.
+//
+// Each identity provider group maps to a list of targets, which are either:
+//   - group:<name> for membership of an authorization group (built-in or OpenFGA).
+//   - server:<role> for a role on the server.
+//   - project:<name>:<role> for a role on a project.
+type IdentityProviderGroups map[string][]string
+
+// identityProviderRoles are the roles which can be granted through identity provider groups.
+var identityProviderRoles = []Entitlement{roleAdmin, roleOperator, roleUser, roleViewer}
+
+// ParseIdentityProviderGroups parses a comma separated list of <identity provider group>=<target> entries.
+func ParseIdentityProviderGroups(value string) (IdentityProviderGroups, error) {
+	mapping := IdentityProviderGroups{}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// Split on the last separator as identity provider groups may contain one.
+		idx := strings.LastIndex(entry, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("Invalid identity provider group mapping %q, expected <group>=<target>", entry)
+		}
+
+		group := strings.TrimSpace(entry[:idx])
+		target := strings.TrimSpace(entry[idx+1:])
+
+		err := validateIdentityProviderTarget(target)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid identity provider group mapping %q: %w", entry, err)
+		}
+
+		if !slices.Contains(mapping[group], target) {
+			mapping[group] = append(mapping[group], target)
+		}
+	}
+
+	return mapping, nil
+}
+
+// validateIdentityProviderTarget checks what an identity provider group maps to.
+func validateIdentityProviderTarget(target string) error {
+	fields := strings.Split(target, ":")
+
+	switch fields[0] {
+	case "group":
+		if len(fields) != 2 {
+			return fmt.Errorf("Expected group:<name>, got %q", target)
+		}
+
+		return validate.IsAPIName(fields[1], false)
+	case string(ObjectTypeServer):
+		if len(fields) != 2 {
+			return fmt.Errorf("Expected server:<role>, got %q", target)
+		}
+
+		return validateIdentityProviderRole(fields[1])
+	case string(ObjectTypeProject):
+		if len(fields) != 3 {
+			return fmt.Errorf("Expected project:<name>:<role>, got %q", target)
+		}
+
+		err := validate.IsAPIName(fields[1], false)
+		if err != nil {
+			return err
+		}
+
+		return validateIdentityProviderRole(fields[2])
+	}
+
+	return fmt.Errorf("Unknown target %q", target)
+}
+
+func validateIdentityProviderRole(role string) error {
+	if !slices.Contains(identityProviderRoles, Entitlement(role)) {
+		return fmt.Errorf("Unknown role %q", role)
+	}
+
+	return nil
+}
+
+// Map returns the sorted list of targets the given identity provider groups map to.
+func (m IdentityProviderGroups) Map(groups []string) []string {
+	targets := []string{}
+	for _, group := range groups {
+		for _, target := range m[group] {
+			if !slices.Contains(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	slices.Sort(targets)
+
+	return targets
+}
+
+// identityProviderGrants splits the mapped identity provider targets into authorization groups and role permissions.
+func identityProviderGrants(targets []string) ([]string, []Permission) {
+	var groups []string
+	var permissions []Permission
+
+	for _, target := range targets {
+		fields := strings.Split(target, ":")
+
+		switch {
+		case fields[0] == "group" && len(fields) == 2:
+			groups = append(groups, fields[1])
+		case fields[0] == string(ObjectTypeServer) && len(fields) == 2:
+			permissions = append(permissions, Permission{Entitlement: Entitlement(fields[1]), Object: ObjectServer()})
+		case fields[0] == string(ObjectTypeProject) && len(fields) == 3:
+			permissions = append(permissions, Permission{Entitlement: Entitlement(fields[2]), Object: ObjectProject(fields[1])})
+		}
+	}
+
+	return groups, permissions
+}
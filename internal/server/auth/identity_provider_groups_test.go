@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentityProviderGroups(t *testing.T) {
+	mapping, err := ParseIdentityProviderGroups("admins=server:admin, devs=project:webapp:operator, devs=group:developers, cn=ops=project:infra:user")
+	require.NoError(t, err)
+
+	assert.Equal(t, IdentityProviderGroups{
+		"admins": {"server:admin"},
+		"devs":   {"project:webapp:operator", "group:developers"},
+		"cn=ops": {"project:infra:user"},
+	}, mapping)
+
+	mapping, err = ParseIdentityProviderGroups("")
+	require.NoError(t, err)
+	assert.Empty(t, mapping)
+
+	for _, value := range []string{
+		"admins",
+		"=server:admin",
+		"admins=server:root",
+		"admins=server",
+		"devs=project:webapp",
+		"devs=project:web app:operator",
+		"devs=instance:default/c1:user",
+		"devs=group:",
+	} {
+		_, err := ParseIdentityProviderGroups(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestIdentityProviderGroupsMap(t *testing.T) {
+	mapping := IdentityProviderGroups{
+		"admins": {"server:admin"},
+		"devs":   {"project:webapp:operator", "group:developers"},
+		"ops":    {"group:developers"},
+	}
+
+	targets := mapping.Map([]string{"ops", "devs", "unknown"})
+	assert.Equal(t, []string{"group:developers", "project:webapp:operator"}, targets)
+	assert.Empty(t, mapping.Map(nil))
+
+	groups, permissions := identityProviderGrants(mapping.Map([]string{"admins", "devs"}))
+	assert.Equal(t, []string{"developers"}, groups)
+	assert.Equal(t, []Permission{
+		{Entitlement: roleOperator, Object: ObjectProject("webapp")},
+		{Entitlement: roleAdmin, Object: ObjectServer()},
+	}, permissions)
+
+	// Roles granted through the mapping follow the usual inheritance.
+	rbac := newRBACPermissions(permissions)
+	assert.True(t, rbac.has(ObjectInstance("webapp", "c1"), EntitlementCanExec))
+}
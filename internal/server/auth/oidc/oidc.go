@@ -26,12 +26,13 @@ import (
 type Verifier struct {
 	accessTokenVerifier *op.AccessTokenVerifier
 
-	clientID  string
-	issuer    string
-	scopes    []string
-	audience  string
-	claim     string
-	cookieKey []byte
+	clientID    string
+	issuer      string
+	scopes      []string
+	audience    string
+	claim       string
+	groupsClaim string
+	cookieKey   []byte
 }
 
 // AuthError represents an authentication error.
@@ -48,7 +49,8 @@ func (e AuthError) Unwrap() error {
 }
 
 // Auth extracts the token, validates it and returns the user information.
-func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Request) (string, error) {
+// It returns the username along with the identity provider groups of the user.
+func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Request) (string, []string, error) {
 	var token string
 
 	auth := r.Header.Get("Authorization")
@@ -61,7 +63,7 @@ func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Requ
 		// Both returned errors contain information which are needed for the client to authenticate.
 		parts := strings.Split(auth, "Bearer ")
 		if len(parts) != 2 {
-			return "", &AuthError{errors.New("Bad authorization token, expected a Bearer token")}
+			return "", nil, &AuthError{errors.New("Bad authorization token, expected a Bearer token")}
 		}
 
 		token = parts[1]
@@ -69,7 +71,7 @@ func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Requ
 		// When not using a Bearer token, fetch the equivalent from a cookie and move on with it.
 		cookie, err := r.Cookie("oidc_access")
 		if err != nil {
-			return "", &AuthError{err}
+			return "", nil, &AuthError{err}
 		}
 
 		token = cookie.Value
@@ -80,7 +82,7 @@ func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Requ
 
 		o.accessTokenVerifier, err = getAccessTokenVerifier(o.issuer)
 		if err != nil {
-			return "", &AuthError{err}
+			return "", nil, &AuthError{err}
 		}
 	}
 
@@ -89,13 +91,13 @@ func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Requ
 		// See if we can refresh the access token.
 		cookie, cookieErr := r.Cookie("oidc_refresh")
 		if cookieErr != nil {
-			return "", &AuthError{err}
+			return "", nil, &AuthError{err}
 		}
 
 		// Get the provider.
 		provider, err := o.getProvider(r)
 		if err != nil {
-			return "", &AuthError{err}
+			return "", nil, &AuthError{err}
 		}
 
 		// Attempt the refresh.
@@ -106,13 +108,13 @@ func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Requ
 				o.clearCookies(w)
 			}
 
-			return "", &AuthError{err}
+			return "", nil, &AuthError{err}
 		}
 
 		// Validate the refreshed token.
 		claims, err = o.VerifyAccessToken(ctx, r, tokens.AccessToken)
 		if err != nil {
-			return "", &AuthError{err}
+			return "", nil, &AuthError{err}
 		}
 
 		// If we have a ResponseWriter, refresh the cookies.
@@ -121,6 +123,16 @@ func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	username, err := o.username(claims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return username, o.groups(claims), nil
+}
+
+// username returns the username from the access token claims.
+func (o *Verifier) username(claims *oidc.AccessTokenClaims) (string, error) {
 	if o.claim != "" {
 		claim := claims.Claims[o.claim]
 		username, ok := claim.(string)
@@ -142,6 +154,31 @@ func (o *Verifier) Auth(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return claims.Subject, nil
 }
 
+// groups returns the identity provider groups from the access token claims.
+// The groups claim can either be a list of strings or a single string.
+func (o *Verifier) groups(claims *oidc.AccessTokenClaims) []string {
+	if o.groupsClaim == "" {
+		return nil
+	}
+
+	switch claim := claims.Claims[o.groupsClaim].(type) {
+	case string:
+		return []string{claim}
+	case []any:
+		groups := make([]string, 0, len(claim))
+		for _, entry := range claim {
+			group, ok := entry.(string)
+			if ok && group != "" {
+				groups = append(groups, group)
+			}
+		}
+
+		return groups
+	}
+
+	return nil
+}
+
 // Login starts the OIDC login flow by redirecting the client to the provider's authorization endpoint.
 func (o *Verifier) Login(w http.ResponseWriter, r *http.Request) {
 	// Get the provider.
@@ -432,14 +469,14 @@ func getAccessTokenVerifier(issuer string) (*op.AccessTokenVerifier, error) {
 }
 
 // NewVerifier returns a Verifier.
-func NewVerifier(issuer string, clientid string, scope string, audience string, claim string) (*Verifier, error) {
+func NewVerifier(issuer string, clientid string, scope string, audience string, claim string, groupsClaim string) (*Verifier, error) {
 	cookieKey, err := uuid.New().MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create UUID: %w", err)
 	}
 
 	scopes := util.SplitNTrimSpace(scope, ",", -1, false)
-	verifier := &Verifier{issuer: issuer, clientID: clientid, scopes: scopes, audience: audience, cookieKey: cookieKey, claim: claim}
+	verifier := &Verifier{issuer: issuer, clientID: clientid, scopes: scopes, audience: audience, cookieKey: cookieKey, claim: claim, groupsClaim: groupsClaim}
 	verifier.accessTokenVerifier, _ = getAccessTokenVerifier(issuer)
 
 	return verifier, nil
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// TokenPrefix is the prefix of all API token secrets.
+const TokenPrefix = "incus_"
+
+// NewTokenSecret generates a new random API token secret.
+func NewTokenSecret() (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", fmt.Errorf("Failed generating API token secret: %w", err)
+	}
+
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// TokenHash returns the hash under which an API token secret is stored.
+func TokenHash(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// TokenFromRequest returns the API token secret passed as a bearer token, if any.
+func TokenFromRequest(r *http.Request) (string, bool) {
+	scheme, secret, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || !strings.HasPrefix(secret, TokenPrefix) {
+		return "", false
+	}
+
+	return secret, true
+}
+
+// Token represents the scope of an API token.
+type Token struct {
+	// Projects the token is restricted to. An empty list gives access to all projects.
+	Projects []string
+
+	// Entitlements granted to the token on the objects within its projects.
+	Entitlements []Entitlement
+}
+
+// allows returns whether the scope of the token covers the entitlement on the object.
+func (t *Token) allows(object Object, entitlement Entitlement) bool {
+	// Viewing the server is required by all clients.
+	if object.Type() == ObjectTypeServer && entitlement == EntitlementCanView {
+		return true
+	}
+
+	if !slices.Contains(t.Entitlements, entitlement) {
+		return false
+	}
+
+	if len(t.Projects) == 0 {
+		return true
+	}
+
+	projectName := object.Project()
+	if projectName == "" {
+		// Tokens restricted to some projects only get read access to server level objects.
+		switch object.Type() {
+		case ObjectTypeServer:
+			return entitlement == EntitlementCanViewResources || entitlement == EntitlementCanViewMetrics
+		case ObjectTypeStoragePool, ObjectTypeCertificate:
+			return entitlement == EntitlementCanView
+		}
+
+		return false
+	}
+
+	if slices.Contains(t.Projects, projectName) {
+		return true
+	}
+
+	// Also allow read-only access to inherited resources.
+	if projectName == api.ProjectDefaultName && entitlement == EntitlementCanView && slices.Contains([]ObjectType{ObjectTypeImage, ObjectTypeProfile, ObjectTypeStorageVolume, ObjectTypeStorageBucket, ObjectTypeNetwork, ObjectTypeNetworkZone}, object.Type()) {
+		return true
+	}
+
+	return false
+}
+
+// ValidateTokenEntitlement checks that an entitlement can be granted to an API token.
+func ValidateTokenEntitlement(entitlement Entitlement) error {
+	if slices.Contains([]Entitlement{roleAdmin, roleOperator, roleUser, roleViewer}, entitlement) {
+		return fmt.Errorf("Roles can't be granted to API tokens, use entitlements instead of %q", entitlement)
+	}
+
+	for _, relations := range rbacModel {
+		_, ok := relations[entitlement]
+		if ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown entitlement %q", entitlement)
+}
+
+// tokenAuthorizer restricts requests made with an API token to the scope of the token.
+type tokenAuthorizer struct {
+	Authorizer
+
+	common    commonAuthorizer
+	tokenFunc func(ctx context.Context, name string) (*Token, error)
+}
+
+// WithTokens returns an Authorizer restricting requests made with an API token to the scope of the token.
+// All other requests are handled by the given authorizer.
+func WithTokens(authorizer Authorizer, tokenFunc func(ctx context.Context, name string) (*Token, error)) Authorizer {
+	return &tokenAuthorizer{Authorizer: authorizer, tokenFunc: tokenFunc}
+}
+
+// requestToken returns the scope of the API token used for the request, if any.
+func (t *tokenAuthorizer) requestToken(ctx context.Context, r *http.Request) (*requestDetails, *Token, error) {
+	details, err := t.common.requestDetails(r)
+	if err != nil || details.authenticationProtocol() != api.AuthenticationMethodToken {
+		return nil, nil, nil
+	}
+
+	token, err := t.tokenFunc(ctx, details.username())
+	if err != nil {
+		return nil, nil, api.StatusErrorf(http.StatusForbidden, "Failed to load API token: %v", err)
+	}
+
+	if details.IsAllProjectsRequest && len(token.Projects) > 0 {
+		return nil, nil, api.StatusErrorf(http.StatusForbidden, "API token is restricted")
+	}
+
+	return details, token, nil
+}
+
+// CheckPermission returns an error if the user does not have the given Entitlement on the given Object.
+func (t *tokenAuthorizer) CheckPermission(ctx context.Context, r *http.Request, object Object, entitlement Entitlement) error {
+	details, token, err := t.requestToken(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		return t.Authorizer.CheckPermission(ctx, r, object, entitlement)
+	}
+
+	if !token.allows(object, entitlement) {
+		return api.StatusErrorf(http.StatusForbidden, "API token %q doesn't have %q on %q", details.username(), entitlement, object)
+	}
+
+	return nil
+}
+
+// GetPermissionChecker returns a function that can be used to check whether a user has the required entitlement on an authorization object.
+func (t *tokenAuthorizer) GetPermissionChecker(ctx context.Context, r *http.Request, entitlement Entitlement, objectType ObjectType) (PermissionChecker, error) {
+	_, token, err := t.requestToken(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil {
+		return t.Authorizer.GetPermissionChecker(ctx, r, entitlement, objectType)
+	}
+
+	return func(object Object) bool {
+		return token.allows(object, entitlement)
+	}, nil
+}
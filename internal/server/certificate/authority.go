@@ -0,0 +1,213 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Authority is the certificate authority issuing short-lived client certificates.
+type Authority struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// RevokedCertificate identifies a revoked certificate for inclusion in the certificate revocation list.
+type RevokedCertificate struct {
+	Serial    string
+	RevokedAt time.Time
+}
+
+// GenerateAuthority generates a new certificate authority and returns its PEM encoded certificate and key.
+func GenerateAuthority() ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to generate key: %w", err)
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validFrom := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Linux Containers"},
+			CommonName:   "Incus client certificate authority",
+		},
+		NotBefore: validFrom,
+		NotAfter:  validFrom.Add(10 * 365 * 24 * time.Hour),
+
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to create certificate: %w", err)
+	}
+
+	data, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: data})
+
+	return cert, keyPEM, nil
+}
+
+// LoadAuthority loads a certificate authority from its PEM encoded certificate and key.
+func LoadAuthority(certPEM []byte, keyPEM []byte) (*Authority, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, errors.New("Invalid certificate authority certificate")
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse certificate authority certificate: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("Invalid certificate authority key")
+	}
+
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse certificate authority key: %w", err)
+	}
+
+	return &Authority{cert: cert, key: key}, nil
+}
+
+// Certificate returns the certificate of the authority.
+func (a *Authority) Certificate() *x509.Certificate {
+	return a.cert
+}
+
+// CertificatePEM returns the PEM encoded certificate of the authority.
+func (a *Authority) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: a.cert.Raw})
+}
+
+// Issue signs a client certificate for the public key of the certificate request.
+// It returns the PEM encoded certificate along with its serial number.
+func (a *Authority) Issue(csrPEM []byte, commonName string, expiresAt time.Time) ([]byte, string, error) {
+	csrBlock, _ := pem.Decode(csrPEM)
+	if csrBlock == nil || csrBlock.Type != "CERTIFICATE REQUEST" {
+		return nil, "", errors.New("Invalid certificate request")
+	}
+
+	csr, err := x509.ParseCertificateRequest(csrBlock.Bytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to parse certificate request: %w", err)
+	}
+
+	err = csr.CheckSignature()
+	if err != nil {
+		return nil, "", fmt.Errorf("Invalid certificate request signature: %w", err)
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if expiresAt.After(a.cert.NotAfter) {
+		expiresAt = a.cert.NotAfter
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Linux Containers"},
+			CommonName:   commonName,
+		},
+		// Allow for some clock skew between the server and its clients.
+		NotBefore: time.Now().Add(-time.Minute),
+		NotAfter:  expiresAt,
+
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, a.cert, csr.PublicKey, a.key)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to create certificate: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}), SerialString(serialNumber), nil
+}
+
+// Issued returns whether the certificate was signed by the authority and is currently valid.
+func (a *Authority) Issued(cert *x509.Certificate) bool {
+	if cert.CheckSignatureFrom(a.cert) != nil {
+		return false
+	}
+
+	now := time.Now()
+
+	return !now.Before(cert.NotBefore) && !now.After(cert.NotAfter)
+}
+
+// CRL returns the PEM encoded certificate revocation list for the given revoked certificates.
+func (a *Authority) CRL(revoked []RevokedCertificate, number int64) ([]byte, error) {
+	entries := make([]x509.RevocationListEntry, 0, len(revoked))
+	for _, entry := range revoked {
+		serialNumber, ok := new(big.Int).SetString(entry.Serial, 16)
+		if !ok {
+			return nil, fmt.Errorf("Invalid certificate serial number %q", entry.Serial)
+		}
+
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serialNumber,
+			RevocationTime: entry.RevokedAt,
+		})
+	}
+
+	now := time.Now()
+	template := x509.RevocationList{
+		RevokedCertificateEntries: entries,
+		Number:                    big.NewInt(number),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(24 * time.Hour),
+	}
+
+	derBytes, err := x509.CreateRevocationList(rand.Reader, &template, a.cert, a.key)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create certificate revocation list: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: derBytes}), nil
+}
+
+// SerialString returns the hexadecimal representation of a certificate serial number.
+func SerialString(serialNumber *big.Int) string {
+	return serialNumber.Text(16)
+}
+
+func newSerialNumber() (*big.Int, error) {
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to generate serial number: %w", err)
+	}
+
+	return serialNumber, nil
+}
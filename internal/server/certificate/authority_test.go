@@ -0,0 +1,95 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCSR(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func parseCert(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestAuthorityIssue(t *testing.T) {
+	certPEM, keyPEM, err := GenerateAuthority()
+	require.NoError(t, err)
+
+	authority, err := LoadAuthority(certPEM, keyPEM)
+	require.NoError(t, err)
+	assert.True(t, authority.Certificate().IsCA)
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	issuedPEM, serial, err := authority.Issue(newCSR(t), "jane@example.com", expiresAt)
+	require.NoError(t, err)
+
+	issued := parseCert(t, issuedPEM)
+	assert.Equal(t, serial, SerialString(issued.SerialNumber))
+	assert.Equal(t, "jane@example.com", issued.Subject.CommonName)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, issued.ExtKeyUsage)
+	assert.True(t, expiresAt.Equal(issued.NotAfter))
+	assert.True(t, authority.Issued(issued))
+
+	// Certificates from another authority aren't recognized.
+	otherCertPEM, otherKeyPEM, err := GenerateAuthority()
+	require.NoError(t, err)
+
+	other, err := LoadAuthority(otherCertPEM, otherKeyPEM)
+	require.NoError(t, err)
+	assert.False(t, other.Issued(issued))
+
+	// Expired certificates aren't valid.
+	expiredPEM, _, err := authority.Issue(newCSR(t), "jane@example.com", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	assert.False(t, authority.Issued(parseCert(t, expiredPEM)))
+
+	// Invalid requests are rejected.
+	_, _, err = authority.Issue([]byte("not a request"), "jane@example.com", expiresAt)
+	assert.Error(t, err)
+}
+
+func TestAuthorityCRL(t *testing.T) {
+	certPEM, keyPEM, err := GenerateAuthority()
+	require.NoError(t, err)
+
+	authority, err := LoadAuthority(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	crlPEM, err := authority.CRL([]RevokedCertificate{{Serial: "1f", RevokedAt: time.Now()}}, 3)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(crlPEM)
+	require.NotNil(t, block)
+
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	require.NoError(t, err)
+	require.NoError(t, crl.CheckSignatureFrom(authority.Certificate()))
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+	assert.Equal(t, "1f", SerialString(crl.RevokedCertificateEntries[0].SerialNumber))
+	assert.Equal(t, int64(3), crl.Number.Int64())
+
+	_, err = authority.CRL([]RevokedCertificate{{Serial: "xyz"}}, 4)
+	assert.Error(t, err)
+}
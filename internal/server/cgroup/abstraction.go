@@ -604,6 +604,34 @@ func (cg *CGroup) GetOOMKills() (int64, error) {
 	return -1, errors.New("Failed getting oom_kill")
 }
 
+// GetMemoryPressure returns the share of time (in percent) some tasks were stalled on memory over the last 10 seconds.
+func (cg *CGroup) GetMemoryPressure() (float64, error) {
+	if !cgControllers["memory"] {
+		return -1, ErrControllerMissing
+	}
+
+	stats, err := cg.rw.Get("memory", "memory.pressure")
+	if err != nil {
+		return -1, err
+	}
+
+	for _, stat := range strings.Split(stats, "\n") {
+		fields := strings.Fields(stat)
+		if len(fields) < 2 || fields[0] != "some" {
+			continue
+		}
+
+		value, ok := strings.CutPrefix(fields[1], "avg10=")
+		if !ok {
+			continue
+		}
+
+		return strconv.ParseFloat(value, 64)
+	}
+
+	return -1, errors.New("Failed getting memory pressure")
+}
+
 // GetIOStats returns disk stats.
 func (cg *CGroup) GetIOStats() (map[string]*IOStats, error) {
 	partitions, err := os.ReadFile("/proc/partitions")
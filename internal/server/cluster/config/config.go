@@ -12,9 +12,11 @@ import (
 	"github.com/sirupsen/logrus"
 
 	internalInstance "github.com/lxc/incus/v7/internal/instance"
+	"github.com/lxc/incus/v7/internal/server/auth"
 	"github.com/lxc/incus/v7/internal/server/config"
 	"github.com/lxc/incus/v7/internal/server/db"
 	scriptletLoad "github.com/lxc/incus/v7/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v7/shared/units"
 	"github.com/lxc/incus/v7/shared/validate"
 )
 
@@ -240,6 +242,19 @@ func (c *Config) AuthorizationScriptlet() string {
 	return c.m.GetString("authorization.scriptlet")
 }
 
+// Audit returns whether the API audit log is enabled along with its maximum size and number of rotated files.
+func (c *Config) Audit() (bool, int64, int) {
+	// Errors can be ignored as the value is validated.
+	maxSize, _ := units.ParseByteSizeString(c.m.GetString("audit.max_size"))
+
+	return c.m.GetBool("audit.enabled"), maxSize, int(c.m.GetInt64("audit.max_files"))
+}
+
+// AuthorizationRBAC returns whether the built-in role-based access control is enabled.
+func (c *Config) AuthorizationRBAC() bool {
+	return c.m.GetBool("authorization.rbac")
+}
+
 // InstancesLXCFSPerInstance returns whether LXCFS should be run on a per-instance basis.
 func (c *Config) InstancesLXCFSPerInstance() bool {
 	return c.m.GetBool("instances.lxcfs.per_instance")
@@ -321,11 +336,29 @@ func (c *Config) RemoteTokenExpiry() string {
 	return c.m.GetString("core.remote_token_expiry")
 }
 
+// IssuedCertificatesExpiry returns the maximum lifetime of client certificates issued by the server.
+func (c *Config) IssuedCertificatesExpiry() string {
+	return c.m.GetString("core.issued_certificates_expiry")
+}
+
+// UsageRetention returns how long the resource usage history of projects is kept.
+func (c *Config) UsageRetention() string {
+	return c.m.GetString("core.usage_retention")
+}
+
 // OIDCServer returns all the OpenID Connect settings needed to connect to a server.
 func (c *Config) OIDCServer() (string, string, string, string, string) {
 	return c.m.GetString("oidc.issuer"), c.m.GetString("oidc.client.id"), c.m.GetString("oidc.scopes"), c.m.GetString("oidc.audience"), c.m.GetString("oidc.claim")
 }
 
+// OIDCGroups returns the OpenID Connect groups claim and how the groups it contains map to Incus.
+func (c *Config) OIDCGroups() (string, auth.IdentityProviderGroups) {
+	// The mapping is validated when set.
+	mapping, _ := auth.ParseIdentityProviderGroups(c.m.GetString("oidc.groups.mapping"))
+
+	return c.m.GetString("oidc.groups.claim"), mapping
+}
+
 // ClusterHealingThreshold returns the configured healing threshold, i.e. the
 // number of seconds after which an offline node will be evacuated automatically. If the config key
 // is set but its value is lower than cluster.offline_threshold it returns
@@ -564,6 +597,34 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Port and interface for HTTP server (used by HTTP-01)
 	"acme.http.port": {Default: ":80", Validator: validate.Optional(validate.IsListenAddress(true, true, false))},
 
+	// gendoc:generate(entity=server, group=audit, key=audit.enabled)
+	// When enabled, every API request is recorded in the hash chained audit log of the server and sent as an `audit` event.
+	// See {ref}`audit-log`.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to record API requests in the audit log
+	"audit.enabled": {Type: config.Bool, Default: "false"},
+
+	// gendoc:generate(entity=server, group=audit, key=audit.max_files)
+	// Rotated audit log files beyond this number are deleted.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `10`
+	//  shortdesc: Number of rotated audit log files to keep
+	"audit.max_files": {Type: config.Int64, Default: "10", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=audit, key=audit.max_size)
+	// The audit log is rotated once it reaches this size.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `100MiB`
+	//  shortdesc: Size at which the audit log is rotated
+	"audit.max_size": {Default: "100MiB", Validator: validate.IsSize},
+
 	// gendoc:generate(entity=server, group=authorization, key=authorization.openfga.api.token)
 	//
 	// ---
@@ -588,6 +649,16 @@ var ConfigSchema = config.Schema{
 	// shortdesc: ID of the OpenFGA permission store
 	"authorization.openfga.store.id": {},
 
+	// gendoc:generate(entity=server, group=authorization, key=authorization.rbac)
+	// When enabled, permissions are granted to identities through the authorization groups stored in the database.
+	// See {ref}`authorization-rbac`.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to use the built-in role-based access control
+	"authorization.rbac": {Type: config.Bool, Default: "false"},
+
 	// gendoc:generate(entity=server, group=authorization, key=authorization.scriptlet)
 	// When using scriptlet-based authorization, this option stores the scriptlet.
 	// ---
@@ -699,6 +770,16 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Percentage load difference between most and least busy server needed to trigger a migration
 	"cluster.rebalance.threshold": {Type: config.Int64, Default: "20", Validator: validate.Optional(rebalanceThresholdValidator)},
 
+	// gendoc:generate(entity=server, group=core, key=core.issued_certificates_expiry)
+	// Client certificates issued by the server to OpenID Connect users and API tokens can't be requested
+	// to remain valid for longer than this.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `1d`
+	//  shortdesc: Maximum lifetime of client certificates issued by the server
+	"core.issued_certificates_expiry": {Type: config.String, Default: "1d", Validator: validate.And(validate.IsNotEmpty, expiryValidator)},
+
 	// gendoc:generate(entity=server, group=core, key=core.metrics_authentication)
 	//
 	// ---
@@ -828,6 +909,16 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to automatically trust clients signed by the CA
 	"core.trust_ca_certificates": {Type: config.Bool, Default: "false"},
 
+	// gendoc:generate(entity=server, group=core, key=core.usage_retention)
+	// The resource usage of the instances of each project is recorded hourly.
+	// Records older than this are removed.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `1y`
+	//  shortdesc: How long to keep the resource usage history of projects
+	"core.usage_retention": {Type: config.String, Default: "1y", Validator: validate.And(validate.IsNotEmpty, expiryValidator)},
+
 	// gendoc:generate(entity=server, group=images, key=images.auto_update_cached)
 	//
 	// ---
@@ -1049,6 +1140,24 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: OpenID Connect claim to use as the username
 	"oidc.claim": {},
 
+	// gendoc:generate(entity=server, group=oidc, key=oidc.groups.claim)
+	// The claim must be contained in the access token and hold either a list of group names or a single group name.
+	// See {ref}`authentication-openid-groups`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: OpenID Connect claim containing the groups of the user
+	"oidc.groups.claim": {},
+
+	// gendoc:generate(entity=server, group=oidc, key=oidc.groups.mapping)
+	// Comma separated list of `<group>=<target>` entries, where the target is one of `group:<name>`, `server:<role>` or `project:<name>:<role>`.
+	// See {ref}`authentication-openid-groups`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Mapping of the OpenID Connect groups to authorization groups and roles
+	"oidc.groups.mapping": {Validator: validate.Optional(oidcGroupsMappingValidator)},
+
 	// OVN networking global keys.
 
 	// gendoc:generate(entity=server, group=miscellaneous, key=network.ovn.integration_bridge)
@@ -1138,6 +1247,15 @@ func expiryValidator(value string) error {
 	return nil
 }
 
+func oidcGroupsMappingValidator(value string) error {
+	_, err := auth.ParseIdentityProviderGroups(value)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func offlineThresholdDefault() string {
 	return strconv.Itoa(db.DefaultOfflineThreshold)
 }
@@ -73,6 +73,11 @@ func Connect(address string, networkCert *localtls.CertInfo, serverCert *localtl
 				req.Header.Add(request.HeaderForwardedProtocol, val)
 			}
 
+			groups, ok := ctx.Value(request.CtxIdentityProviderGroups).([]string)
+			if ok && len(groups) > 0 {
+				req.Header.Add(request.HeaderForwardedIdentityProviderGroups, strings.Join(groups, ","))
+			}
+
 			req.Header.Add(request.HeaderForwardedAddress, r.RemoteAddr)
 		}
 
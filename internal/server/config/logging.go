@@ -100,13 +100,13 @@ func GetLoggingRuleForKey(key string) (Key, error) {
 	case "types":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.types)
 		// Specify a comma-separated list of events to send to the logger.
-		// The events can be any combination of `lifecycle`, `logging`, and `network-acl`.
+		// The events can be any combination of `audit`, `lifecycle`, `logging`, and `network-acl`.
 		// ---
 		//  type: string
 		//  scope: global
 		//  defaultdesc: `lifecycle,logging`
 		//  shortdesc: Events to send to the logger
-		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl"))), Default: "lifecycle,logging"}, nil
+		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("audit", "lifecycle", "logging", "network-acl"))), Default: "lifecycle,logging"}, nil
 	case "logging.level":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.logging.level)
 		//
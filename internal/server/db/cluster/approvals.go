@@ -0,0 +1,144 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Approval is a privileged operation held until it gets approved.
+type Approval struct {
+	OperationUUID     string
+	Project           string
+	Location          string
+	Type              string
+	Description       string
+	RequestorProtocol string
+	RequestorUsername string
+	CreatedAt         time.Time
+}
+
+// ToAPI returns an API entry.
+func (a *Approval) ToAPI() api.Approval {
+	return api.Approval{
+		ID:          a.OperationUUID,
+		Project:     a.Project,
+		Location:    a.Location,
+		Type:        a.Type,
+		Description: a.Description,
+		Operation:   api.NewURL().Path(version.APIVersion, "operations", a.OperationUUID).String(),
+		Requestor: api.EventLifecycleRequestor{
+			Protocol: a.RequestorProtocol,
+			Username: a.RequestorUsername,
+		},
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+// CreateApproval records that the given operation is waiting to be approved.
+func CreateApproval(ctx context.Context, tx *sql.Tx, approval Approval) error {
+	stmt := `
+INSERT INTO approvals (operation_id, type, description, requestor_protocol, requestor_username, created_at)
+  SELECT operations.id, ?, ?, ?, ?, ? FROM operations WHERE operations.uuid = ?
+`
+	result, err := tx.ExecContext(ctx, stmt, approval.Type, approval.Description, approval.RequestorProtocol, approval.RequestorUsername, approval.CreatedAt, approval.OperationUUID)
+	if err != nil {
+		return fmt.Errorf("Failed to create approval: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n != 1 {
+		return api.StatusErrorf(http.StatusNotFound, "Operation not found")
+	}
+
+	return nil
+}
+
+// GetApprovals returns the operations waiting to be approved, optionally only those of the given project.
+func GetApprovals(ctx context.Context, tx *sql.Tx, project string) ([]Approval, error) {
+	return getApprovals(ctx, tx, project, "")
+}
+
+// GetApproval returns the approval for the given operation.
+func GetApproval(ctx context.Context, tx *sql.Tx, operationUUID string) (*Approval, error) {
+	approvals, err := getApprovals(ctx, tx, "", operationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(approvals) != 1 {
+		return nil, api.StatusErrorf(http.StatusNotFound, "Approval not found")
+	}
+
+	return &approvals[0], nil
+}
+
+func getApprovals(ctx context.Context, tx *sql.Tx, project string, operationUUID string) ([]Approval, error) {
+	stmt := `
+SELECT operations.uuid, projects.name, nodes.name, approvals.type, approvals.description,
+       approvals.requestor_protocol, approvals.requestor_username, approvals.created_at
+  FROM approvals
+  JOIN operations ON operations.id = approvals.operation_id
+  JOIN projects ON projects.id = operations.project_id
+  JOIN nodes ON nodes.id = operations.node_id
+ WHERE (? = '' OR projects.name = ?) AND (? = '' OR operations.uuid = ?)
+ ORDER BY approvals.created_at
+`
+	rows, err := tx.QueryContext(ctx, stmt, project, project, operationUUID, operationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch approvals: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	result := []Approval{}
+	for rows.Next() {
+		approval := Approval{}
+
+		err = rows.Scan(&approval.OperationUUID, &approval.Project, &approval.Location, &approval.Type, &approval.Description, &approval.RequestorProtocol, &approval.RequestorUsername, &approval.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, approval)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteApproval removes the approval for the given operation.
+func DeleteApproval(ctx context.Context, tx *sql.Tx, operationUUID string) error {
+	stmt := `DELETE FROM approvals WHERE operation_id = (SELECT id FROM operations WHERE uuid = ?)`
+
+	result, err := tx.ExecContext(ctx, stmt, operationUUID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete approval: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n != 1 {
+		return api.StatusErrorf(http.StatusNotFound, "Approval not found")
+	}
+
+	return nil
+}
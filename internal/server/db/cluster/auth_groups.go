@@ -0,0 +1,231 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lxc/incus/v7/internal/server/db/query"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target auth_groups.mapper.go
+//generate-database:mapper reset -i -b "//go:build linux && cgo && !agent"
+//
+//generate-database:mapper stmt -e auth_group objects table=auth_groups
+//generate-database:mapper stmt -e auth_group objects-by-Name table=auth_groups
+//generate-database:mapper stmt -e auth_group id table=auth_groups
+//generate-database:mapper stmt -e auth_group create table=auth_groups
+//generate-database:mapper stmt -e auth_group rename table=auth_groups
+//generate-database:mapper stmt -e auth_group delete-by-Name table=auth_groups
+//generate-database:mapper stmt -e auth_group update table=auth_groups
+//
+//generate-database:mapper method -i -e auth_group GetMany table=auth_groups
+//generate-database:mapper method -i -e auth_group GetOne table=auth_groups
+//generate-database:mapper method -i -e auth_group ID table=auth_groups
+//generate-database:mapper method -i -e auth_group Exists table=auth_groups
+//generate-database:mapper method -i -e auth_group Rename table=auth_groups
+//generate-database:mapper method -i -e auth_group Create table=auth_groups
+//generate-database:mapper method -i -e auth_group Update table=auth_groups
+//generate-database:mapper method -i -e auth_group DeleteOne-by-Name table=auth_groups
+
+// AuthGroup is a value object holding db-related details about an authorization group.
+type AuthGroup struct {
+	ID          int
+	Name        string
+	Description string `db:"coalesce=''"`
+}
+
+// AuthGroupFilter specifies potential query parameter fields.
+type AuthGroupFilter struct {
+	ID   *int
+	Name *string
+}
+
+// AuthGroupPermission is an entitlement granted to the members of an authorization group on an object.
+type AuthGroupPermission struct {
+	Entitlement string
+	Object      string
+}
+
+// ToAPI returns an API entry.
+func (g *AuthGroup) ToAPI(ctx context.Context, tx *sql.Tx) (*api.AuthGroup, error) {
+	permissions, err := GetAuthGroupPermissions(ctx, tx, g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := GetAuthGroupIdentities(ctx, tx, g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := api.AuthGroup{
+		AuthGroupPut: api.AuthGroupPut{
+			Description: g.Description,
+			Permissions: make([]api.AuthPermission, 0, len(permissions)),
+		},
+		AuthGroupPost: api.AuthGroupPost{
+			Name: g.Name,
+		},
+		Identities: identities,
+	}
+
+	for _, permission := range permissions {
+		result.Permissions = append(result.Permissions, api.AuthPermission{
+			Entitlement: permission.Entitlement,
+			Object:      permission.Object,
+		})
+	}
+
+	return &result, nil
+}
+
+// GetAuthGroupPermissions returns the permissions granted to an authorization group.
+func GetAuthGroupPermissions(ctx context.Context, tx *sql.Tx, groupID int) ([]AuthGroupPermission, error) {
+	stmt := "SELECT entitlement, object FROM auth_groups_permissions WHERE auth_group_id = ? ORDER BY object, entitlement"
+
+	permissions := []AuthGroupPermission{}
+	err := query.Scan(ctx, tx, stmt, func(scan func(dest ...any) error) error {
+		permission := AuthGroupPermission{}
+
+		err := scan(&permission.Entitlement, &permission.Object)
+		if err != nil {
+			return err
+		}
+
+		permissions = append(permissions, permission)
+
+		return nil
+	}, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_groups_permissions\" table: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// GetAuthGroupsPermissions returns the permissions granted by the named authorization groups.
+// Unknown groups are ignored.
+func GetAuthGroupsPermissions(ctx context.Context, tx *sql.Tx, groupNames []string) ([]AuthGroupPermission, error) {
+	if len(groupNames) == 0 {
+		return []AuthGroupPermission{}, nil
+	}
+
+	stmt := fmt.Sprintf(`
+SELECT DISTINCT auth_groups_permissions.entitlement, auth_groups_permissions.object
+  FROM auth_groups_permissions
+  JOIN auth_groups ON auth_groups.id = auth_groups_permissions.auth_group_id
+  WHERE auth_groups.name IN %s
+  ORDER BY auth_groups_permissions.object, auth_groups_permissions.entitlement
+`, query.Params(len(groupNames)))
+
+	args := make([]any, 0, len(groupNames))
+	for _, groupName := range groupNames {
+		args = append(args, groupName)
+	}
+
+	permissions := []AuthGroupPermission{}
+	err := query.Scan(ctx, tx, stmt, func(scan func(dest ...any) error) error {
+		permission := AuthGroupPermission{}
+
+		err := scan(&permission.Entitlement, &permission.Object)
+		if err != nil {
+			return err
+		}
+
+		permissions = append(permissions, permission)
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_groups_permissions\" table: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// UpdateAuthGroupPermissions replaces the permissions granted to an authorization group.
+func UpdateAuthGroupPermissions(ctx context.Context, tx *sql.Tx, groupID int64, permissions []AuthGroupPermission) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM auth_groups_permissions WHERE auth_group_id = ?", groupID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete permissions of the authorization group: %w", err)
+	}
+
+	for _, permission := range permissions {
+		_, err = tx.ExecContext(ctx, "INSERT OR IGNORE INTO auth_groups_permissions (auth_group_id, entitlement, object) VALUES (?, ?, ?)", groupID, permission.Entitlement, permission.Object)
+		if err != nil {
+			return fmt.Errorf("Failed to add permission to the authorization group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateAuthGroupPermissionsObjects updates the objects of the permissions granted to all authorization groups.
+// The update function returns the new object, or false if the permissions on the object should be removed.
+func UpdateAuthGroupPermissionsObjects(ctx context.Context, tx *sql.Tx, update func(object string) (string, bool)) error {
+	objects, err := query.SelectStrings(ctx, tx, "SELECT DISTINCT object FROM auth_groups_permissions")
+	if err != nil {
+		return fmt.Errorf("Failed to fetch from \"auth_groups_permissions\" table: %w", err)
+	}
+
+	for _, object := range objects {
+		newObject, ok := update(object)
+		if !ok {
+			_, err = tx.ExecContext(ctx, "DELETE FROM auth_groups_permissions WHERE object = ?", object)
+			if err != nil {
+				return fmt.Errorf("Failed to delete authorization group permissions: %w", err)
+			}
+
+			continue
+		}
+
+		if newObject == object {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE OR REPLACE auth_groups_permissions SET object = ? WHERE object = ?", newObject, object)
+		if err != nil {
+			return fmt.Errorf("Failed to update authorization group permissions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetAuthGroupIdentities returns the identities which are members of an authorization group,
+// in the `<authentication method>/<identifier>` form.
+func GetAuthGroupIdentities(ctx context.Context, tx *sql.Tx, groupID int) ([]string, error) {
+	stmt := `
+SELECT identities.auth_method, identities.identifier
+  FROM identities_auth_groups
+  JOIN identities ON identities.id = identities_auth_groups.identity_id
+  WHERE identities_auth_groups.auth_group_id = ?
+  ORDER BY identities.auth_method, identities.identifier
+`
+
+	identities := []string{}
+	err := query.Scan(ctx, tx, stmt, func(scan func(dest ...any) error) error {
+		var authMethod string
+		var identifier string
+
+		err := scan(&authMethod, &identifier)
+		if err != nil {
+			return err
+		}
+
+		identities = append(identities, authMethod+"/"+identifier)
+
+		return nil
+	}, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"identities_auth_groups\" table: %w", err)
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,174 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/db/query"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target auth_tokens.mapper.go
+//generate-database:mapper reset -i -b "//go:build linux && cgo && !agent"
+//
+//generate-database:mapper stmt -e auth_token objects table=auth_tokens
+//generate-database:mapper stmt -e auth_token objects-by-Name table=auth_tokens
+//generate-database:mapper stmt -e auth_token objects-by-SecretHash table=auth_tokens
+//generate-database:mapper stmt -e auth_token id table=auth_tokens
+//generate-database:mapper stmt -e auth_token create table=auth_tokens
+//generate-database:mapper stmt -e auth_token delete-by-Name table=auth_tokens
+//generate-database:mapper stmt -e auth_token update table=auth_tokens
+//
+//generate-database:mapper method -i -e auth_token GetMany table=auth_tokens
+//generate-database:mapper method -i -e auth_token GetOne table=auth_tokens
+//generate-database:mapper method -i -e auth_token ID table=auth_tokens
+//generate-database:mapper method -i -e auth_token Exists table=auth_tokens
+//generate-database:mapper method -i -e auth_token Create table=auth_tokens
+//generate-database:mapper method -i -e auth_token Update table=auth_tokens
+//generate-database:mapper method -i -e auth_token DeleteOne-by-Name table=auth_tokens
+
+// AuthToken is a value object holding db-related details about an API token.
+type AuthToken struct {
+	ID          int
+	Name        string
+	Description string `db:"coalesce=''"`
+	SecretHash  string
+	CreatedAt   time.Time
+	ExpiresAt   sql.NullTime
+	LastUsedAt  sql.NullTime
+}
+
+// AuthTokenFilter specifies potential query parameter fields.
+type AuthTokenFilter struct {
+	ID         *int
+	Name       *string
+	SecretHash *string
+}
+
+// ToAPI returns an API entry.
+func (t *AuthToken) ToAPI(ctx context.Context, tx *sql.Tx) (*api.AuthToken, error) {
+	projects, err := GetAuthTokenProjects(ctx, tx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements, err := GetAuthTokenEntitlements(ctx, tx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := api.AuthToken{
+		AuthTokenPut: api.AuthTokenPut{
+			Description: t.Description,
+		},
+		Name:         t.Name,
+		Projects:     projects,
+		Entitlements: entitlements,
+		CreatedAt:    t.CreatedAt,
+	}
+
+	if t.ExpiresAt.Valid {
+		result.ExpiresAt = t.ExpiresAt.Time
+	}
+
+	if t.LastUsedAt.Valid {
+		result.LastUsedAt = t.LastUsedAt.Time
+	}
+
+	return &result, nil
+}
+
+// IsExpired returns whether the API token has expired.
+func (t *AuthToken) IsExpired() bool {
+	return t.ExpiresAt.Valid && !t.ExpiresAt.Time.IsZero() && time.Now().After(t.ExpiresAt.Time)
+}
+
+// GetAuthTokenProjects returns the names of the projects an API token is restricted to.
+func GetAuthTokenProjects(ctx context.Context, tx *sql.Tx, tokenID int) ([]string, error) {
+	stmt := `
+SELECT projects.name
+  FROM auth_tokens_projects
+  JOIN projects ON projects.id = auth_tokens_projects.project_id
+  WHERE auth_tokens_projects.auth_token_id = ?
+  ORDER BY projects.name
+`
+
+	projects, err := query.SelectStrings(ctx, tx, stmt, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_tokens_projects\" table: %w", err)
+	}
+
+	return projects, nil
+}
+
+// GetAuthTokenEntitlements returns the entitlements granted to an API token.
+func GetAuthTokenEntitlements(ctx context.Context, tx *sql.Tx, tokenID int) ([]string, error) {
+	stmt := `
+SELECT entitlement
+  FROM auth_tokens_entitlements
+  WHERE auth_token_id = ?
+  ORDER BY entitlement
+`
+
+	entitlements, err := query.SelectStrings(ctx, tx, stmt, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_tokens_entitlements\" table: %w", err)
+	}
+
+	return entitlements, nil
+}
+
+// UpdateAuthTokenScope replaces the projects and entitlements of an API token.
+func UpdateAuthTokenScope(ctx context.Context, tx *sql.Tx, tokenID int64, projectNames []string, entitlements []string) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM auth_tokens_projects WHERE auth_token_id = ?", tokenID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete projects of the API token: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM auth_tokens_entitlements WHERE auth_token_id = ?", tokenID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete entitlements of the API token: %w", err)
+	}
+
+	for _, projectName := range projectNames {
+		projectID, err := GetProjectID(ctx, tx, projectName)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return api.StatusErrorf(http.StatusNotFound, "Project %q not found", projectName)
+			}
+
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT OR IGNORE INTO auth_tokens_projects (auth_token_id, project_id) VALUES (?, ?)", tokenID, projectID)
+		if err != nil {
+			return fmt.Errorf("Failed to add project to API token: %w", err)
+		}
+	}
+
+	for _, entitlement := range entitlements {
+		_, err = tx.ExecContext(ctx, "INSERT OR IGNORE INTO auth_tokens_entitlements (auth_token_id, entitlement) VALUES (?, ?)", tokenID, entitlement)
+		if err != nil {
+			return fmt.Errorf("Failed to add entitlement to API token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateAuthTokenLastUsed records when an API token was last used.
+func UpdateAuthTokenLastUsed(ctx context.Context, tx *sql.Tx, tokenID int, lastUsedAt time.Time) error {
+	_, err := tx.ExecContext(ctx, "UPDATE auth_tokens SET last_used_at = ? WHERE id = ?", lastUsedAt, tokenID)
+	if err != nil {
+		return fmt.Errorf("Failed to update last use of the API token: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,385 @@
+//go:build linux && cgo && !agent
+
+// Code generated by generate-database from the incus project - DO NOT EDIT.
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var authTokenObjects = RegisterStmt(`
+SELECT auth_tokens.id, auth_tokens.name, coalesce(auth_tokens.description, ''), auth_tokens.secret_hash, auth_tokens.created_at, auth_tokens.expires_at, auth_tokens.last_used_at
+  FROM auth_tokens
+  ORDER BY auth_tokens.name
+`)
+
+var authTokenObjectsByName = RegisterStmt(`
+SELECT auth_tokens.id, auth_tokens.name, coalesce(auth_tokens.description, ''), auth_tokens.secret_hash, auth_tokens.created_at, auth_tokens.expires_at, auth_tokens.last_used_at
+  FROM auth_tokens
+  WHERE ( auth_tokens.name = ? )
+  ORDER BY auth_tokens.name
+`)
+
+var authTokenObjectsBySecretHash = RegisterStmt(`
+SELECT auth_tokens.id, auth_tokens.name, coalesce(auth_tokens.description, ''), auth_tokens.secret_hash, auth_tokens.created_at, auth_tokens.expires_at, auth_tokens.last_used_at
+  FROM auth_tokens
+  WHERE ( auth_tokens.secret_hash = ? )
+  ORDER BY auth_tokens.name
+`)
+
+var authTokenID = RegisterStmt(`
+SELECT auth_tokens.id FROM auth_tokens
+  WHERE auth_tokens.name = ?
+`)
+
+var authTokenCreate = RegisterStmt(`
+INSERT INTO auth_tokens (name, description, secret_hash, created_at, expires_at, last_used_at)
+  VALUES (?, ?, ?, ?, ?, ?)
+`)
+
+var authTokenDeleteByName = RegisterStmt(`
+DELETE FROM auth_tokens WHERE name = ?
+`)
+
+var authTokenUpdate = RegisterStmt(`
+UPDATE auth_tokens
+  SET name = ?, description = ?, secret_hash = ?, created_at = ?, expires_at = ?, last_used_at = ?
+ WHERE id = ?
+`)
+
+// authTokenColumns returns a string of column names to be used with a SELECT statement for the entity.
+// Use this function when building statements to retrieve database entries matching the AuthToken entity.
+func authTokenColumns() string {
+	return "auth_tokens.id, auth_tokens.name, coalesce(auth_tokens.description, ''), auth_tokens.secret_hash, auth_tokens.created_at, auth_tokens.expires_at, auth_tokens.last_used_at"
+}
+
+// getAuthTokens can be used to run handwritten sql.Stmts to return a slice of objects.
+func getAuthTokens(ctx context.Context, stmt *sql.Stmt, args ...any) ([]AuthToken, error) {
+	objects := make([]AuthToken, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		a := AuthToken{}
+		err := scan(&a.ID, &a.Name, &a.Description, &a.SecretHash, &a.CreatedAt, &a.ExpiresAt, &a.LastUsedAt)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, a)
+
+		return nil
+	}
+
+	err := selectObjects(ctx, stmt, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_tokens\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// getAuthTokensRaw can be used to run handwritten query strings to return a slice of objects.
+func getAuthTokensRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]AuthToken, error) {
+	objects := make([]AuthToken, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		a := AuthToken{}
+		err := scan(&a.ID, &a.Name, &a.Description, &a.SecretHash, &a.CreatedAt, &a.ExpiresAt, &a.LastUsedAt)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, a)
+
+		return nil
+	}
+
+	err := scan(ctx, db, sql, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_tokens\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetAuthTokens returns all available auth_tokens.
+// generator: auth_token GetMany
+func GetAuthTokens(ctx context.Context, db dbtx, filters ...AuthTokenFilter) (_ []AuthToken, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Auth_token")
+	}()
+
+	var err error
+
+	// Result slice.
+	objects := make([]AuthToken, 0)
+
+	// Pick the prepared statement and arguments to use based on active criteria.
+	var sqlStmt *sql.Stmt
+	args := []any{}
+	queryParts := [2]string{}
+
+	if len(filters) == 0 {
+		sqlStmt, err = Stmt(db, authTokenObjects)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get \"authTokenObjects\" prepared statement: %w", err)
+		}
+	}
+
+	for i, filter := range filters {
+		if filter.SecretHash != nil && filter.ID == nil && filter.Name == nil {
+			args = append(args, []any{filter.SecretHash}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, authTokenObjectsBySecretHash)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"authTokenObjectsBySecretHash\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(authTokenObjectsBySecretHash)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"authTokenObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.Name != nil && filter.ID == nil && filter.SecretHash == nil {
+			args = append(args, []any{filter.Name}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, authTokenObjectsByName)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"authTokenObjectsByName\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(authTokenObjectsByName)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"authTokenObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.ID == nil && filter.Name == nil && filter.SecretHash == nil {
+			return nil, fmt.Errorf("Cannot filter on empty AuthTokenFilter")
+		} else {
+			return nil, errors.New("No statement exists for the given Filter")
+		}
+	}
+
+	// Select.
+	if sqlStmt != nil {
+		objects, err = getAuthTokens(ctx, sqlStmt, args...)
+	} else {
+		queryStr := strings.Join(queryParts[:], "ORDER BY")
+		objects, err = getAuthTokensRaw(ctx, db, queryStr, args...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_tokens\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetAuthToken returns the auth_token with the given key.
+// generator: auth_token GetOne
+func GetAuthToken(ctx context.Context, db dbtx, name string) (_ *AuthToken, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Auth_token")
+	}()
+
+	filter := AuthTokenFilter{}
+	filter.Name = &name
+
+	objects, err := GetAuthTokens(ctx, db, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"auth_tokens\" table: %w", err)
+	}
+
+	switch len(objects) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return &objects[0], nil
+	default:
+		return nil, fmt.Errorf("More than one \"auth_tokens\" entry matches")
+	}
+}
+
+// GetAuthTokenID return the ID of the auth_token with the given key.
+// generator: auth_token ID
+func GetAuthTokenID(ctx context.Context, db tx, name string) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Auth_token")
+	}()
+
+	stmt, err := Stmt(db, authTokenID)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"authTokenID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, name)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, ErrNotFound
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"auth_tokens\" ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// AuthTokenExists checks if a auth_token with the given key exists.
+// generator: auth_token Exists
+func AuthTokenExists(ctx context.Context, db dbtx, name string) (_ bool, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Auth_token")
+	}()
+
+	stmt, err := Stmt(db, authTokenID)
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"authTokenID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, name)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"auth_tokens\" ID: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateAuthToken adds a new auth_token to the database.
+// generator: auth_token Create
+func CreateAuthToken(ctx context.Context, db dbtx, object AuthToken) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Auth_token")
+	}()
+
+	args := make([]any, 6)
+
+	// Populate the statement arguments.
+	args[0] = object.Name
+	args[1] = object.Description
+	args[2] = object.SecretHash
+	args[3] = object.CreatedAt
+	args[4] = object.ExpiresAt
+	args[5] = object.LastUsedAt
+
+	// Prepared statement to use.
+	stmt, err := Stmt(db, authTokenCreate)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"authTokenCreate\" prepared statement: %w", err)
+	}
+
+	// Execute the statement.
+	result, err := stmt.Exec(args...)
+	if err != nil && strings.HasPrefix(err.Error(), "UNIQUE constraint failed:") {
+		return -1, ErrConflict
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to create \"auth_tokens\" entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("Failed to fetch \"auth_tokens\" entry ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateAuthToken updates the auth_token matching the given key parameters.
+// generator: auth_token Update
+func UpdateAuthToken(ctx context.Context, db tx, name string, object AuthToken) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Auth_token")
+	}()
+
+	id, err := GetAuthTokenID(ctx, db, name)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := Stmt(db, authTokenUpdate)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"authTokenUpdate\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(object.Name, object.Description, object.SecretHash, object.CreatedAt, object.ExpiresAt, object.LastUsedAt, id)
+	if err != nil {
+		return fmt.Errorf("Update \"auth_tokens\" entry failed: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query updated %d rows instead of 1", n)
+	}
+
+	return nil
+}
+
+// DeleteAuthToken deletes the auth_token matching the given key parameters.
+// generator: auth_token DeleteOne-by-Name
+func DeleteAuthToken(ctx context.Context, db dbtx, name string) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Auth_token")
+	}()
+
+	stmt, err := Stmt(db, authTokenDeleteByName)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"authTokenDeleteByName\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(name)
+	if err != nil {
+		return fmt.Errorf("Delete \"auth_tokens\": %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	} else if n > 1 {
+		return fmt.Errorf("Query deleted %d AuthToken rows instead of 1", n)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lxc/incus/v7/internal/server/db/query"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target identities.mapper.go
+//generate-database:mapper reset -i -b "//go:build linux && cgo && !agent"
+//
+//generate-database:mapper stmt -e identity objects table=identities
+//generate-database:mapper stmt -e identity objects-by-AuthMethod table=identities
+//generate-database:mapper stmt -e identity objects-by-AuthMethod-and-Identifier table=identities
+//generate-database:mapper stmt -e identity id table=identities
+//generate-database:mapper stmt -e identity create table=identities
+//generate-database:mapper stmt -e identity delete-by-AuthMethod-and-Identifier table=identities
+//generate-database:mapper stmt -e identity update table=identities
+//
+//generate-database:mapper method -i -e identity GetMany table=identities
+//generate-database:mapper method -i -e identity GetOne table=identities
+//generate-database:mapper method -i -e identity ID table=identities
+//generate-database:mapper method -i -e identity Exists table=identities
+//generate-database:mapper method -i -e identity Create table=identities
+//generate-database:mapper method -i -e identity Update table=identities
+//generate-database:mapper method -i -e identity DeleteOne-by-AuthMethod-and-Identifier table=identities
+
+// Identity is a value object holding db-related details about an identity known to the authorization driver.
+type Identity struct {
+	ID          int
+	AuthMethod  string `db:"primary=yes"`
+	Identifier  string `db:"primary=yes"`
+	Description string `db:"coalesce=''"`
+}
+
+// IdentityFilter specifies potential query parameter fields.
+type IdentityFilter struct {
+	ID         *int
+	AuthMethod *string
+	Identifier *string
+}
+
+// IdentityPermissions represents the permissions an identity is granted through its authorization groups.
+type IdentityPermissions struct {
+	AuthMethod  string
+	Identifier  string
+	Permissions []AuthGroupPermission
+}
+
+// ToAPI returns an API entry.
+func (i *Identity) ToAPI(ctx context.Context, tx *sql.Tx) (*api.AuthIdentity, error) {
+	groups, err := GetIdentityAuthGroups(ctx, tx, i.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := api.AuthIdentity{
+		AuthIdentityPut: api.AuthIdentityPut{
+			Description: i.Description,
+			Groups:      groups,
+		},
+		AuthenticationMethod: i.AuthMethod,
+		Identifier:           i.Identifier,
+	}
+
+	return &result, nil
+}
+
+// GetIdentityAuthGroups returns the names of the authorization groups an identity is a member of.
+func GetIdentityAuthGroups(ctx context.Context, tx *sql.Tx, identityID int) ([]string, error) {
+	stmt := `
+SELECT auth_groups.name
+  FROM identities_auth_groups
+  JOIN auth_groups ON auth_groups.id = identities_auth_groups.auth_group_id
+  WHERE identities_auth_groups.identity_id = ?
+  ORDER BY auth_groups.name
+`
+
+	groups, err := query.SelectStrings(ctx, tx, stmt, identityID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"identities_auth_groups\" table: %w", err)
+	}
+
+	return groups, nil
+}
+
+// UpdateIdentityAuthGroups replaces the authorization groups an identity is a member of.
+func UpdateIdentityAuthGroups(ctx context.Context, tx *sql.Tx, identityID int64, groupNames []string) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM identities_auth_groups WHERE identity_id = ?", identityID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete authorization groups of the identity: %w", err)
+	}
+
+	for _, groupName := range groupNames {
+		groupID, err := GetAuthGroupID(ctx, tx, groupName)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return api.StatusErrorf(http.StatusNotFound, "Authorization group %q not found", groupName)
+			}
+
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT OR IGNORE INTO identities_auth_groups (identity_id, auth_group_id) VALUES (?, ?)", identityID, groupID)
+		if err != nil {
+			return fmt.Errorf("Failed to add identity to authorization group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetIdentitiesPermissions returns the identities along with the permissions granted to them through their
+// authorization groups. Empty authMethod or identifier values match all identities.
+func GetIdentitiesPermissions(ctx context.Context, tx *sql.Tx, authMethod string, identifier string) ([]IdentityPermissions, error) {
+	var where []string
+	var args []any
+
+	if authMethod != "" {
+		where = append(where, "identities.auth_method = ?")
+		args = append(args, authMethod)
+	}
+
+	if identifier != "" {
+		where = append(where, "identities.identifier = ?")
+		args = append(args, identifier)
+	}
+
+	stmt := `
+SELECT identities.id, identities.auth_method, identities.identifier, coalesce(auth_groups_permissions.entitlement, ''), coalesce(auth_groups_permissions.object, '')
+  FROM identities
+  LEFT JOIN identities_auth_groups ON identities_auth_groups.identity_id = identities.id
+  LEFT JOIN auth_groups_permissions ON auth_groups_permissions.auth_group_id = identities_auth_groups.auth_group_id
+`
+
+	if len(where) > 0 {
+		stmt += "  WHERE " + strings.Join(where, " AND ") + "\n"
+	}
+
+	stmt += "  ORDER BY identities.id"
+
+	identities := []IdentityPermissions{}
+	indexes := map[int]int{}
+	err := query.Scan(ctx, tx, stmt, func(scan func(dest ...any) error) error {
+		var id int
+		var identity IdentityPermissions
+		var permission AuthGroupPermission
+
+		err := scan(&id, &identity.AuthMethod, &identity.Identifier, &permission.Entitlement, &permission.Object)
+		if err != nil {
+			return err
+		}
+
+		index, ok := indexes[id]
+		if !ok {
+			index = len(identities)
+			indexes[id] = index
+			identity.Permissions = []AuthGroupPermission{}
+			identities = append(identities, identity)
+		}
+
+		if permission.Entitlement != "" {
+			identities[index].Permissions = append(identities[index].Permissions, permission)
+		}
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch identity permissions: %w", err)
+	}
+
+	return identities, nil
+}
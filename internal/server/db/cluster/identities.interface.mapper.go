@@ -0,0 +1,36 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import "context"
+
+// IdentityGenerated is an interface of generated methods for Identity.
+type IdentityGenerated interface {
+	// GetIdentities returns all available identities.
+	// generator: identity GetMany
+	GetIdentities(ctx context.Context, db dbtx, filters ...IdentityFilter) ([]Identity, error)
+
+	// GetIdentity returns the identity with the given key.
+	// generator: identity GetOne
+	GetIdentity(ctx context.Context, db dbtx, authMethod string, identifier string) (*Identity, error)
+
+	// GetIdentityID return the ID of the identity with the given key.
+	// generator: identity ID
+	GetIdentityID(ctx context.Context, db tx, authMethod string, identifier string) (int64, error)
+
+	// IdentityExists checks if a identity with the given key exists.
+	// generator: identity Exists
+	IdentityExists(ctx context.Context, db dbtx, authMethod string, identifier string) (bool, error)
+
+	// CreateIdentity adds a new identity to the database.
+	// generator: identity Create
+	CreateIdentity(ctx context.Context, db dbtx, object Identity) (int64, error)
+
+	// UpdateIdentity updates the identity matching the given key parameters.
+	// generator: identity Update
+	UpdateIdentity(ctx context.Context, db tx, authMethod string, identifier string, object Identity) error
+
+	// DeleteIdentity deletes the identity matching the given key parameters.
+	// generator: identity DeleteOne-by-AuthMethod-and-Identifier
+	DeleteIdentity(ctx context.Context, db dbtx, authMethod string, identifier string) error
+}
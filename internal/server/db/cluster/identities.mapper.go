@@ -0,0 +1,383 @@
+//go:build linux && cgo && !agent
+
+// Code generated by generate-database from the incus project - DO NOT EDIT.
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var identityObjects = RegisterStmt(`
+SELECT identities.id, identities.auth_method, identities.identifier, coalesce(identities.description, '')
+  FROM identities
+  ORDER BY identities.auth_method, identities.identifier
+`)
+
+var identityObjectsByAuthMethod = RegisterStmt(`
+SELECT identities.id, identities.auth_method, identities.identifier, coalesce(identities.description, '')
+  FROM identities
+  WHERE ( identities.auth_method = ? )
+  ORDER BY identities.auth_method, identities.identifier
+`)
+
+var identityObjectsByAuthMethodAndIdentifier = RegisterStmt(`
+SELECT identities.id, identities.auth_method, identities.identifier, coalesce(identities.description, '')
+  FROM identities
+  WHERE ( identities.auth_method = ? AND identities.identifier = ? )
+  ORDER BY identities.auth_method, identities.identifier
+`)
+
+var identityID = RegisterStmt(`
+SELECT identities.id FROM identities
+  WHERE identities.auth_method = ? AND identities.identifier = ?
+`)
+
+var identityCreate = RegisterStmt(`
+INSERT INTO identities (auth_method, identifier, description)
+  VALUES (?, ?, ?)
+`)
+
+var identityDeleteByAuthMethodAndIdentifier = RegisterStmt(`
+DELETE FROM identities WHERE auth_method = ? AND identifier = ?
+`)
+
+var identityUpdate = RegisterStmt(`
+UPDATE identities
+  SET auth_method = ?, identifier = ?, description = ?
+ WHERE id = ?
+`)
+
+// identityColumns returns a string of column names to be used with a SELECT statement for the entity.
+// Use this function when building statements to retrieve database entries matching the Identity entity.
+func identityColumns() string {
+	return "identities.id, identities.auth_method, identities.identifier, coalesce(identities.description, '')"
+}
+
+// getIdentities can be used to run handwritten sql.Stmts to return a slice of objects.
+func getIdentities(ctx context.Context, stmt *sql.Stmt, args ...any) ([]Identity, error) {
+	objects := make([]Identity, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		i := Identity{}
+		err := scan(&i.ID, &i.AuthMethod, &i.Identifier, &i.Description)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, i)
+
+		return nil
+	}
+
+	err := selectObjects(ctx, stmt, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"identities\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// getIdentitiesRaw can be used to run handwritten query strings to return a slice of objects.
+func getIdentitiesRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]Identity, error) {
+	objects := make([]Identity, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		i := Identity{}
+		err := scan(&i.ID, &i.AuthMethod, &i.Identifier, &i.Description)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, i)
+
+		return nil
+	}
+
+	err := scan(ctx, db, sql, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"identities\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetIdentities returns all available identities.
+// generator: identity GetMany
+func GetIdentities(ctx context.Context, db dbtx, filters ...IdentityFilter) (_ []Identity, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Identity")
+	}()
+
+	var err error
+
+	// Result slice.
+	objects := make([]Identity, 0)
+
+	// Pick the prepared statement and arguments to use based on active criteria.
+	var sqlStmt *sql.Stmt
+	args := []any{}
+	queryParts := [2]string{}
+
+	if len(filters) == 0 {
+		sqlStmt, err = Stmt(db, identityObjects)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get \"identityObjects\" prepared statement: %w", err)
+		}
+	}
+
+	for i, filter := range filters {
+		if filter.AuthMethod != nil && filter.Identifier != nil && filter.ID == nil {
+			args = append(args, []any{filter.AuthMethod, filter.Identifier}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, identityObjectsByAuthMethodAndIdentifier)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"identityObjectsByAuthMethodAndIdentifier\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(identityObjectsByAuthMethodAndIdentifier)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"identityObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.AuthMethod != nil && filter.ID == nil && filter.Identifier == nil {
+			args = append(args, []any{filter.AuthMethod}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, identityObjectsByAuthMethod)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"identityObjectsByAuthMethod\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(identityObjectsByAuthMethod)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"identityObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.ID == nil && filter.AuthMethod == nil && filter.Identifier == nil {
+			return nil, fmt.Errorf("Cannot filter on empty IdentityFilter")
+		} else {
+			return nil, errors.New("No statement exists for the given Filter")
+		}
+	}
+
+	// Select.
+	if sqlStmt != nil {
+		objects, err = getIdentities(ctx, sqlStmt, args...)
+	} else {
+		queryStr := strings.Join(queryParts[:], "ORDER BY")
+		objects, err = getIdentitiesRaw(ctx, db, queryStr, args...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"identities\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetIdentity returns the identity with the given key.
+// generator: identity GetOne
+func GetIdentity(ctx context.Context, db dbtx, authMethod string, identifier string) (_ *Identity, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Identity")
+	}()
+
+	filter := IdentityFilter{}
+	filter.AuthMethod = &authMethod
+	filter.Identifier = &identifier
+
+	objects, err := GetIdentities(ctx, db, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"identities\" table: %w", err)
+	}
+
+	switch len(objects) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return &objects[0], nil
+	default:
+		return nil, fmt.Errorf("More than one \"identities\" entry matches")
+	}
+}
+
+// GetIdentityID return the ID of the identity with the given key.
+// generator: identity ID
+func GetIdentityID(ctx context.Context, db tx, authMethod string, identifier string) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Identity")
+	}()
+
+	stmt, err := Stmt(db, identityID)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"identityID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, authMethod, identifier)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, ErrNotFound
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"identities\" ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// IdentityExists checks if a identity with the given key exists.
+// generator: identity Exists
+func IdentityExists(ctx context.Context, db dbtx, authMethod string, identifier string) (_ bool, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Identity")
+	}()
+
+	stmt, err := Stmt(db, identityID)
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"identityID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, authMethod, identifier)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"identities\" ID: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateIdentity adds a new identity to the database.
+// generator: identity Create
+func CreateIdentity(ctx context.Context, db dbtx, object Identity) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Identity")
+	}()
+
+	args := make([]any, 3)
+
+	// Populate the statement arguments.
+	args[0] = object.AuthMethod
+	args[1] = object.Identifier
+	args[2] = object.Description
+
+	// Prepared statement to use.
+	stmt, err := Stmt(db, identityCreate)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"identityCreate\" prepared statement: %w", err)
+	}
+
+	// Execute the statement.
+	result, err := stmt.Exec(args...)
+	if err != nil && strings.HasPrefix(err.Error(), "UNIQUE constraint failed:") {
+		return -1, ErrConflict
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to create \"identities\" entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("Failed to fetch \"identities\" entry ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateIdentity updates the identity matching the given key parameters.
+// generator: identity Update
+func UpdateIdentity(ctx context.Context, db tx, authMethod string, identifier string, object Identity) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Identity")
+	}()
+
+	id, err := GetIdentityID(ctx, db, authMethod, identifier)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := Stmt(db, identityUpdate)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"identityUpdate\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(object.AuthMethod, object.Identifier, object.Description, id)
+	if err != nil {
+		return fmt.Errorf("Update \"identities\" entry failed: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query updated %d rows instead of 1", n)
+	}
+
+	return nil
+}
+
+// DeleteIdentity deletes the identity matching the given key parameters.
+// generator: identity DeleteOne-by-AuthMethod-and-Identifier
+func DeleteIdentity(ctx context.Context, db dbtx, authMethod string, identifier string) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Identity")
+	}()
+
+	stmt, err := Stmt(db, identityDeleteByAuthMethodAndIdentifier)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"identityDeleteByAuthMethodAndIdentifier\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(authMethod, identifier)
+	if err != nil {
+		return fmt.Errorf("Delete \"identities\": %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	} else if n > 1 {
+		return fmt.Errorf("Query deleted %d Identity rows instead of 1", n)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target issued_certificates.mapper.go
+//generate-database:mapper reset -i -b "//go:build linux && cgo && !agent"
+//
+//generate-database:mapper stmt -e issued_certificate objects table=issued_certificates
+//generate-database:mapper stmt -e issued_certificate objects-by-Serial table=issued_certificates
+//generate-database:mapper stmt -e issued_certificate objects-by-AuthMethod-and-Identifier table=issued_certificates
+//generate-database:mapper stmt -e issued_certificate id table=issued_certificates
+//generate-database:mapper stmt -e issued_certificate create table=issued_certificates
+//generate-database:mapper stmt -e issued_certificate update table=issued_certificates
+//
+//generate-database:mapper method -i -e issued_certificate GetMany table=issued_certificates
+//generate-database:mapper method -i -e issued_certificate GetOne table=issued_certificates
+//generate-database:mapper method -i -e issued_certificate ID table=issued_certificates
+//generate-database:mapper method -i -e issued_certificate Exists table=issued_certificates
+//generate-database:mapper method -i -e issued_certificate Create table=issued_certificates
+//generate-database:mapper method -i -e issued_certificate Update table=issued_certificates
+
+// IssuedCertificate is a value object holding db-related details about a client certificate issued by the server.
+type IssuedCertificate struct {
+	ID                     int
+	Serial                 string `db:"primary=yes"`
+	AuthMethod             string
+	Identifier             string
+	IdentityProviderGroups string
+	Certificate            string
+	CreatedAt              time.Time
+	ExpiresAt              time.Time
+	RevokedAt              sql.NullTime
+}
+
+// IssuedCertificateFilter specifies potential query parameter fields.
+type IssuedCertificateFilter struct {
+	ID         *int
+	Serial     *string
+	AuthMethod *string
+	Identifier *string
+}
+
+// ToAPI returns an API entry.
+func (c *IssuedCertificate) ToAPI() *api.AuthCertificate {
+	result := api.AuthCertificate{
+		Serial:               c.Serial,
+		AuthenticationMethod: c.AuthMethod,
+		Identifier:           c.Identifier,
+		Certificate:          c.Certificate,
+		CreatedAt:            c.CreatedAt,
+		ExpiresAt:            c.ExpiresAt,
+	}
+
+	if c.RevokedAt.Valid {
+		result.RevokedAt = c.RevokedAt.Time
+	}
+
+	return &result
+}
+
+// Groups returns what the identity provider groups of the identity mapped to when the certificate was issued.
+func (c *IssuedCertificate) Groups() []string {
+	if c.IdentityProviderGroups == "" {
+		return nil
+	}
+
+	return strings.Split(c.IdentityProviderGroups, ",")
+}
+
+// GetIssuedCertificatesAuthority returns the PEM encoded certificate and key of the authority issuing client
+// certificates. It returns a not found error if the authority hasn't been generated yet.
+func GetIssuedCertificatesAuthority(ctx context.Context, tx *sql.Tx) (string, string, error) {
+	var certificate string
+	var key string
+
+	err := tx.QueryRowContext(ctx, "SELECT certificate, key FROM issued_certificates_authorities ORDER BY id LIMIT 1").Scan(&certificate, &key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", api.StatusErrorf(http.StatusNotFound, "Certificate authority not found")
+		}
+
+		return "", "", fmt.Errorf("Failed to fetch from \"issued_certificates_authorities\" table: %w", err)
+	}
+
+	return certificate, key, nil
+}
+
+// CreateIssuedCertificatesAuthority stores the PEM encoded certificate and key of the authority issuing client certificates.
+func CreateIssuedCertificatesAuthority(ctx context.Context, tx *sql.Tx, certificate string, key string) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO issued_certificates_authorities (certificate, key) VALUES (?, ?)", certificate, key)
+	if err != nil {
+		return fmt.Errorf("Failed to create certificate authority: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredIssuedCertificates removes the issued certificates which expired before the given time.
+func DeleteExpiredIssuedCertificates(ctx context.Context, tx *sql.Tx, before time.Time) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM issued_certificates WHERE expires_at < ?", before)
+	if err != nil {
+		return fmt.Errorf("Failed to delete expired issued certificates: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import "context"
+
+// IssuedCertificateGenerated is an interface of generated methods for IssuedCertificate.
+type IssuedCertificateGenerated interface {
+	// GetIssuedCertificates returns all available issued_certificates.
+	// generator: issued_certificate GetMany
+	GetIssuedCertificates(ctx context.Context, db dbtx, filters ...IssuedCertificateFilter) ([]IssuedCertificate, error)
+
+	// GetIssuedCertificate returns the issued_certificate with the given key.
+	// generator: issued_certificate GetOne
+	GetIssuedCertificate(ctx context.Context, db dbtx, serial string) (*IssuedCertificate, error)
+
+	// GetIssuedCertificateID return the ID of the issued_certificate with the given key.
+	// generator: issued_certificate ID
+	GetIssuedCertificateID(ctx context.Context, db tx, serial string) (int64, error)
+
+	// IssuedCertificateExists checks if a issued_certificate with the given key exists.
+	// generator: issued_certificate Exists
+	IssuedCertificateExists(ctx context.Context, db dbtx, serial string) (bool, error)
+
+	// CreateIssuedCertificate adds a new issued_certificate to the database.
+	// generator: issued_certificate Create
+	CreateIssuedCertificate(ctx context.Context, db dbtx, object IssuedCertificate) (int64, error)
+
+	// UpdateIssuedCertificate updates the issued_certificate matching the given key parameters.
+	// generator: issued_certificate Update
+	UpdateIssuedCertificate(ctx context.Context, db tx, serial string, object IssuedCertificate) error
+}
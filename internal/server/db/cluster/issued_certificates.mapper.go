@@ -0,0 +1,352 @@
+//go:build linux && cgo && !agent
+
+// Code generated by generate-database from the incus project - DO NOT EDIT.
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var issuedCertificateObjects = RegisterStmt(`
+SELECT issued_certificates.id, issued_certificates.serial, issued_certificates.auth_method, issued_certificates.identifier, issued_certificates.identity_provider_groups, issued_certificates.certificate, issued_certificates.created_at, issued_certificates.expires_at, issued_certificates.revoked_at
+  FROM issued_certificates
+  ORDER BY issued_certificates.serial
+`)
+
+var issuedCertificateObjectsBySerial = RegisterStmt(`
+SELECT issued_certificates.id, issued_certificates.serial, issued_certificates.auth_method, issued_certificates.identifier, issued_certificates.identity_provider_groups, issued_certificates.certificate, issued_certificates.created_at, issued_certificates.expires_at, issued_certificates.revoked_at
+  FROM issued_certificates
+  WHERE ( issued_certificates.serial = ? )
+  ORDER BY issued_certificates.serial
+`)
+
+var issuedCertificateObjectsByAuthMethodAndIdentifier = RegisterStmt(`
+SELECT issued_certificates.id, issued_certificates.serial, issued_certificates.auth_method, issued_certificates.identifier, issued_certificates.identity_provider_groups, issued_certificates.certificate, issued_certificates.created_at, issued_certificates.expires_at, issued_certificates.revoked_at
+  FROM issued_certificates
+  WHERE ( issued_certificates.auth_method = ? AND issued_certificates.identifier = ? )
+  ORDER BY issued_certificates.serial
+`)
+
+var issuedCertificateID = RegisterStmt(`
+SELECT issued_certificates.id FROM issued_certificates
+  WHERE issued_certificates.serial = ?
+`)
+
+var issuedCertificateCreate = RegisterStmt(`
+INSERT INTO issued_certificates (serial, auth_method, identifier, identity_provider_groups, certificate, created_at, expires_at, revoked_at)
+  VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`)
+
+var issuedCertificateUpdate = RegisterStmt(`
+UPDATE issued_certificates
+  SET serial = ?, auth_method = ?, identifier = ?, identity_provider_groups = ?, certificate = ?, created_at = ?, expires_at = ?, revoked_at = ?
+ WHERE id = ?
+`)
+
+// issuedCertificateColumns returns a string of column names to be used with a SELECT statement for the entity.
+// Use this function when building statements to retrieve database entries matching the IssuedCertificate entity.
+func issuedCertificateColumns() string {
+	return "issued_certificates.id, issued_certificates.serial, issued_certificates.auth_method, issued_certificates.identifier, issued_certificates.identity_provider_groups, issued_certificates.certificate, issued_certificates.created_at, issued_certificates.expires_at, issued_certificates.revoked_at"
+}
+
+// getIssuedCertificates can be used to run handwritten sql.Stmts to return a slice of objects.
+func getIssuedCertificates(ctx context.Context, stmt *sql.Stmt, args ...any) ([]IssuedCertificate, error) {
+	objects := make([]IssuedCertificate, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		i := IssuedCertificate{}
+		err := scan(&i.ID, &i.Serial, &i.AuthMethod, &i.Identifier, &i.IdentityProviderGroups, &i.Certificate, &i.CreatedAt, &i.ExpiresAt, &i.RevokedAt)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, i)
+
+		return nil
+	}
+
+	err := selectObjects(ctx, stmt, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"issued_certificates\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// getIssuedCertificatesRaw can be used to run handwritten query strings to return a slice of objects.
+func getIssuedCertificatesRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]IssuedCertificate, error) {
+	objects := make([]IssuedCertificate, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		i := IssuedCertificate{}
+		err := scan(&i.ID, &i.Serial, &i.AuthMethod, &i.Identifier, &i.IdentityProviderGroups, &i.Certificate, &i.CreatedAt, &i.ExpiresAt, &i.RevokedAt)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, i)
+
+		return nil
+	}
+
+	err := scan(ctx, db, sql, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"issued_certificates\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetIssuedCertificates returns all available issued_certificates.
+// generator: issued_certificate GetMany
+func GetIssuedCertificates(ctx context.Context, db dbtx, filters ...IssuedCertificateFilter) (_ []IssuedCertificate, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Issued_certificate")
+	}()
+
+	var err error
+
+	// Result slice.
+	objects := make([]IssuedCertificate, 0)
+
+	// Pick the prepared statement and arguments to use based on active criteria.
+	var sqlStmt *sql.Stmt
+	args := []any{}
+	queryParts := [2]string{}
+
+	if len(filters) == 0 {
+		sqlStmt, err = Stmt(db, issuedCertificateObjects)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get \"issuedCertificateObjects\" prepared statement: %w", err)
+		}
+	}
+
+	for i, filter := range filters {
+		if filter.AuthMethod != nil && filter.Identifier != nil && filter.ID == nil && filter.Serial == nil {
+			args = append(args, []any{filter.AuthMethod, filter.Identifier}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, issuedCertificateObjectsByAuthMethodAndIdentifier)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"issuedCertificateObjectsByAuthMethodAndIdentifier\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(issuedCertificateObjectsByAuthMethodAndIdentifier)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"issuedCertificateObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.Serial != nil && filter.ID == nil && filter.AuthMethod == nil && filter.Identifier == nil {
+			args = append(args, []any{filter.Serial}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, issuedCertificateObjectsBySerial)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"issuedCertificateObjectsBySerial\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(issuedCertificateObjectsBySerial)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"issuedCertificateObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.ID == nil && filter.Serial == nil && filter.AuthMethod == nil && filter.Identifier == nil {
+			return nil, fmt.Errorf("Cannot filter on empty IssuedCertificateFilter")
+		} else {
+			return nil, errors.New("No statement exists for the given Filter")
+		}
+	}
+
+	// Select.
+	if sqlStmt != nil {
+		objects, err = getIssuedCertificates(ctx, sqlStmt, args...)
+	} else {
+		queryStr := strings.Join(queryParts[:], "ORDER BY")
+		objects, err = getIssuedCertificatesRaw(ctx, db, queryStr, args...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"issued_certificates\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetIssuedCertificate returns the issued_certificate with the given key.
+// generator: issued_certificate GetOne
+func GetIssuedCertificate(ctx context.Context, db dbtx, serial string) (_ *IssuedCertificate, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Issued_certificate")
+	}()
+
+	filter := IssuedCertificateFilter{}
+	filter.Serial = &serial
+
+	objects, err := GetIssuedCertificates(ctx, db, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"issued_certificates\" table: %w", err)
+	}
+
+	switch len(objects) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return &objects[0], nil
+	default:
+		return nil, fmt.Errorf("More than one \"issued_certificates\" entry matches")
+	}
+}
+
+// GetIssuedCertificateID return the ID of the issued_certificate with the given key.
+// generator: issued_certificate ID
+func GetIssuedCertificateID(ctx context.Context, db tx, serial string) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Issued_certificate")
+	}()
+
+	stmt, err := Stmt(db, issuedCertificateID)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"issuedCertificateID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, serial)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, ErrNotFound
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"issued_certificates\" ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// IssuedCertificateExists checks if a issued_certificate with the given key exists.
+// generator: issued_certificate Exists
+func IssuedCertificateExists(ctx context.Context, db dbtx, serial string) (_ bool, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Issued_certificate")
+	}()
+
+	stmt, err := Stmt(db, issuedCertificateID)
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"issuedCertificateID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, serial)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"issued_certificates\" ID: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateIssuedCertificate adds a new issued_certificate to the database.
+// generator: issued_certificate Create
+func CreateIssuedCertificate(ctx context.Context, db dbtx, object IssuedCertificate) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Issued_certificate")
+	}()
+
+	args := make([]any, 8)
+
+	// Populate the statement arguments.
+	args[0] = object.Serial
+	args[1] = object.AuthMethod
+	args[2] = object.Identifier
+	args[3] = object.IdentityProviderGroups
+	args[4] = object.Certificate
+	args[5] = object.CreatedAt
+	args[6] = object.ExpiresAt
+	args[7] = object.RevokedAt
+
+	// Prepared statement to use.
+	stmt, err := Stmt(db, issuedCertificateCreate)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"issuedCertificateCreate\" prepared statement: %w", err)
+	}
+
+	// Execute the statement.
+	result, err := stmt.Exec(args...)
+	if err != nil && strings.HasPrefix(err.Error(), "UNIQUE constraint failed:") {
+		return -1, ErrConflict
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to create \"issued_certificates\" entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("Failed to fetch \"issued_certificates\" entry ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateIssuedCertificate updates the issued_certificate matching the given key parameters.
+// generator: issued_certificate Update
+func UpdateIssuedCertificate(ctx context.Context, db tx, serial string, object IssuedCertificate) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Issued_certificate")
+	}()
+
+	id, err := GetIssuedCertificateID(ctx, db, serial)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := Stmt(db, issuedCertificateUpdate)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"issuedCertificateUpdate\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(object.Serial, object.AuthMethod, object.Identifier, object.IdentityProviderGroups, object.Certificate, object.CreatedAt, object.ExpiresAt, object.RevokedAt, id)
+	if err != nil {
+		return fmt.Errorf("Update \"issued_certificates\" entry failed: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query updated %d rows instead of 1", n)
+	}
+
+	return nil
+}
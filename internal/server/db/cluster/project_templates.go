@@ -0,0 +1,81 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target project_templates.mapper.go
+//generate-database:mapper reset -i -b "//go:build linux && cgo && !agent"
+//
+//generate-database:mapper stmt -e project_template objects table=project_templates
+//generate-database:mapper stmt -e project_template objects-by-Name table=project_templates
+//generate-database:mapper stmt -e project_template id table=project_templates
+//generate-database:mapper stmt -e project_template create table=project_templates
+//generate-database:mapper stmt -e project_template rename table=project_templates
+//generate-database:mapper stmt -e project_template update table=project_templates
+//generate-database:mapper stmt -e project_template delete-by-Name table=project_templates
+//
+//generate-database:mapper method -i -e project_template GetMany references=Config table=project_templates
+//generate-database:mapper method -i -e project_template GetOne table=project_templates
+//generate-database:mapper method -i -e project_template Exists table=project_templates
+//generate-database:mapper method -i -e project_template Create references=Config table=project_templates
+//generate-database:mapper method -i -e project_template ID table=project_templates
+//generate-database:mapper method -i -e project_template Rename table=project_templates
+//generate-database:mapper method -i -e project_template Update references=Config table=project_templates
+//generate-database:mapper method -i -e project_template DeleteOne-by-Name table=project_templates
+
+// ProjectTemplate is a value object holding db-related details about a project template.
+type ProjectTemplate struct {
+	ID             int
+	Name           string `db:"primary=yes"`
+	Description    string
+	Profiles       []api.ProfilesPost    `db:"marshal=json"`
+	Networks       []api.NetworksPost    `db:"marshal=json"`
+	NetworkACLList []api.NetworkACLsPost `db:"marshal=json"`
+	SelfService    bool
+}
+
+// ProjectTemplateFilter specifies potential query parameter fields.
+type ProjectTemplateFilter struct {
+	Name *string
+}
+
+// ToAPI converts the database ProjectTemplate struct to an api.ProjectTemplate entry.
+func (t *ProjectTemplate) ToAPI(ctx context.Context, db tx) (*api.ProjectTemplate, error) {
+	config, err := GetProjectTemplateConfig(ctx, db, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := api.ProjectTemplate{
+		Name: t.Name,
+		ProjectTemplatePut: api.ProjectTemplatePut{
+			Description: t.Description,
+			Config:      config,
+			Profiles:    t.Profiles,
+			Networks:    t.Networks,
+			NetworkACLs: t.NetworkACLList,
+			SelfService: t.SelfService,
+		},
+	}
+
+	if resp.Profiles == nil {
+		resp.Profiles = []api.ProfilesPost{}
+	}
+
+	if resp.Networks == nil {
+		resp.Networks = []api.NetworksPost{}
+	}
+
+	if resp.NetworkACLs == nil {
+		resp.NetworkACLs = []api.NetworkACLsPost{}
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,52 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import "context"
+
+// ProjectTemplateGenerated is an interface of generated methods for ProjectTemplate.
+type ProjectTemplateGenerated interface {
+	// GetProjectTemplateConfig returns all available ProjectTemplate Config
+	// generator: project_template GetMany
+	GetProjectTemplateConfig(ctx context.Context, db tx, projectTemplateID int, filters ...ConfigFilter) (map[string]string, error)
+
+	// GetProjectTemplates returns all available project_templates.
+	// generator: project_template GetMany
+	GetProjectTemplates(ctx context.Context, db dbtx, filters ...ProjectTemplateFilter) ([]ProjectTemplate, error)
+
+	// GetProjectTemplate returns the project_template with the given key.
+	// generator: project_template GetOne
+	GetProjectTemplate(ctx context.Context, db dbtx, name string) (*ProjectTemplate, error)
+
+	// ProjectTemplateExists checks if a project_template with the given key exists.
+	// generator: project_template Exists
+	ProjectTemplateExists(ctx context.Context, db dbtx, name string) (bool, error)
+
+	// CreateProjectTemplateConfig adds new project_template Config to the database.
+	// generator: project_template Create
+	CreateProjectTemplateConfig(ctx context.Context, db dbtx, projectTemplateID int64, config map[string]string) error
+
+	// CreateProjectTemplate adds a new project_template to the database.
+	// generator: project_template Create
+	CreateProjectTemplate(ctx context.Context, db dbtx, object ProjectTemplate) (int64, error)
+
+	// GetProjectTemplateID return the ID of the project_template with the given key.
+	// generator: project_template ID
+	GetProjectTemplateID(ctx context.Context, db tx, name string) (int64, error)
+
+	// RenameProjectTemplate renames the project_template matching the given key parameters.
+	// generator: project_template Rename
+	RenameProjectTemplate(ctx context.Context, db dbtx, name string, to string) error
+
+	// UpdateProjectTemplateConfig updates the project_template Config matching the given key parameters.
+	// generator: project_template Update
+	UpdateProjectTemplateConfig(ctx context.Context, db tx, projectTemplateID int64, config map[string]string) error
+
+	// UpdateProjectTemplate updates the project_template matching the given key parameters.
+	// generator: project_template Update
+	UpdateProjectTemplate(ctx context.Context, db tx, name string, object ProjectTemplate) error
+
+	// DeleteProjectTemplate deletes the project_template matching the given key parameters.
+	// generator: project_template DeleteOne-by-Name
+	DeleteProjectTemplate(ctx context.Context, db dbtx, name string) error
+}
@@ -0,0 +1,513 @@
+//go:build linux && cgo && !agent
+
+// Code generated by generate-database from the incus project - DO NOT EDIT.
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var projectTemplateObjects = RegisterStmt(`
+SELECT project_templates.id, project_templates.name, project_templates.description, project_templates.profiles, project_templates.networks, project_templates.network_acl_list, project_templates.self_service
+  FROM project_templates
+  ORDER BY project_templates.name
+`)
+
+var projectTemplateObjectsByName = RegisterStmt(`
+SELECT project_templates.id, project_templates.name, project_templates.description, project_templates.profiles, project_templates.networks, project_templates.network_acl_list, project_templates.self_service
+  FROM project_templates
+  WHERE ( project_templates.name = ? )
+  ORDER BY project_templates.name
+`)
+
+var projectTemplateID = RegisterStmt(`
+SELECT project_templates.id FROM project_templates
+  WHERE project_templates.name = ?
+`)
+
+var projectTemplateCreate = RegisterStmt(`
+INSERT INTO project_templates (name, description, profiles, networks, network_acl_list, self_service)
+  VALUES (?, ?, ?, ?, ?, ?)
+`)
+
+var projectTemplateRename = RegisterStmt(`
+UPDATE project_templates SET name = ? WHERE name = ?
+`)
+
+var projectTemplateUpdate = RegisterStmt(`
+UPDATE project_templates
+  SET name = ?, description = ?, profiles = ?, networks = ?, network_acl_list = ?, self_service = ?
+ WHERE id = ?
+`)
+
+var projectTemplateDeleteByName = RegisterStmt(`
+DELETE FROM project_templates WHERE name = ?
+`)
+
+// projectTemplateColumns returns a string of column names to be used with a SELECT statement for the entity.
+// Use this function when building statements to retrieve database entries matching the ProjectTemplate entity.
+func projectTemplateColumns() string {
+	return "project_templates.id, project_templates.name, project_templates.description, project_templates.profiles, project_templates.networks, project_templates.network_acl_list, project_templates.self_service"
+}
+
+// getProjectTemplates can be used to run handwritten sql.Stmts to return a slice of objects.
+func getProjectTemplates(ctx context.Context, stmt *sql.Stmt, args ...any) ([]ProjectTemplate, error) {
+	objects := make([]ProjectTemplate, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		p := ProjectTemplate{}
+		var profilesStr string
+		var networksStr string
+		var networkACLListStr string
+		err := scan(&p.ID, &p.Name, &p.Description, &profilesStr, &networksStr, &networkACLListStr, &p.SelfService)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(profilesStr, &p.Profiles)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(networksStr, &p.Networks)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(networkACLListStr, &p.NetworkACLList)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, p)
+
+		return nil
+	}
+
+	err := selectObjects(ctx, stmt, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"project_templates\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// getProjectTemplatesRaw can be used to run handwritten query strings to return a slice of objects.
+func getProjectTemplatesRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]ProjectTemplate, error) {
+	objects := make([]ProjectTemplate, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		p := ProjectTemplate{}
+		var profilesStr string
+		var networksStr string
+		var networkACLListStr string
+		err := scan(&p.ID, &p.Name, &p.Description, &profilesStr, &networksStr, &networkACLListStr, &p.SelfService)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(profilesStr, &p.Profiles)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(networksStr, &p.Networks)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(networkACLListStr, &p.NetworkACLList)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, p)
+
+		return nil
+	}
+
+	err := scan(ctx, db, sql, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"project_templates\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetProjectTemplates returns all available project_templates.
+// generator: project_template GetMany
+func GetProjectTemplates(ctx context.Context, db dbtx, filters ...ProjectTemplateFilter) (_ []ProjectTemplate, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	var err error
+
+	// Result slice.
+	objects := make([]ProjectTemplate, 0)
+
+	// Pick the prepared statement and arguments to use based on active criteria.
+	var sqlStmt *sql.Stmt
+	args := []any{}
+	queryParts := [2]string{}
+
+	if len(filters) == 0 {
+		sqlStmt, err = Stmt(db, projectTemplateObjects)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get \"projectTemplateObjects\" prepared statement: %w", err)
+		}
+	}
+
+	for i, filter := range filters {
+		if filter.Name != nil {
+			args = append(args, []any{filter.Name}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, projectTemplateObjectsByName)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"projectTemplateObjectsByName\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(projectTemplateObjectsByName)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"projectTemplateObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.Name == nil {
+			return nil, fmt.Errorf("Cannot filter on empty ProjectTemplateFilter")
+		} else {
+			return nil, errors.New("No statement exists for the given Filter")
+		}
+	}
+
+	// Select.
+	if sqlStmt != nil {
+		objects, err = getProjectTemplates(ctx, sqlStmt, args...)
+	} else {
+		queryStr := strings.Join(queryParts[:], "ORDER BY")
+		objects, err = getProjectTemplatesRaw(ctx, db, queryStr, args...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"project_templates\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetProjectTemplateConfig returns all available ProjectTemplate Config
+// generator: project_template GetMany
+func GetProjectTemplateConfig(ctx context.Context, db tx, projectTemplateID int, filters ...ConfigFilter) (_ map[string]string, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	projectTemplateConfig, err := GetConfig(ctx, db, "project_templates", "project_template", filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	config, ok := projectTemplateConfig[projectTemplateID]
+	if !ok {
+		config = map[string]string{}
+	}
+
+	return config, nil
+}
+
+// GetProjectTemplate returns the project_template with the given key.
+// generator: project_template GetOne
+func GetProjectTemplate(ctx context.Context, db dbtx, name string) (_ *ProjectTemplate, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	filter := ProjectTemplateFilter{}
+	filter.Name = &name
+
+	objects, err := GetProjectTemplates(ctx, db, filter)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"project_templates\" table: %w", err)
+	}
+
+	switch len(objects) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return &objects[0], nil
+	default:
+		return nil, fmt.Errorf("More than one \"project_templates\" entry matches")
+	}
+}
+
+// ProjectTemplateExists checks if a project_template with the given key exists.
+// generator: project_template Exists
+func ProjectTemplateExists(ctx context.Context, db dbtx, name string) (_ bool, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	stmt, err := Stmt(db, projectTemplateID)
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"projectTemplateID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, name)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("Failed to get \"project_templates\" ID: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateProjectTemplate adds a new project_template to the database.
+// generator: project_template Create
+func CreateProjectTemplate(ctx context.Context, db dbtx, object ProjectTemplate) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	args := make([]any, 6)
+
+	// Populate the statement arguments.
+	args[0] = object.Name
+	args[1] = object.Description
+	marshaledProfiles, err := marshalJSON(object.Profiles)
+	if err != nil {
+		return -1, err
+	}
+
+	args[2] = marshaledProfiles
+	marshaledNetworks, err := marshalJSON(object.Networks)
+	if err != nil {
+		return -1, err
+	}
+
+	args[3] = marshaledNetworks
+	marshaledNetworkACLList, err := marshalJSON(object.NetworkACLList)
+	if err != nil {
+		return -1, err
+	}
+
+	args[4] = marshaledNetworkACLList
+	args[5] = object.SelfService
+
+	// Prepared statement to use.
+	stmt, err := Stmt(db, projectTemplateCreate)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"projectTemplateCreate\" prepared statement: %w", err)
+	}
+
+	// Execute the statement.
+	result, err := stmt.Exec(args...)
+	if err != nil && strings.HasPrefix(err.Error(), "UNIQUE constraint failed:") {
+		return -1, ErrConflict
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to create \"project_templates\" entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("Failed to fetch \"project_templates\" entry ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateProjectTemplateConfig adds new project_template Config to the database.
+// generator: project_template Create
+func CreateProjectTemplateConfig(ctx context.Context, db dbtx, projectTemplateID int64, config map[string]string) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	referenceID := int(projectTemplateID)
+	for key, value := range config {
+		insert := Config{
+			ReferenceID: referenceID,
+			Key:         key,
+			Value:       value,
+		}
+
+		err := CreateConfig(ctx, db, "project_templates", "project_template", insert)
+		if err != nil {
+			return fmt.Errorf("Insert Config failed for ProjectTemplate: %w", err)
+		}
+
+	}
+
+	return nil
+}
+
+// GetProjectTemplateID return the ID of the project_template with the given key.
+// generator: project_template ID
+func GetProjectTemplateID(ctx context.Context, db tx, name string) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	stmt, err := Stmt(db, projectTemplateID)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"projectTemplateID\" prepared statement: %w", err)
+	}
+
+	row := stmt.QueryRowContext(ctx, name)
+	var id int64
+	err = row.Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, ErrNotFound
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"project_templates\" ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// RenameProjectTemplate renames the project_template matching the given key parameters.
+// generator: project_template Rename
+func RenameProjectTemplate(ctx context.Context, db dbtx, name string, to string) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	stmt, err := Stmt(db, projectTemplateRename)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"projectTemplateRename\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(to, name)
+	if err != nil {
+		return fmt.Errorf("Rename ProjectTemplate failed: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows failed: %w", err)
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query affected %d rows instead of 1", n)
+	}
+
+	return nil
+}
+
+// UpdateProjectTemplate updates the project_template matching the given key parameters.
+// generator: project_template Update
+func UpdateProjectTemplate(ctx context.Context, db tx, name string, object ProjectTemplate) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	id, err := GetProjectTemplateID(ctx, db, name)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := Stmt(db, projectTemplateUpdate)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"projectTemplateUpdate\" prepared statement: %w", err)
+	}
+
+	marshaledProfiles, err := marshalJSON(object.Profiles)
+	if err != nil {
+		return err
+	}
+
+	marshaledNetworks, err := marshalJSON(object.Networks)
+	if err != nil {
+		return err
+	}
+
+	marshaledNetworkACLList, err := marshalJSON(object.NetworkACLList)
+	if err != nil {
+		return err
+	}
+
+	result, err := stmt.Exec(object.Name, object.Description, marshaledProfiles, marshaledNetworks, marshaledNetworkACLList, object.SelfService, id)
+	if err != nil {
+		return fmt.Errorf("Update \"project_templates\" entry failed: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query updated %d rows instead of 1", n)
+	}
+
+	return nil
+}
+
+// UpdateProjectTemplateConfig updates the project_template Config matching the given key parameters.
+// generator: project_template Update
+func UpdateProjectTemplateConfig(ctx context.Context, db tx, projectTemplateID int64, config map[string]string) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	err := UpdateConfig(ctx, db, "project_templates", "project_template", int(projectTemplateID), config)
+	if err != nil {
+		return fmt.Errorf("Replace Config for ProjectTemplate failed: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProjectTemplate deletes the project_template matching the given key parameters.
+// generator: project_template DeleteOne-by-Name
+func DeleteProjectTemplate(ctx context.Context, db dbtx, name string) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Project_template")
+	}()
+
+	stmt, err := Stmt(db, projectTemplateDeleteByName)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"projectTemplateDeleteByName\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(name)
+	if err != nil {
+		return fmt.Errorf("Delete \"project_templates\": %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	} else if n > 1 {
+		return fmt.Errorf("Query deleted %d ProjectTemplate rows instead of 1", n)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ProjectUsage is the resource usage of an instance of a project, recorded at the end of an accounting period.
+type ProjectUsage struct {
+	Instance             string
+	RecordedAt           time.Time
+	CPUSeconds           float64
+	MemoryGiBHours       float64
+	DiskGiBHours         float64
+	NetworkReceivedBytes int64
+	NetworkSentBytes     int64
+}
+
+// CreateProjectUsage records the resource usage of an instance of a project.
+func CreateProjectUsage(ctx context.Context, tx *sql.Tx, project string, usage ProjectUsage) error {
+	projectID, err := GetProjectID(ctx, tx, project)
+	if err != nil {
+		return err
+	}
+
+	stmt := `
+INSERT INTO projects_usage (project_id, instance_name, recorded_at, cpu_seconds, memory_gib_hours, disk_gib_hours, network_received_bytes, network_sent_bytes)
+  VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+	_, err = tx.ExecContext(ctx, stmt, projectID, usage.Instance, usage.RecordedAt, usage.CPUSeconds, usage.MemoryGiBHours, usage.DiskGiBHours, usage.NetworkReceivedBytes, usage.NetworkSentBytes)
+	if err != nil {
+		return fmt.Errorf("Failed to record project usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetProjectUsage returns the resource usage of the instances of a project recorded between from (excluded) and to (included).
+func GetProjectUsage(ctx context.Context, tx *sql.Tx, project string, from time.Time, to time.Time) ([]ProjectUsage, error) {
+	stmt := `
+SELECT projects_usage.instance_name, projects_usage.recorded_at, projects_usage.cpu_seconds, projects_usage.memory_gib_hours,
+       projects_usage.disk_gib_hours, projects_usage.network_received_bytes, projects_usage.network_sent_bytes
+  FROM projects_usage
+  JOIN projects ON projects.id = projects_usage.project_id
+ WHERE projects.name = ? AND projects_usage.recorded_at > ? AND projects_usage.recorded_at <= ?
+ ORDER BY projects_usage.recorded_at, projects_usage.instance_name
+`
+	rows, err := tx.QueryContext(ctx, stmt, project, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch project usage: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	result := []ProjectUsage{}
+	for rows.Next() {
+		usage := ProjectUsage{}
+
+		err = rows.Scan(&usage.Instance, &usage.RecordedAt, &usage.CPUSeconds, &usage.MemoryGiBHours, &usage.DiskGiBHours, &usage.NetworkReceivedBytes, &usage.NetworkSentBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, usage)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteProjectUsage removes the resource usage recorded before the given time.
+func DeleteProjectUsage(ctx context.Context, tx *sql.Tx, before time.Time) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM projects_usage WHERE recorded_at < ?", before)
+	if err != nil {
+		return fmt.Errorf("Failed to delete project usage: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,57 @@ package cluster
 // modify the database schema, please add a new schema update to update.go
 // and the run 'make update-schema'.
 const freshSchema = `
+CREATE TABLE approvals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    operation_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    description TEXT NOT NULL,
+    requestor_protocol TEXT NOT NULL,
+    requestor_username TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (operation_id),
+    FOREIGN KEY (operation_id) REFERENCES "operations" (id) ON DELETE CASCADE
+);
+CREATE TABLE auth_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    UNIQUE (name)
+);
+CREATE TABLE auth_groups_permissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_group_id INTEGER NOT NULL,
+    entitlement TEXT NOT NULL,
+    object TEXT NOT NULL,
+    UNIQUE (auth_group_id, entitlement, object),
+    FOREIGN KEY (auth_group_id) REFERENCES auth_groups (id) ON DELETE CASCADE
+);
+CREATE TABLE auth_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    secret_hash TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME,
+    last_used_at DATETIME,
+    UNIQUE (name),
+    UNIQUE (secret_hash)
+);
+CREATE TABLE auth_tokens_entitlements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_token_id INTEGER NOT NULL,
+    entitlement TEXT NOT NULL,
+    UNIQUE (auth_token_id, entitlement),
+    FOREIGN KEY (auth_token_id) REFERENCES auth_tokens (id) ON DELETE CASCADE
+);
+CREATE TABLE auth_tokens_projects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_token_id INTEGER NOT NULL,
+    project_id INTEGER NOT NULL,
+    UNIQUE (auth_token_id, project_id),
+    FOREIGN KEY (auth_token_id) REFERENCES auth_tokens (id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
 CREATE TABLE certificates (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     fingerprint TEXT NOT NULL,
@@ -43,6 +94,21 @@ CREATE TABLE config (
     value TEXT,
     UNIQUE (key)
 );
+CREATE TABLE identities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_method TEXT NOT NULL,
+    identifier TEXT NOT NULL,
+    description TEXT NOT NULL,
+    UNIQUE (auth_method, identifier)
+);
+CREATE TABLE identities_auth_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    identity_id INTEGER NOT NULL,
+    auth_group_id INTEGER NOT NULL,
+    FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE CASCADE,
+    FOREIGN KEY (auth_group_id) REFERENCES auth_groups (id) ON DELETE CASCADE,
+    UNIQUE (identity_id, auth_group_id)
+);
 CREATE TABLE "images" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     fingerprint TEXT NOT NULL,
@@ -211,6 +277,23 @@ CREATE TABLE "instances_snapshots_devices_config" (
     FOREIGN KEY (instance_snapshot_device_id) REFERENCES "instances_snapshots_devices" (id) ON DELETE CASCADE,
     UNIQUE (instance_snapshot_device_id, key)
 );
+CREATE TABLE issued_certificates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    serial TEXT NOT NULL,
+    auth_method TEXT NOT NULL,
+    identifier TEXT NOT NULL,
+    identity_provider_groups TEXT NOT NULL,
+    certificate TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    UNIQUE (serial)
+);
+CREATE TABLE issued_certificates_authorities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    certificate TEXT NOT NULL,
+    key TEXT NOT NULL
+);
 CREATE TABLE "networks" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     project_id INTEGER NOT NULL,
@@ -471,6 +554,24 @@ CREATE TABLE "profiles_devices_config" (
     FOREIGN KEY (profile_device_id) REFERENCES "profiles_devices" (id) ON DELETE CASCADE
 );
 CREATE INDEX profiles_project_id_idx ON profiles (project_id);
+CREATE TABLE project_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    profiles TEXT NOT NULL,
+    networks TEXT NOT NULL,
+    network_acl_list TEXT NOT NULL,
+    self_service INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (name)
+);
+CREATE TABLE project_templates_config (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_template_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    UNIQUE (project_template_id, key),
+    FOREIGN KEY (project_template_id) REFERENCES project_templates (id) ON DELETE CASCADE
+);
 CREATE TABLE "projects" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     name TEXT NOT NULL,
@@ -485,6 +586,19 @@ CREATE TABLE "projects_config" (
     FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE,
     UNIQUE (project_id, key)
 );
+CREATE TABLE projects_usage (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    instance_name TEXT NOT NULL,
+    recorded_at DATETIME NOT NULL,
+    cpu_seconds REAL NOT NULL,
+    memory_gib_hours REAL NOT NULL,
+    disk_gib_hours REAL NOT NULL,
+    network_received_bytes INTEGER NOT NULL,
+    network_sent_bytes INTEGER NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+CREATE INDEX projects_usage_project_id_recorded_at_idx ON projects_usage (project_id, recorded_at);
 CREATE TABLE "storage_buckets" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     name TEXT NOT NULL,
@@ -522,6 +636,7 @@ CREATE TABLE "storage_buckets_keys" (
     access_key TEXT NOT NULL,
     secret_key TEXT NOT NULL,
     role TEXT NOT NULL,
+    prefix TEXT NOT NULL DEFAULT '',
     UNIQUE (storage_bucket_id, name),
     FOREIGN KEY (storage_bucket_id) REFERENCES "storage_buckets" (id) ON DELETE CASCADE
 );
@@ -675,5 +790,5 @@ CREATE TABLE "warnings" (
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (77, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (84, strftime("%s"))
 `
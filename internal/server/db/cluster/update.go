@@ -114,6 +114,177 @@ var updates = map[int]schema.Update{
 	75: updateFromV74,
 	76: updateFromV75,
 	77: updateFromV76,
+	78: updateFromV77,
+	79: updateFromV78,
+	80: updateFromV79,
+	81: updateFromV80,
+	82: updateFromV81,
+	83: updateFromV82,
+	84: updateFromV83,
+}
+
+func updateFromV83(ctx context.Context, tx *sql.Tx) error {
+	stmts := `
+CREATE TABLE project_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    profiles TEXT NOT NULL,
+    networks TEXT NOT NULL,
+    network_acl_list TEXT NOT NULL,
+    self_service INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (name)
+);
+CREATE TABLE project_templates_config (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_template_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    UNIQUE (project_template_id, key),
+    FOREIGN KEY (project_template_id) REFERENCES project_templates (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(stmts)
+	return err
+}
+
+func updateFromV82(ctx context.Context, tx *sql.Tx) error {
+	stmts := `
+CREATE TABLE approvals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    operation_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    description TEXT NOT NULL,
+    requestor_protocol TEXT NOT NULL,
+    requestor_username TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (operation_id),
+    FOREIGN KEY (operation_id) REFERENCES "operations" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(stmts)
+	return err
+}
+
+func updateFromV81(ctx context.Context, tx *sql.Tx) error {
+	stmts := `
+CREATE TABLE projects_usage (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    instance_name TEXT NOT NULL,
+    recorded_at DATETIME NOT NULL,
+    cpu_seconds REAL NOT NULL,
+    memory_gib_hours REAL NOT NULL,
+    disk_gib_hours REAL NOT NULL,
+    network_received_bytes INTEGER NOT NULL,
+    network_sent_bytes INTEGER NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+CREATE INDEX projects_usage_project_id_recorded_at_idx ON projects_usage (project_id, recorded_at);
+`
+	_, err := tx.Exec(stmts)
+	return err
+}
+
+func updateFromV80(ctx context.Context, tx *sql.Tx) error {
+	stmts := `
+CREATE TABLE issued_certificates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    serial TEXT NOT NULL,
+    auth_method TEXT NOT NULL,
+    identifier TEXT NOT NULL,
+    identity_provider_groups TEXT NOT NULL,
+    certificate TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    UNIQUE (serial)
+);
+CREATE TABLE issued_certificates_authorities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    certificate TEXT NOT NULL,
+    key TEXT NOT NULL
+);
+`
+	_, err := tx.Exec(stmts)
+	return err
+}
+
+func updateFromV79(ctx context.Context, tx *sql.Tx) error {
+	stmts := `
+CREATE TABLE auth_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    secret_hash TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME,
+    last_used_at DATETIME,
+    UNIQUE (name),
+    UNIQUE (secret_hash)
+);
+CREATE TABLE auth_tokens_entitlements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_token_id INTEGER NOT NULL,
+    entitlement TEXT NOT NULL,
+    UNIQUE (auth_token_id, entitlement),
+    FOREIGN KEY (auth_token_id) REFERENCES auth_tokens (id) ON DELETE CASCADE
+);
+CREATE TABLE auth_tokens_projects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_token_id INTEGER NOT NULL,
+    project_id INTEGER NOT NULL,
+    UNIQUE (auth_token_id, project_id),
+    FOREIGN KEY (auth_token_id) REFERENCES auth_tokens (id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(stmts)
+	return err
+}
+
+func updateFromV78(ctx context.Context, tx *sql.Tx) error {
+	stmts := `
+CREATE TABLE auth_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    UNIQUE (name)
+);
+CREATE TABLE auth_groups_permissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_group_id INTEGER NOT NULL,
+    entitlement TEXT NOT NULL,
+    object TEXT NOT NULL,
+    UNIQUE (auth_group_id, entitlement, object),
+    FOREIGN KEY (auth_group_id) REFERENCES auth_groups (id) ON DELETE CASCADE
+);
+CREATE TABLE identities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    auth_method TEXT NOT NULL,
+    identifier TEXT NOT NULL,
+    description TEXT NOT NULL,
+    UNIQUE (auth_method, identifier)
+);
+CREATE TABLE identities_auth_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    identity_id INTEGER NOT NULL,
+    auth_group_id INTEGER NOT NULL,
+    FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE CASCADE,
+    FOREIGN KEY (auth_group_id) REFERENCES auth_groups (id) ON DELETE CASCADE,
+    UNIQUE (identity_id, auth_group_id)
+);
+`
+	_, err := tx.Exec(stmts)
+	return err
+}
+
+func updateFromV77(ctx context.Context, tx *sql.Tx) error {
+	stmts := `
+ALTER TABLE storage_buckets_keys ADD COLUMN prefix TEXT NOT NULL DEFAULT '';
+`
+	_, err := tx.Exec(stmts)
+	return err
 }
 
 func updateFromV76(ctx context.Context, tx *sql.Tx) error {
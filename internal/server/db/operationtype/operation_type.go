@@ -80,6 +80,13 @@ const (
 	BucketBackupRename
 	BucketBackupRestore
 	VolumeRebuild
+	StoragePoolScrub
+	StoragePoolHealthCheck
+	StoragePoolReclaim
+	StoragePoolsReclaim
+	StorageBucketsLifecycle
+	ProjectUpdate
+	ProfileUpdate
 )
 
 // Description return a human-readable description of the operation type.
@@ -165,6 +172,10 @@ func (t Type) Description() string {
 		return "Renaming storage volume snapshot"
 	case ProjectRename:
 		return "Renaming project"
+	case ProjectUpdate:
+		return "Updating project"
+	case ProfileUpdate:
+		return "Updating profile"
 	case ImagesExpire:
 		return "Cleaning up expired images"
 	case ImagesPruneLeftover:
@@ -213,6 +224,16 @@ func (t Type) Description() string {
 		return "Renaming bucket backup"
 	case BucketBackupRestore:
 		return "Restoring bucket backup"
+	case StoragePoolScrub:
+		return "Scrubbing storage pool"
+	case StoragePoolHealthCheck:
+		return "Checking storage pool health"
+	case StoragePoolReclaim:
+		return "Reclaiming storage pool space"
+	case StoragePoolsReclaim:
+		return "Reclaiming storage pools space"
+	case StorageBucketsLifecycle:
+		return "Applying storage bucket lifecycle rules"
 	default:
 		return "Executing operation"
 	}
@@ -306,6 +327,17 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 	case BucketBackupRestore:
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit
 
+	case StoragePoolScrub:
+		return auth.ObjectTypeStoragePool, auth.EntitlementCanEdit
+	case StoragePoolReclaim:
+		return auth.ObjectTypeStoragePool, auth.EntitlementCanEdit
+
+	case ProjectUpdate:
+		return auth.ObjectTypeProject, auth.EntitlementCanEdit
+
+	case ProfileUpdate:
+		return auth.ObjectTypeProfile, auth.EntitlementCanEdit
+
 	default:
 		return "", ""
 	}
@@ -58,6 +58,12 @@ const (
 	UnableToUpdateClusterCertificate
 	// SELinuxNotAvailable represents the SELinux not available warning.
 	SELinuxNotAvailable
+	// StoragePoolDegraded represents a storage pool reporting device errors or reduced redundancy.
+	StoragePoolDegraded
+	// TrustedCertificateExpiring represents a trusted certificate which is about to expire.
+	TrustedCertificateExpiring
+	// InstanceUnhealthy represents an instance which remains unhealthy after its automatic recoveries.
+	InstanceUnhealthy
 )
 
 // TypeNames associates a warning code to its name.
@@ -87,6 +93,9 @@ var TypeNames = map[Type]string{
 	StoragePoolUnvailable:             "Storage pool unavailable",
 	UnableToUpdateClusterCertificate:  "Unable to update cluster certificate",
 	SELinuxNotAvailable:               "SELinux support has been disabled",
+	StoragePoolDegraded:               "Storage pool degraded",
+	TrustedCertificateExpiring:        "Trusted certificate expiring soon",
+	InstanceUnhealthy:                 "Instance remains unhealthy",
 }
 
 // Severity returns the severity of the warning type.
@@ -142,6 +151,12 @@ func (t Type) Severity() Severity {
 		return SeverityLow
 	case SELinuxNotAvailable:
 		return SeverityLow
+	case StoragePoolDegraded:
+		return SeverityHigh
+	case TrustedCertificateExpiring:
+		return SeverityModerate
+	case InstanceUnhealthy:
+		return SeverityModerate
 	}
 
 	return SeverityLow
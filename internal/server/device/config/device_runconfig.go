@@ -53,6 +53,7 @@ type DiskLimits struct {
 	ReadIOps   int64
 	WriteBytes int64
 	WriteIOps  int64
+	Group      string // Name of the throttle group shared between disks (VM only).
 }
 
 // RunConfig represents run-time config used for device setup/cleanup.
@@ -67,6 +68,7 @@ type RunConfig struct {
 	USBDevice        []USBDeviceItem  // USB device configuration settings.
 	TPMDevice        []RunConfigItem  // TPM device configuration settings.
 	PCIDevice        []RunConfigItem  // PCI device configuration settings.
+	WatchdogDevice   []RunConfigItem  // Watchdog device configuration settings.
 	Revert           revert.Hook      // Revert setup of device on post-setup error.
 	UseUSBBus        bool             // Whether to use a USB bus for the device.
 }
@@ -90,6 +90,8 @@ func newByType(s *state.State, projectName string, conf deviceConfig.Device) (de
 		dev = &tpm{}
 	case "pci":
 		dev = &pci{}
+	case "watchdog":
+		dev = &watchdog{}
 	}
 
 	// Check a valid device type has been found.
@@ -3,6 +3,7 @@ package device
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -1360,20 +1361,20 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 		opts = append(opts, fmt.Sprintf("wwn=%s", d.config["wwn"]))
 	}
 
-	// Add I/O limits if set.
+	// Add I/O limits if set (either on the device or on the custom volume).
 	var diskLimits *deviceConfig.DiskLimits
-	if d.config["limits.read"] != "" || d.config["limits.write"] != "" || d.config["limits.max"] != "" {
-		// Parse the limits into usable values.
-		readBps, readIops, writeBps, writeIops, err := d.parseLimit(d.config)
-		if err != nil {
-			return nil, err
-		}
+	readBps, readIops, writeBps, writeIops, err := d.parseLimit(d.config)
+	if err != nil {
+		return nil, err
+	}
 
+	if readBps > 0 || readIops > 0 || writeBps > 0 || writeIops > 0 {
 		diskLimits = &deviceConfig.DiskLimits{
 			ReadBytes:  readBps,
 			ReadIOps:   readIops,
 			WriteBytes: writeBps,
 			WriteIOps:  writeIops,
+			Group:      d.throttleGroup(),
 		}
 	}
 
@@ -1902,6 +1903,7 @@ func (d *disk) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 					ReadIOps:   readIops,
 					WriteBytes: writeBps,
 					WriteIOps:  writeIops,
+					Group:      d.throttleGroup(),
 				},
 			}}
 		}
@@ -1993,6 +1995,18 @@ func (d *disk) generateLimits(runConf *deviceConfig.RunConfig) error {
 
 		if dev["limits.read"] != "" || dev["limits.write"] != "" || dev["limits.max"] != "" {
 			hasDiskLimits = true
+			break
+		}
+
+		// Check for limits set on the custom volume.
+		volBps, volIops, err := d.volumeLimits(dev)
+		if err != nil {
+			return err
+		}
+
+		if volBps > 0 || volIops > 0 {
+			hasDiskLimits = true
+			break
 		}
 	}
 
@@ -2992,9 +3006,101 @@ func (d *disk) parseLimit(dev deviceConfig.Device) (int64, int64, int64, int64,
 		return -1, -1, -1, -1, err
 	}
 
+	// Apply the limits of the custom volume (if any) on top of the device ones.
+	volBps, volIops, err := d.volumeLimits(dev)
+	if err != nil {
+		return -1, -1, -1, -1, err
+	}
+
+	readBps = diskLowestLimit(readBps, volBps)
+	readIops = diskLowestLimit(readIops, volIops)
+	writeBps = diskLowestLimit(writeBps, volBps)
+	writeIops = diskLowestLimit(writeIops, volIops)
+
 	return readBps, readIops, writeBps, writeIops, nil
 }
 
+// diskLowestLimit returns the most restrictive of a device and a volume limit, zero meaning no limit.
+func diskLowestLimit(limit int64, volLimit int64) int64 {
+	if volLimit > 0 && (limit == 0 || volLimit < limit) {
+		return volLimit
+	}
+
+	return limit
+}
+
+// volumeLimits returns the bandwidth and iops limits configured on the custom volume used by the disk device.
+// Zero values are returned when the device isn't backed by a custom volume or the volume has no limits.
+func (d *disk) volumeLimits(dev deviceConfig.Device) (int64, int64, error) {
+	if dev["pool"] == "" || dev["source"] == "" || internalInstance.IsRootDiskDevice(dev) {
+		return 0, 0, nil
+	}
+
+	pool, err := storagePools.LoadByName(d.state, dev["pool"])
+	if err != nil {
+		return -1, -1, err
+	}
+
+	storageProjectName, err := project.StorageVolumeProject(d.state.DB.Cluster, d.inst.Project().Name, db.StoragePoolVolumeTypeCustom)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	// Parse the volume name and path.
+	volName, _ := internalInstance.SplitVolumeSource(dev["source"])
+
+	var dbVolume *db.StorageVolume
+	err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbVolume, err = tx.GetStoragePoolVolume(ctx, pool.ID(), storageProjectName, db.StoragePoolVolumeTypeCustom, volName, true)
+		return err
+	})
+	if err != nil {
+		return -1, -1, fmt.Errorf("Failed loading custom volume %q: %w", volName, err)
+	}
+
+	var bps, iops int64
+
+	if dbVolume.Config["limits.bandwidth"] != "" {
+		bps, err = units.ParseByteSizeString(dbVolume.Config["limits.bandwidth"])
+		if err != nil {
+			return -1, -1, fmt.Errorf("Invalid limits.bandwidth on custom volume %q: %w", volName, err)
+		}
+	}
+
+	if dbVolume.Config["limits.iops"] != "" {
+		iops, err = strconv.ParseInt(dbVolume.Config["limits.iops"], 10, 64)
+		if err != nil {
+			return -1, -1, fmt.Errorf("Invalid limits.iops on custom volume %q: %w", volName, err)
+		}
+	}
+
+	return bps, iops, nil
+}
+
+// throttleGroup returns the name of the QEMU throttle group to place the disk in.
+// Disks of the instance backed by the same custom volume and without limits of their own share a
+// throttle group so that the volume limits apply to all of them combined.
+func (d *disk) throttleGroup() string {
+	if d.config["pool"] == "" || d.config["source"] == "" || internalInstance.IsRootDiskDevice(d.config) {
+		return ""
+	}
+
+	if d.config["limits.read"] != "" || d.config["limits.write"] != "" || d.config["limits.max"] != "" {
+		return ""
+	}
+
+	volName, _ := internalInstance.SplitVolumeSource(d.config["source"])
+
+	return diskThrottleGroupName(d.inst.Project().Name, d.config["pool"], volName)
+}
+
+// diskThrottleGroupName returns the name of the QEMU throttle group of a custom volume.
+func diskThrottleGroupName(projectName string, poolName string, volName string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", projectName, poolName, volName)))
+
+	return fmt.Sprintf("incus_vol_%x", hash[:8])
+}
+
 func (d *disk) getParentBlocks(path string) ([]string, error) {
 	var devices []string
 	var dev []string
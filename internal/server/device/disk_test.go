@@ -0,0 +1,45 @@
+package device
+
+import (
+	"testing"
+)
+
+func TestDiskLowestLimit(t *testing.T) {
+	tests := []struct {
+		limit    int64
+		volLimit int64
+		expected int64
+	}{
+		{0, 0, 0},
+		{100, 0, 100},
+		{0, 100, 100},
+		{100, 50, 50},
+		{50, 100, 50},
+	}
+
+	for _, test := range tests {
+		result := diskLowestLimit(test.limit, test.volLimit)
+		if result != test.expected {
+			t.Errorf("diskLowestLimit(%d, %d) = %d, expected %d", test.limit, test.volLimit, result, test.expected)
+		}
+	}
+}
+
+func TestDiskThrottleGroupName(t *testing.T) {
+	name := diskThrottleGroupName("default", "pool1", "vol1")
+	if name != diskThrottleGroupName("default", "pool1", "vol1") {
+		t.Errorf("Throttle group name isn't stable for the same volume")
+	}
+
+	others := [][3]string{
+		{"other", "pool1", "vol1"},
+		{"default", "pool2", "vol1"},
+		{"default", "pool1", "vol2"},
+	}
+
+	for _, other := range others {
+		if diskThrottleGroupName(other[0], other[1], other[2]) == name {
+			t.Errorf("Throttle group name of %q/%q/%q collides with default/pool1/vol1", other[0], other[1], other[2])
+		}
+	}
+}
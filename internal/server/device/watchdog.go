@@ -0,0 +1,125 @@
+package device
+
+import (
+	"errors"
+	"fmt"
+
+	deviceConfig "github.com/lxc/incus/v7/internal/server/device/config"
+	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v7/shared/osarch"
+	"github.com/lxc/incus/v7/shared/validate"
+)
+
+type watchdog struct {
+	deviceCommon
+}
+
+// CanMigrate returns whether the device can be migrated to any other cluster member.
+func (d *watchdog) CanMigrate() bool {
+	return true
+}
+
+// validateConfig checks the supplied config for correctness.
+func (d *watchdog) validateConfig(instConf instance.ConfigReader, partialValidation bool) error {
+	if !instanceSupported(instConf.Type(), instancetype.VM) {
+		return ErrUnsupportedDevType
+	}
+
+	rules := map[string]func(string) error{
+		// gendoc:generate(entity=devices, group=watchdog, key=model)
+		// Possible values are `i6300esb` (PCI), `ib700` (ISA, x86_64 only) or `diag288` (s390x only).
+		// ---
+		//  type: string
+		//  default: `diag288` on s390x, `i6300esb` otherwise
+		//  required: no
+		//  shortdesc: Emulated watchdog model
+		"model": validate.Optional(validate.IsOneOf("i6300esb", "ib700", "diag288")),
+
+		// gendoc:generate(entity=devices, group=watchdog, key=action)
+		// Possible values are `reset`, `poweroff`, `pause` or `none`.
+		// ---
+		//  type: string
+		//  default: `reset`
+		//  required: no
+		//  shortdesc: What to do with the VM when the watchdog fires
+		"action": validate.Optional(validate.IsOneOf("reset", "poweroff", "pause", "none")),
+	}
+
+	err := d.config.Validate(rules)
+	if err != nil {
+		return fmt.Errorf("Failed to validate config: %w", err)
+	}
+
+	// QEMU only supports a single watchdog action per VM.
+	for name, dev := range instConf.ExpandedDevices() {
+		if name != d.name && dev["type"] == "watchdog" {
+			return errors.New("Only one watchdog device can be added to an instance")
+		}
+	}
+
+	return nil
+}
+
+// model returns the watchdog model to emulate.
+func (d *watchdog) model() string {
+	if d.config["model"] != "" {
+		return d.config["model"]
+	}
+
+	if d.inst.Architecture() == osarch.ARCH_64BIT_S390_BIG_ENDIAN {
+		return "diag288"
+	}
+
+	return "i6300esb"
+}
+
+// validateEnvironment checks that the watchdog model is supported by the VM architecture.
+func (d *watchdog) validateEnvironment() error {
+	switch d.model() {
+	case "ib700":
+		if d.inst.Architecture() != osarch.ARCH_64BIT_INTEL_X86 {
+			return errors.New("The ib700 watchdog is only supported on x86_64")
+		}
+
+	case "diag288":
+		if d.inst.Architecture() != osarch.ARCH_64BIT_S390_BIG_ENDIAN {
+			return errors.New("The diag288 watchdog is only supported on s390x")
+		}
+
+	default:
+		if d.inst.Architecture() == osarch.ARCH_64BIT_S390_BIG_ENDIAN {
+			return fmt.Errorf("The %s watchdog isn't supported on s390x", d.model())
+		}
+	}
+
+	return nil
+}
+
+// Start is run when the device is added to the instance.
+func (d *watchdog) Start() (*deviceConfig.RunConfig, error) {
+	err := d.validateEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to validate environment: %w", err)
+	}
+
+	action := d.config["action"]
+	if action == "" {
+		action = "reset"
+	}
+
+	runConf := deviceConfig.RunConfig{
+		WatchdogDevice: []deviceConfig.RunConfigItem{
+			{Key: "devName", Value: d.name},
+			{Key: "model", Value: d.model()},
+			{Key: "action", Value: action},
+		},
+	}
+
+	return &runConf, nil
+}
+
+// Stop is run when the device is removed from the instance.
+func (d *watchdog) Stop() (*deviceConfig.RunConfig, error) {
+	return &deviceConfig.RunConfig{}, nil
+}
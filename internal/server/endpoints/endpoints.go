@@ -1,6 +1,7 @@
 package endpoints
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -163,6 +164,8 @@ type Endpoints struct {
 	cert      *localtls.CertInfo    // Keypair and CA to use for TLS.
 	inherited map[kind]bool         // Store whether the listener came through socket activation
 
+	revocationCheck func(cert *x509.Certificate) error // Rejects revoked client certificates during the TLS handshake.
+
 	systemdListenFDsStart int // First socket activation FD, for tests.
 }
 
@@ -2,6 +2,7 @@ package listeners
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"net"
 	"slices"
 	"sync"
@@ -20,6 +21,8 @@ type FancyTLSListener struct {
 	mu           sync.RWMutex
 	config       *tls.Config
 	trustedProxy []net.IP
+
+	revocationCheck func(cert *x509.Certificate) error
 }
 
 // NewFancyTLSListener creates a new FancyTLSListener.
@@ -58,6 +61,40 @@ func (l *FancyTLSListener) Config(cert *localtls.CertInfo) {
 	defer l.mu.Unlock()
 
 	l.config = config
+	l.applyRevocationCheck()
+}
+
+// RevocationCheck sets the function used to reject revoked client certificates during the TLS handshake.
+func (l *FancyTLSListener) RevocationCheck(f func(cert *x509.Certificate) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.config = l.config.Clone()
+	l.revocationCheck = f
+	l.applyRevocationCheck()
+}
+
+// applyRevocationCheck hooks the revocation check into the current TLS configuration.
+// The caller must hold the lock.
+func (l *FancyTLSListener) applyRevocationCheck() {
+	revocationCheck := l.revocationCheck
+	if revocationCheck == nil {
+		l.config.VerifyPeerCertificate = nil
+		return
+	}
+
+	l.config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return nil
+		}
+
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return err
+		}
+
+		return revocationCheck(cert)
+	}
 }
 
 // TrustedProxy sets new the https trusted proxy configuration.
@@ -1,6 +1,7 @@
 package endpoints
 
 import (
+	"crypto/x509"
 	"fmt"
 	"log"
 	"net"
@@ -115,14 +116,14 @@ func (e *Endpoints) NetworkUpdateAddress(address string) error {
 		// Attempt to revert to the previous address
 		listener, err1 := getListener(oldAddress)
 		if err1 == nil {
-			e.listeners[network] = listeners.NewFancyTLSListener(*listener, e.cert)
+			e.listeners[network] = e.newFancyTLSListener(*listener)
 			e.serve(network)
 		}
 
 		return err
 	}
 
-	e.listeners[network] = listeners.NewFancyTLSListener(*listener, e.cert)
+	e.listeners[network] = e.newFancyTLSListener(*listener)
 	e.serve(network)
 
 	return nil
@@ -176,6 +177,33 @@ func (e *Endpoints) NetworkUpdateTrustedProxy(trustedProxy string) {
 	}
 }
 
+// NetworkUpdateRevocationCheck sets the function used by the network endpoint to reject
+// revoked client certificates during the TLS handshake.
+func (e *Endpoints) NetworkUpdateRevocationCheck(f func(cert *x509.Certificate) error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.revocationCheck = f
+
+	listener, ok := e.listeners[network]
+	if !ok || listener == nil {
+		return
+	}
+
+	listener.(*listeners.FancyTLSListener).RevocationCheck(f)
+}
+
+// newFancyTLSListener wraps a network listener, applying the current revocation check.
+// The caller must hold the lock.
+func (e *Endpoints) newFancyTLSListener(listener net.Listener) *listeners.FancyTLSListener {
+	fancyListener := listeners.NewFancyTLSListener(listener, e.cert)
+	if e.revocationCheck != nil {
+		fancyListener.RevocationCheck(e.revocationCheck)
+	}
+
+	return fancyListener
+}
+
 // Create a new net.Listener bound to the tcp socket of the network endpoint.
 func networkCreateListener(address string, cert *localtls.CertInfo) (net.Listener, error) {
 	// Listening on `tcp` network with address 0.0.0.0 will end up with listening
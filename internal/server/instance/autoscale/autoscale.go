@@ -0,0 +1,247 @@
+package autoscale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/units"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+// Defaults for the autoscaling bounds.
+const (
+	DefaultCPUMin    = 10
+	DefaultMemoryMin = 128 * 1024 * 1024
+)
+
+const (
+	// cpuHighRatio is the fraction of its CPU allowance above which an instance is given more CPU time.
+	cpuHighRatio = 0.8
+
+	// cpuLowRatio is the fraction of its CPU allowance below which CPU time is taken away from an instance.
+	cpuLowRatio = 0.3
+
+	// cpuStep is the granularity (in percent of a CPU) of the CPU allowance changes.
+	cpuStep = 5
+
+	// memoryHighRatio is the fraction of its memory limit above which an instance is given more memory.
+	memoryHighRatio = 0.9
+
+	// memoryLowRatio is the fraction of its memory limit below which memory is taken away from an instance.
+	memoryLowRatio = 0.5
+
+	// memoryHighPressure is the memory pressure (percentage of time stalled over the last 10s) above which an instance is given more memory.
+	memoryHighPressure = 10.0
+
+	// memoryLowPressure is the memory pressure below which memory may be taken away from an instance.
+	memoryLowPressure = 1.0
+
+	// memoryHeadroomRatio is the fraction of the new memory limit left free when shrinking it.
+	memoryHeadroomRatio = 0.3
+
+	// memoryStep is the granularity of the memory limit changes.
+	memoryStep = 64 * 1024 * 1024
+
+	// growRatio and shrinkRatio are how much a limit is scaled at once.
+	growRatio   = 1.5
+	shrinkRatio = 0.75
+)
+
+// Cooldown is the minimum time between two changes of the same limit.
+const Cooldown = 5 * time.Minute
+
+// Config represents the autoscaling configuration of an instance.
+type Config struct {
+	// CPUMin and CPUMax are the bounds of the CPU allowance, in percent of a single CPU.
+	// A CPUMax of zero means all the CPUs available to the instance.
+	CPUMin int
+	CPUMax int
+
+	// MemoryMin and MemoryMax are the bounds of the memory limit in bytes.
+	// A MemoryMax of zero means all the host memory.
+	MemoryMin int64
+	MemoryMax int64
+}
+
+// Sample represents the resource usage of an instance at a point in time.
+type Sample struct {
+	Time time.Time
+
+	// CPUUsage is the total CPU time used by the instance.
+	CPUUsage time.Duration
+
+	// CPUs is the number of CPUs the instance can use.
+	CPUs int
+
+	// MemoryUsage and MemoryLimit are the current memory usage and limit of the instance in bytes.
+	MemoryUsage int64
+	MemoryLimit int64
+
+	// MemoryPressure is the share of time (in percent) some tasks were stalled on memory over the last 10 seconds.
+	MemoryPressure float64
+}
+
+// parsePercent parses a percentage such as `50%`.
+func parsePercent(value string) (int, error) {
+	before, ok := strings.CutSuffix(value, "%")
+	if !ok {
+		return -1, fmt.Errorf("Invalid percentage %q", value)
+	}
+
+	percent, err := strconv.Atoi(before)
+	if err != nil || percent <= 0 {
+		return -1, fmt.Errorf("Invalid percentage %q", value)
+	}
+
+	return percent, nil
+}
+
+// IsCPUAllowance validates a CPU allowance bound, expressed as a percentage of a single CPU.
+func IsCPUAllowance(value string) error {
+	_, err := parsePercent(value)
+	return err
+}
+
+// ParseConfig returns the autoscaling configuration from the instance's expanded config.
+// It returns nil if autoscaling isn't enabled.
+func ParseConfig(config map[string]string) (*Config, error) {
+	if !util.IsTrue(config["limits.autoscale"]) {
+		return nil, nil
+	}
+
+	c := &Config{
+		CPUMin:    DefaultCPUMin,
+		MemoryMin: DefaultMemoryMin,
+	}
+
+	var err error
+
+	if config["limits.autoscale.cpu.min"] != "" {
+		c.CPUMin, err = parsePercent(config["limits.autoscale.cpu.min"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", "limits.autoscale.cpu.min", err)
+		}
+	}
+
+	if config["limits.autoscale.cpu.max"] != "" {
+		c.CPUMax, err = parsePercent(config["limits.autoscale.cpu.max"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", "limits.autoscale.cpu.max", err)
+		}
+	}
+
+	if config["limits.autoscale.memory.min"] != "" {
+		c.MemoryMin, err = units.ParseByteSizeString(config["limits.autoscale.memory.min"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", "limits.autoscale.memory.min", err)
+		}
+	}
+
+	if config["limits.autoscale.memory.max"] != "" {
+		c.MemoryMax, err = units.ParseByteSizeString(config["limits.autoscale.memory.max"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", "limits.autoscale.memory.max", err)
+		}
+	}
+
+	if c.CPUMax > 0 && c.CPUMin > c.CPUMax {
+		return nil, fmt.Errorf("%q can't be higher than %q", "limits.autoscale.cpu.min", "limits.autoscale.cpu.max")
+	}
+
+	if c.MemoryMax > 0 && c.MemoryMin > c.MemoryMax {
+		return nil, fmt.Errorf("%q can't be higher than %q", "limits.autoscale.memory.min", "limits.autoscale.memory.max")
+	}
+
+	return c, nil
+}
+
+// CPUAllowance returns the current CPU allowance in percent of a single CPU from a `limits.cpu.allowance` value.
+// Instances without a hard CPU limit are considered to be allowed all their CPUs.
+func CPUAllowance(value string, cpus int) int {
+	fields := strings.SplitN(value, "/", 2)
+	if len(fields) != 2 {
+		return cpus * 100
+	}
+
+	quota, err := strconv.Atoi(strings.TrimSuffix(fields[0], "ms"))
+	if err != nil {
+		return cpus * 100
+	}
+
+	period, err := strconv.Atoi(strings.TrimSuffix(fields[1], "ms"))
+	if err != nil || period <= 0 {
+		return cpus * 100
+	}
+
+	return quota * 100 / period
+}
+
+// FormatCPUAllowance returns the `limits.cpu.allowance` value for an allowance in percent of a single CPU.
+func FormatCPUAllowance(percent int) string {
+	return fmt.Sprintf("%dms/100ms", percent)
+}
+
+// FormatMemoryLimit returns the `limits.memory` value for a limit in bytes.
+func FormatMemoryLimit(limit int64) string {
+	return fmt.Sprintf("%dMiB", limit/(1024*1024))
+}
+
+// CPUTarget returns the new CPU allowance (in percent of a single CPU) of an instance given its current
+// allowance and its average CPU usage (in percent of a single CPU) since the previous sample.
+// It returns the current allowance if no change is needed.
+func CPUTarget(c *Config, cpus int, current int, usage float64) int {
+	maxAllowance := cpus * 100
+	if c.CPUMax > 0 {
+		maxAllowance = min(maxAllowance, c.CPUMax)
+	}
+
+	minAllowance := min(c.CPUMin, maxAllowance)
+
+	target := current
+	if usage > float64(current)*cpuHighRatio {
+		target = int(math.Ceil(float64(current)*growRatio/cpuStep)) * cpuStep
+	} else if usage < float64(current)*cpuLowRatio {
+		target = int(math.Floor(float64(current)*shrinkRatio/cpuStep)) * cpuStep
+	}
+
+	target = max(minAllowance, min(maxAllowance, target))
+
+	if max(target-current, current-target) < cpuStep {
+		return current
+	}
+
+	return target
+}
+
+// MemoryTarget returns the new memory limit of an instance given its current limit and memory usage.
+// It returns the current limit if no change is needed.
+func MemoryTarget(c *Config, hostMemory int64, sample Sample) int64 {
+	maxLimit := hostMemory
+	if c.MemoryMax > 0 {
+		maxLimit = min(maxLimit, c.MemoryMax)
+	}
+
+	minLimit := min(c.MemoryMin, maxLimit)
+
+	current := sample.MemoryLimit
+	target := current
+
+	if sample.MemoryPressure > memoryHighPressure || float64(sample.MemoryUsage) > float64(current)*memoryHighRatio {
+		target = int64(float64(current) * growRatio)
+	} else if sample.MemoryPressure < memoryLowPressure && float64(sample.MemoryUsage) < float64(current)*memoryLowRatio {
+		// Shrink the limit while keeping some headroom above the current usage.
+		target = max(int64(float64(current)*shrinkRatio), int64(float64(sample.MemoryUsage)/(1-memoryHeadroomRatio)))
+	}
+
+	target = target / memoryStep * memoryStep
+	target = max(minLimit, min(maxLimit, target))
+
+	if max(target-current, current-target) < memoryStep {
+		return current
+	}
+
+	return target
+}
@@ -0,0 +1,96 @@
+package autoscale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const mib = 1024 * 1024
+
+func TestParseConfig(t *testing.T) {
+	c, err := ParseConfig(map[string]string{"limits.autoscale.cpu.max": "200%"})
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	c, err = ParseConfig(map[string]string{
+		"limits.autoscale":            "true",
+		"limits.autoscale.cpu.max":    "200%",
+		"limits.autoscale.memory.max": "4GiB",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Config{CPUMin: DefaultCPUMin, CPUMax: 200, MemoryMin: DefaultMemoryMin, MemoryMax: 4096 * mib}, c)
+
+	_, err = ParseConfig(map[string]string{
+		"limits.autoscale":         "true",
+		"limits.autoscale.cpu.min": "300%",
+		"limits.autoscale.cpu.max": "200%",
+	})
+	require.Error(t, err)
+}
+
+func TestCPUAllowance(t *testing.T) {
+	require.Equal(t, 400, CPUAllowance("", 4))
+	require.Equal(t, 400, CPUAllowance("50%", 4))
+	require.Equal(t, 50, CPUAllowance("25ms/50ms", 4))
+	require.Equal(t, 150, CPUAllowance(FormatCPUAllowance(150), 4))
+}
+
+func TestCPUTarget(t *testing.T) {
+	c := &Config{CPUMin: 20, CPUMax: 300}
+
+	// Busy instances are given more CPU time, up to the maximum.
+	require.Equal(t, 150, CPUTarget(c, 4, 100, 95))
+	require.Equal(t, 300, CPUTarget(c, 4, 250, 240))
+
+	// The maximum is capped by the available CPUs.
+	require.Equal(t, 200, CPUTarget(c, 2, 150, 140))
+
+	// Idle instances have their CPU time reduced, down to the minimum.
+	require.Equal(t, 75, CPUTarget(c, 4, 100, 10))
+	require.Equal(t, 20, CPUTarget(c, 4, 25, 1))
+
+	// Nothing changes within the target usage.
+	require.Equal(t, 100, CPUTarget(c, 4, 100, 50))
+}
+
+func TestMemoryTarget(t *testing.T) {
+	c := &Config{MemoryMin: 256 * mib, MemoryMax: 2048 * mib}
+	hostMemory := int64(8192 * mib)
+
+	// Instances under memory pressure are given more memory.
+	require.Equal(t, int64(1536*mib), MemoryTarget(c, hostMemory, Sample{MemoryLimit: 1024 * mib, MemoryUsage: 512 * mib, MemoryPressure: 20}))
+	require.Equal(t, int64(1536*mib), MemoryTarget(c, hostMemory, Sample{MemoryLimit: 1024 * mib, MemoryUsage: 1000 * mib}))
+	require.Equal(t, int64(2048*mib), MemoryTarget(c, hostMemory, Sample{MemoryLimit: 1792 * mib, MemoryUsage: 1700 * mib}))
+
+	// Mostly idle memory is reclaimed while keeping some headroom.
+	require.Equal(t, int64(768*mib), MemoryTarget(c, hostMemory, Sample{MemoryLimit: 1024 * mib, MemoryUsage: 100 * mib}))
+	require.Equal(t, int64(1536*mib), MemoryTarget(c, hostMemory, Sample{MemoryLimit: 2048 * mib, MemoryUsage: 1000 * mib}))
+
+	// The limit isn't shrunk while the instance is under pressure.
+	require.Equal(t, int64(1024*mib), MemoryTarget(c, hostMemory, Sample{MemoryLimit: 1024 * mib, MemoryUsage: 100 * mib, MemoryPressure: 5}))
+}
+
+func TestRecord(t *testing.T) {
+	const instID = 1
+	defer Forget(instID)
+
+	now := time.Now()
+
+	_, ok := Record(instID, Sample{Time: now, CPUUsage: time.Second})
+	require.False(t, ok)
+
+	usage, ok := Record(instID, Sample{Time: now.Add(10 * time.Second), CPUUsage: 6 * time.Second})
+	require.True(t, ok)
+	require.InDelta(t, 50, usage, 0.01)
+
+	require.True(t, Due(instID, ResourceCPU, now))
+	Changed(instID, ResourceCPU, now)
+	require.False(t, Due(instID, ResourceCPU, now.Add(time.Minute)))
+	require.True(t, Due(instID, ResourceMemory, now.Add(time.Minute)))
+	require.True(t, Due(instID, ResourceCPU, now.Add(Cooldown)))
+
+	Prune(map[int]bool{})
+	require.False(t, Due(instID, ResourceCPU, now.Add(Cooldown)))
+}
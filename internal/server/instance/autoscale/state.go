@@ -0,0 +1,93 @@
+package autoscale
+
+import (
+	"sync"
+	"time"
+)
+
+// Resources scaled by the autoscaler.
+const (
+	ResourceCPU    = "cpu"
+	ResourceMemory = "memory"
+)
+
+// instanceState is the in-memory autoscaling state of an instance.
+type instanceState struct {
+	previous Sample
+	changed  map[string]time.Time
+}
+
+var (
+	statesMu sync.Mutex
+	states   = map[int]*instanceState{}
+)
+
+// Record records a new sample for an instance and returns its average CPU usage
+// (in percent of a single CPU) since the previous sample.
+// It returns false if there is no previous sample to compare to.
+func Record(instID int, sample Sample) (float64, bool) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	st, ok := states[instID]
+	if !ok {
+		states[instID] = &instanceState{previous: sample, changed: map[string]time.Time{}}
+		return 0, false
+	}
+
+	previous := st.previous
+	st.previous = sample
+
+	elapsed := sample.Time.Sub(previous.Time)
+	if elapsed <= 0 || sample.CPUUsage < previous.CPUUsage {
+		return 0, false
+	}
+
+	return float64(sample.CPUUsage-previous.CPUUsage) / float64(elapsed) * 100, true
+}
+
+// Due returns whether a resource of the instance may be changed at now.
+func Due(instID int, resource string, now time.Time) bool {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	st, ok := states[instID]
+	if !ok {
+		return false
+	}
+
+	return now.Sub(st.changed[resource]) >= Cooldown
+}
+
+// Changed records that a resource of the instance was changed at now.
+func Changed(instID int, resource string, now time.Time) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	st, ok := states[instID]
+	if !ok {
+		return
+	}
+
+	st.changed[resource] = now
+}
+
+// Forget drops the autoscaling state of an instance.
+func Forget(instID int) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	delete(states, instID)
+}
+
+// Prune drops the autoscaling state of all instances which aren't in keep.
+func Prune(keep map[int]bool) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	for instID := range states {
+		if !keep[instID] {
+			delete(states, instID)
+		}
+	}
+}
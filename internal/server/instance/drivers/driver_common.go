@@ -28,6 +28,7 @@ import (
 	deviceConfig "github.com/lxc/incus/v7/internal/server/device/config"
 	"github.com/lxc/incus/v7/internal/server/device/nictype"
 	"github.com/lxc/incus/v7/internal/server/instance"
+	"github.com/lxc/incus/v7/internal/server/instance/healthcheck"
 	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v7/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v7/internal/server/lifecycle"
@@ -211,6 +212,15 @@ func (d *common) shouldAutoRestart() bool {
 	return false
 }
 
+// healthState returns the health check state of the instance, or nil if no health check is configured.
+func (d *common) healthState() *api.InstanceStateHealth {
+	if d.expandedConfig["healthcheck.type"] == "" {
+		return nil
+	}
+
+	return healthcheck.Get(d.id)
+}
+
 // ID gets instances's ID.
 func (d *common) ID() int {
 	return d.id
@@ -1108,6 +1118,55 @@ func (d *common) setCoreSched(pids []int) error {
 	return err
 }
 
+// validateDependencies checks that the instances listed in boot.depends_on exist in the same
+// project and that none of them (directly or indirectly) depends on this instance.
+func (d *common) validateDependencies() error {
+	visited := map[string]bool{}
+
+	var walk func(name string, path []string) error
+	walk = func(name string, path []string) error {
+		path = append(path, name)
+
+		if name == d.name {
+			return fmt.Errorf("Dependency cycle detected: %s", strings.Join(path, " -> "))
+		}
+
+		if visited[name] {
+			return nil
+		}
+
+		visited[name] = true
+
+		inst, err := instance.LoadByProjectAndName(d.state, d.project.Name, name)
+		if err != nil {
+			if len(path) == 2 && api.StatusErrorCheck(err, http.StatusNotFound) {
+				return fmt.Errorf("Dependency %q not found in project %q", name, d.project.Name)
+			}
+
+			// Stale dependencies of other instances are ignored.
+			return nil
+		}
+
+		for _, dep := range util.SplitNTrimSpace(inst.ExpandedConfig()["boot.depends_on"], ",", -1, true) {
+			err := walk(dep, path)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, dep := range util.SplitNTrimSpace(d.expandedConfig["boot.depends_on"], ",", -1, true) {
+		err := walk(dep, []string{d.name})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // getRootDiskDevice gets the name and configuration of the root disk device of an instance.
 func (d *common) getRootDiskDevice() (string, map[string]string, error) {
 	devices := d.ExpandedDevices()
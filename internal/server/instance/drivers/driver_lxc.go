@@ -4079,6 +4079,7 @@ func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interf
 		status.Network = d.networkState(hostInterfaces)
 		status.Pid = int64(pid)
 		status.Processes = processesState
+		status.Health = d.healthState()
 
 		status.StartedAt, err = d.processStartedAt(d.InitPID())
 		if err != nil {
@@ -5082,6 +5083,14 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 			return fmt.Errorf("Invalid expanded config: %w", err)
 		}
 
+		// Validate the instance dependencies.
+		if d.expandedConfig["boot.depends_on"] != oldExpandedConfig["boot.depends_on"] {
+			err = d.validateDependencies()
+			if err != nil {
+				return err
+			}
+		}
+
 		// Do full expanded validation of the devices diff.
 		err = instance.ValidDevices(d.state, d.project, d.Type(), d.localDevices, d.expandedDevices)
 		if err != nil {
@@ -5985,6 +5994,58 @@ func (d *lxc) migrationSendCheckForPreDumpSupport() (bool, int) {
 	return usePreDumps, maxIterations
 }
 
+// LiveMigrationCheck checks whether the container can be live-migrated.
+// All the problems found are reported at once, before the container gets frozen.
+func (d *lxc) LiveMigrationCheck() error {
+	_, err := exec.LookPath("criu")
+	if err != nil {
+		return localMigration.ErrNoLiveMigrationSource
+	}
+
+	// Nested containers may not be restorable, depending on what runs inside of them.
+	if util.IsTrue(d.expandedConfig["security.nesting"]) {
+		d.logger.Warn("Live migrating a container with nesting enabled, this may fail depending on its workload")
+	}
+
+	problems := []string{}
+
+	for _, dev := range d.expandedDevices.Sorted() {
+		switch dev.Config["type"] {
+		case "gpu", "infiniband", "pci", "tpm", "unix-hotplug", "usb":
+			problems = append(problems, fmt.Sprintf("Device %q of type %q can't be checkpointed", dev.Name, dev.Config["type"]))
+		case "nic":
+			nicType, err := nictype.NICType(d.state, d.Project().Name, dev.Config)
+			if err != nil {
+				return err
+			}
+
+			if slices.Contains([]string{"physical", "sriov"}, nicType) {
+				problems = append(problems, fmt.Sprintf("Device %q of type %q can't be checkpointed", dev.Name, nicType))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Unable to perform live container migration:\n - %s", strings.Join(problems, "\n - "))
+	}
+
+	return nil
+}
+
+// migrationProgress reports the progress of a live migration on the instance operation.
+func (d *lxc) migrationProgress(progress map[string]string, description string) {
+	if d.op == nil {
+		return
+	}
+
+	progress["stage"] = "live_migrate_instance"
+
+	metadata := map[string]any{}
+	metadata["progress"] = progress
+	metadata["live_migrate_instance_progress"] = fmt.Sprintf("Live migration: %s", description)
+	_ = d.op.UpdateMetadata(metadata)
+}
+
 func (d *lxc) migrationSendWriteActionScript(directory string, operation string, secret string, execPath string) error {
 	script := fmt.Sprintf(`#!/bin/sh -e
 if [ "$CRTOOLS_SCRIPT_ACTION" = "post-dump" ]; then
@@ -6377,7 +6438,6 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 					return err
 				}
 
-				preDumpCounter := 0
 				preDumpDir := ""
 
 				// Check if the other side knows about pre-dumping and the associated
@@ -6385,22 +6445,17 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 				if respHeader.GetPredump() {
 					d.logger.Debug("The other side does support pre-copy")
 					final := false
-					for !final {
-						preDumpCounter++
-						if preDumpCounter < maxDumpIterations {
-							final = false
-						} else {
-							final = true
-						}
-
-						dumpDir := fmt.Sprintf("%03d", preDumpCounter)
+					for iteration := 1; !final; iteration++ {
+						dumpDir := fmt.Sprintf("%03d", iteration)
 						loopArgs := preDumpLoopArgs{
 							stateConn:     stateConn,
 							checkpointDir: checkpointDir,
 							bwlimit:       rsyncBwlimit,
 							preDumpDir:    preDumpDir,
 							dumpDir:       dumpDir,
-							final:         final,
+							iteration:     iteration,
+							maxIterations: maxDumpIterations,
+							final:         iteration >= maxDumpIterations,
 							rsyncFeatures: rsyncFeatures,
 						}
 
@@ -6410,8 +6465,7 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 							return err
 						}
 
-						preDumpDir = fmt.Sprintf("%03d", preDumpCounter)
-						preDumpCounter++
+						preDumpDir = dumpDir
 					}
 				} else {
 					d.logger.Debug("The other side does not support pre-copy")
@@ -6423,6 +6477,8 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 					return err
 				}
 
+				d.migrationProgress(map[string]string{"phase": "dump"}, "Checkpointing container")
+
 				go func() {
 					d.logger.Debug("Final CRIU dump started")
 					defer d.logger.Debug("Final CRIU dump stopped")
@@ -6474,6 +6530,8 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 			// However assuming we're network bound, there's really no reason to do these in.
 			// parallel. In the future when we're using p.haul's protocol, it will make sense
 			// to do these in parallel.
+			d.migrationProgress(map[string]string{"phase": "transfer"}, "Transferring container state")
+
 			ctName, _, _ := api.GetParentAndSnapshotName(d.Name())
 			err = rsync.Send(ctName, internalUtil.AddSlash(checkpointDir), stateConn, nil, rsyncFeatures, rsyncBwlimit, d.state.OS.ExecPath)
 			if err != nil {
@@ -6538,6 +6596,8 @@ type preDumpLoopArgs struct {
 	bwlimit       string
 	preDumpDir    string
 	dumpDir       string
+	iteration     int
+	maxIterations int
 	final         bool
 	rsyncFeatures []string
 }
@@ -6557,7 +6617,8 @@ func (d *lxc) migrateSendPreDumpLoop(args *preDumpLoopArgs) (bool, error) {
 		Function:     "migration",
 	}
 
-	d.logger.Debug("Doing another CRIU pre-dump", logger.Ctx{"preDumpDir": args.preDumpDir})
+	d.logger.Debug("Doing another CRIU pre-dump", logger.Ctx{"preDumpDir": args.preDumpDir, "iteration": args.iteration})
+	d.migrationProgress(map[string]string{"phase": "pre-dump", "iteration": strconv.Itoa(args.iteration), "iterations": strconv.Itoa(args.maxIterations)}, fmt.Sprintf("Pre-copying memory (iteration %d/%d)", args.iteration, args.maxIterations))
 
 	final := args.final
 
@@ -6616,6 +6677,14 @@ func (d *lxc) migrateSendPreDumpLoop(args *preDumpLoopArgs) (bool, error) {
 		threshold = 70
 	}
 
+	d.migrationProgress(map[string]string{
+		"phase":      "pre-dump",
+		"iteration":  strconv.Itoa(args.iteration),
+		"iterations": strconv.Itoa(args.maxIterations),
+		"percent":    strconv.Itoa(percentageSkipped),
+		"goal":       strconv.Itoa(threshold),
+	}, fmt.Sprintf("Pre-copied memory (iteration %d/%d, %d%% unchanged, goal %d%%)", args.iteration, args.maxIterations, percentageSkipped, threshold))
+
 	if percentageSkipped > threshold {
 		d.logger.Debug("Memory pages skipped due to pre-copy is larger than threshold", logger.Ctx{"skippedPerc": percentageSkipped, "thresholdPerc": threshold})
 		d.logger.Debug("This was the last pre-dump; next dump is the final dump")
@@ -7096,8 +7165,9 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 			}
 
 			if respHeader.GetPredump() {
-				for !sync.GetFinalPreDump() {
+				for iteration := 1; !sync.GetFinalPreDump(); iteration++ {
 					d.logger.Debug("Waiting to receive pre-dump rsync")
+					d.migrationProgress(map[string]string{"phase": "pre-dump", "iteration": strconv.Itoa(iteration)}, fmt.Sprintf("Receiving pre-copied memory (iteration %d)", iteration))
 
 					// Transfer a CRIU pre-dump.
 					err = rsync.Recv(internalUtil.AddSlash(imagesDir), stateConn, nil, rsyncFeatures)
@@ -7130,6 +7200,7 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 
 			// Final CRIU dump.
 			d.logger.Debug("About to receive final dump rsync")
+			d.migrationProgress(map[string]string{"phase": "transfer"}, "Receiving container state")
 			err = rsync.Recv(internalUtil.AddSlash(imagesDir), stateConn, nil, rsyncFeatures)
 			if err != nil {
 				return fmt.Errorf("Failed receiving final dump rsync: %w", err)
@@ -7156,8 +7227,9 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 				PreDumpDir:   "",
 			}
 
-			// Currently we only do a single CRIU pre-dump so we can hardcode "final"
-			// here since we know that "final" is the folder for CRIU's final dump.
+			// The final dump is always in the "final" folder and references the pre-dumps itself.
+			d.migrationProgress(map[string]string{"phase": "restore"}, "Restoring container")
+
 			err = d.migrate(&criuMigrationArgs)
 			if err != nil {
 				return err
@@ -0,0 +1,46 @@
+package drivers
+
+import (
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/instance/autoscale"
+)
+
+// AutoscaleSample returns the current resource usage of the container for the autoscaler.
+func (d *lxc) AutoscaleSample() (*autoscale.Sample, error) {
+	cg, err := d.cgroup(nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &autoscale.Sample{Time: time.Now()}
+
+	cpuUsage, err := cg.GetCPUAcctUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	sample.CPUUsage = time.Duration(cpuUsage)
+
+	sample.CPUs, err = cg.GetEffectiveCPUs()
+	if err != nil {
+		return nil, err
+	}
+
+	sample.MemoryUsage, err = cg.GetMemoryUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	sample.MemoryLimit, err = cg.GetEffectiveMemoryLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	sample.MemoryPressure, err = cg.GetMemoryPressure()
+	if err != nil {
+		return nil, err
+	}
+
+	return sample, nil
+}
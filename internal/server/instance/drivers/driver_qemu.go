@@ -479,7 +479,7 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 	s := d.state
 
 	return func(event string, data map[string]any) {
-		if !slices.Contains([]string{qmp.EventVMShutdown, qmp.EventVMReset, qmp.EventAgentStarted, qmp.EventAgentStopped, qmp.EventRTCChange}, event) {
+		if !slices.Contains([]string{qmp.EventVMShutdown, qmp.EventVMReset, qmp.EventAgentStarted, qmp.EventAgentStopped, qmp.EventRTCChange, qmp.EventWatchdog}, event) {
 			return // Don't bother loading the instance from DB if we aren't going to handle the event.
 		}
 
@@ -592,6 +592,17 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 			if err != nil {
 				d.logger.Error("Failed to apply rtc change", logger.Ctx{"offset": val, "err": err})
 			}
+
+		case qmp.EventWatchdog:
+			action, _ := data["action"].(string)
+			d.logger.Warn("Instance watchdog fired", logger.Ctx{"action": action})
+
+			err = d.onWatchdog()
+			if err != nil {
+				d.logger.Error("Failed recording watchdog event", logger.Ctx{"err": err})
+			}
+
+			s.Events.SendLifecycle(instProject.Name, lifecycle.InstanceWatchdogFired.Event(d, logger.Ctx{"action": action}))
 		}
 	}
 }
@@ -1286,6 +1297,19 @@ func (d *qemu) validateStartup(stateful bool, statusCode api.StatusCode) error {
 		return errors.New("Secure boot can't be enabled while CSM is turned on. Please set security.secureboot=false on the instance")
 	}
 
+	// Ensure custom secure boot keys are complete and only used with secureboot.
+	if d.hasCustomSecureBootKeys() {
+		if util.IsFalse(d.expandedConfig["security.secureboot"]) || util.IsTrue(d.expandedConfig["security.csm"]) {
+			return errors.New("Custom secure boot keys require secure boot to be enabled. Please unset the security.secureboot.* keys or set security.secureboot=true on the instance")
+		}
+
+		for _, key := range []string{"security.secureboot.pk", "security.secureboot.kek", "security.secureboot.db"} {
+			if d.expandedConfig[key] == "" {
+				return fmt.Errorf("Custom secure boot keys require %q to be set", key)
+			}
+		}
+	}
+
 	// gendoc:generate(entity=image, group=requirements, key=requirements.cdrom_agent)
 	//
 	// ---
@@ -2181,7 +2205,7 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 
 	// Apply CPU pinning.
 	if bs.CPUTopology.VCPUs == nil {
-		if d.architectureSupportsCPUHotplug() && !bs.CPUTopology.Explicit && bs.CPUTopology.Cores > 1 {
+		if d.architectureSupportsCPUHotplug() && !bs.CPUTopology.Explicit && !bs.CPUTopology.NoHotplug && bs.CPUTopology.Cores > 1 {
 			// Hotplug the CPUs.
 			err := d.setCPUs(monitor, bs.CPUTopology.Cores)
 			if err != nil {
@@ -2259,6 +2283,7 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		"shutdown": "poweroff",
 		"reboot":   "reset",
 		"panic":    "exit-failure",
+		"watchdog": d.watchdogAction(),
 	}
 
 	err = monitor.SetAction(actions)
@@ -2267,6 +2292,12 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		return fmt.Errorf("Failed setting reboot action: %w", err)
 	}
 
+	// Have the guest report its memory statistics through the balloon device.
+	err = monitor.SetBalloonStatsPollingInterval(qemuBalloonPath, qemuBalloonStatsInterval)
+	if err != nil {
+		d.logger.Warn("Failed enabling memory balloon statistics", logger.Ctx{"err": err})
+	}
+
 	// Restore the state.
 	if stateful {
 		// Add back any memory hotplug slot.
@@ -2565,6 +2596,51 @@ func (d *qemu) firmwarePairs() ([]edk2.FirmwarePair, error) {
 	}
 }
 
+// hasCustomSecureBootKeys returns whether custom secure boot keys are configured for the instance.
+func (d *qemu) hasCustomSecureBootKeys() bool {
+	for _, key := range []string{"security.secureboot.pk", "security.secureboot.kek", "security.secureboot.db", "security.secureboot.dbx"} {
+		if d.expandedConfig[key] != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// secureBootKeys returns the custom secure boot keys configured for the instance.
+func (d *qemu) secureBootKeys() (*edk2.SecureBootKeys, error) {
+	owner, err := uuid.Parse(d.localConfig["volatile.uuid"])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse instance UUID from volatile.uuid: %w", err)
+	}
+
+	keys := &edk2.SecureBootKeys{Owner: owner}
+
+	keys.PK, _, err = edk2.ParseSecureBootCertificates(d.expandedConfig["security.secureboot.pk"], false)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid security.secureboot.pk: %w", err)
+	}
+
+	keys.KEK, _, err = edk2.ParseSecureBootCertificates(d.expandedConfig["security.secureboot.kek"], false)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid security.secureboot.kek: %w", err)
+	}
+
+	keys.DB, _, err = edk2.ParseSecureBootCertificates(d.expandedConfig["security.secureboot.db"], false)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid security.secureboot.db: %w", err)
+	}
+
+	if d.expandedConfig["security.secureboot.dbx"] != "" {
+		keys.DBX, keys.DBXHashes, err = edk2.ParseSecureBootCertificates(d.expandedConfig["security.secureboot.dbx"], true)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid security.secureboot.dbx: %w", err)
+		}
+	}
+
+	return keys, nil
+}
+
 func (d *qemu) setupNvram() error {
 	var err error
 
@@ -2632,6 +2708,19 @@ func (d *qemu) setupNvram() error {
 		return err
 	}
 
+	// Replace the default secure boot keys with the custom ones.
+	if d.hasCustomSecureBootKeys() && util.IsTrueOrEmpty(d.expandedConfig["security.secureboot"]) && util.IsFalseOrEmpty(d.expandedConfig["security.csm"]) {
+		keys, err := d.secureBootKeys()
+		if err != nil {
+			return err
+		}
+
+		err = edk2.EnrollSecureBootKeys(filepath.Join(d.Path(), efiVarsName), *keys)
+		if err != nil {
+			return err
+		}
+	}
+
 	nvramPath := d.nvramPath()
 
 	// Handle the case where the firmware vars filename matches our internal one.
@@ -3910,6 +3999,13 @@ func (d *qemu) onRTCChange(change int) error {
 	return nil
 }
 
+// onWatchdog increments the number of times the watchdog device fired.
+func (d *qemu) onWatchdog() error {
+	count, _ := strconv.ParseInt(d.localConfig["volatile.vm.watchdog_fired"], 10, 64)
+
+	return d.VolatileSet(map[string]string{"volatile.vm.watchdog_fired": strconv.FormatInt(count+1, 10)})
+}
+
 // gpuNativeContextConfig scans the device run configs for a virtio-gpu DRM native
 // context GPU. It returns whether one is present, its resolved host DRM render node
 // (which may be empty, meaning QEMU should use its default render node), and the
@@ -4070,11 +4166,16 @@ func (d *qemu) generateQemuConfig(bs *qemuBootState, mountInfo *storagePools.Mou
 	// total of 256 devices, but this assumes 32 chassis * 8 function. By using VFs for the internal fixed
 	// devices we avoid consuming a chassis for each one.
 	devBus, devAddr, multi := bus.allocate(busFunctionGroupGeneric)
-	balloonOpts := qemuDevOpts{
-		busName:       bus.name,
-		devBus:        devBus,
-		devAddr:       devAddr,
-		multifunction: multi,
+	balloonMode := d.expandedConfig["limits.memory.balloon"]
+	balloonOpts := qemuBalloonOpts{
+		dev: qemuDevOpts{
+			busName:       bus.name,
+			devBus:        devBus,
+			devAddr:       devAddr,
+			multifunction: multi,
+		},
+		freePageReporting: slices.Contains([]string{"reporting", "auto"}, balloonMode),
+		deflateOnOOM:      balloonMode == "auto",
 	}
 
 	conf = append(conf, qemuBalloon(&balloonOpts)...)
@@ -4432,6 +4533,11 @@ func (d *qemu) generateQemuConfig(bs *qemuBootState, mountInfo *storagePools.Mou
 				return nil, err
 			}
 		}
+
+		// Add watchdog device.
+		if len(runConf.WatchdogDevice) > 0 {
+			d.addWatchdogDeviceConfig(&conf, bus, runConf.WatchdogDevice)
+		}
 	}
 
 	// VM generation ID is only available on x86.
@@ -4491,6 +4597,8 @@ func (d *qemu) getCPUOpts(cpuInfo *qemuCPUTopology, memSizeBytes int64) (*qemuCP
 
 	hostNodes := []uint64{}
 	if cpuInfo.VCPUs == nil {
+		cpuOpts.cpuMax = cpuInfo.MaxVCPUs
+
 		if cpuInfo.Explicit {
 			// An explicit CPU topology was requested, expose it verbatim to the guest.
 			// This is incompatible with CPU hotplugging.
@@ -4498,7 +4606,7 @@ func (d *qemu) getCPUOpts(cpuInfo *qemuCPUTopology, memSizeBytes int64) (*qemuCP
 			cpuOpts.cpuCores = cpuInfo.Cores
 			cpuOpts.cpuThreads = cpuInfo.Threads
 			cpuOpts.cpuCount = cpuInfo.Sockets * cpuInfo.Cores * cpuInfo.Threads
-		} else if d.architectureSupportsCPUHotplug() {
+		} else if d.architectureSupportsCPUHotplug() && !cpuInfo.NoHotplug {
 			// If not pinning, default to exposing cores.
 			// Only one CPU will be added here, as the others will be hotplugged during start.
 			cpuOpts.cpuCount = 1
@@ -5305,7 +5413,7 @@ func (d *qemu) addDriveConfig(qemuDev map[string]any, bootIndexes map[string]int
 		}
 
 		if driveConf.Limits != nil {
-			err = m.SetBlockThrottle(qemuDev["id"].(string), int(driveConf.Limits.ReadBytes), int(driveConf.Limits.WriteBytes), int(driveConf.Limits.ReadIOps), int(driveConf.Limits.WriteIOps))
+			err = m.SetBlockThrottle(qemuDev["id"].(string), driveConf.Limits.Group, int(driveConf.Limits.ReadBytes), int(driveConf.Limits.WriteBytes), int(driveConf.Limits.ReadIOps), int(driveConf.Limits.WriteIOps))
 			if err != nil {
 				return fmt.Errorf("Failed applying limits for disk device %q: %w", driveConf.DevName, err)
 			}
@@ -5906,6 +6014,48 @@ func (d *qemu) addTPMDeviceConfig(conf *[]cfg.Section, tpmConfig []deviceConfig.
 	return nil
 }
 
+// addWatchdogDeviceConfig adds the qemu config required for adding a watchdog device.
+func (d *qemu) addWatchdogDeviceConfig(conf *[]cfg.Section, bus *qemuBus, watchdogConfig []deviceConfig.RunConfigItem) {
+	var devName, model string
+
+	for _, watchdogItem := range watchdogConfig {
+		switch watchdogItem.Key {
+		case "devName":
+			devName = watchdogItem.Value
+		case "model":
+			model = watchdogItem.Value
+		}
+	}
+
+	watchdogOpts := qemuWatchdogOpts{
+		dev: qemuDevOpts{
+			busName: bus.name,
+		},
+		devName: devName,
+		model:   model,
+	}
+
+	if model == "i6300esb" {
+		devBus, devAddr, multi := bus.allocate(busFunctionGroupNone)
+		watchdogOpts.dev.devBus = devBus
+		watchdogOpts.dev.devAddr = devAddr
+		watchdogOpts.dev.multifunction = multi
+	}
+
+	*conf = append(*conf, qemuWatchdog(&watchdogOpts)...)
+}
+
+// watchdogAction returns the action to take when the watchdog device fires.
+func (d *qemu) watchdogAction() string {
+	for _, dev := range d.expandedDevices {
+		if dev["type"] == "watchdog" && dev["action"] != "" {
+			return dev["action"]
+		}
+	}
+
+	return "reset"
+}
+
 func (d *qemu) addVmgenDeviceConfig(conf *[]cfg.Section, guid string) error {
 	vmgenIDOpts := qemuVmgenIDOpts{
 		guid: guid,
@@ -6832,6 +6982,14 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			return fmt.Errorf("Invalid expanded config: %w", err)
 		}
 
+		// Validate the instance dependencies.
+		if d.expandedConfig["boot.depends_on"] != oldExpandedConfig["boot.depends_on"] {
+			err = d.validateDependencies()
+			if err != nil {
+				return err
+			}
+		}
+
 		// Do full expanded validation of the devices diff.
 		err = instance.ValidDevices(d.state, d.project, d.Type(), d.localDevices, d.expandedDevices)
 		if err != nil {
@@ -6882,12 +7040,17 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			"security.protection.delete",
 			"security.guestapi",
 			"security.secureboot",
+			"security.secureboot.db",
+			"security.secureboot.dbx",
+			"security.secureboot.kek",
+			"security.secureboot.pk",
 		}
 
 		liveUpdateKeyPrefixes := []string{
 			"boot.",
 			"cloud-init.",
 			"environment.",
+			"healthcheck.",
 			"image.",
 			"snapshots.",
 			"user.",
@@ -6902,7 +7065,7 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			}
 
 			if key == "limits.cpu" {
-				return d.architectureSupportsCPUHotplug()
+				return d.architectureSupportsCPUHotplug() && !util.IsFalse(d.expandedConfig["limits.cpu.hotplug"])
 			}
 
 			if slices.Contains(liveUpdateKeys, key) {
@@ -6966,6 +7129,29 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 					return fmt.Errorf("Failed updating cpu limit: %w", err)
 				}
 
+				// If migratable, update state information following hotplug.
+				if d.CanLiveMigrate() {
+					bs, err := d.getBootState()
+					if err != nil {
+						return err
+					}
+
+					if bs.CPUTopology != nil {
+						bs.CPUTopology.Cores = limit
+
+						err = d.saveBootState(*bs)
+						if err != nil {
+							return err
+						}
+					}
+				}
+
+				// Have the agent online the new CPUs.
+				err = d.devIncusEventSend("cpu", map[string]any{"action": "updated", "count": limit})
+				if err != nil {
+					d.logger.Warn("Failed notifying the agent of the CPU change", logger.Ctx{"err": err})
+				}
+
 			case "limits.memory":
 				err = d.updateMemoryLimit(value)
 				if err != nil {
@@ -6976,7 +7162,7 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			case "security.csm":
 				// Defer rebuilding nvram until next start.
 				d.localConfig["volatile.apply_nvram"] = "true"
-			case "security.secureboot":
+			case "security.secureboot", "security.secureboot.pk", "security.secureboot.kek", "security.secureboot.db", "security.secureboot.dbx":
 				// Defer rebuilding nvram until next start.
 				d.localConfig["volatile.apply_nvram"] = "true"
 			case "security.guestapi":
@@ -7002,7 +7188,11 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 	// Clear the "volatile.cpu.nodes" if needed.
 	d.ClearLimitsCPUNodes(changedConfig)
 
-	if d.architectureSupportsUEFI(d.architecture) && (slices.Contains(changedConfig, "security.secureboot") || slices.Contains(changedConfig, "security.csm")) {
+	nvramChanged := slices.ContainsFunc(changedConfig, func(key string) bool {
+		return key == "security.csm" || key == "security.secureboot" || strings.HasPrefix(key, "security.secureboot.")
+	})
+
+	if d.architectureSupportsUEFI(d.architecture) && nvramChanged {
 		// setupNvram() requires instance's config volume to be mounted.
 		// The easiest way to detect that is to check if instance is running.
 		// TODO: extend storage API to be able to check if volume is already mounted?
@@ -9876,6 +10066,7 @@ func (d *qemu) renderState(statusCode api.StatusCode) (*api.InstanceState, error
 	// Populate the process information.
 	pid, _ := d.pid()
 	status.Pid = int64(pid)
+	status.Health = d.healthState()
 	status.StartedAt, err = d.processStartedAt(d.InitPID())
 	if err != nil {
 		return status, err
@@ -10033,7 +10224,7 @@ func (d *qemu) DeviceEventHandler(runConf *deviceConfig.RunConfig) error {
 
 		if mount.Limits != nil {
 			// Apply the limits.
-			err = m.SetBlockThrottle(devID, int(mount.Limits.ReadBytes), int(mount.Limits.WriteBytes), int(mount.Limits.ReadIOps), int(mount.Limits.WriteIOps))
+			err = m.SetBlockThrottle(devID, mount.Limits.Group, int(mount.Limits.ReadBytes), int(mount.Limits.WriteBytes), int(mount.Limits.ReadIOps), int(mount.Limits.WriteIOps))
 			if err != nil {
 				return fmt.Errorf("Failed applying limits for disk device %q: %w", mount.DevName, err)
 			}
@@ -10797,6 +10988,16 @@ func (d *qemu) getAgentMetrics() (*metrics.MetricSet, error) {
 		return nil, err
 	}
 
+	monitor, err := d.qmpConnect()
+	if err == nil {
+		err = d.addQemuBalloonMetrics(monitor, metricSet)
+		if err != nil {
+			d.logger.Warn("Failed to get memory balloon metrics", logger.Ctx{"err": err})
+		}
+	}
+
+	d.addWatchdogMetrics(metricSet)
+
 	return metricSet, nil
 }
 
@@ -10927,6 +11128,23 @@ func (d *qemu) setCPUs(monitor *qmp.Monitor, count int) error {
 		}
 	}
 
+	// Always add the lowest and remove the highest CPUs so the set of hotplugged CPUs only depends on their count.
+	// This allows re-creating the same topology on the target of a live migration.
+	cpuLess := func(a qmp.HotpluggableCPU, b qmp.HotpluggableCPU) bool {
+		if a.Props.SocketID != b.Props.SocketID {
+			return a.Props.SocketID < b.Props.SocketID
+		}
+
+		if a.Props.CoreID != b.Props.CoreID {
+			return a.Props.CoreID < b.Props.CoreID
+		}
+
+		return a.Props.ThreadID < b.Props.ThreadID
+	}
+
+	sort.Slice(availableCPUs, func(i, j int) bool { return cpuLess(availableCPUs[i], availableCPUs[j]) })
+	sort.Slice(hotpluggedCPUs, func(i, j int) bool { return cpuLess(hotpluggedCPUs[j], hotpluggedCPUs[i]) })
+
 	// The reserved CPUs includes both the hotplugged CPUs as well as the fixed one.
 	totalReservedCPUs := len(hotpluggedCPUs) + 1
 
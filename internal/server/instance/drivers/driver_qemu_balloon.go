@@ -0,0 +1,120 @@
+package drivers
+
+import (
+	"github.com/lxc/incus/v7/internal/server/instance/drivers/qemudefault"
+	"github.com/lxc/incus/v7/internal/server/instance/drivers/qmp"
+	"github.com/lxc/incus/v7/shared/logger"
+	"github.com/lxc/incus/v7/shared/units"
+	"github.com/lxc/incus/v7/shared/util"
+)
+
+// qemuBalloonPath is the QOM path of the memory balloon device.
+const qemuBalloonPath = "/machine/peripheral/qemu_balloon"
+
+// qemuBalloonStatsInterval is how often (in seconds) the guest reports its memory statistics.
+const qemuBalloonStatsInterval = 5
+
+const (
+	// qemuBalloonMinRatio is the lowest fraction of its memory limit a VM may be shrunk to.
+	qemuBalloonMinRatio = 0.25
+
+	// qemuBalloonHeadroomRatio is the fraction of its current memory a guest is left with as available memory when shrinking it.
+	qemuBalloonHeadroomRatio = 0.2
+
+	// qemuBalloonLowRatio is the fraction of available memory below which memory is returned to the guest.
+	qemuBalloonLowRatio = 0.1
+
+	// qemuBalloonGrowRatio is the fraction of its memory limit returned to the guest at once.
+	qemuBalloonGrowRatio = 0.25
+
+	// qemuBalloonMinStep is the smallest change to the memory balloon worth applying.
+	qemuBalloonMinStep = 64 * 1024 * 1024
+)
+
+// memoryLimitBytes returns the configured memory size of the VM.
+func (d *qemu) memoryLimitBytes() (int64, error) {
+	memLimit := d.expandedConfig["limits.memory"]
+	if memLimit == "" {
+		memLimit = qemudefault.MemSize // Default if no memory limit specified.
+	}
+
+	return ParseMemoryStr(memLimit)
+}
+
+// balloonTarget computes the new memory size of a guest based on its current size and available memory.
+// It returns the current size if no change is needed.
+func balloonTarget(limit int64, current int64, available int64, hostPressure bool) int64 {
+	target := current
+
+	if float64(available) < float64(current)*qemuBalloonLowRatio {
+		// The guest is running low on memory, give some back.
+		target = min(limit, current+int64(float64(limit)*qemuBalloonGrowRatio))
+	} else if hostPressure {
+		// Reclaim the guest memory which is available beyond the headroom.
+		target = max(int64(float64(limit)*qemuBalloonMinRatio), current-(available-int64(float64(current)*qemuBalloonHeadroomRatio)))
+		target = min(target, current)
+	}
+
+	if target != limit && max(target-current, current-target) < qemuBalloonMinStep {
+		return current
+	}
+
+	return target
+}
+
+// BalloonAdjust resizes the memory balloon of a VM using automatic ballooning.
+// Idle guest memory is reclaimed when the host is under memory pressure and returned when the guest needs it.
+func (d *qemu) BalloonAdjust(hostPressure bool) error {
+	if d.expandedConfig["limits.memory.balloon"] != "auto" || util.IsTrue(d.expandedConfig["limits.memory.hugepages"]) {
+		return nil
+	}
+
+	if !d.IsRunning() {
+		return nil
+	}
+
+	limit, err := d.memoryLimitBytes()
+	if err != nil {
+		return err
+	}
+
+	// Connect to the monitor.
+	monitor, err := d.qmpConnect()
+	if err != nil {
+		return err
+	}
+
+	current, stats, err := d.getQemuBalloonStats(monitor)
+	if err != nil {
+		return err
+	}
+
+	// Skip guests which don't report their memory usage.
+	if stats.LastUpdate == 0 || stats.Stats.AvailableMemory < 0 {
+		return nil
+	}
+
+	target := balloonTarget(limit, current, stats.Stats.AvailableMemory, hostPressure)
+	if target == current {
+		return nil
+	}
+
+	d.logger.Debug("Resizing memory balloon", logger.Ctx{"current": units.GetByteSizeStringIEC(current, 2), "target": units.GetByteSizeStringIEC(target, 2), "hostPressure": hostPressure})
+
+	return monitor.SetMemoryBalloonSizeBytes(target)
+}
+
+// getQemuBalloonStats returns the current memory size of the guest along with its balloon statistics.
+func (d *qemu) getQemuBalloonStats(monitor *qmp.Monitor) (int64, *qmp.BalloonStats, error) {
+	current, err := monitor.GetMemoryBalloonSizeBytes()
+	if err != nil {
+		return -1, nil, err
+	}
+
+	stats, err := monitor.GetBalloonStats(qemuBalloonPath)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return current, stats, nil
+}
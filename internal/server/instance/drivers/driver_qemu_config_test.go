@@ -256,10 +256,10 @@ func TestQemuConfigTemplates(t *testing.T) {
 
 	t.Run("qemu_balloon", func(t *testing.T) {
 		testCases := []struct {
-			opts     qemuDevOpts
+			opts     qemuBalloonOpts
 			expected string
 		}{{
-			qemuDevOpts{"pcie", "qemu_pcie0", "00.0", true},
+			qemuBalloonOpts{dev: qemuDevOpts{"pcie", "qemu_pcie0", "00.0", true}},
 			`# Balloon driver
 			[device "qemu_balloon"]
 			addr = "00.0"
@@ -268,11 +268,22 @@ func TestQemuConfigTemplates(t *testing.T) {
 			multifunction = "on"
 			`,
 		}, {
-			qemuDevOpts{"ccw", "qemu_pcie0", "00.0", false},
+			qemuBalloonOpts{dev: qemuDevOpts{"ccw", "qemu_pcie0", "00.0", false}},
 			`# Balloon driver
 			[device "qemu_balloon"]
 			driver = "virtio-balloon-ccw"
 			`,
+		}, {
+			qemuBalloonOpts{dev: qemuDevOpts{"pcie", "qemu_pcie0", "00.0", true}, freePageReporting: true, deflateOnOOM: true},
+			`# Balloon driver
+			[device "qemu_balloon"]
+			addr = "00.0"
+			bus = "qemu_pcie0"
+			deflate-on-oom = "on"
+			driver = "virtio-balloon-pci"
+			free-page-reporting = "on"
+			multifunction = "on"
+			`,
 		}}
 		for _, tc := range testCases {
 			runTest(tc.expected, qemuBalloon(&tc.opts))
@@ -1171,6 +1182,42 @@ func TestQemuConfigTemplates(t *testing.T) {
 		}
 	})
 
+	t.Run("qemu_watchdog", func(t *testing.T) {
+		testCases := []struct {
+			opts     qemuWatchdogOpts
+			expected string
+		}{{
+			qemuWatchdogOpts{
+				dev: qemuDevOpts{
+					busName: "pcie",
+					devBus:  "qemu_pcie4",
+					devAddr: "00.0",
+				},
+				devName: "myWatchdog",
+				model:   "i6300esb",
+			},
+			`# Watchdog ("myWatchdog" device)
+			[device "dev-incus_myWatchdog"]
+			addr = "00.0"
+			bus = "qemu_pcie4"
+			driver = "i6300esb"`,
+		}, {
+			qemuWatchdogOpts{
+				dev: qemuDevOpts{
+					busName: "pcie",
+				},
+				devName: "myWatchdog",
+				model:   "ib700",
+			},
+			`# Watchdog ("myWatchdog" device)
+			[device "dev-incus_myWatchdog"]
+			driver = "ib700"`,
+		}}
+		for _, tc := range testCases {
+			runTest(tc.expected, qemuWatchdog(&tc.opts))
+		}
+	})
+
 	t.Run("qemu_raw_cfg_override", func(t *testing.T) {
 		conf := []cfg.Section{{
 			Name: "global",
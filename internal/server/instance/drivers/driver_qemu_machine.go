@@ -32,6 +32,12 @@ type qemuCPUTopology struct {
 
 	VCPUs map[uint64]uint64   `json:"vcpus,omitempty"`
 	Nodes map[uint64][]uint64 `json:"nodes,omitempty"`
+
+	// MaxVCPUs is the maximum number of vCPUs which can be hotplugged (0 for the default).
+	MaxVCPUs int `json:"max_vcpus,omitempty"`
+
+	// NoHotplug indicates that all vCPUs are present at boot and can't be hotplugged.
+	NoHotplug bool `json:"no_hotplug,omitempty"`
 }
 
 // cpuTopology sets up the qemuCPUTopology struct based on configured CPU limits, host system and guest OS.
@@ -68,6 +74,33 @@ func (d *qemu) cpuTopology() (*qemuCPUTopology, error) {
 		topology.Cores = nrLimit
 		topology.Threads = 1
 
+		// Apply the hotplug limit.
+		limitsCPUHotplug := d.expandedConfig["limits.cpu.hotplug"]
+		if util.IsFalse(limitsCPUHotplug) {
+			topology.NoHotplug = true
+		} else if !util.IsTrueOrEmpty(limitsCPUHotplug) {
+			topology.MaxVCPUs, err = strconv.Atoi(limitsCPUHotplug)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid limits.cpu.hotplug value %q: %w", limitsCPUHotplug, err)
+			}
+
+			if topology.MaxVCPUs < nrLimit {
+				return nil, errors.New("'limits.cpu.hotplug' value should be greater than or equal to 'limits.cpu'")
+			}
+		}
+
+		if topology.NoHotplug {
+			topology.MaxVCPUs = nrLimit
+		} else if topology.MaxVCPUs == 0 {
+			// Record the default limit so it doesn't change when moving to a different host.
+			cpus, err := resources.GetCPU()
+			if err != nil {
+				return nil, err
+			}
+
+			topology.MaxVCPUs = qemuDefaultMaxVCPUs(int(cpus.Total), nrLimit)
+		}
+
 		return topology, nil
 	}
 
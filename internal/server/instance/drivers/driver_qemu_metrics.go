@@ -74,9 +74,56 @@ func (d *qemu) getQemuMetrics() (*metrics.MetricSet, error) {
 		return nil, err
 	}
 
+	err = d.addQemuBalloonMetrics(monitor, metricSet)
+	if err != nil {
+		d.logger.Warn("Failed to get memory balloon metrics", logger.Ctx{"err": err})
+	}
+
+	d.addWatchdogMetrics(metricSet)
+
 	return metricSet, nil
 }
 
+// addWatchdogMetrics adds the watchdog metrics to the metric set if the VM has a watchdog device.
+func (d *qemu) addWatchdogMetrics(metricSet *metrics.MetricSet) {
+	for _, dev := range d.expandedDevices {
+		if dev["type"] != "watchdog" {
+			continue
+		}
+
+		count, _ := strconv.ParseInt(d.localConfig["volatile.vm.watchdog_fired"], 10, 64)
+		metricSet.AddSamples(metrics.WatchdogFiredTotal, metrics.Sample{Value: float64(count)})
+
+		return
+	}
+}
+
+// addQemuBalloonMetrics adds the memory balloon metrics to the metric set.
+func (d *qemu) addQemuBalloonMetrics(monitor *qmp.Monitor, metricSet *metrics.MetricSet) error {
+	limit, err := d.memoryLimitBytes()
+	if err != nil {
+		return err
+	}
+
+	current, stats, err := d.getQemuBalloonStats(monitor)
+	if err != nil {
+		return err
+	}
+
+	metricSet.AddSamples(metrics.MemoryBalloonSizeBytes, metrics.Sample{Value: float64(current)})
+	metricSet.AddSamples(metrics.MemoryBalloonInflatedBytes, metrics.Sample{Value: float64(max(limit-current, 0))})
+
+	// The swap statistics are reported in pages.
+	if stats.LastUpdate > 0 && stats.Stats.SwapIn >= 0 && stats.Stats.SwapOut >= 0 {
+		pageSize := int64(os.Getpagesize())
+
+		metricSet.AddSamples(metrics.MemoryBalloonSwapInBytes, metrics.Sample{Value: float64(stats.Stats.SwapIn * pageSize)})
+		metricSet.AddSamples(metrics.MemoryBalloonSwapOutBytes, metrics.Sample{Value: float64(stats.Stats.SwapOut * pageSize)})
+	}
+
+	return nil
+}
+
 func (d *qemu) getQemuDiskMetrics(monitor *qmp.Monitor) ([]metrics.DiskMetrics, error) {
 	stats, err := monitor.GetBlockStats()
 	if err != nil {
@@ -101,6 +148,22 @@ func (d *qemu) getQemuDiskMetrics(monitor *qmp.Monitor) ([]metrics.DiskMetrics,
 func (d *qemu) getQemuMemoryMetrics(monitor *qmp.Monitor) (metrics.MemoryMetrics, error) {
 	out := metrics.MemoryMetrics{}
 
+	// Prefer the memory usage reported by the guest through the balloon device.
+	_, stats, err := d.getQemuBalloonStats(monitor)
+	if err == nil && stats.LastUpdate > 0 && stats.Stats.TotalMemory > 0 && stats.Stats.FreeMemory >= 0 && stats.Stats.AvailableMemory >= 0 {
+		out = metrics.MemoryMetrics{
+			MemAvailableBytes: uint64(stats.Stats.AvailableMemory),
+			MemFreeBytes:      uint64(stats.Stats.FreeMemory),
+			MemTotalBytes:     uint64(stats.Stats.TotalMemory),
+		}
+
+		if stats.Stats.DiskCaches >= 0 {
+			out.CachedBytes = uint64(stats.Stats.DiskCaches)
+		}
+
+		return out, nil
+	}
+
 	// Get the QEMU PID.
 	pid, err := d.pid()
 	if err != nil {
@@ -353,17 +353,31 @@ func qemuSCSI(opts *qemuDevOpts, numQueues int) []cfg.Section {
 	}}
 }
 
-func qemuBalloon(opts *qemuDevOpts) []cfg.Section {
-	entriesOpts := qemuDevEntriesOpts{
-		dev:     *opts,
+type qemuBalloonOpts struct {
+	dev               qemuDevOpts
+	freePageReporting bool
+	deflateOnOOM      bool
+}
+
+func qemuBalloon(opts *qemuBalloonOpts) []cfg.Section {
+	entries := qemuDeviceEntries(&qemuDevEntriesOpts{
+		dev:     opts.dev,
 		pciName: "virtio-balloon-pci",
 		ccwName: "virtio-balloon-ccw",
+	})
+
+	if opts.freePageReporting {
+		entries["free-page-reporting"] = "on"
+	}
+
+	if opts.deflateOnOOM {
+		entries["deflate-on-oom"] = "on"
 	}
 
 	return []cfg.Section{{
 		Name:    `device "qemu_balloon"`,
 		Comment: "Balloon driver",
-		Entries: qemuDeviceEntries(&entriesOpts),
+		Entries: entries,
 	}}
 }
 
@@ -560,6 +574,7 @@ type qemuCPUOpts struct {
 	architecture     int
 	cpuCount         int
 	cpuRequested     int
+	cpuMax           int
 	cpuSockets       int
 	cpuCores         int
 	cpuThreads       int
@@ -598,6 +613,12 @@ func qemuCPUNumaHostNode(opts *qemuCPUOpts, index int) []cfg.Section {
 	}}
 }
 
+// qemuDefaultMaxVCPUs returns the default maximum number of vCPUs of a VM.
+// It's capped to the host's CPU count, or to 64 CPUs on larger hosts, unless more were requested.
+func qemuDefaultMaxVCPUs(hostCPUs int, requested int) int {
+	return max(min(hostCPUs, 64), requested)
+}
+
 func qemuCPU(opts *qemuCPUOpts, pinning bool) []cfg.Section {
 	entries := map[string]string{"cpus": fmt.Sprintf("%d", opts.cpuCount)}
 
@@ -606,19 +627,14 @@ func qemuCPU(opts *qemuCPUOpts, pinning bool) []cfg.Section {
 		entries["cores"] = fmt.Sprintf("%d", opts.cpuCores)
 		entries["threads"] = fmt.Sprintf("%d", opts.cpuThreads)
 	} else {
-		cpu, err := resources.GetCPU()
-		if err != nil {
-			return nil
-		}
+		maxCpus := opts.cpuMax
+		if maxCpus == 0 {
+			cpu, err := resources.GetCPU()
+			if err != nil {
+				return nil
+			}
 
-		// Cap the max number of CPUs to 64 unless directly assigned more.
-		maxCpus := 64
-		if int(cpu.Total) < maxCpus {
-			maxCpus = int(cpu.Total)
-		} else if opts.cpuRequested > maxCpus {
-			maxCpus = opts.cpuRequested
-		} else if opts.cpuCount > maxCpus {
-			maxCpus = opts.cpuCount
+			maxCpus = qemuDefaultMaxVCPUs(int(cpu.Total), max(opts.cpuRequested, opts.cpuCount))
 		}
 
 		entries["maxcpus"] = fmt.Sprintf("%d", maxCpus)
@@ -1061,6 +1077,32 @@ func qemuTPM(opts *qemuTPMOpts) []cfg.Section {
 	}}
 }
 
+type qemuWatchdogOpts struct {
+	dev     qemuDevOpts
+	devName string
+	model   string
+}
+
+func qemuWatchdog(opts *qemuWatchdogOpts) []cfg.Section {
+	entries := map[string]string{"driver": opts.model}
+
+	// The i6300esb watchdog is a PCI device, the other models are on the system bus.
+	if opts.model == "i6300esb" {
+		entriesOpts := qemuDevEntriesOpts{
+			dev:     opts.dev,
+			pciName: opts.model,
+		}
+
+		entries = qemuDeviceEntries(&entriesOpts)
+	}
+
+	return []cfg.Section{{
+		Name:    fmt.Sprintf(`device "%s%s"`, qemuDeviceIDPrefix, opts.devName),
+		Comment: fmt.Sprintf(`Watchdog ("%s" device)`, opts.devName),
+		Entries: entries,
+	}}
+}
+
 type qemuVmgenIDOpts struct {
 	guid string
 }
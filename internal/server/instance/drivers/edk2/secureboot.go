@@ -0,0 +1,363 @@
+package edk2
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+)
+
+// SecureBootKeys represents a set of custom Secure Boot keys to enroll into the firmware variables.
+type SecureBootKeys struct {
+	// Owner is the GUID recorded as the owner of the enrolled keys.
+	Owner uuid.UUID
+
+	// PK is the Platform Key.
+	PK []*x509.Certificate
+
+	// KEK contains the Key Exchange Keys.
+	KEK []*x509.Certificate
+
+	// DB contains the certificates allowed to sign boot images.
+	DB []*x509.Certificate
+
+	// DBX contains the certificates forbidden from signing boot images.
+	DBX []*x509.Certificate
+
+	// DBXHashes contains the SHA-256 hashes of forbidden boot images.
+	DBXHashes [][sha256.Size]byte
+}
+
+// Firmware variable store constants, as defined by EDK2.
+const (
+	fvSignature         = "_FVH"
+	fvHeaderLengthStart = 0x30
+	fvSignatureStart    = 0x28
+
+	varStoreHeaderSize = 28
+	varStoreFormatted  = 0x5a
+	varStoreHealthy    = 0xfe
+
+	varHeaderSize          = 60
+	varStartID             = 0x55aa
+	varAdded               = 0x3f
+	varDeleted             = 0xfd
+	varInDeletedTransition = 0xfe
+
+	varAttrNonVolatile    = 0x01
+	varAttrBootService    = 0x02
+	varAttrRuntime        = 0x04
+	varAttrTimeBasedAuth  = 0x20
+	varAttrSecureBootKeys = varAttrNonVolatile | varAttrBootService | varAttrRuntime | varAttrTimeBasedAuth
+	varAttrSecureBootMode = varAttrNonVolatile | varAttrBootService
+)
+
+var (
+	guidAuthVarStore          = uuid.MustParse("aaf32c78-947b-439a-a180-2e144ec37792")
+	guidGlobalVariable        = uuid.MustParse("8be4df61-93ca-11d2-aa0d-00e098032b8c")
+	guidImageSecurityDatabase = uuid.MustParse("d719b2cb-3d3a-4596-a3bc-dad00e67656f")
+	guidSecureBootEnable      = uuid.MustParse("f0a30bc7-af08-4556-99c4-001009c93a44")
+	guidCustomMode            = uuid.MustParse("c076ec0c-7028-4399-a072-71ee5c448b9f")
+	guidCertX509              = uuid.MustParse("a5c059a1-94e4-4aa7-87b5-ab155c2bf072")
+	guidCertSHA256            = uuid.MustParse("c1c41626-504c-4092-aca9-41f936934328")
+)
+
+// efiGUID returns the mixed-endian binary representation of a GUID used by UEFI.
+func efiGUID(u uuid.UUID) []byte {
+	b := make([]byte, 16)
+	copy(b, u[:])
+
+	b[0], b[1], b[2], b[3] = u[3], u[2], u[1], u[0]
+	b[4], b[5] = u[5], u[4]
+	b[6], b[7] = u[7], u[6]
+
+	return b
+}
+
+// efiTime returns the binary representation of a timestamp as an EFI_TIME structure.
+func efiTime(t time.Time) []byte {
+	t = t.UTC()
+
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint16(b[0:], uint16(t.Year()))
+	b[2] = byte(t.Month())
+	b[3] = byte(t.Day())
+	b[4] = byte(t.Hour())
+	b[5] = byte(t.Minute())
+	b[6] = byte(t.Second())
+
+	return b
+}
+
+// align4 rounds up an offset to the variable header alignment.
+func align4(n int) int {
+	return (n + 3) &^ 3
+}
+
+// ParseSecureBootCertificates parses a list of PEM encoded certificates.
+// When allowHashes is true, lines containing a hex encoded SHA-256 hash are accepted too.
+func ParseSecureBootCertificates(value string, allowHashes bool) ([]*x509.Certificate, [][sha256.Size]byte, error) {
+	var certs []*x509.Certificate
+	var hashes [][sha256.Size]byte
+
+	var pemData strings.Builder
+	for line := range strings.SplitSeq(value, "\n") {
+		line = strings.TrimSpace(line)
+
+		if allowHashes && len(line) == sha256.Size*2 {
+			hash, err := hex.DecodeString(line)
+			if err == nil {
+				hashes = append(hashes, [sha256.Size]byte(hash))
+				continue
+			}
+		}
+
+		pemData.WriteString(line + "\n")
+	}
+
+	rest := []byte(pemData.String())
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, nil, fmt.Errorf("Unexpected PEM block %q", block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed parsing certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, nil, errors.New("Invalid data after the PEM encoded certificates")
+	}
+
+	if len(certs) == 0 && len(hashes) == 0 {
+		return nil, nil, errors.New("No certificate found")
+	}
+
+	return certs, hashes, nil
+}
+
+// IsSecureBootCertificates validates a list of PEM encoded certificates.
+func IsSecureBootCertificates(value string) error {
+	_, _, err := ParseSecureBootCertificates(value, false)
+	return err
+}
+
+// IsSecureBootForbiddenList validates a list of PEM encoded certificates and SHA-256 hashes.
+func IsSecureBootForbiddenList(value string) error {
+	_, _, err := ParseSecureBootCertificates(value, true)
+	return err
+}
+
+// signatureList builds an EFI_SIGNATURE_LIST structure.
+func signatureList(owner uuid.UUID, sigType uuid.UUID, data []byte) []byte {
+	var buf bytes.Buffer
+
+	sigSize := 16 + len(data)
+
+	buf.Write(efiGUID(sigType))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(28+sigSize)) // SignatureListSize
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(0))          // SignatureHeaderSize
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(sigSize))    // SignatureSize
+	buf.Write(efiGUID(owner))
+	buf.Write(data)
+
+	return buf.Bytes()
+}
+
+// signatureDatabase builds the content of a Secure Boot key variable.
+func signatureDatabase(owner uuid.UUID, certs []*x509.Certificate, hashes [][sha256.Size]byte) []byte {
+	var buf bytes.Buffer
+
+	for _, cert := range certs {
+		buf.Write(signatureList(owner, guidCertX509, cert.Raw))
+	}
+
+	for _, hash := range hashes {
+		buf.Write(signatureList(owner, guidCertSHA256, hash[:]))
+	}
+
+	return buf.Bytes()
+}
+
+// firmwareVariable represents a variable to be written to the firmware variable store.
+// A variable without data is only removed from the store.
+type firmwareVariable struct {
+	name       string
+	guid       uuid.UUID
+	attributes uint32
+	data       []byte
+}
+
+// encode returns the binary representation of the variable, including its authenticated variable header.
+func (v *firmwareVariable) encode(now time.Time) []byte {
+	name := utf16.Encode([]rune(v.name + "\x00"))
+	nameSize := len(name) * 2
+
+	buf := bytes.NewBuffer(make([]byte, 0, align4(varHeaderSize+nameSize+len(v.data))))
+
+	_ = binary.Write(buf, binary.LittleEndian, uint16(varStartID))
+	buf.WriteByte(varAdded)
+	buf.WriteByte(0)
+	_ = binary.Write(buf, binary.LittleEndian, v.attributes)
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0)) // MonotonicCount
+
+	if v.attributes&varAttrTimeBasedAuth != 0 {
+		buf.Write(efiTime(now))
+	} else {
+		buf.Write(make([]byte, 16))
+	}
+
+	_ = binary.Write(buf, binary.LittleEndian, uint32(0)) // PubKeyIndex
+	_ = binary.Write(buf, binary.LittleEndian, uint32(nameSize))
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(v.data)))
+	buf.Write(efiGUID(v.guid))
+	_ = binary.Write(buf, binary.LittleEndian, name)
+	buf.Write(v.data)
+
+	for buf.Len()%4 != 0 {
+		buf.WriteByte(0xff)
+	}
+
+	return buf.Bytes()
+}
+
+// setFirmwareVariables replaces the given variables in an EDK2 authenticated variable store image.
+func setFirmwareVariables(image []byte, variables []firmwareVariable, now time.Time) error {
+	if len(image) < fvHeaderLengthStart+2 || string(image[fvSignatureStart:fvSignatureStart+4]) != fvSignature {
+		return errors.New("Invalid firmware volume header")
+	}
+
+	storeStart := int(binary.LittleEndian.Uint16(image[fvHeaderLengthStart:]))
+	if len(image) < storeStart+varStoreHeaderSize {
+		return errors.New("Truncated firmware variable store")
+	}
+
+	store := image[storeStart:]
+	if !bytes.Equal(store[0:16], efiGUID(guidAuthVarStore)) {
+		return errors.New("Firmware variable store doesn't support authenticated variables")
+	}
+
+	if store[20] != varStoreFormatted || store[21] != varStoreHealthy {
+		return errors.New("Firmware variable store isn't healthy")
+	}
+
+	storeEnd := storeStart + int(binary.LittleEndian.Uint32(store[16:]))
+	if storeEnd > len(image) {
+		return errors.New("Firmware variable store exceeds the firmware volume")
+	}
+
+	// Go through the existing variables, deleting the ones being replaced.
+	offset := storeStart + varStoreHeaderSize
+	for offset+varHeaderSize <= storeEnd && binary.LittleEndian.Uint16(image[offset:]) == varStartID {
+		state := image[offset+2]
+		nameSize := int(binary.LittleEndian.Uint32(image[offset+36:]))
+		dataSize := int(binary.LittleEndian.Uint32(image[offset+40:]))
+		guid := image[offset+44 : offset+60]
+
+		// The data directly follows the name, only headers are aligned.
+		nameStart := offset + varHeaderSize
+		next := align4(nameStart + nameSize + dataSize)
+		if next > storeEnd {
+			return errors.New("Corrupted firmware variable store")
+		}
+
+		if state == varAdded || state == varAdded&varInDeletedTransition {
+			name := make([]uint16, nameSize/2)
+			_ = binary.Read(bytes.NewReader(image[nameStart:nameStart+nameSize]), binary.LittleEndian, name)
+			nameStr := strings.TrimRight(string(utf16.Decode(name)), "\x00")
+
+			for _, v := range variables {
+				if v.name == nameStr && bytes.Equal(guid, efiGUID(v.guid)) {
+					image[offset+2] = state & varDeleted
+					break
+				}
+			}
+		}
+
+		offset = next
+	}
+
+	// Append the new variables in the free space.
+	for _, v := range variables {
+		if v.data == nil {
+			continue
+		}
+
+		data := v.encode(now)
+		if offset+len(data) > storeEnd {
+			return errors.New("Not enough space left in the firmware variable store")
+		}
+
+		copy(image[offset:], data)
+		offset += len(data)
+	}
+
+	return nil
+}
+
+// EnrollSecureBootKeys enrolls custom Secure Boot keys into an EDK2 variables file.
+// Any keys already present in the file (such as the Microsoft ones) are replaced and Secure Boot is enabled.
+func EnrollSecureBootKeys(varsPath string, keys SecureBootKeys) error {
+	if len(keys.PK) != 1 {
+		return errors.New("Exactly one Platform Key certificate is required")
+	}
+
+	if len(keys.KEK) == 0 {
+		return errors.New("At least one Key Exchange Key certificate is required")
+	}
+
+	if len(keys.DB) == 0 {
+		return errors.New("At least one signature database certificate is required")
+	}
+
+	variables := []firmwareVariable{
+		{name: "PK", guid: guidGlobalVariable, attributes: varAttrSecureBootKeys, data: signatureDatabase(keys.Owner, keys.PK, nil)},
+		{name: "KEK", guid: guidGlobalVariable, attributes: varAttrSecureBootKeys, data: signatureDatabase(keys.Owner, keys.KEK, nil)},
+		{name: "db", guid: guidImageSecurityDatabase, attributes: varAttrSecureBootKeys, data: signatureDatabase(keys.Owner, keys.DB, nil)},
+	}
+
+	if len(keys.DBX) > 0 || len(keys.DBXHashes) > 0 {
+		variables = append(variables, firmwareVariable{name: "dbx", guid: guidImageSecurityDatabase, attributes: varAttrSecureBootKeys, data: signatureDatabase(keys.Owner, keys.DBX, keys.DBXHashes)})
+	} else {
+		// Still drop any pre-enrolled forbidden signatures, as they come with the replaced keys.
+		variables = append(variables, firmwareVariable{name: "dbx", guid: guidImageSecurityDatabase})
+	}
+
+	variables = append(variables,
+		firmwareVariable{name: "SecureBootEnable", guid: guidSecureBootEnable, attributes: varAttrSecureBootMode, data: []byte{1}},
+		firmwareVariable{name: "CustomMode", guid: guidCustomMode, attributes: varAttrSecureBootMode, data: []byte{0}},
+	)
+
+	image, err := os.ReadFile(varsPath)
+	if err != nil {
+		return err
+	}
+
+	err = setFirmwareVariables(image, variables, time.Now())
+	if err != nil {
+		return fmt.Errorf("Failed enrolling Secure Boot keys into %q: %w", varsPath, err)
+	}
+
+	return os.WriteFile(varsPath, image, 0o600)
+}
@@ -0,0 +1,226 @@
+package edk2
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+)
+
+func tCertificate(t *testing.T, name string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+// tVarStore returns an empty EDK2 authenticated variable store image.
+func tVarStore(t *testing.T, size int) []byte {
+	t.Helper()
+
+	image := bytes.Repeat([]byte{0xff}, size)
+	copy(image[0:16], make([]byte, 16))
+	copy(image[fvSignatureStart:], fvSignature)
+	binary.LittleEndian.PutUint16(image[fvHeaderLengthStart:], 0x48)
+
+	store := image[0x48:]
+	copy(store[0:16], efiGUID(guidAuthVarStore))
+	binary.LittleEndian.PutUint32(store[16:], uint32(size-0x48))
+	store[20] = varStoreFormatted
+	store[21] = varStoreHealthy
+	copy(store[22:28], make([]byte, 6))
+
+	return image
+}
+
+// tVariables returns the name and state of all variables in the store.
+func tVariables(t *testing.T, image []byte) map[string][]byte {
+	t.Helper()
+
+	found := map[string][]byte{}
+
+	offset := 0x48 + varStoreHeaderSize
+	for binary.LittleEndian.Uint16(image[offset:]) == varStartID {
+		nameSize := int(binary.LittleEndian.Uint32(image[offset+36:]))
+		dataSize := int(binary.LittleEndian.Uint32(image[offset+40:]))
+
+		nameStart := offset + varHeaderSize
+		name := make([]uint16, nameSize/2)
+		_ = binary.Read(bytes.NewReader(image[nameStart:nameStart+nameSize]), binary.LittleEndian, name)
+
+		key := strings.TrimRight(string(utf16.Decode(name)), "\x00")
+		if image[offset+2] != varAdded {
+			key += " (deleted)"
+		}
+
+		found[key] = image[nameStart+nameSize : nameStart+nameSize+dataSize]
+		offset = align4(nameStart + nameSize + dataSize)
+	}
+
+	return found
+}
+
+func TestSetFirmwareVariables(t *testing.T) {
+	image := tVarStore(t, 0x4000)
+
+	old := firmwareVariable{name: "PK", guid: guidGlobalVariable, attributes: varAttrSecureBootKeys, data: []byte("old")}
+	other := firmwareVariable{name: "Boot0000", guid: guidGlobalVariable, attributes: varAttrNonVolatile, data: []byte("boot")}
+
+	err := setFirmwareVariables(image, []firmwareVariable{old, other}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replacement := firmwareVariable{name: "PK", guid: guidGlobalVariable, attributes: varAttrSecureBootKeys, data: []byte("new")}
+	removed := firmwareVariable{name: "Boot0000", guid: guidImageSecurityDatabase}
+
+	err = setFirmwareVariables(image, []firmwareVariable{replacement, removed}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := tVariables(t, image)
+
+	if string(found["PK (deleted)"]) != "old" {
+		t.Errorf("Expected the old PK to be deleted, got %v", found)
+	}
+
+	if string(found["PK"]) != "new" {
+		t.Errorf("Expected the new PK to be added, got %v", found)
+	}
+
+	// The removed variable has a different GUID so it must be left alone.
+	if string(found["Boot0000"]) != "boot" {
+		t.Errorf("Expected Boot0000 to be left untouched, got %v", found)
+	}
+}
+
+func TestSetFirmwareVariablesInvalid(t *testing.T) {
+	err := setFirmwareVariables(make([]byte, 0x100), nil, time.Now())
+	if err == nil {
+		t.Error("Expected an error for an invalid firmware volume")
+	}
+
+	image := tVarStore(t, 0x100)
+	large := firmwareVariable{name: "db", guid: guidImageSecurityDatabase, attributes: varAttrSecureBootKeys, data: make([]byte, 0x100)}
+
+	err = setFirmwareVariables(image, []firmwareVariable{large}, time.Now())
+	if err == nil {
+		t.Error("Expected an error when running out of space")
+	}
+}
+
+func TestEnrollSecureBootKeys(t *testing.T) {
+	pk := tCertificate(t, "PK")
+	kek := tCertificate(t, "KEK")
+	db := tCertificate(t, "db")
+	owner := uuid.New()
+
+	varsPath := filepath.Join(t.TempDir(), "OVMF_VARS.fd")
+	err := os.WriteFile(varsPath, tVarStore(t, 0x4000), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = EnrollSecureBootKeys(varsPath, SecureBootKeys{Owner: owner, PK: []*x509.Certificate{pk}})
+	if err == nil {
+		t.Error("Expected an error without KEK and db certificates")
+	}
+
+	err = EnrollSecureBootKeys(varsPath, SecureBootKeys{Owner: owner, PK: []*x509.Certificate{pk}, KEK: []*x509.Certificate{kek}, DB: []*x509.Certificate{db}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image, err := os.ReadFile(varsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := tVariables(t, image)
+
+	for _, name := range []string{"PK", "KEK", "db", "SecureBootEnable", "CustomMode"} {
+		_, ok := found[name]
+		if !ok {
+			t.Errorf("Missing variable %q", name)
+		}
+	}
+
+	_, ok := found["dbx"]
+	if ok {
+		t.Error("Unexpected dbx variable")
+	}
+
+	data := found["PK"]
+	if len(data) != 28+16+len(pk.Raw) {
+		t.Fatalf("Unexpected signature list size %d", len(data))
+	}
+
+	if !bytes.Equal(data[0:16], efiGUID(guidCertX509)) || !bytes.Equal(data[28:44], efiGUID(owner)) || !bytes.Equal(data[44:], pk.Raw) {
+		t.Error("Unexpected signature list content")
+	}
+
+	if !bytes.Equal(found["SecureBootEnable"], []byte{1}) {
+		t.Error("Secure Boot wasn't enabled")
+	}
+}
+
+func TestParseSecureBootCertificates(t *testing.T) {
+	cert := tCertificate(t, "db")
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	hash := strings.Repeat("ab", 32)
+
+	certs, hashes, err := ParseSecureBootCertificates(certPEM+certPEM, false)
+	if err != nil || len(certs) != 2 || len(hashes) != 0 {
+		t.Errorf("Unexpected result: %d certificates, %d hashes, %v", len(certs), len(hashes), err)
+	}
+
+	certs, hashes, err = ParseSecureBootCertificates(hash+"\n"+certPEM, true)
+	if err != nil || len(certs) != 1 || len(hashes) != 1 {
+		t.Errorf("Unexpected result: %d certificates, %d hashes, %v", len(certs), len(hashes), err)
+	}
+
+	_, _, err = ParseSecureBootCertificates(hash, false)
+	if err == nil {
+		t.Error("Expected hashes to be rejected")
+	}
+
+	_, _, err = ParseSecureBootCertificates("", false)
+	if err == nil {
+		t.Error("Expected an empty list to be rejected")
+	}
+}
@@ -106,6 +106,22 @@ type MemDev struct {
 	HostNodes []int  `json:"host-nodes"`
 }
 
+// BalloonStats contains the guest memory statistics reported through the balloon device.
+// Statistics which aren't reported by the guest are set to -1.
+type BalloonStats struct {
+	LastUpdate int64 `json:"last-update"`
+	Stats      struct {
+		SwapIn          int64 `json:"stat-swap-in"`
+		SwapOut         int64 `json:"stat-swap-out"`
+		MajorFaults     int64 `json:"stat-major-faults"`
+		MinorFaults     int64 `json:"stat-minor-faults"`
+		FreeMemory      int64 `json:"stat-free-memory"`
+		TotalMemory     int64 `json:"stat-total-memory"`
+		AvailableMemory int64 `json:"stat-available-memory"`
+		DiskCaches      int64 `json:"stat-disk-caches"`
+	} `json:"stats"`
+}
+
 // MigrationStatus contains information about the ongoing migration.
 type MigrationStatus struct {
 	Status string `json:"status"`
@@ -718,6 +734,27 @@ func (m *Monitor) SetMemoryBalloonSizeBytes(sizeBytes int64) error {
 	return m.Run("balloon", args, nil)
 }
 
+// SetBalloonStatsPollingInterval sets how often (in seconds) the guest reports memory statistics through the balloon device.
+func (m *Monitor) SetBalloonStatsPollingInterval(path string, interval int) error {
+	args := map[string]any{"path": path, "property": "guest-stats-polling-interval", "value": interval}
+	return m.Run("qom-set", args, nil)
+}
+
+// GetBalloonStats returns the latest guest memory statistics reported through the balloon device.
+func (m *Monitor) GetBalloonStats(path string) (*BalloonStats, error) {
+	// Prepare the response.
+	var resp struct {
+		Return BalloonStats `json:"return"`
+	}
+
+	err := m.Run("qom-get", map[string]string{"path": path, "property": "guest-stats"}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Return, nil
+}
+
 // GetMemdev retrieves memory devices by executing the query-memdev QMP command.
 func (m *Monitor) GetMemdev() ([]MemDev, error) {
 	// Prepare the response.
@@ -1673,9 +1710,11 @@ func (m *Monitor) UpdateBlockSize(id string, size int64) error {
 }
 
 // SetBlockThrottle applies an I/O limit on a disk.
-func (m *Monitor) SetBlockThrottle(id string, bytesRead int, bytesWrite int, iopsRead int, iopsWrite int) error {
+// When a group is provided, all disks in the same group share the limits.
+func (m *Monitor) SetBlockThrottle(id string, group string, bytesRead int, bytesWrite int, iopsRead int, iopsWrite int) error {
 	var args struct {
-		ID string `json:"id"`
+		ID    string `json:"id"`
+		Group string `json:"group,omitempty"`
 
 		Bytes      int `json:"bps"`
 		BytesRead  int `json:"bps_rd"`
@@ -1686,6 +1725,7 @@ func (m *Monitor) SetBlockThrottle(id string, bytesRead int, bytesWrite int, iop
 	}
 
 	args.ID = id
+	args.Group = group
 	args.BytesRead = bytesRead
 	args.BytesWrite = bytesWrite
 	args.IOPsRead = iopsRead
@@ -52,6 +52,9 @@ var EventDiskEjected = "DEVICE_TRAY_MOVED"
 // EventRTCChange is used to get RTC adjustment.
 var EventRTCChange = "RTC_CHANGE"
 
+// EventWatchdog is the event sent when the watchdog device of the VM fires.
+var EventWatchdog = "WATCHDOG"
+
 // ExcludedCommands is used to filter verbose commands from the QMP logs.
 var ExcludedCommands = []string{"ringbuf-read"}
 
@@ -0,0 +1,226 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Check types.
+const (
+	TypeExec = "exec"
+	TypeTCP  = "tcp"
+	TypeHTTP = "http"
+)
+
+// Actions taken when an instance becomes unhealthy.
+const (
+	ActionNone     = "none"
+	ActionRestart  = "restart"
+	ActionEvacuate = "evacuate"
+)
+
+// Defaults for the health check settings.
+const (
+	DefaultInterval    = 30 * time.Second
+	DefaultTimeout     = 5 * time.Second
+	DefaultRetries     = 3
+	DefaultMaxRestarts = 3
+)
+
+// maxRemediationBackoff is the longest delay between two remediations of an instance.
+const maxRemediationBackoff = time.Hour
+
+// maxOutput is the maximum length of the check output kept in the health state.
+const maxOutput = 4096
+
+// Config represents the health check configuration of an instance.
+type Config struct {
+	Type        string
+	Command     string
+	Address     string
+	Port        int
+	Path        string
+	Interval    time.Duration
+	Timeout     time.Duration
+	StartPeriod time.Duration
+	Retries     int
+	MaxRestarts int
+	Action      string
+}
+
+// ParseConfig returns the health check configuration from the instance's expanded config.
+// It returns nil if no health check is configured.
+func ParseConfig(config map[string]string) (*Config, error) {
+	checkType := config["healthcheck.type"]
+	if checkType == "" {
+		return nil, nil
+	}
+
+	c := &Config{
+		Type:        checkType,
+		Command:     config["healthcheck.command"],
+		Address:     config["healthcheck.address"],
+		Path:        config["healthcheck.path"],
+		Interval:    DefaultInterval,
+		Timeout:     DefaultTimeout,
+		Retries:     DefaultRetries,
+		MaxRestarts: DefaultMaxRestarts,
+		Action:      config["healthcheck.action"],
+	}
+
+	if c.Path == "" {
+		c.Path = "/"
+	}
+
+	if c.Action == "" {
+		c.Action = ActionNone
+	}
+
+	seconds := func(key string, value *time.Duration) error {
+		if config[key] == "" {
+			return nil
+		}
+
+		v, err := strconv.ParseUint(config[key], 10, 32)
+		if err != nil {
+			return fmt.Errorf("Invalid value for %q: %w", key, err)
+		}
+
+		*value = time.Duration(v) * time.Second
+
+		return nil
+	}
+
+	err := seconds("healthcheck.interval", &c.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	err = seconds("healthcheck.timeout", &c.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	err = seconds("healthcheck.start_period", &c.StartPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	if config["healthcheck.retries"] != "" {
+		c.Retries, err = strconv.Atoi(config["healthcheck.retries"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", "healthcheck.retries", err)
+		}
+	}
+
+	if config["healthcheck.max_restarts"] != "" {
+		c.MaxRestarts, err = strconv.Atoi(config["healthcheck.max_restarts"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", "healthcheck.max_restarts", err)
+		}
+	}
+
+	if config["healthcheck.port"] != "" {
+		c.Port, err = strconv.Atoi(config["healthcheck.port"])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", "healthcheck.port", err)
+		}
+	}
+
+	switch c.Type {
+	case TypeExec:
+		if c.Command == "" {
+			return nil, errors.New("healthcheck.command is required for exec health checks")
+		}
+
+	case TypeTCP, TypeHTTP:
+		if c.Port == 0 {
+			return nil, fmt.Errorf("healthcheck.port is required for %s health checks", c.Type)
+		}
+
+	default:
+		return nil, fmt.Errorf("Invalid health check type %q", c.Type)
+	}
+
+	if c.Interval <= 0 || c.Timeout <= 0 {
+		return nil, errors.New("healthcheck.interval and healthcheck.timeout must be greater than zero")
+	}
+
+	if c.Timeout > c.Interval {
+		return nil, errors.New("healthcheck.timeout can't be greater than healthcheck.interval")
+	}
+
+	if c.Retries < 1 {
+		return nil, errors.New("healthcheck.retries must be at least 1")
+	}
+
+	return c, nil
+}
+
+// CheckTCP connects to the given address and port.
+func CheckTCP(ctx context.Context, address string, port int) (string, error) {
+	dialer := net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+	if err != nil {
+		return "", err
+	}
+
+	_ = conn.Close()
+
+	return fmt.Sprintf("Connected to %s", conn.RemoteAddr().String()), nil
+}
+
+// CheckHTTP performs a GET request against the given address, port and path.
+// Any 2xx or 3xx response is considered healthy. Only the response status is reported, never the body.
+func CheckHTTP(ctx context.Context, address string, port int, path string) (string, error) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(address, strconv.Itoa(port)),
+		Path:   path,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{
+		// Don't follow redirects, the instance itself answered.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	_ = resp.Body.Close()
+
+	output := fmt.Sprintf("HTTP status %s", resp.Status)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return output, fmt.Errorf("Unexpected HTTP status %q", resp.Status)
+	}
+
+	return output, nil
+}
+
+// Truncate limits the length of a check output.
+func Truncate(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxOutput {
+		output = output[:maxOutput]
+	}
+
+	return output
+}
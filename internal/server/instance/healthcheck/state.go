@@ -0,0 +1,218 @@
+package healthcheck
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// Health status values.
+const (
+	StatusStarting  = "starting"
+	StatusHealthy   = "healthy"
+	StatusUnhealthy = "unhealthy"
+)
+
+// instanceState is the in-memory health state of an instance.
+type instanceState struct {
+	health   api.InstanceStateHealth
+	inFlight bool
+	since    time.Time
+}
+
+// remediationState tracks the automatic remediations of an instance which didn't become healthy again.
+type remediationState struct {
+	count     int
+	last      time.Time
+	inFlight  bool
+	exhausted bool
+}
+
+var (
+	statesMu     sync.Mutex
+	states       = map[int]*instanceState{}
+	remediations = map[int]*remediationState{}
+)
+
+// Get returns the health state of an instance.
+// Instances which haven't been checked yet are reported as starting.
+func Get(instID int) *api.InstanceStateHealth {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	st, ok := states[instID]
+	if !ok {
+		return &api.InstanceStateHealth{Status: StatusStarting}
+	}
+
+	health := st.health
+
+	return &health
+}
+
+// Begin marks a check of the instance as running if one is due at now.
+// It returns false if the previous check is still running or if the interval hasn't elapsed yet.
+func Begin(instID int, interval time.Duration, now time.Time) bool {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	st, ok := states[instID]
+	if !ok {
+		st = &instanceState{health: api.InstanceStateHealth{Status: StatusStarting}, since: now}
+		states[instID] = st
+	}
+
+	if st.inFlight || (!st.health.LastCheck.IsZero() && now.Sub(st.health.LastCheck) < interval) {
+		return false
+	}
+
+	st.inFlight = true
+
+	return true
+}
+
+// Record records the result of a check started with Begin and returns the
+// health status before and after the check.
+//
+// Failures during the start period (counted from the first check of the
+// running instance) are ignored until the instance was reported healthy.
+func Record(instID int, c *Config, now time.Time, output string, checkErr error) (string, string) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	st, ok := states[instID]
+	if !ok {
+		// The state was dropped while the check was running.
+		return "", ""
+	}
+
+	st.inFlight = false
+
+	oldStatus := st.health.Status
+	st.health.LastCheck = now
+	st.health.LastOutput = Truncate(output)
+
+	if checkErr == nil {
+		st.health.Status = StatusHealthy
+		st.health.FailingStreak = 0
+
+		// The previous remediations were successful.
+		rs, ok := remediations[instID]
+		if ok && !rs.inFlight {
+			delete(remediations, instID)
+		}
+
+		return oldStatus, st.health.Status
+	}
+
+	if st.health.LastOutput == "" {
+		st.health.LastOutput = Truncate(checkErr.Error())
+	}
+
+	if oldStatus == StatusStarting && now.Before(st.since.Add(c.StartPeriod)) {
+		return oldStatus, oldStatus
+	}
+
+	st.health.FailingStreak++
+	if st.health.FailingStreak >= c.Retries {
+		st.health.Status = StatusUnhealthy
+	}
+
+	return oldStatus, st.health.Status
+}
+
+// BeginRemediation marks a remediation of the unhealthy instance as running if one is allowed at now.
+//
+// Consecutive remediations are spaced out by an exponential backoff starting at the check interval
+// and stop after c.MaxRestarts of them didn't make the instance healthy again. The second return
+// value is true the first time a remediation is refused because that limit was reached.
+func BeginRemediation(instID int, c *Config, now time.Time) (bool, bool) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	rs, ok := remediations[instID]
+	if !ok {
+		rs = &remediationState{}
+		remediations[instID] = rs
+	}
+
+	if rs.inFlight {
+		return false, false
+	}
+
+	if rs.count >= c.MaxRestarts {
+		if rs.exhausted {
+			return false, false
+		}
+
+		rs.exhausted = true
+
+		return false, true
+	}
+
+	if rs.count > 0 && now.Before(rs.last.Add(remediationBackoff(c.Interval, rs.count))) {
+		return false, false
+	}
+
+	rs.count++
+	rs.last = now
+	rs.inFlight = true
+
+	return true, false
+}
+
+// EndRemediation records the end of a remediation started with BeginRemediation.
+// The health state of the instance is dropped so that it gets a new start period.
+func EndRemediation(instID int) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	rs, ok := remediations[instID]
+	if ok {
+		rs.inFlight = false
+	}
+
+	delete(states, instID)
+}
+
+// remediationBackoff returns the delay to wait for after count remediations before the next one.
+func remediationBackoff(interval time.Duration, count int) time.Duration {
+	backoff := interval
+	for range count {
+		backoff *= 2
+		if backoff >= maxRemediationBackoff {
+			return maxRemediationBackoff
+		}
+	}
+
+	return backoff
+}
+
+// Forget drops the health and remediation state of an instance.
+func Forget(instID int) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	delete(states, instID)
+	delete(remediations, instID)
+}
+
+// Prune drops the health state of all instances which aren't in keep.
+// The remediation state of instances which are being remediated is kept.
+func Prune(keep map[int]bool) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	for instID := range states {
+		if !keep[instID] {
+			delete(states, instID)
+		}
+	}
+
+	for instID, rs := range remediations {
+		if !keep[instID] && !rs.inFlight {
+			delete(remediations, instID)
+		}
+	}
+}
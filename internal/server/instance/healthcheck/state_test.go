@@ -0,0 +1,136 @@
+package healthcheck
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test the health status transitions.
+func TestRecord(t *testing.T) {
+	c, err := ParseConfig(map[string]string{
+		"healthcheck.type":         "tcp",
+		"healthcheck.port":         "80",
+		"healthcheck.retries":      "2",
+		"healthcheck.start_period": "60",
+	})
+	require.NoError(t, err)
+
+	const instID = 1
+	defer Forget(instID)
+
+	now := time.Now()
+	failure := errors.New("Connection refused")
+
+	// Failures during the start period aren't counted.
+	require.True(t, Begin(instID, c.Interval, now))
+	oldStatus, newStatus := Record(instID, c, now, "", failure)
+	require.Equal(t, StatusStarting, oldStatus)
+	require.Equal(t, StatusStarting, newStatus)
+	require.Equal(t, 0, Get(instID).FailingStreak)
+	require.Equal(t, "Connection refused", Get(instID).LastOutput)
+
+	// A check isn't due before the interval elapsed.
+	require.False(t, Begin(instID, c.Interval, now.Add(time.Second)))
+
+	// A successful check makes the instance healthy.
+	now = now.Add(c.Interval)
+	require.True(t, Begin(instID, c.Interval, now))
+	_, newStatus = Record(instID, c, now, "OK", nil)
+	require.Equal(t, StatusHealthy, newStatus)
+
+	// Once healthy, failures are counted until the retries are exhausted.
+	now = now.Add(c.Interval)
+	require.True(t, Begin(instID, c.Interval, now))
+	_, newStatus = Record(instID, c, now, "", failure)
+	require.Equal(t, StatusHealthy, newStatus)
+
+	now = now.Add(c.Interval)
+	require.True(t, Begin(instID, c.Interval, now))
+	oldStatus, newStatus = Record(instID, c, now, "", failure)
+	require.Equal(t, StatusHealthy, oldStatus)
+	require.Equal(t, StatusUnhealthy, newStatus)
+	require.Equal(t, 2, Get(instID).FailingStreak)
+
+	// Dropped instances are reported as starting.
+	Prune(map[int]bool{})
+	require.Equal(t, StatusStarting, Get(instID).Status)
+}
+
+// Test the backoff and limit of the remediations.
+func TestBeginRemediation(t *testing.T) {
+	c, err := ParseConfig(map[string]string{
+		"healthcheck.type":         "tcp",
+		"healthcheck.port":         "80",
+		"healthcheck.action":       "restart",
+		"healthcheck.max_restarts": "2",
+	})
+	require.NoError(t, err)
+
+	const instID = 2
+	defer Forget(instID)
+
+	now := time.Now()
+
+	// The first remediation happens right away.
+	allowed, exhausted := BeginRemediation(instID, c, now)
+	require.True(t, allowed)
+	require.False(t, exhausted)
+
+	// Only one remediation runs at a time.
+	allowed, _ = BeginRemediation(instID, c, now)
+	require.False(t, allowed)
+	EndRemediation(instID)
+
+	// The next one waits for the backoff.
+	allowed, _ = BeginRemediation(instID, c, now.Add(c.Interval))
+	require.False(t, allowed)
+
+	now = now.Add(2 * c.Interval)
+	allowed, _ = BeginRemediation(instID, c, now)
+	require.True(t, allowed)
+	EndRemediation(instID)
+
+	// Once the limit is reached, it is reported a single time.
+	now = now.Add(maxRemediationBackoff)
+	allowed, exhausted = BeginRemediation(instID, c, now)
+	require.False(t, allowed)
+	require.True(t, exhausted)
+
+	allowed, exhausted = BeginRemediation(instID, c, now)
+	require.False(t, allowed)
+	require.False(t, exhausted)
+
+	// A healthy check resets the remediations.
+	require.True(t, Begin(instID, c.Interval, now))
+	_, newStatus := Record(instID, c, now, "OK", nil)
+	require.Equal(t, StatusHealthy, newStatus)
+
+	allowed, _ = BeginRemediation(instID, c, now)
+	require.True(t, allowed)
+	EndRemediation(instID)
+}
+
+// Test the validation of the health check configuration.
+func TestParseConfig(t *testing.T) {
+	c, err := ParseConfig(map[string]string{})
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	_, err = ParseConfig(map[string]string{"healthcheck.type": "exec"})
+	require.Error(t, err)
+
+	_, err = ParseConfig(map[string]string{"healthcheck.type": "http"})
+	require.Error(t, err)
+
+	_, err = ParseConfig(map[string]string{"healthcheck.type": "tcp", "healthcheck.port": "22", "healthcheck.timeout": "60"})
+	require.Error(t, err)
+
+	c, err = ParseConfig(map[string]string{"healthcheck.type": "http", "healthcheck.port": "8080"})
+	require.NoError(t, err)
+	require.Equal(t, "/", c.Path)
+	require.Equal(t, ActionNone, c.Action)
+	require.Equal(t, DefaultInterval, c.Interval)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/cgroup"
 	"github.com/lxc/incus/v7/internal/server/db"
 	deviceConfig "github.com/lxc/incus/v7/internal/server/device/config"
+	"github.com/lxc/incus/v7/internal/server/instance/autoscale"
 	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v7/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v7/internal/server/metrics"
@@ -216,6 +217,8 @@ type Container interface {
 	InsertSeccompUnixDevice(prefix string, m deviceConfig.Device, pid int) error
 	DevptsFd() (*os.File, error)
 	IdmappedStorage(path string, fstype string) idmap.StorageType
+	LiveMigrationCheck() error
+	AutoscaleSample() (*autoscale.Sample, error)
 }
 
 // VM interface is for VM specific functions.
@@ -223,6 +226,7 @@ type VM interface {
 	Instance
 
 	AgentCertificate() *x509.Certificate
+	BalloonAdjust(hostPressure bool) error
 	ConsoleLog() (string, error)
 	ConsoleScreenshot(screenshotFile *os.File) error
 	DumpGuestMemory(w *os.File, format string) error
@@ -27,6 +27,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/db/cluster"
 	deviceConfig "github.com/lxc/incus/v7/internal/server/device/config"
 	"github.com/lxc/incus/v7/internal/server/instance/drivers/qemudefault"
+	"github.com/lxc/incus/v7/internal/server/instance/healthcheck"
 	"github.com/lxc/incus/v7/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v7/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v7/internal/server/operations"
@@ -148,6 +149,13 @@ func ValidConfig(sysOS *sys.OS, config map[string]string, expanded bool, instanc
 		return errors.New("nvidia.runtime is incompatible with privileged containers")
 	}
 
+	if expanded {
+		_, err = healthcheck.ParseConfig(config)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
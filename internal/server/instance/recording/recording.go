@@ -0,0 +1,239 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// FileExtension is the extension used for session recordings.
+const FileExtension = ".cast"
+
+// Event types defined by the asciicast v2 format.
+const (
+	EventOutput = "o"
+	EventInput  = "i"
+	EventResize = "r"
+)
+
+// Default terminal size used when the client didn't provide one.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// Header is the first line of an asciicast v2 recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder writes a terminal session to a file in the asciicast v2 format.
+type Recorder struct {
+	mu      sync.Mutex
+	w       io.WriteCloser
+	start   time.Time
+	pending map[string][]byte
+	err     error
+}
+
+// Create creates a new recording at the given path and writes its header.
+func Create(path string, header Header) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating session recording: %w", err)
+	}
+
+	r, err := NewRecorder(f, header)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewRecorder returns a new Recorder writing to w, starting with the given header.
+func NewRecorder(w io.WriteCloser, header Header) (*Recorder, error) {
+	r := &Recorder{
+		w:       w,
+		start:   time.Now(),
+		pending: map[string][]byte{},
+	}
+
+	header.Version = 2
+
+	if header.Width <= 0 || header.Height <= 0 {
+		header.Width = defaultWidth
+		header.Height = defaultHeight
+	}
+
+	if header.Timestamp == 0 {
+		header.Timestamp = r.start.Unix()
+	}
+
+	err := r.writeLine(header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed writing session recording header: %w", err)
+	}
+
+	return r, nil
+}
+
+// writeLine writes a JSON encoded line to the recording.
+func (r *Recorder) writeLine(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.w.Write(append(data, '\n'))
+
+	return err
+}
+
+// record adds an event to the recording.
+// Incomplete UTF-8 sequences at the end of data are held back until the next event of the same type.
+func (r *Recorder) record(eventType string, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+
+	buf := append(r.pending[eventType], data...)
+
+	// Find the start of a trailing incomplete UTF-8 sequence.
+	end := len(buf)
+	for i := len(buf) - 1; i >= 0 && i >= len(buf)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(buf[i]) {
+			if !utf8.FullRune(buf[i:]) {
+				end = i
+			}
+
+			break
+		}
+	}
+
+	r.pending[eventType] = append([]byte(nil), buf[end:]...)
+	if end == 0 {
+		return
+	}
+
+	r.err = r.writeLine([]any{time.Since(r.start).Seconds(), eventType, string(buf[:end])})
+}
+
+// Output records data written to the terminal.
+func (r *Recorder) Output(data []byte) {
+	r.record(EventOutput, data)
+}
+
+// Input records data typed into the terminal.
+func (r *Recorder) Input(data []byte) {
+	r.record(EventInput, data)
+}
+
+// Resize records a change of the terminal size.
+func (r *Recorder) Resize(width int, height int) {
+	r.record(EventResize, fmt.Appendf(nil, "%dx%d", width, height))
+}
+
+// Err returns the first error encountered while writing the recording.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// Close flushes any held back data and closes the recording.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, eventType := range []string{EventOutput, EventInput} {
+		if r.err == nil && len(r.pending[eventType]) > 0 {
+			r.err = r.writeLine([]any{time.Since(r.start).Seconds(), eventType, string(r.pending[eventType])})
+		}
+
+		delete(r.pending, eventType)
+	}
+
+	err := r.w.Close()
+	if r.err != nil {
+		return r.err
+	}
+
+	return err
+}
+
+// Reader returns a reader recording everything read from rd as terminal output.
+func (r *Recorder) Reader(rd io.Reader) io.Reader {
+	return &reader{rd: rd, rec: r}
+}
+
+// Writer returns a writer recording everything written to w as terminal input.
+func (r *Recorder) Writer(w io.Writer) io.Writer {
+	return &writer{w: w, rec: r}
+}
+
+// ReadWriteCloser returns a wrapper around rwc recording reads as terminal output and writes as terminal input.
+func (r *Recorder) ReadWriteCloser(rwc io.ReadWriteCloser) io.ReadWriteCloser {
+	return &readWriteCloser{
+		reader: reader{rd: rwc, rec: r},
+		writer: writer{w: rwc, rec: r},
+		c:      rwc,
+	}
+}
+
+type reader struct {
+	rd  io.Reader
+	rec *Recorder
+}
+
+// Read implements io.Reader.
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	if n > 0 {
+		r.rec.Output(p[:n])
+	}
+
+	return n, err
+}
+
+type writer struct {
+	w   io.Writer
+	rec *Recorder
+}
+
+// Write implements io.Writer.
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if n > 0 {
+		w.rec.Input(p[:n])
+	}
+
+	return n, err
+}
+
+type readWriteCloser struct {
+	reader
+	writer
+	c io.Closer
+}
+
+// Close implements io.Closer.
+func (rwc *readWriteCloser) Close() error {
+	return rwc.c.Close()
+}
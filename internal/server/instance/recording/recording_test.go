@@ -0,0 +1,126 @@
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+type nopWriteCloser struct {
+	bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func readEvents(t *testing.T, data string) (Header, [][]any) {
+	t.Helper()
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	if !scanner.Scan() {
+		t.Fatal("Recording is missing its header")
+	}
+
+	var header Header
+
+	err := json.Unmarshal(scanner.Bytes(), &header)
+	if err != nil {
+		t.Fatalf("Failed parsing header: %v", err)
+	}
+
+	events := [][]any{}
+	for scanner.Scan() {
+		var event []any
+
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			t.Fatalf("Failed parsing event %q: %v", scanner.Text(), err)
+		}
+
+		events = append(events, event)
+	}
+
+	return header, events
+}
+
+func TestRecorder(t *testing.T) {
+	out := &nopWriteCloser{}
+
+	rec, err := NewRecorder(out, Header{Command: "bash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = io.ReadAll(rec.Reader(strings.NewReader("hello\r\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rec.Writer(io.Discard).Write([]byte("ls\r"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec.Resize(120, 40)
+
+	err = rec.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, events := readEvents(t, out.String())
+
+	if header.Version != 2 || header.Width != defaultWidth || header.Height != defaultHeight || header.Command != "bash" {
+		t.Errorf("Unexpected header: %+v", header)
+	}
+
+	expected := [][2]string{{EventOutput, "hello\r\n"}, {EventInput, "ls\r"}, {EventResize, "120x40"}}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(events), events)
+	}
+
+	for i, event := range events {
+		if event[1] != expected[i][0] || event[2] != expected[i][1] {
+			t.Errorf("Unexpected event %d: %v", i, event)
+		}
+	}
+}
+
+func TestRecorderSplitRune(t *testing.T) {
+	out := &nopWriteCloser{}
+
+	rec, err := NewRecorder(out, Header{Width: 100, Height: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("ünïcode")
+	rec.Output(data[:1])
+	rec.Output(data[1:4])
+	rec.Output(data[4:])
+
+	err = rec.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, events := readEvents(t, out.String())
+
+	if header.Width != 100 || header.Height != 30 {
+		t.Errorf("Unexpected header: %+v", header)
+	}
+
+	var output string
+	for _, event := range events {
+		output += event[2].(string)
+	}
+
+	if output != string(data) {
+		t.Errorf("Expected output %q, got %q", string(data), output)
+	}
+}
@@ -0,0 +1,28 @@
+package lifecycle
+
+import (
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// ApprovalAction represents a lifecycle event action for approvals.
+type ApprovalAction string
+
+// All supported lifecycle events for approvals.
+const (
+	ApprovalApproved = ApprovalAction(api.EventLifecycleApprovalApproved)
+	ApprovalCreated  = ApprovalAction(api.EventLifecycleApprovalCreated)
+	ApprovalRejected = ApprovalAction(api.EventLifecycleApprovalRejected)
+)
+
+// Event creates the lifecycle event for an action on an approval.
+func (a ApprovalAction) Event(id string, projectName string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "approvals", id).Project(projectName)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
@@ -0,0 +1,94 @@
+package lifecycle
+
+import (
+	"github.com/lxc/incus/v7/internal/version"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// AuthGroupAction represents a lifecycle event action for authorization groups.
+type AuthGroupAction string
+
+// All supported lifecycle events for authorization groups.
+const (
+	AuthGroupCreated = AuthGroupAction(api.EventLifecycleAuthGroupCreated)
+	AuthGroupDeleted = AuthGroupAction(api.EventLifecycleAuthGroupDeleted)
+	AuthGroupUpdated = AuthGroupAction(api.EventLifecycleAuthGroupUpdated)
+	AuthGroupRenamed = AuthGroupAction(api.EventLifecycleAuthGroupRenamed)
+)
+
+// Event creates the lifecycle event for an action on an authorization group.
+func (a AuthGroupAction) Event(name string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "auth", "groups", name)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
+
+// IdentityAction represents a lifecycle event action for identities.
+type IdentityAction string
+
+// All supported lifecycle events for identities.
+const (
+	IdentityCreated = IdentityAction(api.EventLifecycleIdentityCreated)
+	IdentityDeleted = IdentityAction(api.EventLifecycleIdentityDeleted)
+	IdentityUpdated = IdentityAction(api.EventLifecycleIdentityUpdated)
+)
+
+// Event creates the lifecycle event for an action on an identity.
+func (a IdentityAction) Event(authenticationMethod string, identifier string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "auth", "identities", authenticationMethod, identifier)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
+
+// AuthTokenAction represents a lifecycle event action for API tokens.
+type AuthTokenAction string
+
+// All supported lifecycle events for API tokens.
+const (
+	AuthTokenCreated = AuthTokenAction(api.EventLifecycleAuthTokenCreated)
+	AuthTokenDeleted = AuthTokenAction(api.EventLifecycleAuthTokenDeleted)
+	AuthTokenUpdated = AuthTokenAction(api.EventLifecycleAuthTokenUpdated)
+)
+
+// Event creates the lifecycle event for an action on an API token.
+func (a AuthTokenAction) Event(name string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "auth", "tokens", name)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
+
+// AuthCertificateAction represents a lifecycle event action for issued client certificates.
+type AuthCertificateAction string
+
+// All supported lifecycle events for issued client certificates.
+const (
+	AuthCertificateIssued  = AuthCertificateAction(api.EventLifecycleAuthCertificateIssued)
+	AuthCertificateRevoked = AuthCertificateAction(api.EventLifecycleAuthCertificateRevoked)
+)
+
+// Event creates the lifecycle event for an action on an issued client certificate.
+func (a AuthCertificateAction) Event(serial string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "auth", "certificates", serial)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
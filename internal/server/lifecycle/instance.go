@@ -20,6 +20,7 @@ type InstanceAction string
 const (
 	InstanceAgentStarted     = InstanceAction(api.EventLifecycleInstanceAgentStarted)
 	InstanceAgentStopped     = InstanceAction(api.EventLifecycleInstanceAgentStopped)
+	InstanceAutoscaled       = InstanceAction(api.EventLifecycleInstanceAutoscaled)
 	InstanceConsole          = InstanceAction(api.EventLifecycleInstanceConsole)
 	InstanceConsoleReset     = InstanceAction(api.EventLifecycleInstanceConsoleReset)
 	InstanceConsoleRetrieved = InstanceAction(api.EventLifecycleInstanceConsoleRetrieved)
@@ -29,17 +30,22 @@ const (
 	InstanceFileDeleted      = InstanceAction(api.EventLifecycleInstanceFileDeleted)
 	InstanceFilePushed       = InstanceAction(api.EventLifecycleInstanceFilePushed)
 	InstanceFileRetrieved    = InstanceAction(api.EventLifecycleInstanceFileRetrieved)
+	InstanceHealthy          = InstanceAction(api.EventLifecycleInstanceHealthy)
 	InstanceMigrated         = InstanceAction(api.EventLifecycleInstanceMigrated)
 	InstancePaused           = InstanceAction(api.EventLifecycleInstancePaused)
+	InstancePowerScheduled   = InstanceAction(api.EventLifecycleInstancePowerScheduled)
 	InstanceReady            = InstanceAction(api.EventLifecycleInstanceReady)
 	InstanceRenamed          = InstanceAction(api.EventLifecycleInstanceRenamed)
 	InstanceRestarted        = InstanceAction(api.EventLifecycleInstanceRestarted)
 	InstanceRestored         = InstanceAction(api.EventLifecycleInstanceRestored)
 	InstanceResumed          = InstanceAction(api.EventLifecycleInstanceResumed)
+	InstanceSessionRecorded  = InstanceAction(api.EventLifecycleInstanceSessionRecorded)
 	InstanceShutdown         = InstanceAction(api.EventLifecycleInstanceShutdown)
 	InstanceStarted          = InstanceAction(api.EventLifecycleInstanceStarted)
 	InstanceStopped          = InstanceAction(api.EventLifecycleInstanceStopped)
+	InstanceUnhealthy        = InstanceAction(api.EventLifecycleInstanceUnhealthy)
 	InstanceUpdated          = InstanceAction(api.EventLifecycleInstanceUpdated)
+	InstanceWatchdogFired    = InstanceAction(api.EventLifecycleInstanceWatchdogFired)
 )
 
 // Event creates the lifecycle event for an action on an instance.
@@ -42,6 +42,8 @@ func newCommonLogger(name string, cfg *clusterConfig.Config) common {
 // processEvent verifies whether the event should be processed for the specific logger.
 func (c *common) processEvent(event api.Event) bool {
 	switch event.Type {
+	case api.EventTypeAudit:
+		return contains(c.types, "audit")
 	case api.EventTypeLifecycle:
 		if !contains(c.types, "lifecycle") {
 			return false
@@ -298,6 +298,58 @@ func (l *LokiLogger) HandleEvent(event api.Event) {
 	ctx := make(map[string]string)
 
 	switch event.Type {
+	case api.EventTypeAudit:
+		auditEvent := api.EventAudit{}
+
+		err := json.Unmarshal(event.Metadata, &auditEvent)
+		if err != nil {
+			return
+		}
+
+		if auditEvent.Project != "" {
+			entry.labels["project"] = auditEvent.Project
+		}
+
+		ctx["sequence"] = fmt.Sprintf("%d", auditEvent.Sequence)
+		ctx["username"] = auditEvent.Username
+		ctx["protocol"] = auditEvent.Protocol
+		ctx["address"] = auditEvent.Address
+		ctx["endpoint"] = auditEvent.Endpoint
+		ctx["status-code"] = fmt.Sprintf("%d", auditEvent.StatusCode)
+		ctx["outcome"] = auditEvent.Outcome
+		ctx["duration"] = fmt.Sprintf("%d", auditEvent.Duration)
+		ctx["hash"] = auditEvent.Hash
+
+		// Add key-value pairs as labels but don't override any labels.
+		for k, v := range ctx {
+			if slices.Contains(l.cfg.labels, k) {
+				_, ok := entry.labels[k]
+				if !ok {
+					// Label names may not contain any hyphens.
+					entry.labels[strings.ReplaceAll(k, "-", "_")] = v
+					delete(ctx, k)
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(ctx))
+
+		for k := range ctx {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		var message strings.Builder
+
+		// Add the remaining context as the message prefix. The keys are sorted alphabetically.
+		for _, k := range keys {
+			fmt.Fprintf(&message, "%s=%q ", k, ctx[k])
+		}
+
+		fmt.Fprintf(&message, "%s %s", auditEvent.Method, auditEvent.URL)
+
+		entry.Line = message.String()
 	case api.EventTypeLifecycle:
 		lifecycleEvent := api.EventLifecycle{}
 
@@ -77,6 +77,14 @@ const (
 	MemoryWritebackBytes
 	// MemoryOOMKillsTotal represents the amount of oom kills.
 	MemoryOOMKillsTotal
+	// MemoryBalloonSizeBytes represents the amount of memory left to the guest by the memory balloon.
+	MemoryBalloonSizeBytes
+	// MemoryBalloonInflatedBytes represents the amount of memory reclaimed from the guest by the memory balloon.
+	MemoryBalloonInflatedBytes
+	// MemoryBalloonSwapInBytes represents the amount of memory swapped in by the guest.
+	MemoryBalloonSwapInBytes
+	// MemoryBalloonSwapOutBytes represents the amount of memory swapped out by the guest.
+	MemoryBalloonSwapOutBytes
 	// NetworkReceiveBytesTotal represents the amount of received bytes on a given interface.
 	NetworkReceiveBytesTotal
 	// NetworkReceiveDropTotal represents the amount of received dropped bytes on a given interface.
@@ -97,6 +105,8 @@ const (
 	ProcsTotal
 	// TimeSeconds represents current Unix time on the instance.
 	TimeSeconds
+	// WatchdogFiredTotal represents the number of times the watchdog of the instance fired.
+	WatchdogFiredTotal
 	// OperationsTotal represents the number of running operations.
 	OperationsTotal
 	// WarningsTotal represents the number of active warnings.
@@ -216,6 +226,10 @@ var MetricNames = map[MetricType]string{
 	MemoryUnevictableBytes:      "incus_memory_Unevictable_bytes",
 	MemoryWritebackBytes:        "incus_memory_Writeback_bytes",
 	MemoryOOMKillsTotal:         "incus_memory_OOM_kills_total",
+	MemoryBalloonSizeBytes:      "incus_memory_balloon_size_bytes",
+	MemoryBalloonInflatedBytes:  "incus_memory_balloon_inflated_bytes",
+	MemoryBalloonSwapInBytes:    "incus_memory_balloon_swap_in_bytes_total",
+	MemoryBalloonSwapOutBytes:   "incus_memory_balloon_swap_out_bytes_total",
 	NetworkReceiveBytesTotal:    "incus_network_receive_bytes_total",
 	NetworkReceiveDropTotal:     "incus_network_receive_drop_total",
 	NetworkReceiveErrsTotal:     "incus_network_receive_errs_total",
@@ -234,6 +248,7 @@ var MetricNames = map[MetricType]string{
 	TimeSeconds:                 "incus_time_seconds",
 	UptimeSeconds:               "incus_uptime_seconds",
 	WarningsTotal:               "incus_warnings_total",
+	WatchdogFiredTotal:          "incus_watchdog_fired_total",
 }
 
 // MetricHeaders represents the metric headers which contain help messages as specified by OpenMetrics.
@@ -291,6 +306,10 @@ var MetricHeaders = map[MetricType]string{
 	MemoryUnevictableBytes:      "# HELP incus_memory_Unevictable_bytes The amount of unevictable memory.",
 	MemoryWritebackBytes:        "# HELP incus_memory_Writeback_bytes The amount of memory queued for syncing to disk.",
 	MemoryOOMKillsTotal:         "# HELP incus_memory_OOM_kills_total The number of out of memory kills.",
+	MemoryBalloonSizeBytes:      "# HELP incus_memory_balloon_size_bytes The amount of memory left to the guest by the memory balloon.",
+	MemoryBalloonInflatedBytes:  "# HELP incus_memory_balloon_inflated_bytes The amount of memory reclaimed from the guest by the memory balloon.",
+	MemoryBalloonSwapInBytes:    "# HELP incus_memory_balloon_swap_in_bytes_total The amount of memory swapped in by the guest.",
+	MemoryBalloonSwapOutBytes:   "# HELP incus_memory_balloon_swap_out_bytes_total The amount of memory swapped out by the guest.",
 	NetworkReceiveBytesTotal:    "# HELP incus_network_receive_bytes_total The amount of received bytes on a given interface.",
 	NetworkReceiveDropTotal:     "# HELP incus_network_receive_drop_total The amount of received dropped bytes on a given interface.",
 	NetworkReceiveErrsTotal:     "# HELP incus_network_receive_errs_total The amount of received errors on a given interface.",
@@ -309,4 +328,5 @@ var MetricHeaders = map[MetricType]string{
 	TimeSeconds:                 "# HELP incus_time_seconds The current unix epoch.",
 	UptimeSeconds:               "# HELP incus_uptime_seconds The daemon uptime in seconds.",
 	WarningsTotal:               "# HELP incus_warnings_total The number of active warnings.",
+	WatchdogFiredTotal:          "# HELP incus_watchdog_fired_total The number of times the watchdog of the instance fired.",
 }
@@ -442,6 +442,29 @@ func (op *Operation) Cancel() (chan error, error) {
 	return chanCancel, nil
 }
 
+// Discard cancels a pending operation without ever running it.
+func (op *Operation) Discard(err error) error {
+	op.lock.Lock()
+	if op.status != api.Pending {
+		op.lock.Unlock()
+		return errors.New("Only pending operations can be discarded")
+	}
+
+	op.status = api.Cancelled
+	op.err = err
+	op.lock.Unlock()
+	op.done()
+
+	op.logger.Debug("Discarded operation", logger.Ctx{"err": err})
+	_, md, _ := op.Render()
+
+	op.lock.Lock()
+	op.sendEvent(md)
+	op.lock.Unlock()
+
+	return nil
+}
+
 // Connect connects a websocket operation. If the operation is not a websocket
 // operation or the operation is not running, it returns an error.
 func (op *Operation) Connect(r *http.Request, w http.ResponseWriter) (chan error, error) {
@@ -13,19 +13,28 @@ import (
 
 // Operation response.
 type operationResponse struct {
-	op *Operation
+	op      *Operation
+	pending bool
 }
 
 // OperationResponse returns an operation response.
 func OperationResponse(op *Operation) response.Response {
-	return &operationResponse{op}
+	return &operationResponse{op: op}
+}
+
+// PendingOperationResponse returns an operation response for an operation which
+// is left pending, to be started later on.
+func PendingOperationResponse(op *Operation) response.Response {
+	return &operationResponse{op: op, pending: true}
 }
 
 // Render writes the operation response to the client.
 func (r *operationResponse) Render(w http.ResponseWriter) error {
-	err := r.op.Start()
-	if err != nil {
-		return err
+	if !r.pending {
+		err := r.op.Start()
+		if err != nil {
+			return err
+		}
 	}
 
 	url, md, err := r.op.Render()
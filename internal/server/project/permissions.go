@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lxc/incus/v7/internal/instance"
 	"github.com/lxc/incus/v7/internal/server/auth"
@@ -333,6 +335,80 @@ func AllowVolumeCreation(tx *db.ClusterTx, projectName string, poolName string,
 	return nil
 }
 
+// AllowNetworkAddressCreation returns an error if any project-specific limit is
+// violated when creating a new network forward or load balancer in a project.
+func AllowNetworkAddressCreation(tx *db.ClusterTx, projectName string) error {
+	ctx := context.Background()
+
+	dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+	if err != nil {
+		return fmt.Errorf("Fetch project database object: %w", err)
+	}
+
+	project, err := dbProject.ToAPI(ctx, tx.Tx())
+	if err != nil {
+		return err
+	}
+
+	count, limit, err := getNetworkAddressCountLimit(ctx, tx, project)
+	if err != nil {
+		return err
+	}
+
+	if limit >= 0 && count >= limit {
+		return fmt.Errorf("Reached maximum number of network addresses in project %q", projectName)
+	}
+
+	return nil
+}
+
+// getNetworkAddressCountLimit returns the number of listen addresses used by the network forwards and
+// load balancers of the project networks, along with the project limit (-1 if unlimited).
+func getNetworkAddressCountLimit(ctx context.Context, tx *db.ClusterTx, project *api.Project) (int, int, error) {
+	networks, err := tx.GetCreatedNetworksByProject(ctx, project.Name)
+	if err != nil {
+		return -1, -1, fmt.Errorf("Fetch networks from database: %w", err)
+	}
+
+	count := 0
+	for networkID := range networks {
+		// Forwards of non-OVN networks are per-member, so count each listen address once.
+		listenAddresses := map[string]struct{}{}
+
+		forwards, err := cluster.GetNetworkForwards(ctx, tx.Tx(), cluster.NetworkForwardFilter{NetworkID: &networkID})
+		if err != nil {
+			return -1, -1, fmt.Errorf("Fetch network forwards from database: %w", err)
+		}
+
+		for _, forward := range forwards {
+			listenAddresses[forward.ListenAddress] = struct{}{}
+		}
+
+		loadBalancers, err := cluster.GetNetworkLoadBalancers(ctx, tx.Tx(), cluster.NetworkLoadBalancerFilter{NetworkID: &networkID})
+		if err != nil {
+			return -1, -1, fmt.Errorf("Fetch network load balancers from database: %w", err)
+		}
+
+		for _, loadBalancer := range loadBalancers {
+			listenAddresses[loadBalancer.ListenAddress] = struct{}{}
+		}
+
+		count += len(listenAddresses)
+	}
+
+	value, ok := project.Config["limits.network.addresses"]
+	if ok {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return -1, -1, fmt.Errorf("Unexpected %q value: %q", "limits.network.addresses", value)
+		}
+
+		return count, limit, nil
+	}
+
+	return count, -1, nil
+}
+
 // GetImageSpaceBudget returns how much disk space is left in the given project
 // for writing images.
 //
@@ -952,6 +1028,8 @@ var allAggregateLimits = []string{
 	"limits.cpu",
 	"limits.disk",
 	"limits.memory",
+	"limits.network.egress",
+	"limits.network.ingress",
 	"limits.processes",
 }
 
@@ -1220,7 +1298,17 @@ func AllowProjectUpdate(tx *db.ClusterTx, projectName string, config map[string]
 		case "limits.memory":
 			fallthrough
 		case "limits.disk":
+			fallthrough
+		case "limits.network.ingress":
+			fallthrough
+		case "limits.network.egress":
 			aggregateKeys = append(aggregateKeys, key)
+
+		case "limits.network.addresses":
+			err := validateNetworkAddressesLimit(tx, projectName, config[key])
+			if err != nil {
+				return fmt.Errorf("Can't change limits.network.addresses in project %q: %w", projectName, err)
+			}
 		}
 	}
 
@@ -1294,6 +1382,32 @@ func validateInstanceCountLimit(instances []api.Instance, key, value, project st
 	return nil
 }
 
+// Check that limits.network.addresses is equal to or above the current number
+// of network addresses in the project.
+func validateNetworkAddressesLimit(tx *db.ClusterTx, projectName, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	project := &api.Project{
+		Name: projectName,
+		ProjectPut: api.ProjectPut{
+			Config: map[string]string{"limits.network.addresses": value},
+		},
+	}
+
+	count, limit, err := getNetworkAddressCountLimit(context.Background(), tx, project)
+	if err != nil {
+		return err
+	}
+
+	if limit < count {
+		return fmt.Errorf(`"limits.network.addresses" is too low: there currently are %d network addresses in project %q`, count, projectName)
+	}
+
+	return nil
+}
+
 var countConfigInstanceType = map[string]api.InstanceType{
 	"limits.containers":       api.InstanceTypeContainer,
 	"limits.virtual-machines": api.InstanceTypeVM,
@@ -1602,6 +1716,40 @@ func getInstanceLimits(inst api.Instance, keys []string, skipUnset bool) (map[st
 
 				limit += sizeStateLimit
 			}
+		} else if key == "limits.network.ingress" || key == "limits.network.egress" {
+			nicKey := strings.Replace(key, "limits.network.", "limits.", 1)
+
+			for _, devName := range slices.Sorted(maps.Keys(inst.Devices)) {
+				device := inst.Devices[devName]
+				if device["type"] != "nic" {
+					continue
+				}
+
+				// The limits.max setting overrides both directions.
+				value := device["limits.max"]
+				if value == "" {
+					value = device[nicKey]
+				}
+
+				if value == "" {
+					if skipUnset {
+						continue
+					}
+
+					return nil, fmt.Errorf("Instance %q in project %q has no %q config set on NIC device %q either directly or via a profile", inst.Name, inst.Project, nicKey, devName)
+				}
+
+				nicLimit, err := parser(value)
+				if err != nil {
+					if skipUnset {
+						continue
+					}
+
+					return nil, fmt.Errorf("Failed parsing %q of NIC device %q for instance %q in project %q", nicKey, devName, inst.Name, inst.Project)
+				}
+
+				limit += nicLimit
+			}
 		} else {
 			// Skip processing for 'limits.processes' if the instance type is VM,
 			// as this limit is only applicable to containers.
@@ -1665,6 +1813,12 @@ var aggregateLimitConfigValueParsers = map[string]func(string) (int64, error){
 	"limits.disk": func(value string) (int64, error) {
 		return units.ParseByteSizeString(value)
 	},
+	"limits.network.ingress": func(value string) (int64, error) {
+		return units.ParseBitSizeString(value)
+	},
+	"limits.network.egress": func(value string) (int64, error) {
+		return units.ParseBitSizeString(value)
+	},
 }
 
 var aggregateLimitConfigValuePrinters = map[string]func(int64) string{
@@ -1680,6 +1834,12 @@ var aggregateLimitConfigValuePrinters = map[string]func(int64) string{
 	"limits.disk": func(limit int64) string {
 		return units.GetByteSizeStringIEC(limit, 1)
 	},
+	"limits.network.ingress": func(limit int64) string {
+		return units.GetBitSizeString(limit, 1)
+	},
+	"limits.network.egress": func(limit int64) string {
+		return units.GetBitSizeString(limit, 1)
+	},
 }
 
 // FilterUsedBy filters a UsedBy list based on project access.
@@ -1789,6 +1949,47 @@ func AllowSnapshotCreation(p *api.Project) error {
 	return nil
 }
 
+// AllowDeletion returns an error if the project protection policy prevents deleting or rebuilding
+// instances and custom storage volumes of the project.
+func AllowDeletion(p *api.Project) error {
+	if util.IsTrue(p.Config["protection.delete"]) {
+		return api.StatusErrorf(http.StatusForbidden, "Project %q doesn't allow deleting instances and custom volumes", p.Name)
+	}
+
+	return nil
+}
+
+// AllowSnapshotDeletion returns an error if the project protection policy prevents deleting
+// a snapshot created at the given time.
+func AllowSnapshotDeletion(p *api.Project, creationDate time.Time) error {
+	minAge := p.Config["protection.snapshots.min_age"]
+	if minAge == "" {
+		return nil
+	}
+
+	deletableAt, err := instance.GetExpiry(creationDate, minAge)
+	if err != nil {
+		return fmt.Errorf("Invalid protection.snapshots.min_age: %w", err)
+	}
+
+	if time.Now().Before(deletableAt) {
+		return api.StatusErrorf(http.StatusForbidden, "Project %q doesn't allow deleting snapshots until %s", p.Name, deletableAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// SnapshotBeforeRestore returns whether the project protection policy requires taking a snapshot
+// of instances and custom storage volumes before restoring them from a snapshot.
+func SnapshotBeforeRestore(p *api.Project) bool {
+	return util.IsTrue(p.Config["protection.restore.snapshot"])
+}
+
+// IsProtectionConfigKey returns whether a project config key is part of the project protection policy.
+func IsProtectionConfigKey(key string) bool {
+	return strings.HasPrefix(key, "protection.")
+}
+
 // GetRestrictedClusterGroups returns a slice of restricted cluster groups for the given project.
 func GetRestrictedClusterGroups(p *api.Project) []string {
 	return util.SplitNTrimSpace(p.Config["restricted.cluster.groups"], ",", -1, true)
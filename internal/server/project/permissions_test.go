@@ -163,6 +163,85 @@ func TestAllowInstanceCreation_AboveInstances(t *testing.T) {
 	assert.EqualError(t, err, `Reached maximum number of instances in project "p1"`)
 }
 
+// If a network bandwidth limit is configured, the limits of all the NICs of the
+// instance are added up and the check fails if the sum is above the limit.
+func TestAllowInstanceCreation_AboveNetworkIngress(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"limits.network.ingress": "100Mbit"})
+	require.NoError(t, err)
+
+	req := api.InstancesPost{
+		Name: "c1",
+		Type: api.InstanceTypeContainer,
+		InstancePut: api.InstancePut{
+			Devices: map[string]map[string]string{
+				"eth0": {"type": "nic", "network": "incusbr0", "limits.ingress": "60Mbit"},
+				"eth1": {"type": "nic", "network": "incusbr0", "limits.max": "50Mbit"},
+			},
+		},
+	}
+
+	err = project.AllowInstanceCreation(tx, "p1", req)
+	assert.EqualError(t, err, `Failed checking if instance creation allowed: Reached maximum aggregate value "100Mbit" for "limits.network.ingress" in project "p1"`)
+}
+
+// If a network bandwidth limit is configured, all the NICs must have a limit set.
+func TestAllowInstanceCreation_UnsetNetworkIngress(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"limits.network.ingress": "100Mbit"})
+	require.NoError(t, err)
+
+	req := api.InstancesPost{
+		Name: "c1",
+		Type: api.InstanceTypeContainer,
+		InstancePut: api.InstancePut{
+			Devices: map[string]map[string]string{
+				"eth0": {"type": "nic", "network": "incusbr0"},
+			},
+		},
+	}
+
+	err = project.AllowInstanceCreation(tx, "p1", req)
+	assert.ErrorContains(t, err, `Instance "c1" in project "p1" has no "limits.ingress" config set on NIC device "eth0"`)
+}
+
+// If the network address limit is reached, creating a new forward or load balancer fails.
+func TestAllowNetworkAddressCreation_AboveLimit(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"limits.network.addresses": "1"})
+	require.NoError(t, err)
+
+	networkID, err := tx.CreateNetwork(ctx, "p1", "ovn0", "", db.NetworkTypeOVN, nil)
+	require.NoError(t, err)
+
+	err = project.AllowNetworkAddressCreation(tx, "p1")
+	require.NoError(t, err)
+
+	_, err = cluster.CreateNetworkForward(ctx, tx.Tx(), cluster.NetworkForward{NetworkID: networkID, ListenAddress: "192.0.2.1"})
+	require.NoError(t, err)
+
+	err = project.AllowNetworkAddressCreation(tx, "p1")
+	assert.EqualError(t, err, `Reached maximum number of network addresses in project "p1"`)
+}
+
 // If a direct targeting is blocked, the check fails.
 func TestCheckClusterTargetRestriction_RestrictedTrue(t *testing.T) {
 	tx, cleanup := db.NewTestClusterTx(t)
@@ -274,3 +353,18 @@ func TestCheckClusterTargetRestriction_RestrictedFalse(t *testing.T) {
 	err = project.CheckClusterTargetRestriction(authorizer, req, p, "n1")
 	assert.NoError(t, err)
 }
+
+// Snapshots can only be deleted once they reached the minimum age of the project protection policy.
+func TestAllowSnapshotDeletion(t *testing.T) {
+	p := &api.Project{Name: "p1", ProjectPut: api.ProjectPut{Config: map[string]string{"protection.snapshots.min_age": "7d"}}}
+
+	err := project.AllowSnapshotDeletion(p, time.Now().Add(-6*24*time.Hour))
+	assert.True(t, api.StatusErrorCheck(err, http.StatusForbidden))
+
+	err = project.AllowSnapshotDeletion(p, time.Now().Add(-8*24*time.Hour))
+	assert.NoError(t, err)
+
+	p.Config = map[string]string{}
+	err = project.AllowSnapshotDeletion(p, time.Now())
+	assert.NoError(t, err)
+}
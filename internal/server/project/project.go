@@ -375,3 +375,32 @@ func NetworkZoneProjectFromRecord(p *api.Project) string {
 
 	return api.ProjectDefaultName
 }
+
+// Types of privileged operations which can be made to require an approval.
+const (
+	// ApprovalInstancesDeleteProtected is the deletion of an instance protected by security.protection.delete.
+	ApprovalInstancesDeleteProtected = "instances.delete-protected"
+
+	// ApprovalInstancesPrivileged is the creation or update of an instance making it privileged.
+	ApprovalInstancesPrivileged = "instances.privileged"
+
+	// ApprovalProjectsRestrictions is a change to the restrictions or approvals of a project.
+	ApprovalProjectsRestrictions = "projects.restrictions"
+)
+
+// ApprovalTypes lists all the types of privileged operations which can be made to require an approval.
+var ApprovalTypes = []string{
+	ApprovalInstancesDeleteProtected,
+	ApprovalInstancesPrivileged,
+	ApprovalProjectsRestrictions,
+}
+
+// ApprovalRequired returns whether the given type of privileged operation must be approved in a project.
+func ApprovalRequired(projectConfig map[string]string, approvalType string) bool {
+	return slices.Contains(util.SplitNTrimSpace(projectConfig["approvals.required"], ",", -1, true), approvalType)
+}
+
+// IsRestrictionConfigKey returns whether a project config key is part of the project restrictions or approvals.
+func IsRestrictionConfigKey(key string) bool {
+	return key == "restricted" || strings.HasPrefix(key, "restricted.") || strings.HasPrefix(key, "approvals.")
+}
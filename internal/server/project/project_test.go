@@ -45,3 +45,15 @@ func ExampleStorageVolume() {
 	// Output: default_test
 	// project_name_test1
 }
+
+func ExampleApprovalRequired() {
+	config := map[string]string{"approvals.required": "instances.privileged, projects.restrictions"}
+
+	fmt.Println(project.ApprovalRequired(config, project.ApprovalInstancesPrivileged))
+	fmt.Println(project.ApprovalRequired(config, project.ApprovalInstancesDeleteProtected))
+	fmt.Println(project.ApprovalRequired(nil, project.ApprovalProjectsRestrictions))
+
+	// Output: true
+	// false
+	// false
+}
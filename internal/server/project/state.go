@@ -49,6 +49,8 @@ func GetCurrentAllocations(ctx context.Context, tx *db.ClusterTx, projectName st
 	result["cpu"] = raw["limits.cpu"]
 	result["disk"] = raw["limits.disk"]
 	result["memory"] = raw["limits.memory"]
+	result["network.egress"] = raw["limits.network.egress"]
+	result["network.ingress"] = raw["limits.network.ingress"]
 	result["networks"] = raw["limits.networks"]
 	result["processes"] = raw["limits.processes"]
 
@@ -110,5 +112,16 @@ func GetCurrentAllocations(ctx context.Context, tx *db.ClusterTx, projectName st
 		Usage: int64(len(networks[projectName])),
 	}
 
+	// Get the network address limit and usage.
+	count, limit, err = getNetworkAddressCountLimit(ctx, tx, &info.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	result["network.addresses"] = api.ProjectStateResource{
+		Limit: int64(limit),
+		Usage: int64(count),
+	}
+
 	return result, nil
 }
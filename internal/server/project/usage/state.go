@@ -0,0 +1,57 @@
+package usage
+
+import (
+	"sync"
+)
+
+// Instance identifies the instance usage is accounted to.
+type Instance struct {
+	Project string
+	Name    string
+}
+
+var (
+	statesMu sync.Mutex
+	previous = map[int]Sample{}
+	pending  = map[Instance]Usage{}
+)
+
+// Record records a new sample for an instance and accounts the usage since its previous sample.
+func Record(instID int, inst Instance, sample Sample) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	last, ok := previous[instID]
+	previous[instID] = sample
+	if !ok {
+		return
+	}
+
+	usage := pending[inst]
+	usage.Add(Between(last, sample))
+	pending[inst] = usage
+}
+
+// Flush returns the usage accounted since the previous flush and resets it.
+func Flush() map[Instance]Usage {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	flushed := pending
+	pending = map[Instance]Usage{}
+
+	return flushed
+}
+
+// Prune drops the previous sample of all instances which aren't in keep.
+// The usage already accounted to them is kept until the next flush.
+func Prune(keep map[int]bool) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	for instID := range previous {
+		if !keep[instID] {
+			delete(previous, instID)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package usage
+
+import (
+	"time"
+)
+
+// gib is the number of bytes in a GiB.
+const gib = 1024 * 1024 * 1024
+
+// Sample is a point-in-time reading of the resource usage of an instance.
+type Sample struct {
+	Time time.Time
+
+	// CPUTime is the cumulative CPU time of the instance in nanoseconds.
+	CPUTime int64
+
+	// Memory and Disk are the current memory and disk usage in bytes.
+	Memory int64
+	Disk   int64
+
+	// NetworkReceived and NetworkSent are the cumulative bytes transferred by all the NICs of the instance.
+	NetworkReceived int64
+	NetworkSent     int64
+}
+
+// Usage is the resource consumption of an instance over a period of time.
+type Usage struct {
+	CPUSeconds           float64
+	MemoryGiBHours       float64
+	DiskGiBHours         float64
+	NetworkReceivedBytes int64
+	NetworkSentBytes     int64
+}
+
+// Add adds other to the usage.
+func (u *Usage) Add(other Usage) {
+	u.CPUSeconds += other.CPUSeconds
+	u.MemoryGiBHours += other.MemoryGiBHours
+	u.DiskGiBHours += other.DiskGiBHours
+	u.NetworkReceivedBytes += other.NetworkReceivedBytes
+	u.NetworkSentBytes += other.NetworkSentBytes
+}
+
+// IsZero returns whether nothing was consumed.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// Between returns the usage of an instance between two of its samples.
+// Cumulative counters which went backwards are assumed to have been reset in between.
+func Between(previous Sample, current Sample) Usage {
+	elapsed := current.Time.Sub(previous.Time)
+	if elapsed <= 0 {
+		return Usage{}
+	}
+
+	hours := elapsed.Hours()
+
+	return Usage{
+		CPUSeconds:           float64(counterDelta(previous.CPUTime, current.CPUTime)) / float64(time.Second),
+		MemoryGiBHours:       float64(previous.Memory+current.Memory) / 2 / gib * hours,
+		DiskGiBHours:         float64(previous.Disk+current.Disk) / 2 / gib * hours,
+		NetworkReceivedBytes: counterDelta(previous.NetworkReceived, current.NetworkReceived),
+		NetworkSentBytes:     counterDelta(previous.NetworkSent, current.NetworkSent),
+	}
+}
+
+func counterDelta(previous int64, current int64) int64 {
+	if current < previous {
+		return current
+	}
+
+	return current - previous
+}
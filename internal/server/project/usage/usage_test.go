@@ -0,0 +1,57 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBetween(t *testing.T) {
+	now := time.Now()
+
+	previous := Sample{Time: now, CPUTime: int64(10 * time.Second), Memory: gib, Disk: 4 * gib, NetworkReceived: 1000, NetworkSent: 500}
+	current := Sample{Time: now.Add(30 * time.Minute), CPUTime: int64(70 * time.Second), Memory: 3 * gib, Disk: 4 * gib, NetworkReceived: 3000, NetworkSent: 600}
+
+	assert.Equal(t, Usage{
+		CPUSeconds:           60,
+		MemoryGiBHours:       1,
+		DiskGiBHours:         2,
+		NetworkReceivedBytes: 2000,
+		NetworkSentBytes:     100,
+	}, Between(previous, current))
+
+	// Counters reset by a restart of the instance.
+	current.CPUTime = int64(5 * time.Second)
+	current.NetworkReceived = 200
+	usage := Between(previous, current)
+	assert.Equal(t, float64(5), usage.CPUSeconds)
+	assert.Equal(t, int64(200), usage.NetworkReceivedBytes)
+
+	// Samples out of order.
+	assert.True(t, Between(current, previous).IsZero())
+}
+
+func TestRecordFlush(t *testing.T) {
+	now := time.Now()
+	inst := Instance{Project: "default", Name: "c1"}
+
+	Record(1, inst, Sample{Time: now, CPUTime: 0})
+	require.Empty(t, Flush())
+
+	Record(1, inst, Sample{Time: now.Add(time.Minute), CPUTime: int64(2 * time.Second)})
+	Record(1, inst, Sample{Time: now.Add(2 * time.Minute), CPUTime: int64(5 * time.Second)})
+
+	// Usage accounted to stopped instances is kept until flushed.
+	Prune(map[int]bool{})
+
+	flushed := Flush()
+	require.Len(t, flushed, 1)
+	assert.Equal(t, float64(5), flushed[inst].CPUSeconds)
+	assert.Empty(t, Flush())
+
+	// The next sample after pruning only sets a new baseline.
+	Record(1, inst, Sample{Time: now.Add(3 * time.Minute), CPUTime: int64(time.Second)})
+	assert.Empty(t, Flush())
+}
@@ -255,6 +255,177 @@ func (b *backend) GetResources() (*api.ResourcesStoragePool, error) {
 	return b.driver.GetResources()
 }
 
+// Health returns the health of the pool.
+func (b *backend) Health() (*api.StoragePoolHealth, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("Health started")
+	defer l.Debug("Health finished")
+
+	if b.Status() == api.StoragePoolStatusPending {
+		return nil, errors.New("The pool is in pending state")
+	}
+
+	return b.driver.Health()
+}
+
+// Scrub starts a scrub of the pool.
+func (b *backend) Scrub(op *operations.Operation) error {
+	l := b.logger.AddContext(nil)
+	l.Debug("Scrub started")
+	defer l.Debug("Scrub finished")
+
+	if b.Status() == api.StoragePoolStatusPending {
+		return errors.New("The pool is in pending state")
+	}
+
+	return b.driver.Scrub(op)
+}
+
+// Reclaim releases the unused space of the pool's volumes back to the pool and returns the number of bytes reclaimed.
+// Block volumes in use by a running instance are skipped, as are custom volumes on remote pools.
+func (b *backend) Reclaim(op *operations.Operation) (int64, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("Reclaim started")
+	defer l.Debug("Reclaim finished")
+
+	if b.Status() == api.StoragePoolStatusPending {
+		return -1, errors.New("The pool is in pending state")
+	}
+
+	if !b.driver.Info().Reclaim {
+		return -1, drivers.ErrNotSupported
+	}
+
+	var dbVolumes []*db.StorageVolume
+
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		dbVolumes, err = tx.GetStoragePoolVolumes(ctx, b.ID(), true)
+
+		return err
+	})
+	if err != nil {
+		return -1, fmt.Errorf("Failed loading storage volumes: %w", err)
+	}
+
+	var reclaimed int64
+
+	for _, dbVol := range dbVolumes {
+		if internalInstance.IsSnapshot(dbVol.Name) {
+			continue
+		}
+
+		vol, err := b.reclaimableVolume(dbVol)
+		if err != nil {
+			l.Warn("Failed checking volume for reclaim", logger.Ctx{"project": dbVol.Project, "volName": dbVol.Name, "err": err})
+			continue
+		}
+
+		if vol == nil {
+			continue
+		}
+
+		volReclaimed, err := b.driver.ReclaimVolume(*vol, op)
+		if err != nil {
+			if !errors.Is(err, drivers.ErrNotSupported) {
+				l.Warn("Failed reclaiming volume space", logger.Ctx{"project": dbVol.Project, "volName": dbVol.Name, "err": err})
+			}
+
+			continue
+		}
+
+		l.Debug("Reclaimed volume space", logger.Ctx{"project": dbVol.Project, "volName": dbVol.Name, "bytes": volReclaimed})
+		reclaimed += volReclaimed
+	}
+
+	return reclaimed, nil
+}
+
+// reclaimableVolume returns the volume to reclaim the space of, or nil if it must be skipped.
+func (b *backend) reclaimableVolume(dbVol *db.StorageVolume) (*drivers.Volume, error) {
+	volDBType, err := VolumeTypeNameToDBType(dbVol.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	volType, err := VolumeDBTypeToType(volDBType)
+	if err != nil {
+		return nil, err
+	}
+
+	contentDBType, err := VolumeContentTypeNameToContentType(dbVol.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, err := VolumeDBContentTypeToContentType(contentDBType)
+	if err != nil {
+		return nil, err
+	}
+
+	var volStorageName string
+
+	switch volType {
+	case drivers.VolumeTypeContainer, drivers.VolumeTypeVM:
+		inst, err := instance.LoadByProjectAndName(b.state, dbVol.Project, dbVol.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Volumes on remote pools are handled by the server running the instance.
+		if inst.Location() != "" && inst.Location() != b.state.ServerName {
+			return nil, nil
+		}
+
+		// The disk of a running virtual machine can't be modified underneath it.
+		if volType == drivers.VolumeTypeVM && inst.IsRunning() {
+			return nil, nil
+		}
+
+		volStorageName = project.Instance(dbVol.Project, dbVol.Name)
+
+	case drivers.VolumeTypeCustom:
+		// There is no single server responsible for custom volumes on remote pools.
+		if b.driver.Info().Remote {
+			return nil, nil
+		}
+
+		if contentType == drivers.ContentTypeBlock {
+			inUse := false
+
+			err = VolumeUsedByInstanceDevices(b.state, b.name, dbVol.Project, &dbVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+				inst, err := instance.Load(b.state, dbInst, project)
+				if err != nil {
+					return err
+				}
+
+				if inst.IsRunning() {
+					inUse = true
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if inUse {
+				return nil, nil
+			}
+		}
+
+		volStorageName = project.StorageVolume(dbVol.Project, dbVol.Name)
+
+	default:
+		return nil, nil
+	}
+
+	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
+
+	return &vol, nil
+}
+
 // IsUsed returns whether the storage pool is used by any volumes or profiles (excluding image volumes).
 func (b *backend) IsUsed() (bool, error) {
 	usedBy, err := UsedBy(context.TODO(), b.state, b, true, true, db.StoragePoolVolumeTypeNameImage)
@@ -4453,6 +4624,24 @@ func generateLocalBucketKey(accessKey, secretKey string) (*drivers.S3Credentials
 	return &drivers.S3Credentials{AccessKey: accessKey, SecretKey: secretKey}, nil
 }
 
+// validateBucketKeyPrefix validates the object key prefix a bucket key is restricted to.
+// Prefixes are enforced by the built-in S3 server and so only available on local storage pools.
+func (b *backend) validateBucketKeyPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+
+	if b.Driver().Info().Remote {
+		return errors.New("Bucket key prefixes are only supported on local storage pools")
+	}
+
+	if strings.HasPrefix(prefix, "/") {
+		return errors.New("Bucket key prefix cannot start with a slash")
+	}
+
+	return nil
+}
+
 // initLocalBucketLayout mounts the bucket volume and ensures the data/
 // directory exists so that the in-process S3 handler can serve writes
 // against it.
@@ -4703,6 +4892,11 @@ func (b *backend) CreateBucketKey(projectName string, bucketName string, key api
 		return nil, err
 	}
 
+	err = b.validateBucketKeyPrefix(key.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
 	var newCreds *drivers.S3Credentials
 
 	if memberSpecific {
@@ -4732,6 +4926,7 @@ func (b *backend) CreateBucketKey(projectName string, bucketName string, key api
 			Role:        key.Role,
 			AccessKey:   key.AccessKey,
 			SecretKey:   key.SecretKey,
+			Prefix:      key.Prefix,
 		},
 	}
 
@@ -4817,6 +5012,11 @@ func (b *backend) UpdateBucketKey(projectName string, bucketName string, keyName
 		return err
 	}
 
+	err = b.validateBucketKeyPrefix(key.Prefix)
+	if err != nil {
+		return err
+	}
+
 	if memberSpecific {
 		// For local buckets the key fields are stored only in the DB;
 		// generate any missing values here.
@@ -5941,6 +6141,48 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 		}
 	}
 
+	// Re-apply the I/O limits on the running instances using the volume.
+	_, iopsChanged := changedConfig["limits.iops"]
+	_, bandwidthChanged := changedConfig["limits.bandwidth"]
+	if iopsChanged || bandwidthChanged {
+		err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+			c, err := ConnectIfInstanceIsRemote(b.state, dbInst.Project, dbInst.Name, nil)
+			if err != nil {
+				return err
+			}
+
+			if c != nil {
+				// Send a remote notification.
+				uri := fmt.Sprintf("/internal/instances/%d/onreload?devices=%s", dbInst.ID, url.QueryEscape(strings.Join(usedByDevices, ",")))
+				_, _, err := c.RawQuery("GET", uri, nil, "")
+
+				return err
+			}
+
+			// Update the local instance.
+			inst, err := instance.Load(b.state, dbInst, project)
+			if err != nil {
+				return err
+			}
+
+			if !inst.IsRunning() {
+				return nil
+			}
+
+			for _, devName := range usedByDevices {
+				err = inst.ReloadDevice(devName)
+				if err != nil {
+					return fmt.Errorf("Failed applying limits to device %q of instance %q in project %q: %w", devName, inst.Name(), inst.Project().Name, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), projectName, op, nil))
 
 	return nil
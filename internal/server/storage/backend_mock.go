@@ -82,6 +82,21 @@ func (b *mockBackend) GetResources() (*api.ResourcesStoragePool, error) {
 	return nil, nil
 }
 
+// Health returns the health of the storage pool.
+func (b *mockBackend) Health() (*api.StoragePoolHealth, error) {
+	return nil, nil
+}
+
+// Scrub starts a scrub of the storage pool.
+func (b *mockBackend) Scrub(op *operations.Operation) error {
+	return nil
+}
+
+// Reclaim releases the unused space of the pool's volumes.
+func (b *mockBackend) Reclaim(op *operations.Operation) (int64, error) {
+	return 0, nil
+}
+
 // IsUsed returns whether the storage pool is in use.
 func (b *mockBackend) IsUsed() (bool, error) {
 	return false, nil
@@ -86,6 +86,7 @@ func (d *btrfs) Info() Info {
 		IOUring:                      true,
 		MountedRoot:                  true,
 		Buckets:                      true,
+		Reclaim:                      true,
 	}
 }
 
@@ -308,6 +309,14 @@ func (d *btrfs) Delete(op *operations.Operation) error {
 
 // Validate checks that all provide keys are supported and that no conflicting or missing configuration is present.
 func (d *btrfs) Validate(config map[string]string) error {
+	// gendoc:generate(entity=storage_btrfs, group=common, key=reclaim.auto)
+	//
+	// ---
+	//  type: bool
+	//  scope: global
+	//  default: `true`
+	//  shortdesc: Whether to reclaim the unused space of the volumes daily
+
 	// gendoc:generate(entity=storage_btrfs, group=common, key=source)
 	//
 	// ---
@@ -511,6 +520,58 @@ func (d *btrfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return genericVFSGetResources(d)
 }
 
+// Health returns the health of the pool.
+func (d *btrfs) Health() (*api.StoragePoolHealth, error) {
+	poolMntPath := GetPoolMountPath(d.name)
+
+	health := &api.StoragePoolHealth{
+		Status:   api.StoragePoolHealthStatusHealthy,
+		Messages: []string{},
+	}
+
+	out, err := subprocess.RunCommand("btrfs", "device", "stats", poolMntPath)
+	if err != nil {
+		return nil, err
+	}
+
+	health.Devices = btrfsParseDeviceStats(out)
+	for _, device := range health.Devices {
+		if device.Status != api.StoragePoolHealthStatusHealthy {
+			health.Status = api.StoragePoolHealthStatusDegraded
+			health.Messages = append(health.Messages, fmt.Sprintf("Device %q has recorded I/O or corruption errors", device.Name))
+		}
+	}
+
+	out, err = subprocess.RunCommand("btrfs", "filesystem", "show", poolMntPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(out, "Some devices missing") {
+		health.Status = api.StoragePoolHealthStatusDegraded
+		health.Messages = append(health.Messages, "Some devices are missing from the filesystem")
+	}
+
+	out, err = subprocess.RunCommand("btrfs", "scrub", "status", poolMntPath)
+	if err != nil {
+		return nil, err
+	}
+
+	health.Scrub = btrfsParseScrubStatus(out)
+
+	return health, nil
+}
+
+// Scrub starts a scrub of the pool.
+func (d *btrfs) Scrub(op *operations.Operation) error {
+	_, err := subprocess.RunCommand("btrfs", "scrub", "start", GetPoolMountPath(d.name))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MigrationTypes returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *btrfs) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
 	var rsyncFeatures []string
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/google/uuid"
@@ -660,3 +661,121 @@ func (d *btrfs) receiveSubVolume(r io.Reader, receivePath string, tracker *iopro
 
 	return subVolPath, nil
 }
+
+// btrfsParseDeviceStats parses the output of "btrfs device stats" into per-device health.
+func btrfsParseDeviceStats(output string) []api.StoragePoolHealthDevice {
+	devices := []api.StoragePoolHealthDevice{}
+	indexes := map[string]int{}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[0], "[") {
+			continue
+		}
+
+		name, counter, found := strings.Cut(strings.TrimPrefix(fields[0], "["), "].")
+		if !found {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		idx, ok := indexes[name]
+		if !ok {
+			devices = append(devices, api.StoragePoolHealthDevice{Name: name, Status: api.StoragePoolHealthStatusHealthy})
+			idx = len(devices) - 1
+			indexes[name] = idx
+		}
+
+		switch counter {
+		case "read_io_errs":
+			devices[idx].ReadErrors += value
+		case "write_io_errs", "flush_io_errs":
+			devices[idx].WriteErrors += value
+		case "corruption_errs", "generation_errs":
+			devices[idx].ChecksumErrors += value
+		}
+	}
+
+	for i, device := range devices {
+		if device.ReadErrors+device.WriteErrors+device.ChecksumErrors > 0 {
+			devices[i].Status = api.StoragePoolHealthStatusDegraded
+		}
+	}
+
+	return devices
+}
+
+// btrfsParseScrubStatus parses the output of "btrfs scrub status".
+func btrfsParseScrubStatus(output string) *api.StoragePoolHealthScrub {
+	scrub := &api.StoragePoolHealthScrub{Status: api.StoragePoolScrubStatusNone}
+
+	var duration time.Duration
+
+	for line := range strings.SplitSeq(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(key) {
+		case "Scrub started":
+			t, err := time.ParseInLocation("Mon Jan _2 15:04:05 2006", value, time.Local)
+			if err == nil {
+				scrub.StartedAt = t
+			}
+
+		case "Status":
+			switch value {
+			case "running":
+				scrub.Status = api.StoragePoolScrubStatusRunning
+			case "finished":
+				scrub.Status = api.StoragePoolScrubStatusFinished
+				scrub.Progress = 100
+			case "aborted", "interrupted":
+				scrub.Status = api.StoragePoolScrubStatusCanceled
+			}
+
+		case "Duration":
+			elapsed, err := parseClockDuration(value)
+			if err == nil {
+				duration = elapsed
+			}
+
+		case "Bytes scrubbed":
+			// Format: 1.23GiB  (45.67%)
+			_, percent, found := strings.Cut(value, "(")
+			if found {
+				progress, err := strconv.ParseFloat(strings.TrimSuffix(percent, "%)"), 64)
+				if err == nil {
+					scrub.Progress = progress
+				}
+			}
+
+		case "Error summary":
+			// Format: "no errors found" or "csum=12 read=1".
+			for field := range strings.FieldsSeq(value) {
+				_, count, found := strings.Cut(field, "=")
+				if !found {
+					continue
+				}
+
+				n, err := strconv.ParseUint(count, 10, 64)
+				if err == nil {
+					scrub.Errors += n
+				}
+			}
+		}
+	}
+
+	if scrub.Status != api.StoragePoolScrubStatusRunning && !scrub.StartedAt.IsZero() {
+		scrub.FinishedAt = scrub.StartedAt.Add(duration)
+	}
+
+	return scrub
+}
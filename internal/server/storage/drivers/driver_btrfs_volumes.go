@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -1076,6 +1077,20 @@ func (d *btrfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.initial.uid` or `0`
 	//  shortdesc: UID of the volume owner in the instance
 
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=limits.bandwidth)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: I/O bandwidth limit in byte/s for each instance using the volume
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=limits.iops)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  shortdesc: I/O operations per second limit for each instance using the volume
+
 	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=security.shared)
 	//
 	// ---
@@ -1148,7 +1163,47 @@ func (d *btrfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage bucket
 
-	return d.validateVolume(vol, d.commonVolumeRules(), removeUnknownKeys)
+	// gendoc:generate(entity=storage_bucket_btrfs, group=common, key=versioning)
+	//
+	// ---
+	//  type: bool
+	//  default: `false`
+	//  shortdesc: Whether to keep previous versions of overwritten and deleted objects
+
+	// gendoc:generate(entity=storage_bucket_btrfs, group=common, key=lifecycle.expiration)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of days after which objects expire
+
+	// gendoc:generate(entity=storage_bucket_btrfs, group=common, key=lifecycle.noncurrent_expiration)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of days after which noncurrent object versions are removed
+
+	// gendoc:generate(entity=storage_bucket_btrfs, group=common, key=lifecycle.noncurrent_versions)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of noncurrent object versions to retain
+
+	// gendoc:generate(entity=storage_bucket_btrfs, group=common, key=security.public_read)
+	//
+	// ---
+	//  type: bool
+	//  default: `false`
+	//  shortdesc: Whether to allow anonymous read access to the bucket
+
+	rules := d.commonVolumeRules()
+	if vol.volType == VolumeTypeBucket {
+		maps.Copy(rules, localBucketRules())
+	}
+
+	return d.validateVolume(vol, rules, removeUnknownKeys)
 }
 
 // UpdateVolume applies config changes to the volume.
@@ -1341,6 +1396,11 @@ func (d *btrfs) GetVolumeDiskPath(vol Volume) (string, error) {
 	return genericVFSGetVolumeDiskPath(vol)
 }
 
+// ReclaimVolume releases the unused space of a volume back to the pool.
+func (d *btrfs) ReclaimVolume(vol Volume, op *operations.Operation) (int64, error) {
+	return genericVFSReclaimVolume(d, vol, op)
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *btrfs) ListVolumes() ([]Volume, error) {
 	return genericVFSListVolumes(d)
@@ -100,6 +100,7 @@ func (d *ceph) Info() Info {
 		DirectIO:                     true,
 		IOUring:                      true,
 		MountedRoot:                  false,
+		Reclaim:                      true,
 	}
 }
 
@@ -314,6 +315,14 @@ func (d *ceph) Delete(op *operations.Operation) error {
 
 // Validate checks that all provide keys are supported and that no conflicting or missing configuration is present.
 func (d *ceph) Validate(config map[string]string) error {
+	// gendoc:generate(entity=storage_ceph, group=common, key=reclaim.auto)
+	//
+	// ---
+	//  type: bool
+	//  scope: global
+	//  default: `true`
+	//  shortdesc: Whether to reclaim the unused space of the volumes daily
+
 	// gendoc:generate(entity=storage_ceph, group=common, key=source)
 	//
 	// ---
@@ -501,6 +510,28 @@ func (d *ceph) GetResources() (*api.ResourcesStoragePool, error) {
 	return &res, nil
 }
 
+// Health returns the health of the pool.
+// Ceph tracks health at the cluster level, so this reflects the state of the whole cluster.
+func (d *ceph) Health() (*api.StoragePoolHealth, error) {
+	return cephGetHealth(d.config["ceph.cluster_name"], d.config["ceph.user.name"])
+}
+
+// Scrub starts a deep scrub of the OSD pool.
+func (d *ceph) Scrub(op *operations.Operation) error {
+	_, err := subprocess.RunCommand("ceph",
+		"--name", EnsureClientPrefix(d.config["ceph.user.name"]),
+		"--cluster", d.config["ceph.cluster_name"],
+		"osd",
+		"pool",
+		"deep-scrub",
+		d.config["ceph.osd.pool_name"])
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MigrationTypes returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *ceph) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
 	var rsyncFeatures []string
@@ -963,6 +963,20 @@ func (d *ceph) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.initial.uid` or `0`
 	//  shortdesc: UID of the volume owner in the instance
 
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=limits.bandwidth)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: I/O bandwidth limit in byte/s for each instance using the volume
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=limits.iops)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  shortdesc: I/O operations per second limit for each instance using the volume
+
 	// gendoc:generate(entity=storage_volume_ceph, group=common, key=security.shared)
 	//
 	// ---
@@ -1273,6 +1287,11 @@ func (d *ceph) GetVolumeDiskPath(vol Volume) (string, error) {
 	return "", ErrNotSupported
 }
 
+// ReclaimVolume releases the unused space of a volume back to the pool.
+func (d *ceph) ReclaimVolume(vol Volume, op *operations.Operation) (int64, error) {
+	return genericVFSReclaimVolume(d, vol, op)
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *ceph) ListVolumes() ([]Volume, error) {
 	vols := make(map[string]Volume)
@@ -569,6 +569,12 @@ func (d *cephfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return genericVFSGetResources(d)
 }
 
+// Health returns the health of the pool.
+// Ceph tracks health at the cluster level, so this reflects the state of the whole cluster.
+func (d *cephfs) Health() (*api.StoragePoolHealth, error) {
+	return cephGetHealth(d.config["cephfs.cluster_name"], d.config["cephfs.user.name"])
+}
+
 // MigrationTypes returns the supported migration types and options supported by the driver.
 func (d *cephfs) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
 	var rsyncFeatures []string
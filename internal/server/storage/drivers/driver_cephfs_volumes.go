@@ -310,6 +310,20 @@ func (d *cephfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.initial.uid` or `0`
 	//  shortdesc: UID of the volume owner in the instance
 
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=limits.bandwidth)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: I/O bandwidth limit in byte/s for each instance using the volume
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=limits.iops)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  shortdesc: I/O operations per second limit for each instance using the volume
+
 	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=security.shared)
 	//
 	// ---
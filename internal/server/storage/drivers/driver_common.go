@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/v7/internal/server/operations"
 	"github.com/lxc/incus/v7/internal/server/project"
 	"github.com/lxc/incus/v7/internal/server/state"
+	"github.com/lxc/incus/v7/shared/api"
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/revert"
 	"github.com/lxc/incus/v7/shared/subprocess"
@@ -343,6 +344,16 @@ func (d *common) moveGPTAltHeader(devPath string) error {
 	return err
 }
 
+// Health returns the health of the pool.
+func (d *common) Health() (*api.StoragePoolHealth, error) {
+	return nil, ErrNotSupported
+}
+
+// Scrub starts a scrub of the pool.
+func (d *common) Scrub(op *operations.Operation) error {
+	return ErrNotSupported
+}
+
 // CreateVolume creates a new storage volume on disk.
 func (d *common) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Operation) error {
 	return ErrNotSupported
@@ -408,6 +419,11 @@ func (d *common) ListVolumes() ([]Volume, error) {
 	return nil, ErrNotSupported
 }
 
+// ReclaimVolume releases the unused space of a volume back to the pool.
+func (d *common) ReclaimVolume(vol Volume, op *operations.Operation) (int64, error) {
+	return 0, ErrNotSupported
+}
+
 // MountVolume sets up the volume for use.
 func (d *common) MountVolume(vol Volume, op *operations.Operation) error {
 	return ErrNotSupported
@@ -40,6 +40,7 @@ func (d *dir) Info() Info {
 		IOUring:                      true,
 		MountedRoot:                  true,
 		Buckets:                      true,
+		Reclaim:                      true,
 	}
 }
 
@@ -106,6 +107,14 @@ func (d *dir) Delete(op *operations.Operation) error {
 
 // Validate checks that all provide keys are supported and that no conflicting or missing configuration is present.
 func (d *dir) Validate(config map[string]string) error {
+	// gendoc:generate(entity=storage_dir, group=common, key=reclaim.auto)
+	//
+	// ---
+	//  type: bool
+	//  scope: global
+	//  default: `true`
+	//  shortdesc: Whether to reclaim the unused space of the volumes daily
+
 	// gendoc:generate(entity=storage_dir, group=common, key=rsync.bwlimit)
 	//
 	// ---
@@ -262,6 +262,20 @@ func (d *dir) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.initial.uid` or `0`
 	//  shortdesc: UID of the volume owner in the instance
 
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=limits.bandwidth)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: I/O bandwidth limit in byte/s for each instance using the volume
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=limits.iops)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  shortdesc: I/O operations per second limit for each instance using the volume
+
 	// gendoc:generate(entity=storage_volume_dir, group=common, key=security.shared)
 	//
 	// ---
@@ -326,7 +340,47 @@ func (d *dir) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.schedule`
 	//  shortdesc: {{snapshot_schedule_format}}
 
-	err := d.validateVolume(vol, nil, removeUnknownKeys)
+	// gendoc:generate(entity=storage_bucket_dir, group=common, key=versioning)
+	//
+	// ---
+	//  type: bool
+	//  default: `false`
+	//  shortdesc: Whether to keep previous versions of overwritten and deleted objects
+
+	// gendoc:generate(entity=storage_bucket_dir, group=common, key=lifecycle.expiration)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of days after which objects expire
+
+	// gendoc:generate(entity=storage_bucket_dir, group=common, key=lifecycle.noncurrent_expiration)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of days after which noncurrent object versions are removed
+
+	// gendoc:generate(entity=storage_bucket_dir, group=common, key=lifecycle.noncurrent_versions)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of noncurrent object versions to retain
+
+	// gendoc:generate(entity=storage_bucket_dir, group=common, key=security.public_read)
+	//
+	// ---
+	//  type: bool
+	//  default: `false`
+	//  shortdesc: Whether to allow anonymous read access to the bucket
+
+	var rules map[string]func(value string) error
+	if vol.volType == VolumeTypeBucket {
+		rules = localBucketRules()
+	}
+
+	err := d.validateVolume(vol, rules, removeUnknownKeys)
 	if err != nil {
 		return err
 	}
@@ -450,6 +504,11 @@ func (d *dir) GetVolumeDiskPath(vol Volume) (string, error) {
 	return genericVFSGetVolumeDiskPath(vol)
 }
 
+// ReclaimVolume releases the unused space of a volume back to the pool.
+func (d *dir) ReclaimVolume(vol Volume, op *operations.Operation) (int64, error) {
+	return genericVFSReclaimVolume(d, vol, op)
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *dir) ListVolumes() ([]Volume, error) {
 	return genericVFSListVolumes(d)
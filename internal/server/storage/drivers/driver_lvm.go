@@ -168,6 +168,7 @@ func (d *lvm) Info() Info {
 		Deactivate:                   d.isRemote(),
 		ZeroUnpack:                   !d.usesThinpool(),
 		TargetFormat:                 targetFormat,
+		Reclaim:                      d.usesThinpool(), // Only thinpool pools get space back from their volumes.
 	}
 }
 
@@ -656,6 +657,15 @@ func (d *lvm) Delete(op *operations.Operation) error {
 
 // Validate checks that all provided keys are supported and that no conflicting or missing config exists.
 func (d *lvm) Validate(config map[string]string) error {
+	// gendoc:generate(entity=storage_lvm, group=common, key=reclaim.auto)
+	//
+	// ---
+	//  type: bool
+	//  condition: thin pool
+	//  scope: global
+	//  default: `true`
+	//  shortdesc: Whether to reclaim the unused space of the volumes daily
+
 	// gendoc:generate(entity=storage_lvm, group=common, key=source)
 	//
 	// ---
@@ -1082,6 +1092,47 @@ func (d *lvm) GetResources() (*api.ResourcesStoragePool, error) {
 	return &res, nil
 }
 
+// Health returns the health of the pool.
+func (d *lvm) Health() (*api.StoragePoolHealth, error) {
+	vgName := d.config["lvm.vg_name"]
+
+	lvsOutput, err := subprocess.RunCommand("lvs", "--noheadings", "--separator", ",", "-o", "lv_name,segtype,lv_health_status,raid_sync_action,sync_percent,raid_mismatch_count", vgName)
+	if err != nil {
+		return nil, err
+	}
+
+	pvsOutput, err := subprocess.RunCommand("pvs", "--noheadings", "--separator", ",", "-o", "pv_name,pv_missing", "--select", "vg_name="+vgName)
+	if err != nil {
+		return nil, err
+	}
+
+	return lvmParseHealth(lvsOutput, pvsOutput), nil
+}
+
+// Scrub starts a scrub of the pool.
+// This triggers a consistency check of all RAID logical volumes in the volume group.
+func (d *lvm) Scrub(op *operations.Operation) error {
+	vgName := d.config["lvm.vg_name"]
+
+	lvNames, err := d.raidLogicalVolumes(vgName)
+	if err != nil {
+		return err
+	}
+
+	if len(lvNames) == 0 {
+		return errors.New("The volume group doesn't contain any RAID logical volumes to scrub")
+	}
+
+	for _, lvName := range lvNames {
+		_, err := subprocess.RunCommand("lvchange", "--syncaction", "check", fmt.Sprintf("%s/%s", vgName, lvName))
+		if err != nil {
+			return fmt.Errorf("Failed starting consistency check of %q: %w", lvName, err)
+		}
+	}
+
+	return nil
+}
+
 // roundVolumeBlockSizeBytes returns sizeBytes rounded up to the next multiple
 // of the volume group extent size.
 func (d *lvm) roundVolumeBlockSizeBytes(vol Volume, sizeBytes int64) (int64, error) {
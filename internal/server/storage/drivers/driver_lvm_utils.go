@@ -1040,3 +1040,109 @@ func (d *lvm) getSourceType(source string) lvmSourceType {
 
 	return lvmSourceTypeUnknown
 }
+
+// raidLogicalVolumes returns the names of the RAID logical volumes in the volume group.
+func (d *lvm) raidLogicalVolumes(vgName string) ([]string, error) {
+	out, err := subprocess.RunCommand("lvs", "--noheadings", "--separator", ",", "-o", "lv_name,segtype", vgName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for line := range strings.SplitSeq(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], "raid") {
+			continue
+		}
+
+		names = append(names, fields[0])
+	}
+
+	return names, nil
+}
+
+// lvmParseHealth parses the output of "lvs -o lv_name,segtype,lv_health_status,raid_sync_action,sync_percent,raid_mismatch_count"
+// and "pvs -o pv_name,pv_missing" into a storage pool health.
+func lvmParseHealth(lvsOutput string, pvsOutput string) *api.StoragePoolHealth {
+	health := &api.StoragePoolHealth{
+		Status:   api.StoragePoolHealthStatusHealthy,
+		Messages: []string{},
+		Devices:  []api.StoragePoolHealthDevice{},
+	}
+
+	setStatus := func(status string) {
+		if health.Status != api.StoragePoolHealthStatusFailed {
+			health.Status = status
+		}
+	}
+
+	for line := range strings.SplitSeq(strings.TrimSpace(pvsOutput), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) != 2 || fields[0] == "" {
+			continue
+		}
+
+		device := api.StoragePoolHealthDevice{Name: fields[0], Status: api.StoragePoolHealthStatusHealthy}
+		if fields[1] == "missing" {
+			device.Status = api.StoragePoolHealthStatusFailed
+			setStatus(api.StoragePoolHealthStatusDegraded)
+			health.Messages = append(health.Messages, fmt.Sprintf("Physical volume %q is missing", device.Name))
+		}
+
+		health.Devices = append(health.Devices, device)
+	}
+
+	var raidCount int
+	var syncTotal float64
+
+	for line := range strings.SplitSeq(strings.TrimSpace(lvsOutput), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) != 6 {
+			continue
+		}
+
+		lvName := fields[0]
+		segType := fields[1]
+		healthStatus := fields[2]
+
+		switch healthStatus {
+		case "":
+		case "failed":
+			setStatus(api.StoragePoolHealthStatusFailed)
+			health.Messages = append(health.Messages, fmt.Sprintf("Logical volume %q has failed", lvName))
+		default:
+			setStatus(api.StoragePoolHealthStatusDegraded)
+			health.Messages = append(health.Messages, fmt.Sprintf("Logical volume %q reports %q", lvName, healthStatus))
+		}
+
+		if !strings.HasPrefix(segType, "raid") {
+			continue
+		}
+
+		if health.Scrub == nil {
+			health.Scrub = &api.StoragePoolHealthScrub{Status: api.StoragePoolScrubStatusFinished}
+		}
+
+		raidCount++
+
+		syncPercent, err := strconv.ParseFloat(fields[4], 64)
+		if err == nil {
+			syncTotal += syncPercent
+		}
+
+		if fields[3] == "check" || fields[3] == "repair" {
+			health.Scrub.Status = api.StoragePoolScrubStatusRunning
+		}
+
+		mismatches, err := strconv.ParseUint(fields[5], 10, 64)
+		if err == nil {
+			health.Scrub.Errors += mismatches
+		}
+	}
+
+	if health.Scrub != nil && raidCount > 0 {
+		health.Scrub.Progress = syncTotal / float64(raidCount)
+	}
+
+	return health
+}
@@ -78,6 +78,16 @@ func (d *mock) GetResources() (*api.ResourcesStoragePool, error) {
 	return nil, nil
 }
 
+// Health returns the health of the pool.
+func (d *mock) Health() (*api.StoragePoolHealth, error) {
+	return &api.StoragePoolHealth{Status: api.StoragePoolHealthStatusHealthy}, nil
+}
+
+// Scrub starts a scrub of the pool.
+func (d *mock) Scrub(op *operations.Operation) error {
+	return nil
+}
+
 // CreateVolume creates an empty volume and can optionally fill it by executing the supplied filler function.
 func (d *mock) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Operation) error {
 	return nil
@@ -156,6 +166,11 @@ func (d *mock) ListVolumes() ([]Volume, error) {
 	return nil, nil
 }
 
+// ReclaimVolume simulates reclaiming the unused space of a volume.
+func (d *mock) ReclaimVolume(vol Volume, op *operations.Operation) (int64, error) {
+	return 0, nil
+}
+
 // MountVolume simulates mounting a volume.
 func (d *mock) MountVolume(vol Volume, op *operations.Operation) error {
 	return nil
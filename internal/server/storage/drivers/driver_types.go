@@ -22,6 +22,7 @@ type Info struct {
 	Deactivate                   bool         // Whether an unmount action is required prior to removing the pool.
 	ZeroUnpack                   bool         // Whether to write zeroes (no discard) during unpacking.
 	TargetFormat                 string       // Whether the output image format should be raw or qcow2.
+	Reclaim                      bool         // Whether the driver can release the unused space of volumes back to the pool.
 }
 
 // VolumeFiller provides a struct for filling a volume.
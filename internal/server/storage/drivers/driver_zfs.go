@@ -128,6 +128,7 @@ func (d *zfs) Info() Info {
 		DirectIO:                     true,
 		MountedRoot:                  false,
 		Buckets:                      true,
+		Reclaim:                      true,
 	}
 
 	return info
@@ -515,6 +516,14 @@ func (d *zfs) Delete(op *operations.Operation) error {
 
 // Validate checks that all provide keys are supported and that no conflicting or missing configuration is present.
 func (d *zfs) Validate(config map[string]string) error {
+	// gendoc:generate(entity=storage_zfs, group=common, key=reclaim.auto)
+	//
+	// ---
+	//  type: bool
+	//  scope: global
+	//  default: `true`
+	//  shortdesc: Whether to reclaim the unused space of the volumes daily
+
 	// gendoc:generate(entity=storage_zfs, group=common, key=source)
 	//
 	// ---
@@ -766,6 +775,30 @@ func (d *zfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return &res, nil
 }
 
+// Health returns the health of the pool.
+func (d *zfs) Health() (*api.StoragePoolHealth, error) {
+	poolName, _, _ := strings.Cut(d.config["zfs.pool_name"], "/")
+
+	out, err := subprocess.RunCommand("zpool", "status", "-p", poolName)
+	if err != nil {
+		return nil, err
+	}
+
+	return zfsParsePoolStatus(poolName, out), nil
+}
+
+// Scrub starts a scrub of the pool.
+func (d *zfs) Scrub(op *operations.Operation) error {
+	poolName, _, _ := strings.Cut(d.config["zfs.pool_name"], "/")
+
+	_, err := subprocess.RunCommand("zpool", "scrub", poolName)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MigrationTypes returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *zfs) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
 	var rsyncFeatures []string
@@ -9,7 +9,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -505,3 +507,172 @@ func (d *zfs) delegateDataset(vol Volume, pid int) error {
 func ZFSSupportsDelegation() bool {
 	return zfsDelegate
 }
+
+// zfsHealthStatus converts a ZFS vdev or pool state into a storage pool health status.
+func zfsHealthStatus(state string) string {
+	switch state {
+	case "ONLINE", "AVAIL", "INUSE":
+		return api.StoragePoolHealthStatusHealthy
+	case "DEGRADED":
+		return api.StoragePoolHealthStatusDegraded
+	case "FAULTED", "OFFLINE", "REMOVED", "UNAVAIL", "SUSPENDED":
+		return api.StoragePoolHealthStatusFailed
+	}
+
+	return api.StoragePoolHealthStatusUnknown
+}
+
+// zfsParseScan parses the "scan:" section of "zpool status".
+func zfsParseScan(scan string) *api.StoragePoolHealthScrub {
+	// ZFS uses the ctime format for dates.
+	parseTime := func(value string) time.Time {
+		t, err := time.ParseInLocation("Mon Jan _2 15:04:05 2006", strings.TrimSpace(value), time.Local)
+		if err != nil {
+			return time.Time{}
+		}
+
+		return t
+	}
+
+	scrub := &api.StoragePoolHealthScrub{Status: api.StoragePoolScrubStatusNone}
+
+	firstLine, rest, _ := strings.Cut(scan, "\n")
+	switch {
+	case strings.HasPrefix(firstLine, "scrub in progress since "):
+		scrub.Status = api.StoragePoolScrubStatusRunning
+		scrub.StartedAt = parseTime(strings.TrimPrefix(firstLine, "scrub in progress since "))
+
+		// Look for the "xx.xx% done" progress marker.
+		for field := range strings.SplitSeq(rest, ",") {
+			value, found := strings.CutSuffix(strings.TrimSpace(field), "% done")
+			if !found {
+				continue
+			}
+
+			progress, err := strconv.ParseFloat(value, 64)
+			if err == nil {
+				scrub.Progress = progress
+			}
+		}
+
+	case strings.HasPrefix(firstLine, "scrub repaired "):
+		// Format: scrub repaired 0B in 00:00:01 with 0 errors on Sun Oct 18 14:00:00 2026
+		scrub.Status = api.StoragePoolScrubStatusFinished
+		scrub.Progress = 100
+
+		_, after, found := strings.Cut(firstLine, " with ")
+		if found {
+			errCount, finished, _ := strings.Cut(after, " errors on ")
+			count, err := strconv.ParseUint(errCount, 10, 64)
+			if err == nil {
+				scrub.Errors = count
+			}
+
+			scrub.FinishedAt = parseTime(finished)
+		}
+
+		_, duration, found := strings.Cut(firstLine, " in ")
+		if found && !scrub.FinishedAt.IsZero() {
+			duration, _, _ = strings.Cut(duration, " with ")
+
+			elapsed, err := parseClockDuration(duration)
+			if err == nil {
+				scrub.StartedAt = scrub.FinishedAt.Add(-elapsed)
+			}
+		}
+
+	case strings.HasPrefix(firstLine, "scrub canceled on "):
+		scrub.Status = api.StoragePoolScrubStatusCanceled
+		scrub.FinishedAt = parseTime(strings.TrimPrefix(firstLine, "scrub canceled on "))
+	}
+
+	return scrub
+}
+
+// zfsParsePoolStatus parses the output of "zpool status -p" into a storage pool health.
+func zfsParsePoolStatus(poolName string, output string) *api.StoragePoolHealth {
+	health := &api.StoragePoolHealth{
+		Status:   api.StoragePoolHealthStatusUnknown,
+		Messages: []string{},
+		Devices:  []api.StoragePoolHealthDevice{},
+	}
+
+	// Split the output into its sections, continuation lines are indented.
+	sections := map[string]string{}
+	var section string
+	var inConfig bool
+
+	for line := range strings.SplitSeq(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		if found && !strings.HasPrefix(line, "\t") && key != "" && !strings.Contains(key, " ") {
+			section = key
+			sections[section] = strings.TrimSpace(value)
+			inConfig = section == "config"
+			continue
+		}
+
+		if !inConfig {
+			if section != "" && strings.TrimSpace(line) != "" {
+				sections[section] = sections[section] + "\n" + strings.TrimSpace(line)
+			}
+
+			continue
+		}
+
+		// Parse the device table.
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] == "NAME" {
+			continue
+		}
+
+		if fields[0] == poolName {
+			health.Status = zfsHealthStatus(fields[1])
+			continue
+		}
+
+		device := api.StoragePoolHealthDevice{
+			Name:   fields[0],
+			Status: zfsHealthStatus(fields[1]),
+		}
+
+		device.ReadErrors, _ = strconv.ParseUint(fields[2], 10, 64)
+		device.WriteErrors, _ = strconv.ParseUint(fields[3], 10, 64)
+		device.ChecksumErrors, _ = strconv.ParseUint(fields[4], 10, 64)
+
+		if device.Status == api.StoragePoolHealthStatusHealthy && device.ReadErrors+device.WriteErrors+device.ChecksumErrors > 0 {
+			device.Status = api.StoragePoolHealthStatusDegraded
+		}
+
+		health.Devices = append(health.Devices, device)
+	}
+
+	// Fallback to the state line if the pool wasn't found in the device table.
+	if health.Status == api.StoragePoolHealthStatusUnknown && sections["state"] != "" {
+		health.Status = zfsHealthStatus(sections["state"])
+	}
+
+	// Errors on a device of an otherwise online pool still indicate a problem.
+	if health.Status == api.StoragePoolHealthStatusHealthy {
+		for _, device := range health.Devices {
+			if device.Status != api.StoragePoolHealthStatusHealthy {
+				health.Status = api.StoragePoolHealthStatusDegraded
+				break
+			}
+		}
+	}
+
+	if sections["status"] != "" {
+		health.Messages = append(health.Messages, strings.ReplaceAll(sections["status"], "\n", " "))
+	}
+
+	if sections["errors"] != "" && sections["errors"] != "No known data errors" {
+		health.Messages = append(health.Messages, strings.ReplaceAll(sections["errors"], "\n", " "))
+	}
+
+	if sections["scan"] != "" {
+		health.Scrub = zfsParseScan(sections["scan"])
+	}
+
+	return health
+}
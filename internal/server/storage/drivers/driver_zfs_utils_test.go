@@ -0,0 +1,107 @@
+package drivers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+func Test_zfsParsePoolStatus(t *testing.T) {
+	degraded := `  pool: tank
+ state: DEGRADED
+status: One or more devices could not be used because the label is missing or
+	invalid.  Sufficient replicas exist for the pool to continue
+	functioning in a degraded state.
+action: Replace the device using 'zpool replace'.
+   see: https://openzfs.github.io/openzfs-docs/msg/ZFS-8000-4J
+  scan: scrub repaired 0B in 01:02:03 with 2 errors on Sun Oct 18 14:00:00 2026
+config:
+
+	NAME        STATE     READ WRITE CKSUM
+	tank        DEGRADED     0     0     0
+	  mirror-0  DEGRADED     0     0     0
+	    sdb     ONLINE       0     0     3
+	    sdc     UNAVAIL      0     0     0  corrupted data
+
+errors: No known data errors
+`
+
+	health := zfsParsePoolStatus("tank", degraded)
+	assert.Equal(t, api.StoragePoolHealthStatusDegraded, health.Status)
+	assert.Len(t, health.Messages, 1)
+	assert.Len(t, health.Devices, 3)
+	assert.Equal(t, "sdb", health.Devices[1].Name)
+	assert.Equal(t, api.StoragePoolHealthStatusDegraded, health.Devices[1].Status)
+	assert.Equal(t, uint64(3), health.Devices[1].ChecksumErrors)
+	assert.Equal(t, api.StoragePoolHealthStatusFailed, health.Devices[2].Status)
+
+	assert.NotNil(t, health.Scrub)
+	assert.Equal(t, api.StoragePoolScrubStatusFinished, health.Scrub.Status)
+	assert.Equal(t, uint64(2), health.Scrub.Errors)
+	assert.Equal(t, time.Date(2026, time.October, 18, 14, 0, 0, 0, time.Local), health.Scrub.FinishedAt)
+	assert.Equal(t, time.Date(2026, time.October, 18, 12, 57, 57, 0, time.Local), health.Scrub.StartedAt)
+
+	running := `  pool: tank
+ state: ONLINE
+  scan: scrub in progress since Sun Oct 18 14:00:00 2026
+	1.23G scanned at 100M/s, 512M issued at 50M/s, 2.00G total
+	0B repaired, 25.00% done, 00:00:30 to go
+config:
+
+	NAME        STATE     READ WRITE CKSUM
+	tank        ONLINE       0     0     0
+	  sdb       ONLINE       0     0     0
+
+errors: No known data errors
+`
+
+	health = zfsParsePoolStatus("tank", running)
+	assert.Equal(t, api.StoragePoolHealthStatusHealthy, health.Status)
+	assert.Empty(t, health.Messages)
+	assert.Len(t, health.Devices, 1)
+	assert.Equal(t, api.StoragePoolScrubStatusRunning, health.Scrub.Status)
+	assert.Equal(t, 25.0, health.Scrub.Progress)
+
+	none := `  pool: tank
+ state: ONLINE
+  scan: none requested
+config:
+
+	NAME        STATE     READ WRITE CKSUM
+	tank        ONLINE       0     0     0
+	  sdb       ONLINE       0     0     0
+
+errors: No known data errors
+`
+
+	health = zfsParsePoolStatus("tank", none)
+	assert.Equal(t, api.StoragePoolHealthStatusHealthy, health.Status)
+	assert.Equal(t, api.StoragePoolScrubStatusNone, health.Scrub.Status)
+}
+
+func Test_parseClockDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"00:00:01", time.Second, false},
+		{"1:02:03", time.Hour + 2*time.Minute + 3*time.Second, false},
+		{"2 days 00:00:10", 48*time.Hour + 10*time.Second, false},
+		{"10s", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseClockDuration(tt.value)
+		if tt.wantErr {
+			assert.Error(t, err, tt.value)
+			continue
+		}
+
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+}
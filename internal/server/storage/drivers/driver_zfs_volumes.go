@@ -1857,6 +1857,20 @@ func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.initial.uid` or `0`
 	//  shortdesc: UID of the volume owner in the instance
 
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=limits.bandwidth)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: I/O bandwidth limit in byte/s for each instance using the volume
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=limits.iops)
+	// See {ref}`devices-disk-volume-limits`.
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  shortdesc: I/O operations per second limit for each instance using the volume
+
 	// gendoc:generate(entity=storage_volume_zfs, group=common, key=security.shared)
 	//
 	// ---
@@ -1929,6 +1943,41 @@ func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage bucket
 
+	// gendoc:generate(entity=storage_bucket_zfs, group=common, key=versioning)
+	//
+	// ---
+	//  type: bool
+	//  default: `false`
+	//  shortdesc: Whether to keep previous versions of overwritten and deleted objects
+
+	// gendoc:generate(entity=storage_bucket_zfs, group=common, key=lifecycle.expiration)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of days after which objects expire
+
+	// gendoc:generate(entity=storage_bucket_zfs, group=common, key=lifecycle.noncurrent_expiration)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of days after which noncurrent object versions are removed
+
+	// gendoc:generate(entity=storage_bucket_zfs, group=common, key=lifecycle.noncurrent_versions)
+	//
+	// ---
+	//  type: integer
+	//  default: -
+	//  shortdesc: Number of noncurrent object versions to retain
+
+	// gendoc:generate(entity=storage_bucket_zfs, group=common, key=security.public_read)
+	//
+	// ---
+	//  type: bool
+	//  default: `false`
+	//  shortdesc: Whether to allow anonymous read access to the bucket
+
 	commonRules := d.commonVolumeRules()
 
 	// Disallow block.* settings for regular custom block volumes. These settings only make sense
@@ -1942,6 +1991,8 @@ func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	} else if vol.volType == VolumeTypeCustom && !vol.IsBlockBacked() {
 		delete(commonRules, "block.filesystem")
 		delete(commonRules, "block.mount_options")
+	} else if vol.volType == VolumeTypeBucket {
+		maps.Copy(commonRules, localBucketRules())
 	}
 
 	return d.validateVolume(vol, commonRules, removeUnknownKeys)
@@ -2321,6 +2372,11 @@ func (d *zfs) GetVolumeDiskPath(vol Volume) (string, error) {
 	return d.tryGetVolumeDiskPathFromDataset(ctx, d.dataset(vol, false))
 }
 
+// ReclaimVolume releases the unused space of a volume back to the pool.
+func (d *zfs) ReclaimVolume(vol Volume, op *operations.Operation) (int64, error) {
+	return genericVFSReclaimVolume(d, vol, op)
+}
+
 // ListVolumes returns a list of volumes in storage pool.
 func (d *zfs) ListVolumes() ([]Volume, error) {
 	vols := make(map[string]Volume)
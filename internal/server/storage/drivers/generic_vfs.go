@@ -518,6 +518,65 @@ func genericVFSGetVolumeDiskPath(vol Volume) (string, error) {
 	return filepath.Join(vol.MountPath(), genericVolumeDiskFile), nil
 }
 
+// genericVFSReclaimVolume is a generic ReclaimVolume implementation for VFS-only drivers.
+// Block volumes must not be in use by a running instance.
+func genericVFSReclaimVolume(d Driver, vol Volume, op *operations.Operation) (int64, error) {
+	var reclaimed int64
+
+	if vol.contentType == ContentTypeFS {
+		if !vol.IsBlockBacked() {
+			return 0, ErrNotSupported
+		}
+
+		err := vol.MountTask(func(mountPath string, op *operations.Operation) error {
+			var err error
+
+			reclaimed, err = fstrimPath(mountPath)
+
+			return err
+		}, op)
+		if err != nil {
+			return -1, err
+		}
+
+		return reclaimed, nil
+	}
+
+	if vol.contentType != ContentTypeBlock {
+		return 0, ErrNotSupported
+	}
+
+	err := vol.MountTask(func(_ string, op *operations.Operation) error {
+		diskPath, err := d.GetVolumeDiskPath(vol)
+		if err != nil {
+			return err
+		}
+
+		if IsQcow2Block(vol) {
+			if !linux.IsBlockdevPath(diskPath) {
+				return ErrNotSupported
+			}
+
+			reclaimed, err = Qcow2DiscardUnused(diskPath)
+
+			return err
+		}
+
+		if linux.IsBlockdevPath(diskPath) {
+			return ErrNotSupported
+		}
+
+		reclaimed, err = digHoles(diskPath)
+
+		return err
+	}, op)
+	if err != nil {
+		return -1, err
+	}
+
+	return reclaimed, nil
+}
+
 // genericVFSBackupVolume is a generic BackupVolume implementation for VFS-only drivers.
 func genericVFSBackupVolume(d Driver, vol Volume, writer instancewriter.InstanceWriter, basePrefix string, snapshots []string, op *operations.Operation) error {
 	if len(snapshots) > 0 {
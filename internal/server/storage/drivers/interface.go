@@ -47,6 +47,12 @@ type Driver interface {
 	// Unmount unmounts a storage pool if needed, returns true if unmounted, false if was not mounted.
 	Unmount() (bool, error)
 	GetResources() (*api.ResourcesStoragePool, error)
+
+	// Health returns the health of the pool, including device errors and scrub status.
+	Health() (*api.StoragePoolHealth, error)
+
+	// Scrub starts a scrub of the pool, returns once the scrub was started.
+	Scrub(op *operations.Operation) error
 	Validate(config map[string]string) error
 	Update(changedConfig map[string]string) error
 	ApplyPatch(name string) error
@@ -76,6 +82,9 @@ type Driver interface {
 	GetVolumeDiskPath(vol Volume) (string, error)
 	ListVolumes() ([]Volume, error)
 
+	// ReclaimVolume releases the unused space of a volume back to the pool and returns the number of bytes reclaimed.
+	ReclaimVolume(vol Volume, op *operations.Operation) (int64, error)
+
 	// ActivateTask is a low-level access function to get to the underlying storage.
 	ActivateTask(vol Volume, task func(devPath string, op *operations.Operation) error, op *operations.Operation) error
 
@@ -30,6 +30,7 @@ import (
 	"github.com/lxc/incus/v7/shared/logger"
 	"github.com/lxc/incus/v7/shared/subprocess"
 	"github.com/lxc/incus/v7/shared/util"
+	"github.com/lxc/incus/v7/shared/validate"
 )
 
 // MinBlockBoundary minimum block boundary size to use.
@@ -636,9 +637,51 @@ func regenerateFilesystemXFSUUID(devPath string) error {
 	return nil
 }
 
+// reflinkCopyFile clones the content of one regular file into another one using the FICLONE ioctl.
+// This shares the data extents between both files rather than copying them and so only works when
+// both files are on the same filesystem and that filesystem supports reflinks (btrfs, xfs, ...).
+func reflinkCopyFile(inputPath string, outputPath string) error {
+	from, err := os.Open(inputPath)
+	if err != nil {
+		return err
+	}
+
+	defer logger.WarnOnError(from.Close, "Failed to close file")
+
+	to, err := os.OpenFile(outputPath, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = to.Close() }()
+
+	// Clear any existing content so the result matches the source exactly.
+	err = to.Truncate(0)
+	if err != nil {
+		return err
+	}
+
+	err = unix.IoctlFileClone(int(to.Fd()), int(from.Fd()))
+	if err != nil {
+		return err
+	}
+
+	return to.Close()
+}
+
 // copyDevice copies one device path to another using dd running at low priority.
+// When both paths are regular files, a reflink copy is attempted first.
 // It expects outputPath to exist already, so will not create it.
 func copyDevice(inputPath string, outputPath string) error {
+	if !linux.IsBlockdevPath(inputPath) && !linux.IsBlockdevPath(outputPath) {
+		err := reflinkCopyFile(inputPath, outputPath)
+		if err == nil {
+			return nil
+		}
+
+		logger.Debug("Reflink copy not possible, falling back to regular copy", logger.Ctx{"inputPath": inputPath, "outputPath": outputPath, "err": err})
+	}
+
 	cmd := []string{
 		"nice", "-n19", // Run dd with low priority to reduce CPU impact on other processes.
 		"dd", fmt.Sprintf("if=%s", inputPath), fmt.Sprintf("of=%s", outputPath),
@@ -667,6 +710,66 @@ func copyDevice(inputPath string, outputPath string) error {
 	return nil
 }
 
+// fstrimPath discards the unused blocks of the filesystem mounted at the given path and returns the number of bytes trimmed.
+func fstrimPath(path string) (int64, error) {
+	out, err := subprocess.RunCommand("fstrim", "-v", path)
+	if err != nil {
+		if strings.Contains(err.Error(), "not supported") {
+			return 0, ErrNotSupported
+		}
+
+		return -1, err
+	}
+
+	return parseFstrimOutput(out)
+}
+
+// parseFstrimOutput parses the output of "fstrim -v", for example "/mnt: 1 GiB (1073741824 bytes) trimmed".
+func parseFstrimOutput(output string) (int64, error) {
+	_, after, ok := strings.Cut(output, "(")
+	if !ok {
+		return -1, fmt.Errorf("Unexpected fstrim output %q", output)
+	}
+
+	fields := strings.Fields(after)
+	if len(fields) < 2 || !strings.HasPrefix(fields[1], "bytes") {
+		return -1, fmt.Errorf("Unexpected fstrim output %q", output)
+	}
+
+	return strconv.ParseInt(fields[0], 10, 64)
+}
+
+// digHoles deallocates the ranges of zeroes in a raw disk file and returns the number of bytes reclaimed.
+func digHoles(path string) (int64, error) {
+	allocatedBytes := func() (int64, error) {
+		var st unix.Stat_t
+
+		err := unix.Stat(path, &st)
+		if err != nil {
+			return -1, err
+		}
+
+		return st.Blocks * 512, nil
+	}
+
+	before, err := allocatedBytes()
+	if err != nil {
+		return -1, err
+	}
+
+	_, err = subprocess.RunCommand("nice", "-n19", "fallocate", "--dig-holes", path)
+	if err != nil {
+		return -1, err
+	}
+
+	after, err := allocatedBytes()
+	if err != nil {
+		return -1, err
+	}
+
+	return max(before-after, 0), nil
+}
+
 // loopFilePath returns the loop file path for a storage pool.
 func loopFilePath(poolName string) string {
 	return filepath.Join(internalUtil.VarPath("disks"), fmt.Sprintf("%s.img", poolName))
@@ -1331,3 +1434,49 @@ func UnpackVolume(d Driver, vol Volume, r io.ReadSeeker, tarArgs []string, unpac
 
 	return nil
 }
+
+// parseClockDuration parses durations in the "[N days ]H:MM:SS" format used by storage tools.
+func parseClockDuration(value string) (time.Duration, error) {
+	var elapsed time.Duration
+
+	value = strings.TrimSpace(value)
+
+	days, clock, found := strings.Cut(value, " days ")
+	if found {
+		count, err := strconv.ParseUint(days, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration %q: %w", value, err)
+		}
+
+		elapsed += time.Duration(count) * 24 * time.Hour
+	} else {
+		clock = value
+	}
+
+	parts := strings.Split(clock, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("Invalid duration %q", value)
+	}
+
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		count, err := strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration %q: %w", value, err)
+		}
+
+		elapsed += time.Duration(count) * unit
+	}
+
+	return elapsed, nil
+}
+
+// localBucketRules returns the config rules for storage buckets served by the built-in S3 server.
+func localBucketRules() map[string]func(value string) error {
+	return map[string]func(value string) error{
+		"versioning":                      validate.Optional(validate.IsBool),
+		"lifecycle.expiration":            validate.Optional(validate.IsUint32),
+		"lifecycle.noncurrent_expiration": validate.Optional(validate.IsUint32),
+		"lifecycle.noncurrent_versions":   validate.Optional(validate.IsUint32),
+		"security.public_read":            validate.Optional(validate.IsBool),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/lxc/incus/v7/shared/api"
@@ -349,3 +350,68 @@ func EnsureClientPrefix(client string) string {
 
 	return "client." + client
 }
+
+// cephParseHealth parses the output of "ceph health detail -f json" into a storage pool health.
+func cephParseHealth(output []byte) (*api.StoragePoolHealth, error) {
+	type cephHealthCheck struct {
+		Severity string `json:"severity"`
+		Summary  struct {
+			Message string `json:"message"`
+		} `json:"summary"`
+	}
+
+	type cephHealth struct {
+		Status string                     `json:"status"`
+		Checks map[string]cephHealthCheck `json:"checks"`
+	}
+
+	data := cephHealth{}
+	err := json.Unmarshal(output, &data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing ceph health: %w", err)
+	}
+
+	health := &api.StoragePoolHealth{
+		Messages: []string{},
+		Devices:  []api.StoragePoolHealthDevice{},
+	}
+
+	switch data.Status {
+	case "HEALTH_OK":
+		health.Status = api.StoragePoolHealthStatusHealthy
+	case "HEALTH_WARN":
+		health.Status = api.StoragePoolHealthStatusDegraded
+	case "HEALTH_ERR":
+		health.Status = api.StoragePoolHealthStatusFailed
+	default:
+		health.Status = api.StoragePoolHealthStatusUnknown
+	}
+
+	checkNames := make([]string, 0, len(data.Checks))
+	for name := range data.Checks {
+		checkNames = append(checkNames, name)
+	}
+
+	slices.Sort(checkNames)
+
+	for _, name := range checkNames {
+		health.Messages = append(health.Messages, fmt.Sprintf("%s: %s", name, data.Checks[name].Summary.Message))
+	}
+
+	return health, nil
+}
+
+// cephGetHealth returns the health of the Ceph cluster.
+func cephGetHealth(clusterName string, userName string) (*api.StoragePoolHealth, error) {
+	out, err := subprocess.RunCommand("ceph",
+		"--name", EnsureClientPrefix(userName),
+		"--cluster", clusterName,
+		"health",
+		"detail",
+		"-f", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	return cephParseHealth([]byte(out))
+}
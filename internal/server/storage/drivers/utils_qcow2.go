@@ -151,6 +151,46 @@ func Qcow2Info(path string) (*ImageInfo, error) {
 	return &imgInfo, nil
 }
 
+// Qcow2DiscardUnused discards the part of a block device that lies past the end of the qcow2 image it holds
+// and returns the number of bytes discarded. The image must not be in use.
+func Qcow2DiscardUnused(devPath string) (int64, error) {
+	out, err := subprocess.RunCommand("qemu-img", "check", "--output=json", "-f", "qcow2", devPath)
+	if err != nil {
+		return -1, fmt.Errorf("Failed checking qcow2 image %q: %w", devPath, err)
+	}
+
+	var check struct {
+		ImageEndOffset int64 `json:"image-end-offset"`
+	}
+
+	err = json.Unmarshal([]byte(out), &check)
+	if err != nil {
+		return -1, fmt.Errorf("Failed unmarshalling qcow2 check output: %w", err)
+	}
+
+	if check.ImageEndOffset <= 0 {
+		return 0, nil
+	}
+
+	devSize, err := BlockDiskSizeBytes(devPath)
+	if err != nil {
+		return -1, err
+	}
+
+	// Keep some headroom after the image end and align the offset on a MiB boundary.
+	offset := (check.ImageEndOffset/(1024*1024) + 1) * 1024 * 1024
+	if offset >= devSize {
+		return 0, nil
+	}
+
+	_, err = subprocess.RunCommand("blkdiscard", "-o", strconv.FormatInt(offset, 10), devPath)
+	if err != nil {
+		return -1, err
+	}
+
+	return devSize - offset, nil
+}
+
 // Qcow2BackingChain returns information about the backing chain of a qcow2 image.
 func Qcow2BackingChain(path string) ([]string, error) {
 	result := []string{}
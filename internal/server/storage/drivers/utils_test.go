@@ -35,3 +35,17 @@ func TestGetVolumeMountPath(t *testing.T) {
 	expected = GetPoolMountPath(poolName) + "/virtual-machines/testvol"
 	assert.Equal(t, expected, path)
 }
+
+// Test parseFstrimOutput.
+func TestParseFstrimOutput(t *testing.T) {
+	trimmed, err := parseFstrimOutput("/mnt: 1 GiB (1073741824 bytes) trimmed\n")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1073741824), trimmed)
+
+	trimmed, err = parseFstrimOutput("/mnt: 0 B (0 bytes) trimmed on /dev/sdb1\n")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), trimmed)
+
+	_, err = parseFstrimOutput("fstrim: /mnt: FITRIM ioctl failed")
+	assert.Error(t, err)
+}
@@ -50,6 +50,9 @@ type Pool interface {
 	ToAPI() api.StoragePool
 
 	GetResources() (*api.ResourcesStoragePool, error)
+	Health() (*api.StoragePoolHealth, error)
+	Scrub(op *operations.Operation) error
+	Reclaim(op *operations.Operation) (int64, error)
 	IsUsed() (bool, error)
 	Delete(clientType request.ClientType, op *operations.Operation) error
 	Update(clientType request.ClientType, newDesc string, newConfig map[string]string, op *operations.Operation) error
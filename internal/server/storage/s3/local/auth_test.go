@@ -0,0 +1,78 @@
+package local
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestPresignedV4(t *testing.T) {
+	s := newTestServer(t)
+
+	u, err := url.Parse("http://localhost/bucket/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		signedAt time.Time
+		tamper   bool
+		valid    bool
+	}{
+		{"valid", time.Now(), false, true},
+		{"expired", time.Now().Add(-2 * time.Hour), false, false},
+		{"tampered", time.Now(), true, false},
+		{"clock skew", time.Now().Add(5 * time.Minute), false, true},
+		{"future", time.Now().Add(24 * time.Hour), false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signed := PresignURL(u, http.MethodGet, testAccessKey, testSecretKey, "us-east-1", time.Hour, test.signedAt)
+
+			if test.tamper {
+				q := signed.Query()
+				sig := []byte(q.Get("X-Amz-Signature"))
+				if sig[0] == '0' {
+					sig[0] = '1'
+				} else {
+					sig[0] = '0'
+				}
+
+				q.Set("X-Amz-Signature", string(sig))
+				signed.RawQuery = q.Encode()
+			}
+
+			r := httptest.NewRequest(http.MethodGet, signed.String(), nil)
+
+			cred, s3Err := s.authenticatePresignedV4(r)
+			if test.valid {
+				if s3Err != nil {
+					t.Fatalf("Expected valid presigned URL, got %q", s3Err.Message)
+				}
+
+				if cred.AccessKey != testAccessKey {
+					t.Errorf("Expected access key %q, got %q", testAccessKey, cred.AccessKey)
+				}
+
+				return
+			}
+
+			if s3Err == nil {
+				t.Fatal("Expected presigned URL to be rejected")
+			}
+		})
+	}
+
+	// The URL is only valid for the signed method.
+	signed := PresignURL(u, http.MethodGet, testAccessKey, testSecretKey, "us-east-1", time.Hour, time.Now())
+	r := httptest.NewRequest(http.MethodPut, signed.String(), nil)
+
+	_, s3Err := s.authenticatePresignedV4(r)
+	if s3Err == nil {
+		t.Error("Expected presigned URL to be rejected for another method")
+	}
+}
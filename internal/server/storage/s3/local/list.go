@@ -147,7 +147,7 @@ func (s *Server) listObjects(w http.ResponseWriter, r *http.Request) {
 }
 
 // collectKeys walks the data directory and returns the list of object keys.
-// Sidecar files, the uploads directory, and temporary files are skipped.
+// Sidecar files, the uploads and versions directories, and temporary files are skipped.
 func (s *Server) collectKeys() ([]string, error) {
 	root := s.dataDir()
 	keys := []string{}
@@ -171,7 +171,7 @@ func (s *Server) collectKeys() ([]string, error) {
 		}
 
 		if d.IsDir() {
-			if rel == uploadsSubdir {
+			if rel == uploadsSubdir || rel == versionsSubdir {
 				return filepath.SkipDir
 			}
 
@@ -23,6 +23,13 @@ type objectMeta struct {
 	Size        int64             `json:"size"`
 	LastMod     time.Time         `json:"last_modified"`
 	UserMeta    map[string]string `json:"user_meta,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+
+	// Versioning state, the version ID is empty for the null version and the
+	// key is only recorded for noncurrent versions.
+	VersionID    string `json:"version_id,omitempty"`
+	Key          string `json:"key,omitempty"`
+	DeleteMarker bool   `json:"delete_marker,omitempty"`
 }
 
 func readMeta(metaPath string) (*objectMeta, error) {
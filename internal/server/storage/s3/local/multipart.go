@@ -26,6 +26,7 @@ type uploadInfo struct {
 	Key         string            `json:"key"`
 	ContentType string            `json:"content_type,omitempty"`
 	UserMeta    map[string]string `json:"user_meta,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
 	Initiated   time.Time         `json:"initiated"`
 }
 
@@ -41,6 +42,12 @@ func (s *Server) uploadsRoot() (*os.Root, error) {
 }
 
 func (s *Server) initiateMultipartUpload(w http.ResponseWriter, r *http.Request, key string) {
+	tags, err := parseTaggingHeader(r.Header)
+	if err != nil {
+		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: err.Error()}).Response(w)
+		return
+	}
+
 	id := uuid.New().String()
 
 	root, err := s.uploadsRoot()
@@ -61,6 +68,7 @@ func (s *Server) initiateMultipartUpload(w http.ResponseWriter, r *http.Request,
 		Key:         key,
 		ContentType: r.Header.Get("Content-Type"),
 		UserMeta:    extractUserMeta(r.Header),
+		Tags:        tags,
 		Initiated:   time.Now().UTC(),
 	}
 
@@ -279,9 +287,17 @@ func (s *Server) completeMultipartUpload(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	versionID, err := s.nextVersion(key, dataPath)
+	if err != nil {
+		_ = os.Remove(tmp)
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
 	err = os.Rename(tmp, dataPath)
 	if err != nil {
 		_ = os.Remove(tmp)
+		_ = s.promoteLatest(key, dataPath)
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
@@ -293,15 +309,22 @@ func (s *Server) completeMultipartUpload(w http.ResponseWriter, r *http.Request,
 		Size:        size,
 		LastMod:     time.Now().UTC(),
 		UserMeta:    info.UserMeta,
+		Tags:        info.Tags,
+		VersionID:   versionID,
 	}
 
 	err = writeMeta(metaPathFor(dataPath), meta)
 	if err != nil {
 		_ = os.Remove(dataPath)
+		_ = s.promoteLatest(key, dataPath)
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
 
+	if versionID != "" {
+		w.Header().Set("X-Amz-Version-Id", versionID)
+	}
+
 	// Clean up the upload directory.
 	_ = root.RemoveAll(uploadID)
 
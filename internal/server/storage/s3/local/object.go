@@ -70,13 +70,29 @@ func (s *Server) objectPath(key string) (string, error) {
 	}
 
 	first, _, _ := strings.Cut(key, "/")
-	if first == uploadsSubdir || strings.HasSuffix(key, metaSuffix) {
+	if first == uploadsSubdir || first == versionsSubdir || strings.HasSuffix(key, metaSuffix) {
 		return "", errors.New("Reserved object key")
 	}
 
 	return filepath.Join(s.dataDir(), key), nil
 }
 
+// objectNotFound returns the error for a missing object or object version.
+func objectNotFound(versionID string) *s3.Error {
+	if versionID != "" {
+		return &s3.Error{Code: s3.ErrorCodeNoSuchVersion, Message: "Object version not found."}
+	}
+
+	return &s3.Error{Code: s3.ErrorCodeNoSuchBucket, Message: "Object not found."}
+}
+
+// deleteMarkerResponse rejects a request addressing a delete marker.
+func deleteMarkerResponse(w http.ResponseWriter, meta *objectMeta) {
+	w.Header().Set("X-Amz-Delete-Marker", "true")
+	w.Header().Set("X-Amz-Version-Id", versionIDOrNull(meta.VersionID))
+	(&s3.Error{Code: s3.ErrorCodeMethodNotAllowed, Message: "The specified version is a delete marker."}).Response(w)
+}
+
 func (s *Server) headObject(w http.ResponseWriter, r *http.Request, key string) {
 	dataPath, err := s.objectPath(key)
 	if err != nil {
@@ -84,10 +100,12 @@ func (s *Server) headObject(w http.ResponseWriter, r *http.Request, key string)
 		return
 	}
 
-	meta, err := loadOrInferMeta(dataPath)
+	versionID := r.URL.Query().Get("versionId")
+
+	_, meta, err := s.loadVersion(key, dataPath, versionID)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			(&s3.Error{Code: s3.ErrorCodeNoSuchBucket, Message: "Object not found."}).Response(w)
+			objectNotFound(versionID).Response(w)
 			return
 		}
 
@@ -95,6 +113,11 @@ func (s *Server) headObject(w http.ResponseWriter, r *http.Request, key string)
 		return
 	}
 
+	if meta.DeleteMarker {
+		deleteMarkerResponse(w, meta)
+		return
+	}
+
 	writeObjectHeaders(w, meta)
 	w.WriteHeader(http.StatusOK)
 }
@@ -106,10 +129,12 @@ func (s *Server) getObject(w http.ResponseWriter, r *http.Request, key string) {
 		return
 	}
 
-	meta, err := loadOrInferMeta(dataPath)
+	versionID := r.URL.Query().Get("versionId")
+
+	versionPath, meta, err := s.loadVersion(key, dataPath, versionID)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			(&s3.Error{Code: s3.ErrorCodeNoSuchBucket, Message: "Object not found."}).Response(w)
+			objectNotFound(versionID).Response(w)
 			return
 		}
 
@@ -117,8 +142,18 @@ func (s *Server) getObject(w http.ResponseWriter, r *http.Request, key string) {
 		return
 	}
 
-	f, err := os.Open(dataPath)
+	if meta.DeleteMarker {
+		deleteMarkerResponse(w, meta)
+		return
+	}
+
+	f, err := os.Open(versionPath)
 	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			objectNotFound(versionID).Response(w)
+			return
+		}
+
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
@@ -161,6 +196,12 @@ func (s *Server) putObject(w http.ResponseWriter, r *http.Request, key string) {
 		return
 	}
 
+	tags, err := parseTaggingHeader(r.Header)
+	if err != nil {
+		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: err.Error()}).Response(w)
+		return
+	}
+
 	// Fail conditional writes early, before the body is transferred.
 	s3Err := checkWritePreconditions(r, dataPath)
 	if s3Err != nil {
@@ -206,9 +247,17 @@ func (s *Server) putObject(w http.ResponseWriter, r *http.Request, key string) {
 		return
 	}
 
+	versionID, err := s.nextVersion(key, dataPath)
+	if err != nil {
+		_ = os.Remove(tmp)
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
 	err = os.Rename(tmp, dataPath)
 	if err != nil {
 		_ = os.Remove(tmp)
+		_ = s.promoteLatest(key, dataPath)
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
@@ -221,15 +270,22 @@ func (s *Server) putObject(w http.ResponseWriter, r *http.Request, key string) {
 		Size:        written,
 		LastMod:     time.Now().UTC(),
 		UserMeta:    extractUserMeta(r.Header),
+		Tags:        tags,
+		VersionID:   versionID,
 	}
 
 	err = writeMeta(metaPathFor(dataPath), meta)
 	if err != nil {
 		_ = os.Remove(dataPath)
+		_ = s.promoteLatest(key, dataPath)
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
 
+	if versionID != "" {
+		w.Header().Set("X-Amz-Version-Id", versionID)
+	}
+
 	w.Header().Set("ETag", `"`+etag+`"`)
 	w.WriteHeader(http.StatusOK)
 }
@@ -241,7 +297,7 @@ func (s *Server) putObject(w http.ResponseWriter, r *http.Request, key string) {
 // object's content-type and user metadata. REPLACE substitutes the values
 // supplied on the request.
 func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, key string) {
-	srcKey, ok := parseCopySource(r.Header.Get("X-Amz-Copy-Source"))
+	srcKey, srcVersionID, ok := parseCopySource(r.Header.Get("X-Amz-Copy-Source"))
 	if !ok {
 		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: "Invalid X-Amz-Copy-Source header."}).Response(w)
 		return
@@ -259,7 +315,7 @@ func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, key string)
 		return
 	}
 
-	srcMeta, err := loadOrInferMeta(srcPath)
+	srcPath, srcMeta, err := s.loadVersion(srcKey, srcPath, srcVersionID)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			(&s3.Error{Code: s3.ErrorCodeNoSuchBucket, Message: "Source object not found."}).Response(w)
@@ -270,6 +326,20 @@ func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, key string)
 		return
 	}
 
+	if srcMeta.DeleteMarker {
+		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: "The source object version is a delete marker."}).Response(w)
+		return
+	}
+
+	tags := srcMeta.Tags
+	if strings.EqualFold(r.Header.Get("X-Amz-Tagging-Directive"), "REPLACE") {
+		tags, err = parseTaggingHeader(r.Header)
+		if err != nil {
+			(&s3.Error{Code: s3.ErrorInvalidRequest, Message: err.Error()}).Response(w)
+			return
+		}
+	}
+
 	src, err := os.Open(srcPath)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
@@ -310,9 +380,20 @@ func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, key string)
 		return
 	}
 
+	objectWriteMu.Lock()
+	defer objectWriteMu.Unlock()
+
+	versionID, err := s.nextVersion(key, dstPath)
+	if err != nil {
+		_ = os.Remove(tmp)
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
 	err = os.Rename(tmp, dstPath)
 	if err != nil {
 		_ = os.Remove(tmp)
+		_ = s.promoteLatest(key, dstPath)
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
@@ -332,15 +413,26 @@ func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, key string)
 		Size:        written,
 		LastMod:     lastMod,
 		UserMeta:    userMeta,
+		Tags:        tags,
+		VersionID:   versionID,
 	}
 
 	err = writeMeta(metaPathFor(dstPath), meta)
 	if err != nil {
 		_ = os.Remove(dstPath)
+		_ = s.promoteLatest(key, dstPath)
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
 
+	if versionID != "" {
+		w.Header().Set("X-Amz-Version-Id", versionID)
+	}
+
+	if srcVersionID != "" {
+		w.Header().Set("X-Amz-Copy-Source-Version-Id", srcVersionID)
+	}
+
 	type copyResult struct {
 		XMLName      xml.Name `xml:"CopyObjectResult"`
 		ETag         string   `xml:"ETag"`
@@ -362,33 +454,39 @@ func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, key string)
 	_, _ = w.Write(resp)
 }
 
-// parseCopySource extracts the source object key from an X-Amz-Copy-Source
-// header value. The value has the form "[/]bucket/key" with the key
-// optionally percent-encoded and an optional "?versionId=..." suffix.
-func parseCopySource(v string) (string, bool) {
+// parseCopySource extracts the source object key and version ID from an
+// X-Amz-Copy-Source header value. The value has the form "[/]bucket/key" with
+// the key optionally percent-encoded and an optional "?versionId=..." suffix.
+func parseCopySource(v string) (string, string, bool) {
 	if v == "" {
-		return "", false
+		return "", "", false
 	}
 
-	// Drop the optional version-id query suffix.
-	idx := strings.Index(v, "?")
-	if idx >= 0 {
-		v = v[:idx]
+	// Split off the optional version-id query suffix.
+	var versionID string
+	v, rawQuery, found := strings.Cut(v, "?")
+	if found {
+		q, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return "", "", false
+		}
+
+		versionID = q.Get("versionId")
 	}
 
 	decoded, err := url.PathUnescape(v)
 	if err != nil {
-		return "", false
+		return "", "", false
 	}
 
 	decoded = strings.TrimPrefix(decoded, "/")
 
 	_, key, ok := strings.Cut(decoded, "/")
 	if !ok || key == "" {
-		return "", false
+		return "", "", false
 	}
 
-	return key, true
+	return key, versionID, true
 }
 
 // handleObjectACL stubs the object-level ?acl sub-resource.
@@ -429,25 +527,38 @@ func (s *Server) handleObjectACL(w http.ResponseWriter, r *http.Request, key str
 	}
 }
 
-func (s *Server) deleteObject(w http.ResponseWriter, key string) {
+func (s *Server) deleteObject(w http.ResponseWriter, r *http.Request, key string) {
 	dataPath, err := s.objectPath(key)
 	if err != nil {
 		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: err.Error()}).Response(w)
 		return
 	}
 
-	err = os.Remove(dataPath)
-	if err != nil && !errors.Is(err, fs.ErrNotExist) {
-		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
-		return
+	versionID := r.URL.Query().Get("versionId")
+
+	objectWriteMu.Lock()
+	defer objectWriteMu.Unlock()
+
+	var deleteMarker bool
+	if versionID != "" {
+		deleteMarker, err = s.deleteVersion(key, dataPath, versionID)
+	} else {
+		versionID, deleteMarker, err = s.deleteCurrent(key, dataPath)
 	}
 
-	err = removeMeta(metaPathFor(dataPath))
 	if err != nil {
 		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
 		return
 	}
 
+	if versionID != "" {
+		w.Header().Set("X-Amz-Version-Id", versionID)
+	}
+
+	if deleteMarker {
+		w.Header().Set("X-Amz-Delete-Marker", "true")
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -459,6 +570,14 @@ func writeObjectHeaders(w http.ResponseWriter, meta *objectMeta) {
 	w.Header().Set("ETag", `"`+meta.ETag+`"`)
 	w.Header().Set("Content-Length", strconv.FormatInt(meta.Size, 10))
 	w.Header().Set("Last-Modified", meta.LastMod.UTC().Format(http.TimeFormat))
+	if meta.VersionID != "" {
+		w.Header().Set("X-Amz-Version-Id", meta.VersionID)
+	}
+
+	if len(meta.Tags) > 0 {
+		w.Header().Set("X-Amz-Tagging-Count", strconv.Itoa(len(meta.Tags)))
+	}
+
 	for k, v := range meta.UserMeta {
 		w.Header().Set("X-Amz-Meta-"+k, v)
 	}
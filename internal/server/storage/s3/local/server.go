@@ -9,6 +9,7 @@
 //	data/<key>           object data
 //	data/<key>.meta      object metadata (JSON)
 //	data/.uploads/<id>/  in-flight multipart upload state
+//	data/.versions/<h>/  noncurrent versions and delete markers of an object
 package local
 
 import (
@@ -41,6 +42,9 @@ type Credential struct {
 	AccessKey string
 	SecretKey string
 	Role      Role
+
+	// Prefix, if set, restricts the credential to object keys starting with it.
+	Prefix string
 }
 
 // Server serves S3 requests for a single bucket directory.
@@ -53,6 +57,16 @@ type Server struct {
 	// Errors are returned to the client as an internal-error response and
 	// dispatch is aborted.
 	OnAuthenticated func() error
+
+	// Versioning controls whether overwritten and deleted objects are kept
+	// as noncurrent versions.
+	Versioning bool
+
+	// Lifecycle holds the lifecycle rules applied by ApplyLifecycle.
+	Lifecycle LifecycleRules
+
+	// PublicRead allows anonymous read-only access to the bucket.
+	PublicRead bool
 }
 
 // NewServer returns a Server rooted at bucketDir.
@@ -85,7 +99,7 @@ func (s *Server) uploadsDir() string {
 // already. Routing happens on the remainder of the path.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Authenticate the request before any I/O.
-	role, authErr := s.authenticate(r)
+	cred, authErr := s.authenticate(r)
 	if authErr != nil {
 		authErr.Response(w)
 		return
@@ -99,7 +113,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		objectKey = ""
 	}
 
-	if !methodAllowedForRole(r.Method, role, objectKey, r.URL.Query()) {
+	if !methodAllowedForRole(r.Method, cred.Role, objectKey, r.URL.Query()) {
 		(&s3.Error{
 			Code:    s3.ErrorInvalidRequest,
 			Message: "Operation not permitted by credential role.",
@@ -107,6 +121,14 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !requestAllowedForPrefix(r, cred.Prefix, objectKey) {
+		(&s3.Error{
+			Code:    s3.ErrorCodeAccessDenied,
+			Message: "Operation not permitted outside of the credential prefix.",
+		}).Response(w)
+		return
+	}
+
 	if s.OnAuthenticated != nil {
 		err := s.OnAuthenticated()
 		if err != nil {
@@ -153,6 +175,49 @@ func methodAllowedForRole(method string, role Role, objectKey string, q url.Valu
 	return false
 }
 
+// requestAllowedForPrefix checks that a request stays within the object key
+// prefix of the credential. Bucket listings must be filtered by a prefix
+// within it and copies must read from it.
+func requestAllowedForPrefix(r *http.Request, prefix string, objectKey string) bool {
+	if prefix == "" {
+		return true
+	}
+
+	if objectKey != "" {
+		if !strings.HasPrefix(objectKey, prefix) {
+			return false
+		}
+
+		copySource := r.Header.Get("X-Amz-Copy-Source")
+		if copySource != "" {
+			srcKey, _, ok := parseCopySource(copySource)
+			return ok && strings.HasPrefix(srcKey, prefix)
+		}
+
+		return true
+	}
+
+	q := r.URL.Query()
+
+	switch r.Method {
+	case http.MethodHead:
+		return true
+	case http.MethodGet:
+		if q.Has("versioning") || q.Has("lifecycle") {
+			return true
+		}
+
+		// In-flight uploads can't be filtered by prefix.
+		if q.Has("uploads") {
+			return false
+		}
+
+		return strings.HasPrefix(q.Get("prefix"), prefix)
+	}
+
+	return false
+}
+
 func (s *Server) handleBucket(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
@@ -171,10 +236,30 @@ func (s *Server) handleBucket(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		_, ok = q["versions"]
+		if ok {
+			s.listObjectVersions(w, r)
+			return
+		}
+
+		_, ok = q["lifecycle"]
+		if ok {
+			s.getBucketLifecycle(w)
+			return
+		}
+
 		s.listObjects(w, r)
 	case http.MethodHead:
 		// Bucket exist if we made it this far.
 		w.WriteHeader(http.StatusOK)
+	case http.MethodPut:
+		q := r.URL.Query()
+		if q.Has("versioning") || q.Has("lifecycle") || q.Has("tagging") {
+			s.putBucketConfig(w, r)
+			return
+		}
+
+		fallthrough
 	default:
 		// We don't allow bucket creation/deletion.
 		(&s3.Error{
@@ -184,15 +269,22 @@ func (s *Server) handleBucket(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// getBucketVersioning stubs the bucket-level ?versioning sub-resource.
-// Versioning is never enabled, so return an empty configuration.
-func (s *Server) getBucketVersioning(w http.ResponseWriter) {
-	const body = `<?xml version="1.0" encoding="UTF-8"?>` +
-		`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></VersioningConfiguration>`
+// putBucketConfig rejects changes to the bucket versioning and lifecycle rules,
+// which are set through the bucket configuration instead. Tags are only
+// supported on objects.
+func (s *Server) putBucketConfig(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("tagging") {
+		(&s3.Error{
+			Code:    s3.ErrorInvalidRequest,
+			Message: "Bucket tagging is not supported.",
+		}).Response(w)
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/xml")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(body))
+	(&s3.Error{
+		Code:    s3.ErrorInvalidRequest,
+		Message: "Bucket versioning and lifecycle rules are managed through the Incus API.",
+	}).Response(w)
 }
 
 func (s *Server) handleObject(w http.ResponseWriter, r *http.Request, objectKey string) {
@@ -226,6 +318,12 @@ func (s *Server) handleObject(w http.ResponseWriter, r *http.Request, objectKey
 		return
 	}
 
+	_, ok = q["tagging"]
+	if ok {
+		s.handleObjectTagging(w, r, objectKey)
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		s.getObject(w, r, objectKey)
@@ -239,7 +337,7 @@ func (s *Server) handleObject(w http.ResponseWriter, r *http.Request, objectKey
 
 		s.putObject(w, r, objectKey)
 	case http.MethodDelete:
-		s.deleteObject(w, objectKey)
+		s.deleteObject(w, r, objectKey)
 	default:
 		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: "Unsupported method."}).Response(w)
 	}
@@ -0,0 +1,187 @@
+package local
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "access"
+	testSecretKey = "secret"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	return NewServer(t.TempDir(), []Credential{{AccessKey: testAccessKey, SecretKey: testSecretKey, Role: RoleAdmin}})
+}
+
+// testRequest sends a signed request to the server and returns the recorded response.
+func testRequest(t *testing.T, s *Server, method string, target string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequest(method, "http://localhost"+target, nil)
+
+	err := SignRequest(r, testAccessKey, testSecretKey, "us-east-1", "s3", body, time.Now())
+	if err != nil {
+		t.Fatalf("Failed signing request: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestBucketPut(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		target  string
+		message string
+	}{
+		{"/bucket", "Bucket lifecycle is managed by the Incus API."},
+		{"/bucket?versioning", "Bucket versioning and lifecycle rules are managed through the Incus API."},
+		{"/bucket?lifecycle", "Bucket versioning and lifecycle rules are managed through the Incus API."},
+		{"/bucket?tagging", "Bucket tagging is not supported."},
+	}
+
+	for _, test := range tests {
+		w := testRequest(t, s, http.MethodPut, test.target, []byte{})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("PUT %s: expected status %d, got %d", test.target, http.StatusBadRequest, w.Code)
+		}
+
+		if !bytes.Contains(w.Body.Bytes(), []byte(test.message)) {
+			t.Errorf("PUT %s: expected message %q, got %q", test.target, test.message, w.Body.String())
+		}
+	}
+}
+
+func TestListObjectVersions(t *testing.T) {
+	s := newTestServer(t)
+	s.Versioning = true
+
+	for _, content := range []string{"v1", "v2"} {
+		w := testRequest(t, s, http.MethodPut, "/bucket/foo", []byte(content))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed writing object: %d %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := testRequest(t, s, http.MethodDelete, "/bucket/foo", nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Failed deleting object: %d %s", w.Code, w.Body.String())
+	}
+
+	w = testRequest(t, s, http.MethodGet, "/bucket?versions", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed listing versions: %d %s", w.Code, w.Body.String())
+	}
+
+	var result struct {
+		Versions      []listVersionsEntry `xml:"Version"`
+		DeleteMarkers []listVersionsEntry `xml:"DeleteMarker"`
+	}
+
+	err := xml.Unmarshal(w.Body.Bytes(), &result)
+	if err != nil {
+		t.Fatalf("Failed parsing versions listing: %v", err)
+	}
+
+	if len(result.Versions) != 2 {
+		t.Fatalf("Expected 2 versions, got %d", len(result.Versions))
+	}
+
+	if len(result.DeleteMarkers) != 1 || !result.DeleteMarkers[0].IsLatest {
+		t.Fatalf("Expected a single latest delete marker, got %+v", result.DeleteMarkers)
+	}
+
+	// The noncurrent versions remain readable by ID.
+	w = testRequest(t, s, http.MethodGet, "/bucket/foo?versionId="+result.Versions[len(result.Versions)-1].VersionID, nil)
+	if w.Code != http.StatusOK || w.Body.String() != "v1" {
+		t.Errorf("Expected first version content %q, got %d %q", "v1", w.Code, w.Body.String())
+	}
+}
+
+func TestApplyLifecycleExpiry(t *testing.T) {
+	s := newTestServer(t)
+	s.Lifecycle = LifecycleRules{ExpirationDays: 1}
+
+	w := testRequest(t, s, http.MethodPut, "/bucket/foo", []byte("data"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed writing object: %d %s", w.Code, w.Body.String())
+	}
+
+	// Objects which haven't reached the expiry are kept.
+	err := s.ApplyLifecycle(time.Now())
+	if err != nil {
+		t.Fatalf("Failed applying lifecycle: %v", err)
+	}
+
+	w = testRequest(t, s, http.MethodGet, "/bucket/foo", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected object to be kept, got %d", w.Code)
+	}
+
+	err = s.ApplyLifecycle(time.Now().AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("Failed applying lifecycle: %v", err)
+	}
+
+	w = testRequest(t, s, http.MethodGet, "/bucket/foo", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected object to be expired, got %d", w.Code)
+	}
+}
+
+func TestObjectTagging(t *testing.T) {
+	s := newTestServer(t)
+
+	w := testRequest(t, s, http.MethodPut, "/bucket/foo", []byte("data"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed writing object: %d %s", w.Code, w.Body.String())
+	}
+
+	tagging := objectTagging{TagSet: []objectTag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}}
+
+	body, err := xml.Marshal(tagging)
+	if err != nil {
+		t.Fatalf("Failed encoding tags: %v", err)
+	}
+
+	w = testRequest(t, s, http.MethodPut, "/bucket/foo?tagging", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed setting tags: %d %s", w.Code, w.Body.String())
+	}
+
+	w = testRequest(t, s, http.MethodGet, "/bucket/foo?tagging", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed getting tags: %d %s", w.Code, w.Body.String())
+	}
+
+	var result objectTagging
+
+	err = xml.Unmarshal(w.Body.Bytes(), &result)
+	if err != nil {
+		t.Fatalf("Failed parsing tags: %v", err)
+	}
+
+	if len(result.TagSet) != 2 || result.TagSet[0] != tagging.TagSet[0] || result.TagSet[1] != tagging.TagSet[1] {
+		t.Errorf("Expected tags %+v, got %+v", tagging.TagSet, result.TagSet)
+	}
+
+	w = testRequest(t, s, http.MethodDelete, "/bucket/foo?tagging", nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Failed deleting tags: %d %s", w.Code, w.Body.String())
+	}
+
+	w = testRequest(t, s, http.MethodGet, "/bucket/foo?tagging", nil)
+	if w.Code != http.StatusOK || bytes.Contains(w.Body.Bytes(), []byte("<Tag>")) {
+		t.Errorf("Expected no tags after delete, got %d %s", w.Code, w.Body.String())
+	}
+}
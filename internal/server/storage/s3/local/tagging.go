@@ -0,0 +1,193 @@
+package local
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/lxc/incus/v7/internal/server/storage/s3"
+)
+
+// S3 limits on object tags.
+const (
+	maxObjectTags     = 10
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// objectTagging is the XML body of the object ?tagging sub-resource.
+type objectTagging struct {
+	XMLName xml.Name    `xml:"Tagging"`
+	TagSet  []objectTag `xml:"TagSet>Tag"`
+}
+
+type objectTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+func validateTags(tags map[string]string) error {
+	if len(tags) > maxObjectTags {
+		return fmt.Errorf("Objects can't have more than %d tags", maxObjectTags)
+	}
+
+	for k, v := range tags {
+		if k == "" || len(k) > maxTagKeyLength {
+			return fmt.Errorf("Invalid tag key %q", k)
+		}
+
+		if len(v) > maxTagValueLength {
+			return fmt.Errorf("Invalid value for tag %q", k)
+		}
+	}
+
+	return nil
+}
+
+// parseTaggingHeader parses the URL-encoded X-Amz-Tagging request header.
+func parseTaggingHeader(h http.Header) (map[string]string, error) {
+	v := h.Get("X-Amz-Tagging")
+	if v == "" {
+		return nil, nil
+	}
+
+	values, err := url.ParseQuery(v)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid X-Amz-Tagging header: %w", err)
+	}
+
+	tags := make(map[string]string, len(values))
+	for k, vs := range values {
+		if len(vs) != 1 {
+			return nil, fmt.Errorf("Duplicate tag key %q", k)
+		}
+
+		tags[k] = vs[0]
+	}
+
+	err = validateTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// handleObjectTagging implements the object-level ?tagging sub-resource.
+func (s *Server) handleObjectTagging(w http.ResponseWriter, r *http.Request, key string) {
+	dataPath, err := s.objectPath(key)
+	if err != nil {
+		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: err.Error()}).Response(w)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+
+	var tags map[string]string
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+			return
+		}
+
+		req := &objectTagging{}
+		err = xml.Unmarshal(body, req)
+		if err != nil {
+			(&s3.Error{Code: s3.ErrorInvalidRequest, Message: err.Error()}).Response(w)
+			return
+		}
+
+		tags = make(map[string]string, len(req.TagSet))
+		for _, tag := range req.TagSet {
+			_, ok := tags[tag.Key]
+			if ok {
+				(&s3.Error{Code: s3.ErrorInvalidRequest, Message: fmt.Sprintf("Duplicate tag key %q.", tag.Key)}).Response(w)
+				return
+			}
+
+			tags[tag.Key] = tag.Value
+		}
+
+		err = validateTags(tags)
+		if err != nil {
+			(&s3.Error{Code: s3.ErrorInvalidRequest, Message: err.Error()}).Response(w)
+			return
+		}
+
+	case http.MethodDelete:
+	default:
+		(&s3.Error{Code: s3.ErrorInvalidRequest, Message: "Unsupported method for ?tagging."}).Response(w)
+		return
+	}
+
+	objectWriteMu.Lock()
+	defer objectWriteMu.Unlock()
+
+	path, meta, err := s.loadVersion(key, dataPath, versionID)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			objectNotFound(versionID).Response(w)
+			return
+		}
+
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
+	if meta.DeleteMarker {
+		deleteMarkerResponse(w, meta)
+		return
+	}
+
+	if meta.VersionID != "" {
+		w.Header().Set("X-Amz-Version-Id", meta.VersionID)
+	}
+
+	if r.Method == http.MethodGet {
+		resp := &objectTagging{TagSet: []objectTag{}}
+		for k, v := range meta.Tags {
+			resp.TagSet = append(resp.TagSet, objectTag{Key: k, Value: v})
+		}
+
+		sort.Slice(resp.TagSet, func(i, j int) bool { return resp.TagSet[i].Key < resp.TagSet[j].Key })
+
+		body, err := xml.Marshal(resp)
+		if err != nil {
+			(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>`))
+		_, _ = w.Write(body)
+		return
+	}
+
+	if len(tags) == 0 {
+		tags = nil
+	}
+
+	meta.Tags = tags
+
+	err = writeMeta(metaPathFor(path), meta)
+	if err != nil {
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,632 @@
+package local
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/internal/server/storage/s3"
+)
+
+const (
+	versionsSubdir = ".versions"
+
+	// nullVersionID is the version ID reported for objects written while versioning was disabled.
+	nullVersionID = "null"
+)
+
+// objectVersion is a noncurrent version or delete marker of an object.
+type objectVersion struct {
+	path string
+	meta *objectMeta
+}
+
+func (s *Server) versionsDir() string {
+	return filepath.Join(s.dataDir(), versionsSubdir)
+}
+
+// versionDir returns the directory holding the noncurrent versions of key.
+func (s *Server) versionDir(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(s.versionsDir(), hex.EncodeToString(hash[:]))
+}
+
+// newVersionID returns a new version ID. Version IDs sort by creation time.
+func newVersionID() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+
+	return fmt.Sprintf("%016x%s", time.Now().UnixNano(), hex.EncodeToString(b))
+}
+
+func validVersionID(id string) bool {
+	if id == nullVersionID {
+		return true
+	}
+
+	if id == "" {
+		return false
+	}
+
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+func versionIDOrNull(id string) string {
+	if id == "" {
+		return nullVersionID
+	}
+
+	return id
+}
+
+// loadVersions returns the noncurrent versions and delete markers of key, newest first.
+func (s *Server) loadVersions(key string) ([]objectVersion, error) {
+	dir := s.versionDir(key)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	versions := []objectVersion{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), metaSuffix)
+		if !ok {
+			continue
+		}
+
+		meta, err := readMeta(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, objectVersion{path: filepath.Join(dir, name), meta: meta})
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].meta.LastMod.After(versions[j].meta.LastMod) })
+
+	return versions, nil
+}
+
+// loadVersion returns the data path and metadata of the requested version of key.
+// An empty version ID selects the current version.
+func (s *Server) loadVersion(key string, dataPath string, versionID string) (string, *objectMeta, error) {
+	meta, err := loadOrInferMeta(dataPath)
+	if versionID == "" {
+		return dataPath, meta, err
+	}
+
+	if !validVersionID(versionID) {
+		return "", nil, fs.ErrNotExist
+	}
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", nil, err
+	}
+
+	if err == nil && versionIDOrNull(meta.VersionID) == versionID {
+		return dataPath, meta, nil
+	}
+
+	versionPath := filepath.Join(s.versionDir(key), versionID)
+
+	meta, err = readMeta(metaPathFor(versionPath))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return versionPath, meta, nil
+}
+
+// archiveCurrent moves the current version of key into the version store.
+// Must be called with objectWriteMu held.
+func (s *Server) archiveCurrent(key string, dataPath string, meta *objectMeta) error {
+	dir := s.versionDir(key)
+
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return err
+	}
+
+	versionPath := filepath.Join(dir, versionIDOrNull(meta.VersionID))
+
+	err = os.Rename(dataPath, versionPath)
+	if err != nil {
+		return err
+	}
+
+	archived := *meta
+	archived.Key = key
+
+	err = writeMeta(metaPathFor(versionPath), &archived)
+	if err != nil {
+		_ = os.Rename(versionPath, dataPath)
+		return err
+	}
+
+	return removeMeta(metaPathFor(dataPath))
+}
+
+// removeVersion deletes a noncurrent version or delete marker of key.
+func (s *Server) removeVersion(key string, versionID string) error {
+	dir := s.versionDir(key)
+	versionPath := filepath.Join(dir, versionID)
+
+	err := os.Remove(versionPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	err = removeMeta(metaPathFor(versionPath))
+	if err != nil {
+		return err
+	}
+
+	// Drop the version directory once empty.
+	_ = os.Remove(dir)
+
+	return nil
+}
+
+// promoteLatest makes the newest noncurrent version of key current again if
+// the object has no current version and the newest entry isn't a delete marker.
+// Must be called with objectWriteMu held.
+func (s *Server) promoteLatest(key string, dataPath string) error {
+	_, err := os.Lstat(dataPath)
+	if err == nil {
+		return nil
+	}
+
+	versions, err := s.loadVersions(key)
+	if err != nil {
+		return err
+	}
+
+	if len(versions) == 0 || versions[0].meta.DeleteMarker {
+		return nil
+	}
+
+	latest := versions[0]
+
+	err = os.MkdirAll(filepath.Dir(dataPath), 0o700)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(latest.path, dataPath)
+	if err != nil {
+		return err
+	}
+
+	meta := *latest.meta
+	meta.Key = ""
+
+	err = writeMeta(metaPathFor(dataPath), &meta)
+	if err != nil {
+		return err
+	}
+
+	err = removeMeta(metaPathFor(latest.path))
+	if err != nil {
+		return err
+	}
+
+	_ = os.Remove(filepath.Dir(latest.path))
+
+	return nil
+}
+
+// nextVersion prepares key for a new current version and returns the version ID to assign to it.
+// Must be called with objectWriteMu held.
+func (s *Server) nextVersion(key string, dataPath string) (string, error) {
+	current, err := loadOrInferMeta(dataPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	if s.Versioning {
+		if current != nil {
+			err = s.archiveCurrent(key, dataPath, current)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		return newVersionID(), nil
+	}
+
+	// Without versioning, the new object becomes the null version which replaces any previous one.
+	if current != nil && current.VersionID != "" {
+		err = s.archiveCurrent(key, dataPath, current)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = s.removeVersion(key, nullVersionID)
+	if err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// deleteCurrent deletes the current version of key. When versioning is or
+// was enabled on the bucket, a delete marker is created instead and its
+// version ID is returned. Must be called with objectWriteMu held.
+func (s *Server) deleteCurrent(key string, dataPath string) (string, bool, error) {
+	current, err := loadOrInferMeta(dataPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", false, err
+	}
+
+	_, err = os.Stat(s.versionDir(key))
+	hasVersions := err == nil
+
+	if !s.Versioning && !hasVersions && (current == nil || current.VersionID == "") {
+		// Unversioned object.
+		err = os.Remove(dataPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", false, err
+		}
+
+		err = removeMeta(metaPathFor(dataPath))
+		if err != nil {
+			return "", false, err
+		}
+
+		return "", false, nil
+	}
+
+	if current != nil {
+		if s.Versioning || current.VersionID != "" {
+			err = s.archiveCurrent(key, dataPath, current)
+		} else {
+			// The null delete marker replaces the null version.
+			err = os.Remove(dataPath)
+			if err == nil {
+				err = removeMeta(metaPathFor(dataPath))
+			}
+		}
+
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	markerID := ""
+	if s.Versioning {
+		markerID = newVersionID()
+	} else {
+		err = s.removeVersion(key, nullVersionID)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	dir := s.versionDir(key)
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return "", false, err
+	}
+
+	marker := &objectMeta{
+		LastMod:      time.Now().UTC(),
+		VersionID:    markerID,
+		Key:          key,
+		DeleteMarker: true,
+	}
+
+	err = writeMeta(metaPathFor(filepath.Join(dir, versionIDOrNull(markerID))), marker)
+	if err != nil {
+		return "", false, err
+	}
+
+	return versionIDOrNull(markerID), true, nil
+}
+
+// deleteVersion permanently deletes a specific version of key. It returns
+// whether the deleted version was a delete marker. Must be called with
+// objectWriteMu held.
+func (s *Server) deleteVersion(key string, dataPath string, versionID string) (bool, error) {
+	versionPath, meta, err := s.loadVersion(key, dataPath, versionID)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if versionPath == dataPath {
+		err = os.Remove(dataPath)
+		if err == nil {
+			err = removeMeta(metaPathFor(dataPath))
+		}
+	} else {
+		err = s.removeVersion(key, versionID)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return meta.DeleteMarker, s.promoteLatest(key, dataPath)
+}
+
+// getBucketVersioning reports the bucket versioning state. Buckets which
+// have had versioning enabled in the past report it as suspended.
+func (s *Server) getBucketVersioning(w http.ResponseWriter) {
+	type versioningConfiguration struct {
+		XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ VersioningConfiguration"`
+		Status  string   `xml:"Status,omitempty"`
+	}
+
+	config := &versioningConfiguration{}
+	if s.Versioning {
+		config.Status = "Enabled"
+	} else {
+		_, err := os.Stat(s.versionsDir())
+		if err == nil {
+			config.Status = "Suspended"
+		}
+	}
+
+	body, err := xml.Marshal(config)
+	if err != nil {
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>`))
+	_, _ = w.Write(body)
+}
+
+// listVersionsResult is the XML root for ListObjectVersions responses.
+type listVersionsResult struct {
+	XMLName             xml.Name            `xml:"ListVersionsResult"`
+	Name                string              `xml:"Name,omitempty"`
+	Prefix              string              `xml:"Prefix"`
+	KeyMarker           string              `xml:"KeyMarker"`
+	VersionIDMarker     string              `xml:"VersionIdMarker"`
+	NextKeyMarker       string              `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string              `xml:"NextVersionIdMarker,omitempty"`
+	MaxKeys             int                 `xml:"MaxKeys"`
+	IsTruncated         bool                `xml:"IsTruncated"`
+	Entries             []listVersionsEntry // Version and DeleteMarker elements, in order.
+}
+
+type listVersionsEntry struct {
+	XMLName      xml.Name
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag,omitempty"`
+	Size         *int64 `xml:"Size,omitempty"`
+	StorageClass string `xml:"StorageClass,omitempty"`
+}
+
+// listObjectVersions implements ListObjectVersions.
+func (s *Server) listObjectVersions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	prefix := q.Get("prefix")
+	keyMarker := q.Get("key-marker")
+	versionIDMarker := q.Get("version-id-marker")
+
+	maxKeys := 1000
+
+	v := q.Get("max-keys")
+	if v != "" {
+		n, err := strconv.Atoi(v)
+		if err == nil && n > 0 && n < 1000 {
+			maxKeys = n
+		}
+	}
+
+	keys, err := s.collectVersionedKeys()
+	if err != nil {
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
+	result := &listVersionsResult{
+		Prefix:          prefix,
+		KeyMarker:       keyMarker,
+		VersionIDMarker: versionIDMarker,
+		MaxKeys:         maxKeys,
+	}
+
+	for _, key := range keys {
+		if prefix != "" && !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if keyMarker != "" && (key < keyMarker || (key == keyMarker && versionIDMarker == "")) {
+			continue
+		}
+
+		entries, err := s.keyVersions(key)
+		if err != nil {
+			(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+			return
+		}
+
+		// Resume after the version ID marker when continuing a listing.
+		if key == keyMarker {
+			for i, entry := range entries {
+				if entry.VersionID == versionIDMarker {
+					entries = entries[i+1:]
+					break
+				}
+			}
+		}
+
+		for _, entry := range entries {
+			if len(result.Entries) >= maxKeys {
+				last := result.Entries[len(result.Entries)-1]
+				result.IsTruncated = true
+				result.NextKeyMarker = last.Key
+				result.NextVersionIDMarker = last.VersionID
+				break
+			}
+
+			result.Entries = append(result.Entries, entry)
+		}
+
+		if result.IsTruncated {
+			break
+		}
+	}
+
+	body, err := xml.Marshal(result)
+	if err != nil {
+		(&s3.Error{Code: s3.ErrorCodeInternalError, Message: err.Error()}).Response(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>`))
+	_, _ = w.Write(body)
+}
+
+// keyVersions returns the listing entries for all versions of key, newest first.
+func (s *Server) keyVersions(key string) ([]listVersionsEntry, error) {
+	entries := []listVersionsEntry{}
+
+	toEntry := func(meta *objectMeta) listVersionsEntry {
+		entry := listVersionsEntry{
+			XMLName:      xml.Name{Local: "Version"},
+			Key:          key,
+			VersionID:    versionIDOrNull(meta.VersionID),
+			LastModified: meta.LastMod.UTC().Format("2006-01-02T15:04:05.000Z"),
+		}
+
+		if meta.DeleteMarker {
+			entry.XMLName.Local = "DeleteMarker"
+			return entry
+		}
+
+		size := meta.Size
+		entry.ETag = `"` + meta.ETag + `"`
+		entry.Size = &size
+		entry.StorageClass = "STANDARD"
+
+		return entry
+	}
+
+	current, err := loadOrInferMeta(filepath.Join(s.dataDir(), key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if current != nil {
+		entries = append(entries, toEntry(current))
+	}
+
+	versions, err := s.loadVersions(key)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		entries = append(entries, toEntry(version.meta))
+	}
+
+	if len(entries) > 0 {
+		entries[0].IsLatest = true
+	}
+
+	return entries, nil
+}
+
+// collectVersionedKeys returns the sorted list of keys which have a current
+// version, noncurrent versions or delete markers.
+func (s *Server) collectVersionedKeys() ([]string, error) {
+	keys, err := s.collectKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	versionKeys, err := s.collectNoncurrentKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+
+	for _, key := range versionKeys {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// collectNoncurrentKeys returns the keys which have noncurrent versions or delete markers.
+func (s *Server) collectNoncurrentKeys() ([]string, error) {
+	dirs, err := os.ReadDir(s.versionsDir())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(s.versionsDir(), dir.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			if !strings.HasSuffix(e.Name(), metaSuffix) {
+				continue
+			}
+
+			meta, err := readMeta(filepath.Join(s.versionsDir(), dir.Name(), e.Name()))
+			if err != nil || meta.Key == "" {
+				continue
+			}
+
+			keys = append(keys, meta.Key)
+			break
+		}
+	}
+
+	return keys, nil
+}
@@ -24,13 +24,29 @@ const ErrorCodePreconditionFailed = "PreconditionFailed"
 // ErrorCodeNotImplemented means the requested functionality isn't implemented.
 const ErrorCodeNotImplemented = "NotImplemented"
 
+// ErrorCodeNoSuchVersion means the specified object version does not exist.
+const ErrorCodeNoSuchVersion = "NoSuchVersion"
+
+// ErrorCodeMethodNotAllowed means the method isn't allowed against the resource (such as a delete marker).
+const ErrorCodeMethodNotAllowed = "MethodNotAllowed"
+
+// ErrorCodeNoSuchLifecycleConfiguration means the bucket has no lifecycle configuration.
+const ErrorCodeNoSuchLifecycleConfiguration = "NoSuchLifecycleConfiguration"
+
+// ErrorCodeAccessDenied means the credential isn't allowed to access the resource.
+const ErrorCodeAccessDenied = "AccessDenied"
+
 var errorHTTPStatusCodes = map[string]int{
-	ErrorCodeNoSuchBucket:       http.StatusNotFound,
-	ErrorCodeInternalError:      http.StatusInternalServerError,
-	ErrorCodeInvalidAccessKeyID: http.StatusForbidden,
-	ErrorInvalidRequest:         http.StatusBadRequest,
-	ErrorCodePreconditionFailed: http.StatusPreconditionFailed,
-	ErrorCodeNotImplemented:     http.StatusNotImplemented,
+	ErrorCodeNoSuchBucket:                 http.StatusNotFound,
+	ErrorCodeInternalError:                http.StatusInternalServerError,
+	ErrorCodeInvalidAccessKeyID:           http.StatusForbidden,
+	ErrorInvalidRequest:                   http.StatusBadRequest,
+	ErrorCodePreconditionFailed:           http.StatusPreconditionFailed,
+	ErrorCodeNotImplemented:               http.StatusNotImplemented,
+	ErrorCodeNoSuchVersion:                http.StatusNotFound,
+	ErrorCodeMethodNotAllowed:             http.StatusMethodNotAllowed,
+	ErrorCodeNoSuchLifecycleConfiguration: http.StatusNotFound,
+	ErrorCodeAccessDenied:                 http.StatusForbidden,
 }
 
 // Error S3 error response.
@@ -526,6 +526,7 @@ func validatePoolCommonRules() map[string]func(string) error {
 		"volatile.initial_source": validate.IsAny,
 		"rsync.bwlimit":           validate.Optional(validate.IsSize),
 		"rsync.compression":       validate.Optional(validate.IsBool),
+		"reclaim.auto":            validate.Optional(validate.IsBool),
 	}
 
 	// Add to pool config rules (prefixed with volume.*) which are common for pool and volume.
@@ -564,6 +565,8 @@ func validateVolumeCommonRules(vol drivers.Volume) map[string]func(string) error
 
 	if vol.Type() == drivers.VolumeTypeCustom {
 		rules["dependent"] = validate.Optional(validate.IsBool)
+		rules["limits.bandwidth"] = validate.Optional(validate.IsSize)
+		rules["limits.iops"] = validate.Optional(validate.IsUint32)
 	}
 
 	return rules
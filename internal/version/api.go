@@ -556,6 +556,31 @@ var APIExtensions = []string{
 	"network_allocations_network",
 	"gpu_native_context",
 	"instance_port_forward",
+	"storage_pool_health",
+	"storage_volume_limits",
+	"storage_pool_reclaim",
+	"storage_bucket_versioning",
+	"storage_bucket_policies",
+	"instance_healthcheck",
+	"instance_boot_dependencies",
+	"limits_cpu_hotplug",
+	"limits_memory_balloon",
+	"container_live_migration_progress",
+	"session_recording",
+	"device_watchdog",
+	"secureboot_custom_keys",
+	"instance_power_schedules",
+	"instance_autoscale",
+	"auth_rbac",
+	"audit_log",
+	"auth_tokens",
+	"oidc_groups_claim",
+	"auth_certificates",
+	"project_usage_accounting",
+	"project_limits_network",
+	"approvals",
+	"project_templates",
+	"project_protection",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
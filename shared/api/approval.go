@@ -0,0 +1,43 @@
+package api
+
+import (
+	"time"
+)
+
+// Approval represents a privileged operation waiting to be approved.
+//
+// swagger:model
+//
+// API extension: approvals.
+type Approval struct {
+	// ID of the approval (same as the pending operation)
+	// Example: 6916c8a6-9b7d-4abd-90b3-aedfec7ec7da
+	ID string `json:"id" yaml:"id"`
+
+	// The project the operation belongs to
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// What cluster member the operation is pending on
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// Type of privileged operation
+	// Example: instances.privileged
+	Type string `json:"type" yaml:"type"`
+
+	// Description of the privileged operation
+	// Example: Deleting protected instance "c1"
+	Description string `json:"description" yaml:"description"`
+
+	// URL of the pending operation
+	// Example: /1.0/operations/6916c8a6-9b7d-4abd-90b3-aedfec7ec7da
+	Operation string `json:"operation" yaml:"operation"`
+
+	// Identity which requested the operation
+	Requestor EventLifecycleRequestor `json:"requestor" yaml:"requestor"`
+
+	// When the operation was requested
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
@@ -1,9 +1,311 @@
 package api
 
+import (
+	"time"
+)
+
 const (
 	// AuthenticationMethodTLS is the default authentication method for interacting with Incus remotely.
 	AuthenticationMethodTLS = "tls"
 
 	// AuthenticationMethodOIDC is a token based authentication method.
 	AuthenticationMethodOIDC = "oidc"
+
+	// AuthenticationMethodToken is the authentication method for scoped API tokens.
+	//
+	// API extension: auth_tokens.
+	AuthenticationMethodToken = "token"
 )
+
+// AuthPermission represents an entitlement granted on an authorization object.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthPermission struct {
+	// Name of the entitlement
+	// Example: can_exec
+	Entitlement string `json:"entitlement" yaml:"entitlement"`
+
+	// Authorization object the entitlement applies to
+	// Example: instance:default/c1
+	Object string `json:"object" yaml:"object"`
+}
+
+// AuthGroupPost used for renaming an authorization group.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthGroupPost struct {
+	// The new name of the authorization group
+	// Example: developers
+	Name string `json:"name" yaml:"name"`
+}
+
+// AuthGroupPut used for updating an authorization group.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthGroupPut struct {
+	// Description of the authorization group
+	// Example: Developers of the web application
+	Description string `json:"description" yaml:"description"`
+
+	// Permissions granted to the members of the authorization group
+	Permissions []AuthPermission `json:"permissions" yaml:"permissions"`
+}
+
+// AuthGroupsPost used for creating a new authorization group.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthGroupsPost struct {
+	AuthGroupPost `yaml:",inline"`
+	AuthGroupPut  `yaml:",inline"`
+}
+
+// AuthGroup represents an authorization group.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthGroup struct {
+	AuthGroupPost `yaml:",inline"`
+	AuthGroupPut  `yaml:",inline"`
+
+	// Identities which are members of the authorization group
+	// Read only: true
+	// Example: ["oidc/jane@example.com"]
+	Identities []string `json:"identities" yaml:"identities"`
+}
+
+// Writable converts a full AuthGroup struct into a AuthGroupPut struct (filters read-only fields).
+func (g *AuthGroup) Writable() AuthGroupPut {
+	return g.AuthGroupPut
+}
+
+// AuthIdentityPut used for updating an identity.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthIdentityPut struct {
+	// Description of the identity
+	// Example: Jane Doe
+	Description string `json:"description" yaml:"description"`
+
+	// Authorization groups the identity is a member of
+	// Example: ["developers"]
+	Groups []string `json:"groups" yaml:"groups"`
+}
+
+// AuthIdentitiesPost used for adding a new identity.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthIdentitiesPost struct {
+	AuthIdentityPut `yaml:",inline"`
+
+	// Authentication method of the identity (tls or oidc)
+	// Example: oidc
+	AuthenticationMethod string `json:"authentication_method" yaml:"authentication_method"`
+
+	// Identifier of the identity (certificate fingerprint or OIDC username)
+	// Example: jane@example.com
+	Identifier string `json:"identifier" yaml:"identifier"`
+}
+
+// AuthIdentity represents an identity known to the authorization driver.
+//
+// swagger:model
+//
+// API extension: auth_rbac.
+type AuthIdentity struct {
+	AuthIdentityPut `yaml:",inline"`
+
+	// Authentication method of the identity (tls or oidc)
+	// Example: oidc
+	AuthenticationMethod string `json:"authentication_method" yaml:"authentication_method"`
+
+	// Identifier of the identity (certificate fingerprint or OIDC username)
+	// Example: jane@example.com
+	Identifier string `json:"identifier" yaml:"identifier"`
+}
+
+// Writable converts a full AuthIdentity struct into a AuthIdentityPut struct (filters read-only fields).
+func (i *AuthIdentity) Writable() AuthIdentityPut {
+	return i.AuthIdentityPut
+}
+
+// AuthTokenPut used for updating an API token.
+//
+// swagger:model
+//
+// API extension: auth_tokens.
+type AuthTokenPut struct {
+	// Description of the API token
+	// Example: CI pipeline of the web application
+	Description string `json:"description" yaml:"description"`
+}
+
+// AuthTokensPost used for creating a new API token.
+//
+// swagger:model
+//
+// API extension: auth_tokens.
+type AuthTokensPost struct {
+	AuthTokenPut `yaml:",inline"`
+
+	// Name of the API token
+	// Example: webapp-ci
+	Name string `json:"name" yaml:"name"`
+
+	// Projects the API token is restricted to (empty for all projects)
+	// Example: ["webapp"]
+	Projects []string `json:"projects" yaml:"projects"`
+
+	// Entitlements granted to the API token
+	// Example: ["can_view", "can_edit", "can_create_instances", "can_update_state"]
+	Entitlements []string `json:"entitlements" yaml:"entitlements"`
+
+	// When the API token expires (empty for no expiry)
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+// AuthTokenSecret represents the secret of a newly created API token.
+//
+// swagger:model
+//
+// API extension: auth_tokens.
+type AuthTokenSecret struct {
+	// Name of the API token
+	// Example: webapp-ci
+	Name string `json:"name" yaml:"name"`
+
+	// Secret to use as a bearer token (only returned on creation)
+	// Example: incus_sTvOEHRqLaAtvCwX4wQ2RPuOqmmxMUJLK5HoXDrLrAs
+	Token string `json:"token" yaml:"token"`
+}
+
+// AuthToken represents an API token.
+//
+// swagger:model
+//
+// API extension: auth_tokens.
+type AuthToken struct {
+	AuthTokenPut `yaml:",inline"`
+
+	// Name of the API token
+	// Read only: true
+	// Example: webapp-ci
+	Name string `json:"name" yaml:"name"`
+
+	// Projects the API token is restricted to (empty for all projects)
+	// Read only: true
+	// Example: ["webapp"]
+	Projects []string `json:"projects" yaml:"projects"`
+
+	// Entitlements granted to the API token
+	// Read only: true
+	// Example: ["can_view", "can_edit", "can_create_instances", "can_update_state"]
+	Entitlements []string `json:"entitlements" yaml:"entitlements"`
+
+	// When the API token was created
+	// Read only: true
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+
+	// When the API token expires (empty for no expiry)
+	// Read only: true
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+
+	// When the API token was last used
+	// Read only: true
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	LastUsedAt time.Time `json:"last_used_at" yaml:"last_used_at"`
+}
+
+// Writable converts a full AuthToken struct into a AuthTokenPut struct (filters read-only fields).
+func (t *AuthToken) Writable() AuthTokenPut {
+	return t.AuthTokenPut
+}
+
+// AuthCertificatesPost used for requesting a short-lived client certificate.
+//
+// swagger:model
+//
+// API extension: auth_certificates.
+type AuthCertificatesPost struct {
+	// PEM encoded certificate signing request
+	// Example: X509 PEM certificate request
+	CSR string `json:"csr" yaml:"csr"`
+
+	// When the certificate should expire (empty for the server default)
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+// AuthCertificate represents a client certificate issued by the server.
+//
+// swagger:model
+//
+// API extension: auth_certificates.
+type AuthCertificate struct {
+	// Serial number of the certificate
+	// Read only: true
+	// Example: 3f2a9c1e0b7d4e6f8a1b2c3d4e5f6a7b
+	Serial string `json:"serial" yaml:"serial"`
+
+	// Authentication method of the identity the certificate was issued to
+	// Read only: true
+	// Example: oidc
+	AuthenticationMethod string `json:"authentication_method" yaml:"authentication_method"`
+
+	// Identifier of the identity the certificate was issued to
+	// Read only: true
+	// Example: jane@example.com
+	Identifier string `json:"identifier" yaml:"identifier"`
+
+	// PEM encoded certificate
+	// Read only: true
+	// Example: X509 PEM certificate
+	Certificate string `json:"certificate" yaml:"certificate"`
+
+	// When the certificate was issued
+	// Read only: true
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+
+	// When the certificate expires
+	// Read only: true
+	// Example: 2021-03-24T17:38:37.753398689-04:00
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+
+	// When the certificate was revoked (empty if not revoked)
+	// Read only: true
+	// Example: 2021-03-23T18:38:37.753398689-04:00
+	RevokedAt time.Time `json:"revoked_at" yaml:"revoked_at"`
+}
+
+// AuthCertificateAuthority represents the authority issuing short-lived client certificates.
+//
+// swagger:model
+//
+// API extension: auth_certificates.
+type AuthCertificateAuthority struct {
+	// PEM encoded certificate of the authority
+	// Read only: true
+	// Example: X509 PEM certificate
+	Certificate string `json:"certificate" yaml:"certificate"`
+
+	// PEM encoded certificate revocation list
+	// Read only: true
+	// Example: X509 PEM certificate revocation list
+	CRL string `json:"crl" yaml:"crl"`
+}
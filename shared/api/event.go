@@ -8,6 +8,7 @@ import (
 
 // Event types.
 const (
+	EventTypeAudit      = "audit"
 	EventTypeLifecycle  = "lifecycle"
 	EventTypeLogging    = "logging"
 	EventTypeOperation  = "operation"
@@ -96,6 +97,29 @@ func (event *Event) ToLogging() (EventLogRecord, error) {
 
 		return record, nil
 
+	case EventTypeAudit:
+		e := &EventAudit{}
+		err := json.Unmarshal(event.Metadata, &e)
+		if err != nil {
+			return EventLogRecord{}, err
+		}
+
+		record := EventLogRecord{
+			Time: event.Timestamp,
+			Lvl:  "info",
+			Msg:  fmt.Sprintf("Method: %s, URL: %s, Outcome: %s, Requestor: %s/%s (%s)", e.Method, e.URL, e.Outcome, e.Protocol, e.Username, e.Address),
+			Ctx: []any{
+				"Sequence", e.Sequence,
+				"Endpoint", e.Endpoint,
+				"Project", e.Project,
+				"StatusCode", e.StatusCode,
+				"Duration", e.Duration,
+				"Hash", e.Hash,
+			},
+		}
+
+		return record, nil
+
 	case EventTypeOperation:
 		e := &Operation{}
 		err := json.Unmarshal(event.Metadata, &e)
@@ -170,3 +194,64 @@ type EventLifecycleRequestor struct {
 	// API extension: event_lifecycle_requestor_address
 	Address string `yaml:"address" json:"address"`
 }
+
+// EventAudit represents an audit type event entry (admin only)
+//
+// API extension: audit_log.
+type EventAudit struct {
+	// Sequence number of the record in the audit log of the server
+	// Example: 42
+	Sequence uint64 `yaml:"sequence" json:"sequence"`
+
+	// Time at which the request was received
+	// Example: 2021-02-24T19:00:45.452649098-05:00
+	Timestamp time.Time `yaml:"timestamp" json:"timestamp"`
+
+	// Name of the user or certificate fingerprint making the request
+	// Example: jane@example.com
+	Username string `yaml:"username" json:"username"`
+
+	// Authentication method used for the request
+	// Example: oidc
+	Protocol string `yaml:"protocol" json:"protocol"`
+
+	// Source address of the request
+	// Example: 10.0.2.15
+	Address string `yaml:"address" json:"address"`
+
+	// HTTP method of the request
+	// Example: PUT
+	Method string `yaml:"method" json:"method"`
+
+	// API endpoint handling the request
+	// Example: /1.0/instances/{name}
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+
+	// URL of the request, with the values of sensitive query parameters redacted
+	// Example: /1.0/instances/c1?project=default
+	URL string `yaml:"url" json:"url"`
+
+	// Project of the request
+	// Example: default
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+
+	// HTTP status code of the response
+	// Example: 200
+	StatusCode int `yaml:"status_code" json:"status_code"`
+
+	// Outcome of the request (one of success, denied or failure)
+	// Example: success
+	Outcome string `yaml:"outcome" json:"outcome"`
+
+	// Time taken to handle the request, in milliseconds
+	// Example: 12
+	Duration int64 `yaml:"duration" json:"duration"`
+
+	// HMAC of the previous record in the audit log
+	// Example: 5b0d5b1e6d4c6d3e2a0b7e8f5c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d
+	PreviousHash string `yaml:"previous_hash" json:"previous_hash"`
+
+	// HMAC of this record, covering the previous hash
+	// Example: 0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0
+	Hash string `yaml:"hash" json:"hash"`
+}
@@ -2,6 +2,18 @@ package api
 
 // Define consts for all the lifecycle events.
 const (
+	EventLifecycleApprovalApproved                  = "approval-approved"
+	EventLifecycleApprovalCreated                   = "approval-created"
+	EventLifecycleApprovalRejected                  = "approval-rejected"
+	EventLifecycleAuthCertificateIssued             = "auth-certificate-issued"
+	EventLifecycleAuthCertificateRevoked            = "auth-certificate-revoked"
+	EventLifecycleAuthGroupCreated                  = "auth-group-created"
+	EventLifecycleAuthGroupDeleted                  = "auth-group-deleted"
+	EventLifecycleAuthGroupRenamed                  = "auth-group-renamed"
+	EventLifecycleAuthGroupUpdated                  = "auth-group-updated"
+	EventLifecycleAuthTokenCreated                  = "auth-token-created"
+	EventLifecycleAuthTokenDeleted                  = "auth-token-deleted"
+	EventLifecycleAuthTokenUpdated                  = "auth-token-updated"
 	EventLifecycleCertificateCreated                = "certificate-created"
 	EventLifecycleCertificateDeleted                = "certificate-deleted"
 	EventLifecycleCertificateUpdated                = "certificate-updated"
@@ -21,6 +33,9 @@ const (
 	EventLifecycleClusterMemberUpdated              = "cluster-member-updated"
 	EventLifecycleClusterTokenCreated               = "cluster-token-created"
 	EventLifecycleConfigUpdated                     = "config-updated"
+	EventLifecycleIdentityCreated                   = "identity-created"
+	EventLifecycleIdentityDeleted                   = "identity-deleted"
+	EventLifecycleIdentityUpdated                   = "identity-updated"
 	EventLifecycleImageAliasCreated                 = "image-alias-created"
 	EventLifecycleImageAliasDeleted                 = "image-alias-deleted"
 	EventLifecycleImageAliasRenamed                 = "image-alias-renamed"
@@ -47,19 +62,23 @@ const (
 	EventLifecycleInstanceLogDeleted                = "instance-log-deleted"
 	EventLifecycleInstanceLogRetrieved              = "instance-log-retrieved"
 	EventLifecycleInstanceMetadataRetrieved         = "instance-metadata-retrieved"
+	EventLifecycleInstanceHealthy                   = "instance-healthy"
 	EventLifecycleInstanceMetadataTemplateCreated   = "instance-metadata-template-created"
 	EventLifecycleInstanceMetadataTemplateDeleted   = "instance-metadata-template-deleted"
 	EventLifecycleInstanceMetadataTemplateRetrieved = "instance-metadata-template-retrieved"
 	EventLifecycleInstanceMetadataUpdated           = "instance-metadata-updated"
 	EventLifecycleInstanceMigrated                  = "instance-migrated"
 	EventLifecycleInstancePaused                    = "instance-paused"
+	EventLifecycleInstancePowerScheduled            = "instance-power-scheduled"
 	EventLifecycleInstanceAgentStarted              = "instance-agent-started"
 	EventLifecycleInstanceAgentStopped              = "instance-agent-stopped"
+	EventLifecycleInstanceAutoscaled                = "instance-autoscaled"
 	EventLifecycleInstanceReady                     = "instance-ready"
 	EventLifecycleInstanceRenamed                   = "instance-renamed"
 	EventLifecycleInstanceRestarted                 = "instance-restarted"
 	EventLifecycleInstanceRestored                  = "instance-restored"
 	EventLifecycleInstanceResumed                   = "instance-resumed"
+	EventLifecycleInstanceSessionRecorded           = "instance-session-recorded"
 	EventLifecycleInstanceShutdown                  = "instance-shutdown"
 	EventLifecycleInstanceSnapshotCreated           = "instance-snapshot-created"
 	EventLifecycleInstanceSnapshotDeleted           = "instance-snapshot-deleted"
@@ -67,7 +86,9 @@ const (
 	EventLifecycleInstanceSnapshotUpdated           = "instance-snapshot-updated"
 	EventLifecycleInstanceStarted                   = "instance-started"
 	EventLifecycleInstanceStopped                   = "instance-stopped"
+	EventLifecycleInstanceUnhealthy                 = "instance-unhealthy"
 	EventLifecycleInstanceUpdated                   = "instance-updated"
+	EventLifecycleInstanceWatchdogFired             = "instance-watchdog-fired"
 	EventLifecycleNetworkACLCreated                 = "network-acl-created"
 	EventLifecycleNetworkACLDeleted                 = "network-acl-deleted"
 	EventLifecycleNetworkACLRenamed                 = "network-acl-renamed"
@@ -107,6 +128,10 @@ const (
 	EventLifecycleProjectCreated                    = "project-created"
 	EventLifecycleProjectDeleted                    = "project-deleted"
 	EventLifecycleProjectRenamed                    = "project-renamed"
+	EventLifecycleProjectTemplateCreated            = "project-template-created"
+	EventLifecycleProjectTemplateDeleted            = "project-template-deleted"
+	EventLifecycleProjectTemplateRenamed            = "project-template-renamed"
+	EventLifecycleProjectTemplateUpdated            = "project-template-updated"
 	EventLifecycleProjectUpdated                    = "project-updated"
 	EventLifecycleStorageBucketBackupCreated        = "storage-bucket-backup-created"
 	EventLifecycleStorageBucketBackupDeleted        = "storage-bucket-backup-deleted"
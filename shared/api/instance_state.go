@@ -25,6 +25,12 @@ type InstanceStatePut struct {
 	// Whether to store the runtime state (for stop)
 	// Example: false
 	Stateful bool `json:"stateful" yaml:"stateful"`
+
+	// Whether to first start the instances listed in boot.depends_on (for start)
+	// Example: false
+	//
+	// API extension: instance_boot_dependencies
+	WithDependencies bool `json:"with_dependencies" yaml:"with_dependencies"`
 }
 
 // InstanceState represents an instance's state.
@@ -70,6 +76,11 @@ type InstanceState struct {
 	//
 	// API extension: instances_state_os_info.
 	OSInfo *InstanceStateOSInfo `json:"os_info" yaml:"os_info"`
+
+	// Health check state (only set when a health check is configured)
+	//
+	// API extension: instance_healthcheck.
+	Health *InstanceStateHealth `json:"health,omitempty" yaml:"health,omitempty"`
 }
 
 // InstanceStateDisk represents the disk information section of an instance's state.
@@ -232,6 +243,29 @@ type InstanceStateNetworkCounters struct {
 	PacketsDroppedInbound int64 `json:"packets_dropped_inbound" yaml:"packets_dropped_inbound"`
 }
 
+// InstanceStateHealth represents the health check section of an instance's state.
+//
+// swagger:model
+//
+// API extension: instance_healthcheck.
+type InstanceStateHealth struct {
+	// Health status (starting, healthy or unhealthy)
+	// Example: healthy
+	Status string `json:"status" yaml:"status"`
+
+	// Number of consecutive failed checks
+	// Example: 0
+	FailingStreak int `json:"failing_streak" yaml:"failing_streak"`
+
+	// Time of the last check
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	LastCheck time.Time `json:"last_check" yaml:"last_check"`
+
+	// Output of the last check
+	// Example: OK
+	LastOutput string `json:"last_output" yaml:"last_output"`
+}
+
 // InstanceStateOSInfo represents the operating system information section of an instance's state.
 //
 // swagger:model
@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // ProjectDefaultName is the name of the default project that can never be deleted.
 const ProjectDefaultName = "default"
 
@@ -14,6 +18,12 @@ type ProjectsPost struct {
 	// The name of the new project
 	// Example: foo
 	Name string `json:"name" yaml:"name"`
+
+	// Name of the project template to create the project from
+	// Example: tenant
+	//
+	// API extension: project_templates
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
 }
 
 // ProjectPost represents the fields required to rename a project
@@ -99,3 +109,56 @@ type ProjectStateResource struct {
 	// Example: 4
 	Usage int64
 }
+
+// ProjectUsage represents the resources consumed by the instances of a project over a period of time
+//
+// swagger:model
+//
+// API extension: project_usage_accounting.
+type ProjectUsage struct {
+	// Start of the period (excluded)
+	// Read only: true
+	// Example: 2021-03-01T00:00:00Z
+	From time.Time `json:"from" yaml:"from"`
+
+	// End of the period (included)
+	// Read only: true
+	// Example: 2021-04-01T00:00:00Z
+	To time.Time `json:"to" yaml:"to"`
+
+	// Resources consumed by all the instances of the project
+	// Read only: true
+	Total ProjectUsageResources `json:"total" yaml:"total"`
+
+	// Resources consumed by each instance of the project
+	// Read only: true
+	// Example: {"c1": {"cpu_seconds": 3600, "memory_gib_hours": 24, "disk_gib_hours": 240, "network_received_bytes": 1048576, "network_sent_bytes": 524288}}
+	Instances map[string]ProjectUsageResources `json:"instances" yaml:"instances"`
+}
+
+// ProjectUsageResources represents the resources consumed by instances over a period of time
+//
+// swagger:model
+//
+// API extension: project_usage_accounting.
+type ProjectUsageResources struct {
+	// CPU time in seconds
+	// Example: 3600
+	CPUSeconds float64 `json:"cpu_seconds" yaml:"cpu_seconds"`
+
+	// Memory usage in GiB-hours
+	// Example: 24
+	MemoryGiBHours float64 `json:"memory_gib_hours" yaml:"memory_gib_hours"`
+
+	// Disk usage in GiB-hours
+	// Example: 240
+	DiskGiBHours float64 `json:"disk_gib_hours" yaml:"disk_gib_hours"`
+
+	// Bytes received over the network
+	// Example: 1048576
+	NetworkReceivedBytes int64 `json:"network_received_bytes" yaml:"network_received_bytes"`
+
+	// Bytes sent over the network
+	// Example: 524288
+	NetworkSentBytes int64 `json:"network_sent_bytes" yaml:"network_sent_bytes"`
+}
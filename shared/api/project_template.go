@@ -0,0 +1,72 @@
+package api
+
+// ProjectTemplatesPost represents the fields of a new project template
+//
+// swagger:model
+//
+// API extension: project_templates.
+type ProjectTemplatesPost struct {
+	ProjectTemplatePut `yaml:",inline"`
+
+	// The name of the new project template
+	// Example: tenant
+	Name string `json:"name" yaml:"name"`
+}
+
+// ProjectTemplatePost represents the fields required to rename a project template
+//
+// swagger:model
+//
+// API extension: project_templates.
+type ProjectTemplatePost struct {
+	// The new name for the project template
+	// Example: customer
+	Name string `json:"name" yaml:"name"`
+}
+
+// ProjectTemplatePut represents the modifiable fields of a project template
+//
+// swagger:model
+//
+// API extension: project_templates.
+type ProjectTemplatePut struct {
+	// Description of the project template
+	// Example: Restricted tenant project
+	Description string `json:"description" yaml:"description"`
+
+	// Configuration of the projects created from the template (refer to doc/projects.md)
+	// Example: {"features.networks": "true", "restricted": "true", "limits.instances": "10"}
+	Config ConfigMap `json:"config" yaml:"config"`
+
+	// Profiles to create in the projects, the default profile is updated instead of created
+	Profiles []ProfilesPost `json:"profiles" yaml:"profiles"`
+
+	// Networks to create in the projects
+	Networks []NetworksPost `json:"networks" yaml:"networks"`
+
+	// Network ACLs to create in the projects
+	NetworkACLs []NetworkACLsPost `json:"network_acls" yaml:"network_acls"`
+
+	// Whether users not allowed to create projects may create projects from this template
+	// Example: true
+	SelfService bool `json:"self_service" yaml:"self_service"`
+}
+
+// ProjectTemplate represents a project template
+//
+// swagger:model
+//
+// API extension: project_templates.
+type ProjectTemplate struct {
+	ProjectTemplatePut `yaml:",inline"`
+
+	// The project template name
+	// Read only: true
+	// Example: tenant
+	Name string `json:"name" yaml:"name"`
+}
+
+// Writable converts a full ProjectTemplate struct into a ProjectTemplatePut struct (filters read-only fields).
+func (t *ProjectTemplate) Writable() ProjectTemplatePut {
+	return t.ProjectTemplatePut
+}
@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // StorageBucketsPost represents the fields of a new storage pool bucket
 //
 // swagger:model
@@ -141,6 +145,12 @@ type StorageBucketKeyPut struct {
 	//
 	// API extension: storage_buckets
 	SecretKey string `json:"secret-key" yaml:"secret-key"`
+
+	// Object key prefix the key is restricted to (empty for the whole bucket)
+	// Example: artifacts/
+	//
+	// API extension: storage_bucket_policies
+	Prefix string `json:"prefix" yaml:"prefix"`
 }
 
 // StorageBucketKey represents the fields of a storage pool bucket key
@@ -165,10 +175,60 @@ func (b *StorageBucketKey) URL(apiVersion string, poolName string, projectName s
 
 // Etag returns the values used for etag generation.
 func (b *StorageBucketKey) Etag() []any {
-	return []any{b.Name, b.Description, b.Role, b.AccessKey, b.SecretKey}
+	return []any{b.Name, b.Description, b.Role, b.AccessKey, b.SecretKey, b.Prefix}
 }
 
 // Writable converts a full StorageBucketKey struct into a StorageBucketKeyPut struct (filters read-only fields).
 func (b *StorageBucketKey) Writable() StorageBucketKeyPut {
 	return b.StorageBucketKeyPut
 }
+
+// StorageBucketPresignPost represents the fields of a presigned URL request
+//
+// swagger:model
+//
+// API extension: storage_bucket_policies.
+type StorageBucketPresignPost struct {
+	// Object key
+	// Example: artifacts/build.tar.gz
+	//
+	// API extension: storage_bucket_policies
+	Object string `json:"object" yaml:"object"`
+
+	// HTTP method the URL is valid for (GET or PUT)
+	// Example: GET
+	//
+	// API extension: storage_bucket_policies
+	Method string `json:"method" yaml:"method"`
+
+	// Validity of the URL in seconds (defaults to one hour, at most 7 days)
+	// Example: 3600
+	//
+	// API extension: storage_bucket_policies
+	Expiry int64 `json:"expiry" yaml:"expiry"`
+
+	// Name of the bucket key to sign the URL with (defaults to the first suitable key)
+	// Example: my-read-only-key
+	//
+	// API extension: storage_bucket_policies
+	Key string `json:"key" yaml:"key"`
+}
+
+// StorageBucketPresign represents a presigned storage bucket object URL
+//
+// swagger:model
+//
+// API extension: storage_bucket_policies.
+type StorageBucketPresign struct {
+	// Presigned URL
+	// Example: https://127.0.0.1:8555/foo/artifacts/build.tar.gz?X-Amz-Algorithm=AWS4-HMAC-SHA256&...
+	//
+	// API extension: storage_bucket_policies
+	URL string `json:"url" yaml:"url"`
+
+	// Expiry date of the URL
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	//
+	// API extension: storage_bucket_policies
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"time"
+)
+
+// StoragePoolHealthStatusHealthy indicates that the storage pool is operating normally.
+const StoragePoolHealthStatusHealthy = "healthy"
+
+// StoragePoolHealthStatusDegraded indicates that the storage pool is operating with reduced redundancy or has errors.
+const StoragePoolHealthStatusDegraded = "degraded"
+
+// StoragePoolHealthStatusFailed indicates that the storage pool is no longer able to serve data.
+const StoragePoolHealthStatusFailed = "failed"
+
+// StoragePoolHealthStatusUnknown indicates that the health of the storage pool couldn't be determined.
+const StoragePoolHealthStatusUnknown = "unknown"
+
+// StoragePoolScrubStatusNone indicates that no scrub was ever run on the storage pool.
+const StoragePoolScrubStatusNone = "none"
+
+// StoragePoolScrubStatusRunning indicates that a scrub is currently running.
+const StoragePoolScrubStatusRunning = "running"
+
+// StoragePoolScrubStatusFinished indicates that the last scrub completed.
+const StoragePoolScrubStatusFinished = "finished"
+
+// StoragePoolScrubStatusCanceled indicates that the last scrub was interrupted.
+const StoragePoolScrubStatusCanceled = "canceled"
+
+// StoragePoolHealth represents the health of a storage pool
+//
+// swagger:model
+//
+// API extension: storage_pool_health.
+type StoragePoolHealth struct {
+	// Overall health status of the pool (healthy, degraded, failed or unknown)
+	// Example: healthy
+	Status string `json:"status" yaml:"status"`
+
+	// Human readable messages reported by the storage backend
+	// Example: ["One or more devices has experienced an unrecoverable error."]
+	Messages []string `json:"messages" yaml:"messages"`
+
+	// Health of the individual devices backing the pool
+	Devices []StoragePoolHealthDevice `json:"devices" yaml:"devices"`
+
+	// State of the last or current scrub (nil if not supported by the driver)
+	Scrub *StoragePoolHealthScrub `json:"scrub" yaml:"scrub"`
+}
+
+// StoragePoolHealthDevice represents the health of a device backing a storage pool
+//
+// swagger:model
+//
+// API extension: storage_pool_health.
+type StoragePoolHealthDevice struct {
+	// Name of the device
+	// Example: /dev/sdb
+	Name string `json:"name" yaml:"name"`
+
+	// Status of the device (healthy, degraded, failed or unknown)
+	// Example: healthy
+	Status string `json:"status" yaml:"status"`
+
+	// Number of read errors
+	// Example: 0
+	ReadErrors uint64 `json:"read_errors" yaml:"read_errors"`
+
+	// Number of write errors
+	// Example: 0
+	WriteErrors uint64 `json:"write_errors" yaml:"write_errors"`
+
+	// Number of checksum or corruption errors
+	// Example: 0
+	ChecksumErrors uint64 `json:"checksum_errors" yaml:"checksum_errors"`
+}
+
+// StoragePoolHealthScrub represents the state of a storage pool scrub
+//
+// swagger:model
+//
+// API extension: storage_pool_health.
+type StoragePoolHealthScrub struct {
+	// Status of the scrub (none, running, finished or canceled)
+	// Example: finished
+	Status string `json:"status" yaml:"status"`
+
+	// When the scrub started
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// When the scrub completed (zero while running)
+	// Example: 2021-03-23T18:02:12.122368215-04:00
+	FinishedAt time.Time `json:"finished_at" yaml:"finished_at"`
+
+	// Progress of a running scrub (percentage)
+	// Example: 42.5
+	Progress float64 `json:"progress" yaml:"progress"`
+
+	// Number of errors found by the scrub
+	// Example: 0
+	Errors uint64 `json:"errors" yaml:"errors"`
+}